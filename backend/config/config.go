@@ -5,6 +5,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
@@ -52,17 +53,133 @@ const (
 	// media
 	_defTaskFileDir     = "./media/task_files"     // default dir for task files
 	_defSolutionFileDir = "./media/solution_files" // default dir for solution files
+
+	// media previews
+	_defPreviewWorkers     = 2                // default number of preview workers
+	_defPreviewQueueSize   = 100              // default size of preview queue
+	_defPreviewMaxSize     = 320              // default max preview width and height (px)
+	_defPreviewTimeout     = 30 * time.Second // default timeout to make one preview
+	_defPreviewPDFRenderer = ""               // default pdftoppm path (PDF previews are disabled)
+
+	// media signed links
+	_defFileLinkTTL         = 10 * time.Minute // default signed file link ttl
+	_defFileLinkAccelPrefix = ""               // default X-Accel-Redirect prefix (disabled)
+	_defFileLinkAccelRoot   = "./media"        // default dir matching the X-Accel-Redirect prefix
+
+	// media resumable uploads
+	_defUploadDir     = "./media/uploads"      // default dir for unfinished uploads (staging)
+	_defUploadMaxSize = 2 * 1024 * 1024 * 1024 // default max size of the uploading file (2 GB)
+	_defUploadTTL     = 24 * time.Hour         // default upload ttl (unfinished uploads are deleted)
+
+	// media antivirus scanning
+	_defScanEnabled       = false                // default antivirus scanning state (disabled)
+	_defScanNetwork       = "tcp"                // default clamd network type
+	_defScanAddress       = "127.0.0.1:3310"     // default clamd address
+	_defScanTimeout       = 2 * time.Minute      // default timeout to scan one file
+	_defScanWorkers       = 2                    // default number of scan workers
+	_defScanQueueSize     = 100                  // default size of scan queue
+	_defScanQuarantineDir = "./media/quarantine" // default dir for infected files
+	_defScanRetryInterval = 5 * time.Minute      // default interval to enqueue unfinished files again
+
+	// comments
+	_defCommentEditWindow = 15 * time.Minute // default time after creating to edit or delete the comment
+
+	// real-time events
+	_defEventChannel    = "skadi:events"   // default redis pub/sub channel for events
+	_defEventBufferSize = 16               // default number of buffered events for one subscriber
+	_defEventHeartbeat  = 25 * time.Second // default interval of keep-alive messages
+
+	// e-mails
+	_defMailEnabled      = false                               // default e-mails state (disabled)
+	_defMailHost         = "127.0.0.1"                         // default SMTP-server host
+	_defMailPort         = "1025"                              // default SMTP-server port (local SMTP catcher)
+	_defMailImplicitTLS  = false                               // default TLS mode (STARTTLS if supported)
+	_defMailFrom         = "Skadi <noreply@platform-skadi.ru>" // default sender address
+	_defMailSiteURL      = "http://127.0.0.1:8000"             // default site URL for links in e-mails
+	_defMailTimeout      = 30 * time.Second                    // default timeout to send one e-mail
+	_defMailPollInterval = 30 * time.Second                    // default interval to check the mail queue
+	_defMailBatchSize    = 50                                  // default number of e-mails sent at once
+	_defMailMaxAttempts  = 8                                   // default max attempts to send one e-mail
+	_defMailRetryDelay   = time.Minute                         // default delay before the first retry
+
+	// telegram bot
+	_defTelegramEnabled     = false                      // default telegram bot state (disabled)
+	_defTelegramAPIURL      = "https://api.telegram.org" // default Bot API server URL
+	_defTelegramBotName     = ""                         // default bot username (links to the bot are disabled)
+	_defTelegramPoll        = true                       // default polling state (commands are received)
+	_defTelegramPollTimeout = 30 * time.Second           // default long polling timeout for updates
+	_defTelegramTimeout     = 10 * time.Second           // default timeout of one Bot API request
+	_defTelegramCodeTTL     = 10 * time.Minute           // default ttl of the one-time link code
+	_defTelegramQueueSize   = 100                        // default size of the message queue
+
+	// webhooks
+	_defWebhookTimeout      = 10 * time.Second // default timeout of one webhook request
+	_defWebhookPollInterval = 10 * time.Second // default interval to check the delivery queue
+	_defWebhookBatchSize    = 50               // default number of deliveries sent at once
+	_defWebhookMaxAttempts  = 8                // default max attempts to deliver one event
+	_defWebhookRetryDelay   = time.Minute      // default delay before the first retry
+
+	_defOutboxPollInterval = 2 * time.Second    // default interval to check the outbox
+	_defOutboxBatchSize    = 100                // default number of events dispatched at once
+	_defOutboxMaxAttempts  = 10                 // default max attempts to dispatch one event
+	_defOutboxRetryDelay   = 30 * time.Second   // default delay before the first retry
+	_defOutboxClaimTimeout = 5 * time.Minute    // default time the claimed event is hidden from other instances
+	_defOutboxRetention    = 7 * 24 * time.Hour // default time to keep dispatched events
+
+	_defClassSyncAssignOnJoin    = true // default state of issuing class tasks to joined students (enabled)
+	_defClassSyncWithdrawOnLeave = true // default state of withdrawing unstarted solutions from left students (enabled)
+
+	_defAutotestEnabled      = false              // default autotests state (disabled)
+	_defAutotestRunner       = "docker"           // default sandbox runner
+	_defAutotestRunnerPath   = "docker"           // default path to the sandbox runner binary
+	_defAutotestWorkDir      = "./media/autotest" // default dir for temporary solution copies
+	_defAutotestWorkers      = 2                  // default number of autotest workers
+	_defAutotestPollInterval = 5 * time.Second    // default interval to check the run queue
+	_defAutotestBuildTimeout = time.Minute        // default timeout to compile one solution
+	_defAutotestStartGrace   = 2 * time.Second    // default extra time to start the sandbox
+	_defAutotestMaxOutput    = 64 * 1024          // default max saved output of one test (64 KB)
+	_defAutotestStaleAfter   = 30 * time.Minute   // default time after which running runs are restarted
+
+	_defPlagiarismOnSubmit      = true             // default state of comparing solutions sent for review (enabled)
+	_defPlagiarismKGram         = 5                // default number of tokens in one fingerprint
+	_defPlagiarismWindow        = 4                // default winnowing window
+	_defPlagiarismMinSimilarity = 30               // default min similarity of the reported pair (percent)
+	_defPlagiarismMaxFileSize   = 1024 * 1024      // default max size of the compared file (1 MB)
+	_defPlagiarismMaxFragments  = 20               // default max number of saved fragments of one pair
+	_defPlagiarismWorkers       = 1                // default number of plagiarism analyzer workers
+	_defPlagiarismPollInterval  = 5 * time.Second  // default interval to check the comparison queue
+	_defPlagiarismStaleAfter    = 30 * time.Minute // default time after which running checks are restarted
+
+	_defSchedulerEnabled              = true                // default scheduler state (enabled)
+	_defSchedulerLockTTL              = 10 * time.Minute    // default lifetime of the job run lock
+	_defSchedulerHistoryRetention     = 30 * 24 * time.Hour // default time to keep job run history
+	_defSchedulerExamRemindBefore     = 24 * time.Hour      // default time before the exam closes to remind students
+	_defSchedulerDeadlineRemindBefore = 24 * time.Hour      // default time before the task deadline to remind students
 )
 
 var _dirPerms os.FileMode = 0o755 // permissions for the file dirs
 
+// default host paths mounted read-only to the nsjail sandbox
+var _defAutotestMounts = []string{"/bin", "/lib", "/lib64", "/usr", "/etc/alternatives",
+	"/dev/null", "/dev/urandom"}
+
 type (
 	Config struct {
-		Server  `yaml:"server"`
-		Logging `yaml:"logging"`
-		Cache   `yaml:"cache"`
-		DB      `yaml:"db"`
-		Media   `yaml:"media"`
+		Server     `yaml:"server"`
+		Logging    `yaml:"logging"`
+		Cache      `yaml:"cache"`
+		DB         `yaml:"db"`
+		Media      `yaml:"media"`
+		Comment    `yaml:"comment"`
+		Event      `yaml:"event"`
+		Mail       `yaml:"mail"`
+		Telegram   `yaml:"telegram"`
+		Webhook    `yaml:"webhook"`
+		Outbox     `yaml:"outbox"`
+		ClassSync  `yaml:"class_sync"`
+		Autotest   `yaml:"autotest"`
+		Plagiarism `yaml:"plagiarism"`
+		Scheduler  `yaml:"scheduler"`
 	}
 
 	Server struct {
@@ -136,6 +253,240 @@ type (
 		TaskFileDir string `yaml:"task_file_dir"`
 		// dir for solution files
 		SolutionFileDir string `yaml:"solution_file_dir"`
+		// previews for images and PDF documents
+		Preview Preview `yaml:"preview"`
+		// signed short-lived links to download files without auth
+		FileLink FileLink `yaml:"file_link"`
+		// resumable chunked uploads
+		Upload Upload `yaml:"upload"`
+		// antivirus scanning of uploaded files
+		Scan Scan `yaml:"scan"`
+	}
+
+	Preview struct {
+		// number of workers to make previews
+		Workers int `yaml:"workers"`
+		// max number of files waiting for previews
+		QueueSize int `yaml:"queue_size"`
+		// max preview width and height in pixels
+		MaxSize int `yaml:"max_size"`
+		// timeout to make one preview
+		Timeout time.Duration `yaml:"timeout"`
+		// path to pdftoppm binary (poppler-utils). If empty, PDF previews are disabled.
+		PDFRenderer string `yaml:"pdf_renderer"`
+	}
+
+	FileLink struct {
+		// secret to sign links
+		Secret []byte `env-required:"true" env:"FILE_LINK_SECRET"`
+		// link expiration duration
+		TTL time.Duration `yaml:"ttl"`
+		// internal nginx location prefix for X-Accel-Redirect. If empty, backend sends files itself.
+		AccelPrefix string `yaml:"accel_prefix"`
+		// dir in the file system matching the X-Accel-Redirect prefix
+		AccelRoot string `yaml:"accel_root"`
+	}
+
+	Upload struct {
+		// dir for unfinished uploads (staging). It must be on the same disk with the file dirs.
+		Dir string `yaml:"dir"`
+		// max size of the uploading file in bytes
+		MaxSize int64 `yaml:"max_size"`
+		// upload expiration duration (unfinished and not taken uploads are deleted)
+		TTL time.Duration `yaml:"ttl"`
+	}
+
+	Scan struct {
+		// if true, uploaded files are scanned by clamd and cannot be downloaded until they are clean
+		Enabled bool `yaml:"enabled"`
+		// clamd network type ("tcp" or "unix")
+		Network string `yaml:"network"`
+		// clamd address (host:port for tcp or socket path for unix)
+		Address string `yaml:"address"`
+		// timeout to scan one file
+		Timeout time.Duration `yaml:"timeout"`
+		// number of workers to scan files
+		Workers int `yaml:"workers"`
+		// max number of files waiting for scanning
+		QueueSize int `yaml:"queue_size"`
+		// dir for infected files. It must be on the same disk with the file dirs.
+		QuarantineDir string `yaml:"quarantine_dir"`
+		// interval to enqueue again files left pending (the queue was full) or failed
+		RetryInterval time.Duration `yaml:"retry_interval"`
+	}
+
+	Comment struct {
+		// time after creating when the author can edit or delete the comment
+		EditWindow time.Duration `yaml:"edit_window"`
+	}
+
+	Event struct {
+		// redis pub/sub channel to fan out events to all backend instances
+		Channel string `yaml:"channel"`
+		// max number of buffered events for one subscriber (extra events are dropped)
+		BufferSize int `yaml:"buffer_size"`
+		// interval of keep-alive messages in the event stream
+		Heartbeat time.Duration `yaml:"heartbeat"`
+	}
+
+	Mail struct {
+		// if true, e-mails are enqueued and sent
+		Enabled bool `yaml:"enabled"`
+		// SMTP-server host
+		Host string `yaml:"host"`
+		// SMTP-server port
+		Port string `yaml:"port"`
+		// SMTP username (auth is disabled if it is empty)
+		Username string `yaml:"username"`
+		// SMTP password
+		Password string `env:"SMTP_PASSWORD"`
+		// if true, connection uses TLS from the start (port 465), otherwise STARTTLS is used if supported
+		ImplicitTLS bool `yaml:"implicit_tls"`
+		// sender address (with optional name)
+		From string `yaml:"from"`
+		// site URL for links in e-mails
+		SiteURL string `yaml:"site_url"`
+		// timeout to send one e-mail
+		Timeout time.Duration `yaml:"timeout"`
+		// interval to check the mail queue
+		PollInterval time.Duration `yaml:"poll_interval"`
+		// max number of e-mails sent at once
+		BatchSize int `yaml:"batch_size"`
+		// max attempts to send one e-mail
+		MaxAttempts int `yaml:"max_attempts"`
+		// delay before the first retry (it is doubled for every next one)
+		RetryDelay time.Duration `yaml:"retry_delay"`
+	}
+
+	Telegram struct {
+		// if true, bot gets commands and sends notifications to the linked chats
+		Enabled bool `yaml:"enabled"`
+		// bot token from @BotFather
+		Token string `env:"TELEGRAM_TOKEN"`
+		// Bot API server URL (it can be replaced with a local fake server for testing)
+		APIURL string `yaml:"api_url"`
+		// bot username for the links with the link code (links are disabled if it is empty)
+		BotName string `yaml:"bot_name"`
+		// if true, commands are received by long polling (it must be enabled on one backend instance only)
+		Poll bool `yaml:"poll"`
+		// long polling timeout for updates
+		PollTimeout time.Duration `yaml:"poll_timeout"`
+		// timeout of one Bot API request
+		Timeout time.Duration `yaml:"timeout"`
+		// ttl of the one-time code to link the chat with the account
+		CodeTTL time.Duration `yaml:"code_ttl"`
+		// max number of messages waiting for sending (extra messages are dropped)
+		QueueSize int `yaml:"queue_size"`
+	}
+
+	Webhook struct {
+		// timeout of one webhook request
+		Timeout time.Duration `yaml:"timeout"`
+		// interval to check the delivery queue
+		PollInterval time.Duration `yaml:"poll_interval"`
+		// max number of deliveries sent at once
+		BatchSize int `yaml:"batch_size"`
+		// max attempts to deliver one event
+		MaxAttempts int `yaml:"max_attempts"`
+		// delay before the first retry (it is doubled for every next one)
+		RetryDelay time.Duration `yaml:"retry_delay"`
+	}
+
+	Outbox struct {
+		// interval to check the outbox for new events
+		PollInterval time.Duration `yaml:"poll_interval"`
+		// max number of events dispatched at once
+		BatchSize int `yaml:"batch_size"`
+		// max attempts to dispatch one event
+		MaxAttempts int `yaml:"max_attempts"`
+		// delay before the first retry (it is doubled for every next one)
+		RetryDelay time.Duration `yaml:"retry_delay"`
+		// time the claimed event is hidden from other instances (it must be longer than the slowest handler)
+		ClaimTimeout time.Duration `yaml:"claim_timeout"`
+		// time to keep dispatched events before deletion
+		Retention time.Duration `yaml:"retention"`
+	}
+
+	ClassSync struct {
+		// if true, students joined the class get solutions for all tasks issued to this class
+		AssignOnJoin bool `yaml:"assign_on_join"`
+		// if true, unstarted solutions of the class tasks are withdrawn from students left the class
+		// (solutions with the answer, files, grade or changed status are always kept)
+		WithdrawOnLeave bool `yaml:"withdraw_on_leave"`
+	}
+
+	Autotest struct {
+		// if true, solutions sent for review are tested in the sandbox
+		Enabled bool `yaml:"enabled"`
+		// sandbox runner ("docker" or "nsjail")
+		Runner string `yaml:"runner"`
+		// path to the sandbox runner binary
+		RunnerPath string `yaml:"runner_path"`
+		// host paths mounted read-only to the empty root of the nsjail sandbox (missing ones are skipped)
+		Mounts []string `yaml:"mounts"`
+		// dir for temporary copies of the solution files (it must be accessible by the runner)
+		WorkDir string `yaml:"work_dir"`
+		// number of workers to test solutions
+		Workers int `yaml:"workers"`
+		// interval to check the run queue
+		PollInterval time.Duration `yaml:"poll_interval"`
+		// timeout to compile one solution
+		BuildTimeout time.Duration `yaml:"build_timeout"`
+		// extra time to start the sandbox added to the test time limit
+		StartGrace time.Duration `yaml:"start_grace"`
+		// max saved stdout and stderr of one test in bytes
+		MaxOutput int `yaml:"max_output"`
+		// time after which unfinished running runs are restarted (e.g. after the instance crash)
+		StaleAfter time.Duration `yaml:"stale_after"`
+		// supported languages by names
+		Langs map[string]AutotestLang `yaml:"langs"`
+	}
+
+	AutotestLang struct {
+		// docker image (for docker runner only)
+		Image string `yaml:"image"`
+		// entry file name (the only solution file with the same extension is renamed to it)
+		Entry string `yaml:"entry"`
+		// shell command to compile the solution (it is skipped if empty)
+		Build string `yaml:"build"`
+		// shell command to run the solution
+		Run string `yaml:"run"`
+	}
+
+	Plagiarism struct {
+		// if true, solutions sent for review are compared with other solutions of the task
+		OnSubmit bool `yaml:"on_submit"`
+		// number of tokens in one fingerprinted fragment (k-gram)
+		KGram int `yaml:"kgram"`
+		// winnowing window (matches of at least kgram+window-1 tokens are always found)
+		Window int `yaml:"window"`
+		// min similarity of the solution pair to report it (percent)
+		MinSimilarity int `yaml:"min_similarity"`
+		// max size of the compared solution file in bytes (larger files are skipped)
+		MaxFileSize int64 `yaml:"max_file_size"`
+		// max number of saved matching fragments of one pair (the longest ones are kept)
+		MaxFragments int `yaml:"max_fragments"`
+		// number of workers to compare solutions
+		Workers int `yaml:"workers"`
+		// interval to check the queue of task checks and solutions sent for review
+		PollInterval time.Duration `yaml:"poll_interval"`
+		// time after which unfinished running checks are restarted (e.g. after the instance crash)
+		StaleAfter time.Duration `yaml:"stale_after"`
+	}
+
+	Scheduler struct {
+		// if true, background jobs are run on schedule
+		Enabled bool `yaml:"enabled"`
+		// lifetime of the job run lock (other instances skip the same scheduled run while it is set)
+		LockTTL time.Duration `yaml:"lock_ttl"`
+		// time to keep job run history
+		HistoryRetention time.Duration `yaml:"history_retention"`
+		// time before the exam closes to remind students who have not sent solutions yet
+		ExamRemindBefore time.Duration `yaml:"exam_remind_before"`
+		// time before the task deadline to remind students who have not sent solutions yet
+		DeadlineRemindBefore time.Duration `yaml:"deadline_remind_before"`
+		// cron expressions (UTC) of job schedules by job names (job is disabled if it is empty)
+		Jobs map[string]string `yaml:"jobs"`
 	}
 )
 
@@ -191,6 +542,137 @@ func NewDefault() *Config {
 		Media: Media{
 			TaskFileDir:     _defTaskFileDir,
 			SolutionFileDir: _defSolutionFileDir,
+			Preview: Preview{
+				Workers:     _defPreviewWorkers,
+				QueueSize:   _defPreviewQueueSize,
+				MaxSize:     _defPreviewMaxSize,
+				Timeout:     _defPreviewTimeout,
+				PDFRenderer: _defPreviewPDFRenderer,
+			},
+			FileLink: FileLink{
+				TTL:         _defFileLinkTTL,
+				AccelPrefix: _defFileLinkAccelPrefix,
+				AccelRoot:   _defFileLinkAccelRoot,
+			},
+			Upload: Upload{
+				Dir:     _defUploadDir,
+				MaxSize: _defUploadMaxSize,
+				TTL:     _defUploadTTL,
+			},
+			Scan: Scan{
+				Enabled:       _defScanEnabled,
+				Network:       _defScanNetwork,
+				Address:       _defScanAddress,
+				Timeout:       _defScanTimeout,
+				Workers:       _defScanWorkers,
+				QueueSize:     _defScanQueueSize,
+				QuarantineDir: _defScanQuarantineDir,
+				RetryInterval: _defScanRetryInterval,
+			},
+		},
+		Comment: Comment{
+			EditWindow: _defCommentEditWindow,
+		},
+		Event: Event{
+			Channel:    _defEventChannel,
+			BufferSize: _defEventBufferSize,
+			Heartbeat:  _defEventHeartbeat,
+		},
+		Mail: Mail{
+			Enabled:      _defMailEnabled,
+			Host:         _defMailHost,
+			Port:         _defMailPort,
+			ImplicitTLS:  _defMailImplicitTLS,
+			From:         _defMailFrom,
+			SiteURL:      _defMailSiteURL,
+			Timeout:      _defMailTimeout,
+			PollInterval: _defMailPollInterval,
+			BatchSize:    _defMailBatchSize,
+			MaxAttempts:  _defMailMaxAttempts,
+			RetryDelay:   _defMailRetryDelay,
+		},
+		Telegram: Telegram{
+			Enabled:     _defTelegramEnabled,
+			APIURL:      _defTelegramAPIURL,
+			BotName:     _defTelegramBotName,
+			Poll:        _defTelegramPoll,
+			PollTimeout: _defTelegramPollTimeout,
+			Timeout:     _defTelegramTimeout,
+			CodeTTL:     _defTelegramCodeTTL,
+			QueueSize:   _defTelegramQueueSize,
+		},
+		Webhook: Webhook{
+			Timeout:      _defWebhookTimeout,
+			PollInterval: _defWebhookPollInterval,
+			BatchSize:    _defWebhookBatchSize,
+			MaxAttempts:  _defWebhookMaxAttempts,
+			RetryDelay:   _defWebhookRetryDelay,
+		},
+		Outbox: Outbox{
+			PollInterval: _defOutboxPollInterval,
+			BatchSize:    _defOutboxBatchSize,
+			MaxAttempts:  _defOutboxMaxAttempts,
+			RetryDelay:   _defOutboxRetryDelay,
+			ClaimTimeout: _defOutboxClaimTimeout,
+			Retention:    _defOutboxRetention,
+		},
+		ClassSync: ClassSync{
+			AssignOnJoin:    _defClassSyncAssignOnJoin,
+			WithdrawOnLeave: _defClassSyncWithdrawOnLeave,
+		},
+		Autotest: Autotest{
+			Enabled:      _defAutotestEnabled,
+			Runner:       _defAutotestRunner,
+			RunnerPath:   _defAutotestRunnerPath,
+			Mounts:       slices.Clone(_defAutotestMounts),
+			WorkDir:      _defAutotestWorkDir,
+			Workers:      _defAutotestWorkers,
+			PollInterval: _defAutotestPollInterval,
+			BuildTimeout: _defAutotestBuildTimeout,
+			StartGrace:   _defAutotestStartGrace,
+			MaxOutput:    _defAutotestMaxOutput,
+			StaleAfter:   _defAutotestStaleAfter,
+			Langs: map[string]AutotestLang{
+				"python": {
+					Image: "python:3.12-alpine",
+					Entry: "main.py",
+					Run:   "python3 main.py",
+				},
+				"go": {
+					Image: "golang:1.25-alpine",
+					Entry: "main.go",
+					Build: "go build -o prog *.go",
+					Run:   "./prog",
+				},
+			},
+		},
+		Plagiarism: Plagiarism{
+			OnSubmit:      _defPlagiarismOnSubmit,
+			KGram:         _defPlagiarismKGram,
+			Window:        _defPlagiarismWindow,
+			MinSimilarity: _defPlagiarismMinSimilarity,
+			MaxFileSize:   _defPlagiarismMaxFileSize,
+			MaxFragments:  _defPlagiarismMaxFragments,
+			Workers:       _defPlagiarismWorkers,
+			PollInterval:  _defPlagiarismPollInterval,
+			StaleAfter:    _defPlagiarismStaleAfter,
+		},
+		Scheduler: Scheduler{
+			Enabled:              _defSchedulerEnabled,
+			LockTTL:              _defSchedulerLockTTL,
+			HistoryRetention:     _defSchedulerHistoryRetention,
+			ExamRemindBefore:     _defSchedulerExamRemindBefore,
+			DeadlineRemindBefore: _defSchedulerDeadlineRemindBefore,
+			Jobs: map[string]string{
+				"mail_digest":       "0 18 * * *",
+				"parent_summary":    "0 18 * * 0",
+				"upload_cleanup":    "*/30 * * * *",
+				"history_cleanup":   "0 3 * * *",
+				"task_publish":      "* * * * *",
+				"markdown_render":   "*/10 * * * *",
+				"exam_reminder":     "*/10 * * * *",
+				"deadline_reminder": "*/10 * * * *",
+			},
 		},
 	}
 }
@@ -223,13 +705,22 @@ func New() (*Config, error) {
 	// collect DB connection URL string for migrate manager
 	cfg.DB.Migration.DB = "mysql://" + cfg.DB.DSN
 
-	// create task and solution files dirs
+	// create task and solution files dirs, upload, quarantine and autotest dirs
 	if err := mkdirP(cfg.Media.TaskFileDir); err != nil {
 		return nil, fmt.Errorf("create task file dir: %w", err)
 	}
 	if err := mkdirP(cfg.Media.SolutionFileDir); err != nil {
 		return nil, fmt.Errorf("create solution file dir: %w", err)
 	}
+	if err := mkdirP(cfg.Media.Upload.Dir); err != nil {
+		return nil, fmt.Errorf("create upload dir: %w", err)
+	}
+	if err := mkdirP(cfg.Media.Scan.QuarantineDir); err != nil {
+		return nil, fmt.Errorf("create quarantine dir: %w", err)
+	}
+	if err := mkdirP(cfg.Autotest.WorkDir); err != nil {
+		return nil, fmt.Errorf("create autotest work dir: %w", err)
+	}
 	return cfg, nil
 }
 
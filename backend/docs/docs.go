@@ -114,6 +114,246 @@ const docTemplate = `{
                 }
             }
         },
+        "/autotest/solution/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение последнего запуска автотестов решения с результатами по каждому тесту и предлагаемой оценкой (процент пройденных тестов).\nТесты запускаются автоматически, когда решение переводится в статус \"на проверке\".\nДля ученика у скрытых тестов не возвращаются входные данные и вывод программы, а у упавшего запуска - ошибка песочницы.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "autotest"
+                ],
+                "summary": "Получение результатов автотестов решения.",
+                "operationId": "autotest-read-run",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID решения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.AutotestRun"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "решение не найдено | решение ещё не тестировалось"
+                    }
+                }
+            }
+        },
+        "/autotest/solution/{id}/run": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Постановка решения своего задания в очередь на тестирование (например, после изменения тестов). Прошлый запуск заменяется.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "autotest"
+                ],
+                "summary": "Перезапуск автотестов решения. [Только преподаватель]",
+                "operationId": "autotest-rerun",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID решения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entity.AutotestRun"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "решение не найдено | автотесты не заданы"
+                    },
+                    "409": {
+                        "description": "автотесты отключены"
+                    }
+                }
+            }
+        },
+        "/autotest/task/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение языка программирования и тестов (ввод, ожидаемый вывод, ограничения времени и памяти) своего задания.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "autotest"
+                ],
+                "summary": "Получение набора автотестов задания. [Только преподаватель]",
+                "operationId": "autotest-read-suite",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.AutotestSuite"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "задание не найдено | автотесты не заданы"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Полная замена языка программирования и тестов своего задания (тесты выполняются в переданном порядке).\nРешения тестируются в изолированной песочнице без сети, когда ученик переводит решение в статус \"на проверке\".\nВывод программы сравнивается с ожидаемым без учёта пробелов в концах строк и пустых строк в конце.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "autotest"
+                ],
+                "summary": "Изменение набора автотестов задания. [Только преподаватель]",
+                "operationId": "autotest-update-suite",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "suiteBody",
+                        "name": "suiteBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.suiteBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.AutotestSuite"
+                        }
+                    },
+                    "400": {
+                        "description": "язык не поддерживается"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "задание не найдено"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Удаление всех тестов своего задания. Результаты прошлых запусков сохраняются.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "autotest"
+                ],
+                "summary": "Удаление набора автотестов задания. [Только преподаватель]",
+                "operationId": "autotest-delete-suite",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    }
+                }
+            }
+        },
         "/class": {
             "get": {
                 "security": [
@@ -414,41 +654,53 @@ const docTemplate = `{
                 }
             }
         },
-        "/example/admin": {
-            "get": {
+        "/comment/{id}": {
+            "delete": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Проверочный эндпоинт с доступом только для админов.",
-                "produces": [
+                "description": "Удаление своего комментария. Удалить комментарий можно только в течение ограниченного времени после его создания.\nКомментарий остаётся в списке без текста, чтобы не разрывать ветку ответов.",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "example"
+                    "comment"
+                ],
+                "summary": "Удаление комментария. [Преподаватель и ученик]",
+                "operationId": "comment-delete",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID комментария",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Проверка. [Только админ]",
-                "operationId": "example-admin",
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/v1.exampleData"
-                        }
+                    "204": {
+                        "description": "комментарий удалён"
                     },
                     "401": {
-                        "description": "Неверный токен (пустой, истекший или неверный формат)"
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
                     },
                     "403": {
-                        "description": "Юзер не админ, доступ запрещён"
+                        "description": "доступ запрещён | время редактирования комментария истекло"
+                    },
+                    "404": {
+                        "description": "комментарий не найден"
                     }
                 }
-            }
-        },
-        "/example/free": {
-            "get": {
-                "description": "Проверочный эндпоинт с доступом абсолютно всем.",
+            },
+            "patch": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Изменение текста своего комментария. Редактировать комментарий можно только в течение ограниченного времени после его создания.",
                 "consumes": [
                     "application/json"
                 ],
@@ -456,165 +708,136 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "example"
+                    "comment"
                 ],
-                "summary": "Проверка. [Все]",
-                "operationId": "example-free",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/v1.exampleData"
-                        }
-                    }
-                }
-            }
-        },
-        "/example/private": {
-            "get": {
-                "security": [
+                "summary": "Редактирование комментария. [Преподаватель и ученик]",
+                "operationId": "comment-update",
+                "parameters": [
                     {
-                        "JWTAccess": []
+                        "type": "integer",
+                        "description": "ID комментария",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "updateCommentBody",
+                        "name": "updateCommentBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.updateCommentBody"
+                        }
                     }
                 ],
-                "description": "Проверочный эндпоинт с доступом только для авторизованных юзеров.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "example"
-                ],
-                "summary": "Проверка. [Только авторизованные]",
-                "operationId": "example-private",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.exampleData"
+                            "$ref": "#/definitions/entity.Comment"
                         }
                     },
                     "401": {
-                        "description": "Неверный токен (пустой, истекший или неверный формат)"
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
                     },
                     "403": {
-                        "description": "Юзер не авторизован, доступ запрещён"
+                        "description": "доступ запрещён | время редактирования комментария истекло"
+                    },
+                    "404": {
+                        "description": "комментарий не найден"
                     }
                 }
             }
         },
-        "/example/student": {
-            "get": {
+        "/course": {
+            "post": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Проверочный эндпоинт с доступом только для студентов.",
+                "description": "Создание нового курса преподавателя, привязанного к переданным группам.\nКурс состоит из упорядоченных модулей, модули - из упорядоченных заданий.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "example"
+                    "course"
                 ],
-                "summary": "Проверка. [Только ученик]",
-                "operationId": "example-student",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "Создание курса. [Только преподаватель]",
+                "operationId": "course-create",
+                "parameters": [
+                    {
+                        "description": "courseBody",
+                        "name": "courseBody",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.exampleData"
+                            "$ref": "#/definitions/v1.courseBody"
                         }
-                    },
-                    "401": {
-                        "description": "Неверный токен (пустой, истекший или неверный формат)"
-                    },
-                    "403": {
-                        "description": "Юзер не студент, доступ запрещён"
-                    }
-                }
-            }
-        },
-        "/example/teacher": {
-            "get": {
-                "security": [
-                    {
-                        "JWTAccess": []
                     }
                 ],
-                "description": "Проверочный эндпоинт с доступом только для преподавателей.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "example"
-                ],
-                "summary": "Проверка. [Только преподаватель]",
-                "operationId": "example-teacher",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/v1.exampleData"
+                            "$ref": "#/definitions/entity.Course"
                         }
                     },
-                    "401": {
-                        "description": "Неверный токен (пустой, истекший или неверный формат)"
+                    "400": {
+                        "description": "группа не найдена"
                     },
-                    "403": {
-                        "description": "Юзер не преподаватель, доступ запрещён"
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
                     }
                 }
             }
         },
-        "/file/{id}": {
+        "/course/for-student": {
             "get": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Загрузка файла по его id.",
+                "description": "Получение курсов, привязанных к группе ученика или содержащих его задания.\nДля каждого модуля возвращаются решения ученика (в порядке заданий) и прогресс по статусам решений, для курса - общий прогресс.",
                 "consumes": [
                     "application/json"
                 ],
-                "tags": [
-                    "file"
+                "produces": [
+                    "application/json"
                 ],
-                "summary": "Загрузка файла по id. [Преподаватель и ученик]",
-                "operationId": "file-download",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID файла",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                "tags": [
+                    "course"
                 ],
+                "summary": "Получение списка курсов ученика. [Только ученик]",
+                "operationId": "course-list-for-student",
                 "responses": {
                     "200": {
-                        "description": "файл"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Course"
+                            }
+                        }
                     },
                     "401": {
                         "description": "неверный токен (пустой, истекший или неверный формат)"
-                    },
-                    "403": {
-                        "description": "доступ запрещён"
-                    },
-                    "404": {
-                        "description": "файл не найден"
                     }
                 }
             }
         },
-        "/solution/for-student": {
+        "/course/for-teacher": {
             "get": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Получение списка решений конкретного ученика.",
+                "description": "Получение списка курсов преподавателя (с группами и модулями).",
                 "consumes": [
                     "application/json"
                 ],
@@ -622,10 +845,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "solution"
+                    "course"
                 ],
-                "summary": "Получение списка решений. [Только ученик]",
-                "operationId": "solution-for-student-list",
+                "summary": "Получение списка курсов. [Только преподаватель]",
+                "operationId": "course-list-for-teacher",
                 "parameters": [
                     {
                         "minimum": 1,
@@ -646,30 +869,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "example": "HTML",
-                        "description": "case-insensitive substring to filter data by task title only for students\nor by task title or student fullname for teacher",
+                        "example": "Python",
+                        "description": "substring to filter data by substring (case-insensitive)",
                         "name": "search",
                         "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        },
-                        "collectionFormat": "multi",
-                        "example": [
-                            2
-                        ],
-                        "description": "solution status IDs (accepted: 1, 2, 3, 4)",
-                        "name": "status_id",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.listSolutionOut"
+                            "$ref": "#/definitions/v1.listCourseOut"
                         }
                     },
                     "401": {
@@ -678,76 +888,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/solution/for-student/{id}": {
-            "patch": {
+        "/course/{id}": {
+            "get": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Частичное обновление решения (только переданные поля: статус, кроме \"проверено\", ответ, файл ответа) по его id.",
+                "description": "Получение своего курса с группами, модулями и заданиями модулей (в порядке их позиций).",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "solution"
+                    "course"
                 ],
-                "summary": "Обновление решения. [Только ученик]",
-                "operationId": "solution-for-student-update",
+                "summary": "Получение курса по id. [Только преподаватель]",
+                "operationId": "course-read",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID решения",
+                        "type": "integer",
+                        "description": "ID курса",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "new status ID",
-                        "name": "status_id",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "new answer",
-                        "name": "answer",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        },
-                        "collectionFormat": "multi",
-                        "description": "IDs of files to delete from the solution",
-                        "name": "delete_files",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "file"
-                        },
-                        "collectionFormat": "multi",
-                        "description": "new solution files",
-                        "name": "file",
-                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Solution"
+                            "$ref": "#/definitions/entity.Course"
                         }
                     },
-                    "400": {
-                        "description": "неверный статус | отправка на проверку возможна только при наличии ответа"
-                    },
                     "401": {
                         "description": "неверный токен (пустой, истекший или неверный формат)"
                     },
@@ -755,19 +930,17 @@ const docTemplate = `{
                         "description": "доступ запрещён"
                     },
                     "404": {
-                        "description": "решение не найдено"
+                        "description": "курс не найден"
                     }
                 }
-            }
-        },
-        "/solution/for-teacher": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Получение списка решений для заданий конкретного преподавателя.",
+                "description": "Удаление своего курса с его модулями по его id. Задания модулей не удаляются.",
                 "consumes": [
                     "application/json"
                 ],
@@ -775,70 +948,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "solution"
+                    "course"
                 ],
-                "summary": "Получение списка решений. [Только преподаватель]",
-                "operationId": "solution-for-teacher-list",
+                "summary": "Удаление курса по id. [Только преподаватель]",
+                "operationId": "course-delete",
                 "parameters": [
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "example": 1,
-                        "description": "page pagination param",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 1,
                         "type": "integer",
-                        "default": 10,
-                        "example": 5,
-                        "description": "per page pagination param",
-                        "name": "per-page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "HTML",
-                        "description": "case-insensitive substring to filter data by task title only for students\nor by task title or student fullname for teacher",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        },
-                        "collectionFormat": "multi",
-                        "example": [
-                            2
-                        ],
-                        "description": "solution status IDs (accepted: 1, 2, 3, 4)",
-                        "name": "status_id",
-                        "in": "query"
+                        "description": "ID курса",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/v1.listSolutionOut"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "401": {
                         "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
                     }
                 }
-            }
-        },
-        "/solution/for-teacher/{id}": {
+            },
             "patch": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Частичное обновление решения (только переданные поля: статус - \"готово\"/\"проверено\" - и оценка) по его id.",
+                "description": "Частичное обновление своего курса (только переданные поля: название, описание, привязанные группы) по его id.",
                 "consumes": [
                     "application/json"
                 ],
@@ -846,25 +987,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "solution"
+                    "course"
                 ],
-                "summary": "Обновление решения. [Только преподаватель]",
-                "operationId": "solution-for-teacher-update",
+                "summary": "Обновление курса. [Только преподаватель]",
+                "operationId": "course-update",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID решения",
+                        "type": "integer",
+                        "description": "ID курса",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "updateSolutionBody",
-                        "name": "updateSolutionBody",
+                        "description": "updateCourseBody",
+                        "name": "updateCourseBody",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.updateSolutionBody"
+                            "$ref": "#/definitions/v1.updateCourseBody"
                         }
                     }
                 ],
@@ -872,11 +1013,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Solution"
+                            "$ref": "#/definitions/entity.Course"
                         }
                     },
                     "400": {
-                        "description": "статус не найден"
+                        "description": "группа не найдена"
                     },
                     "401": {
                         "description": "неверный токен (пустой, истекший или неверный формат)"
@@ -885,19 +1026,19 @@ const docTemplate = `{
                         "description": "доступ запрещён"
                     },
                     "404": {
-                        "description": "решение не найдено"
+                        "description": "курс не найден"
                     }
                 }
             }
         },
-        "/solution/{id}": {
-            "get": {
+        "/course/{id}/module": {
+            "post": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Получение всех данных о решении задания (с файлами решения) с полной инфой о задании (с файлами задания) и преподе (ID и полное имя), а также со списком учеников, которые тоже выполняют это задание.",
+                "description": "Создание нового модуля в конце своего курса.",
                 "consumes": [
                     "application/json"
                 ],
@@ -905,24 +1046,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "solution"
+                    "course"
                 ],
-                "summary": "Получение решения задания по id. [Преподаватель и ученик]",
-                "operationId": "solution-read",
+                "summary": "Создание модуля курса. [Только преподаватель]",
+                "operationId": "course-create-module",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID решения задания",
+                        "description": "ID курса",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "moduleBody",
+                        "name": "moduleBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.moduleBody"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/v1.solutionOut"
+                            "$ref": "#/definitions/entity.CourseModule"
                         }
                     },
                     "401": {
@@ -932,17 +1082,19 @@ const docTemplate = `{
                         "description": "доступ запрещён"
                     },
                     "404": {
-                        "description": "решение задания не найдено"
+                        "description": "курс не найден"
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/course/{id}/module/order": {
+            "put": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Удаление решения (не задания целиком) со всеми файлами решения по его id.",
+                "description": "Изменение порядка модулей своего курса. Должны быть переданы все модули курса (каждый один раз).",
                 "consumes": [
                     "application/json"
                 ],
@@ -950,40 +1102,58 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "solution"
+                    "course"
                 ],
-                "summary": "Удаление решения по id. [Только преподаватель]",
-                "operationId": "solution-delete",
+                "summary": "Изменение порядка модулей курса. [Только преподаватель]",
+                "operationId": "course-order-modules",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID решения",
+                        "type": "integer",
+                        "description": "ID курса",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "moduleOrderBody",
+                        "name": "moduleOrderBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.moduleOrderBody"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Course"
+                        }
+                    },
+                    "400": {
+                        "description": "неверный список модулей"
                     },
                     "401": {
                         "description": "неверный токен (пустой, истекший или неверный формат)"
                     },
                     "403": {
                         "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "курс не найден"
                     }
                 }
             }
         },
-        "/solution/{id}/comment": {
-            "get": {
+        "/course/{id}/module/{moduleID}": {
+            "delete": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Получение списка комментариев для данного решения задания.",
+                "description": "Удаление модуля своего курса. Задания модуля не удаляются.",
                 "consumes": [
                     "application/json"
                 ],
@@ -991,61 +1161,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "comment"
+                    "course"
                 ],
-                "summary": "Получение комментариев под решением задания. [Преподаватель и ученик]",
-                "operationId": "comment-list",
+                "summary": "Удаление модуля курса. [Только преподаватель]",
+                "operationId": "course-delete-module",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID решения задания",
+                        "description": "ID курса",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "example": 1,
-                        "description": "page pagination param",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 1,
                         "type": "integer",
-                        "default": 10,
-                        "example": 5,
-                        "description": "per page pagination param",
-                        "name": "per-page",
-                        "in": "query"
+                        "description": "ID модуля",
+                        "name": "moduleID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/v1.listCommentOut"
-                        }
-                    },
-                    "400": {
-                        "description": "решение задания не найдено"
+                    "204": {
+                        "description": "No Content"
                     },
                     "401": {
                         "description": "неверный токен (пустой, истекший или неверный формат)"
                     },
                     "403": {
                         "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "курс не найден"
                     }
                 }
             },
-            "post": {
+            "patch": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Создание комментария от лица преподавателя или ученика для данного решения задания.",
+                "description": "Изменение названия модуля своего курса.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1053,55 +1210,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "comment"
+                    "course"
                 ],
-                "summary": "Создание комментария под решением задания. [Преподаватель и ученик]",
-                "operationId": "comment-create",
+                "summary": "Обновление модуля курса. [Только преподаватель]",
+                "operationId": "course-update-module",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID решения задания",
+                        "description": "ID курса",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "commentBody",
-                        "name": "commentBody",
+                        "type": "integer",
+                        "description": "ID модуля",
+                        "name": "moduleID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "moduleBody",
+                        "name": "moduleBody",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.commentBody"
+                            "$ref": "#/definitions/v1.moduleBody"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Comment"
+                            "$ref": "#/definitions/entity.CourseModule"
                         }
                     },
-                    "400": {
-                        "description": "решение задания не найдено"
-                    },
                     "401": {
                         "description": "неверный токен (пустой, истекший или неверный формат)"
                     },
                     "403": {
                         "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "курс не найден | модуль не найден"
                     }
                 }
             }
         },
-        "/task": {
-            "get": {
+        "/course/{id}/module/{moduleID}/task": {
+            "put": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Получение списка заданий (с файлами задания и учениками, которые выполняют это задание) конкретного преподавателя.",
+                "description": "Замена списка заданий модуля своего курса своими заданиями в переданном порядке.\nЗадание может входить только в один модуль, поэтому оно убирается из предыдущего модуля.\nЗадания, отсутствующие в списке, убираются из модуля (но не удаляются).",
                 "consumes": [
                     "application/json"
                 ],
@@ -1109,437 +1273,293 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "course"
                 ],
-                "summary": "Получение списка заданий. [Только преподаватель]",
-                "operationId": "task-list",
+                "summary": "Изменение заданий модуля курса. [Только преподаватель]",
+                "operationId": "course-set-module-tasks",
                 "parameters": [
                     {
-                        "minimum": 1,
                         "type": "integer",
-                        "example": 1,
-                        "description": "page pagination param",
-                        "name": "page",
-                        "in": "query"
+                        "description": "ID курса",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "minimum": 1,
                         "type": "integer",
-                        "default": 10,
-                        "example": 5,
-                        "description": "per page pagination param",
-                        "name": "per-page",
-                        "in": "query"
+                        "description": "ID модуля",
+                        "name": "moduleID",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "example": "F26",
-                        "description": "substring to filter data by substring (case-insensitive)",
-                        "name": "search",
-                        "in": "query"
+                        "description": "moduleTasksBody",
+                        "name": "moduleTasksBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.moduleTasksBody"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.listTaskOut"
+                            "$ref": "#/definitions/entity.Course"
                         }
                     },
+                    "400": {
+                        "description": "неверное задание"
+                    },
                     "401": {
                         "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "курс не найден | модуль не найден"
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/events": {
+            "get": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Создание нового задания (с прикреплением файлов) для переданных учеников и учеников из переданных групп.",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Server-Sent Events поток событий для текущего пользователя.\nКаждое событие передаётся в поле data в виде JSON с типом (type) и данными (data):\ncomment.created - новый комментарий к решению (данные - комментарий),\nsolution.updated - изменение статуса, оценки или ответа решения (данные - решение),\ntask.assigned - новое задание для ученика (данные - задание).\nДля поддержания соединения периодически отправляются комментарии (\": ping\").\nПосле разрыва соединения клиент должен переподключиться (EventSource делает это сам).",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "task"
-                ],
-                "summary": "Создание нового задания. [Только преподаватель]",
-                "operationId": "task-create",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "task title",
-                        "name": "title",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "task description",
-                        "name": "description",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        },
-                        "collectionFormat": "multi",
-                        "description": "classes for task solutions (list of class IDs)",
-                        "name": "classes",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        },
-                        "collectionFormat": "multi",
-                        "description": "students for task solutions (list of student IDs)",
-                        "name": "students",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "file"
-                        },
-                        "collectionFormat": "multi",
-                        "description": "task files",
-                        "name": "file",
-                        "in": "formData"
-                    }
+                    "event"
                 ],
+                "summary": "Поток событий в реальном времени. [Преподаватель и ученик]",
+                "operationId": "event-stream",
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.createTaskOut"
+                            "$ref": "#/definitions/entity.Event"
                         }
                     },
-                    "400": {
-                        "description": "неверный ученик | неверный преподаватель | преподаватель не найден"
-                    },
                     "401": {
                         "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
                     }
                 }
             }
         },
-        "/task/{id}": {
+        "/example/admin": {
             "get": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Получение всех данных о задании (включая метаданные файлов) и преподе (ID и полное имя), а также списка учеников, которые выполняют это задание.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Проверочный эндпоинт с доступом только для админов.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "task"
-                ],
-                "summary": "Получение задания по id. [Только преподаватель]",
-                "operationId": "task-read",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID решения задания",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "example"
                 ],
+                "summary": "Проверка. [Только админ]",
+                "operationId": "example-admin",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.TaskWithStudents"
+                            "$ref": "#/definitions/v1.exampleData"
                         }
                     },
                     "401": {
-                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                        "description": "Неверный токен (пустой, истекший или неверный формат)"
                     },
                     "403": {
-                        "description": "доступ запрещён"
-                    },
-                    "404": {
-                        "description": "задание не найдено"
+                        "description": "Юзер не админ, доступ запрещён"
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JWTAccess": []
-                    }
-                ],
-                "description": "Удаление задания целиком (со всеми его файлами и решениями) по его id.",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/example/free": {
+            "get": {
+                "description": "Проверочный эндпоинт с доступом абсолютно всем.",
+                "consumes": [
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "example"
                 ],
-                "summary": "Удаление задания по id. [Только преподаватель]",
-                "operationId": "task-delete",
-                "parameters": [
+                "summary": "Проверка. [Все]",
+                "operationId": "example-free",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.exampleData"
+                        }
+                    }
+                }
+            }
+        },
+        "/example/private": {
+            "get": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "ID задания",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "JWTAccess": []
                     }
                 ],
+                "description": "Проверочный эндпоинт с доступом только для авторизованных юзеров.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "example"
+                ],
+                "summary": "Проверка. [Только авторизованные]",
+                "operationId": "example-private",
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.exampleData"
+                        }
                     },
                     "401": {
-                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                        "description": "Неверный токен (пустой, истекший или неверный формат)"
                     },
                     "403": {
-                        "description": "доступ запрещён"
+                        "description": "Юзер не авторизован, доступ запрещён"
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/example/student": {
+            "get": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Частичное обновление задания (только переданные поля: название, описание, привязанные ученики, прикреплённые файлы) по его id.",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Проверочный эндпоинт с доступом только для студентов.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "task"
-                ],
-                "summary": "Обновление задания. [Только преподаватель]",
-                "operationId": "task-update",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID задания",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "task title",
-                        "name": "title",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "task description",
-                        "name": "description",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        },
-                        "collectionFormat": "multi",
-                        "description": "IDs of students (updated list) for the task",
-                        "name": "students",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        },
-                        "collectionFormat": "multi",
-                        "description": "IDs of files to delete from the task",
-                        "name": "delete_files",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "file"
-                        },
-                        "collectionFormat": "multi",
-                        "description": "new task files",
-                        "name": "file",
-                        "in": "formData"
-                    }
+                    "example"
                 ],
+                "summary": "Проверка. [Только ученик]",
+                "operationId": "example-student",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.TaskWithStudents"
+                            "$ref": "#/definitions/v1.exampleData"
                         }
                     },
-                    "400": {
-                        "description": "неверный ученик"
-                    },
                     "401": {
-                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                        "description": "Неверный токен (пустой, истекший или неверный формат)"
                     },
                     "403": {
-                        "description": "доступ запрещён"
-                    },
-                    "404": {
-                        "description": "задание не найдено"
+                        "description": "Юзер не студент, доступ запрещён"
                     }
                 }
             }
         },
-        "/user": {
+        "/example/teacher": {
             "get": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Получение списка юзеров со всеми данными (с настраиваемой пагинацией).",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Проверочный эндпоинт с доступом только для преподавателей.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
-                ],
-                "summary": "Получение списка юзеров. [Админ и преподаватель]",
-                "operationId": "user-list",
-                "parameters": [
-                    {
-                        "type": "boolean",
-                        "example": true,
-                        "description": "return only class-free students (only if param role=student)",
-                        "name": "free",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "example": 1,
-                        "description": "page pagination param",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 10,
-                        "example": 5,
-                        "description": "per page pagination param",
-                        "name": "per-page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "multi",
-                        "example": [
-                            "student"
-                        ],
-                        "description": "user roles (accepted: \"teacher\", \"student\")",
-                        "name": "role",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "иванов",
-                        "description": "substring to filter users by username and fullname (case-insensitive)",
-                        "name": "search",
-                        "in": "query"
-                    }
+                    "example"
                 ],
+                "summary": "Проверка. [Только преподаватель]",
+                "operationId": "example-teacher",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.listUserOut"
+                            "$ref": "#/definitions/v1.exampleData"
                         }
                     },
                     "401": {
-                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                        "description": "Неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "Юзер не преподаватель, доступ запрещён"
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/file/{id}": {
+            "get": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Создание нового юзера и профиля для него со всеми данными.",
+                "description": "Загрузка файла по его id.",
                 "consumes": [
                     "application/json"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "user"
+                    "file"
                 ],
-                "summary": "Создание нового юзера. [Только админ]",
-                "operationId": "user-create",
+                "summary": "Загрузка файла по id. [Преподаватель и ученик]",
+                "operationId": "file-download",
                 "parameters": [
                     {
-                        "description": "userBody",
-                        "name": "userBody",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.userBody"
-                        }
+                        "type": "integer",
+                        "description": "ID файла",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/entity.User"
-                        }
-                    },
-                    "400": {
-                        "description": "группа не найдена | слабый пароль"
+                    "200": {
+                        "description": "файл"
                     },
                     "401": {
                         "description": "неверный токен (пустой, истекший или неверный формат)"
                     },
-                    "409": {
-                        "description": "пользователь с введенным логином уже существует"
+                    "403": {
+                        "description": "доступ запрещён | файл заражён вирусом и помещён в карантин"
+                    },
+                    "404": {
+                        "description": "файл не найден"
+                    },
+                    "423": {
+                        "description": "файл ещё не проверен антивирусом"
                     }
                 }
             }
         },
-        "/user/me": {
+        "/file/{id}/link": {
             "get": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Получение всей информации о себе (юзер, профиль).",
+                "description": "Получение короткоживущей подписанной ссылки для загрузки файла без авторизации (для внешних просмотрщиков и мобильных приложений).",
                 "consumes": [
                     "application/json"
                 ],
@@ -1547,77 +1567,96 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "file"
                 ],
-                "summary": "Получение информации о себе.",
-                "operationId": "user-me-get",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/entity.User"
-                        }
-                    },
+                "summary": "Получение подписанной ссылки на файл. [Преподаватель и ученик]",
+                "operationId": "file-link",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID файла",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.FileLink"
+                        }
+                    },
                     "401": {
                         "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён | файл заражён вирусом и помещён в карантин"
+                    },
+                    "404": {
+                        "description": "файл не найден"
+                    },
+                    "423": {
+                        "description": "файл ещё не проверен антивирусом"
                     }
                 }
             }
         },
-        "/user/me/password": {
-            "put": {
+        "/file/{id}/preview": {
+            "get": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Смена своего пароля.",
+                "description": "Загрузка превью (JPEG) для изображения или первой страницы PDF-документа по id файла.\nПревью создаётся в фоне после загрузки файла, поэтому может быть ещё не готово.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "image/jpeg"
                 ],
                 "tags": [
-                    "user"
+                    "file"
                 ],
-                "summary": "Смена своего пароля.",
-                "operationId": "user-me-password-update",
+                "summary": "Загрузка превью файла по id. [Преподаватель и ученик]",
+                "operationId": "file-preview",
                 "parameters": [
                     {
-                        "description": "updatePasswordBody",
-                        "name": "updatePasswordBody",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.updatePasswordBody"
-                        }
+                        "type": "integer",
+                        "description": "ID файла",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "неверный старый пароль"
+                    "200": {
+                        "description": "превью файла"
                     },
                     "401": {
                         "description": "неверный токен (пустой, истекший или неверный формат)"
                     },
-                    "409": {
-                        "description": "пароли не должны совпадать"
+                    "403": {
+                        "description": "доступ запрещён | файл заражён вирусом и помещён в карантин"
+                    },
+                    "404": {
+                        "description": "файл не найден | превью не найдено"
+                    },
+                    "423": {
+                        "description": "файл ещё не проверен антивирусом"
                     }
                 }
             }
         },
-        "/user/me/profile": {
-            "put": {
+        "/job": {
+            "get": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Полное обновление своего профиля.",
+                "description": "Получение списка фоновых задач планировщика с расписанием (cron, UTC),\nвременем следующего запуска и последним запуском.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1625,42 +1664,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
-                ],
-                "summary": "Обновление своего профиля.",
-                "operationId": "user-me-profile-update",
-                "parameters": [
-                    {
-                        "description": "profileBody",
-                        "name": "profileBody",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.profileBody"
-                        }
-                    }
+                    "job"
                 ],
+                "summary": "Список фоновых задач. [Только админ]",
+                "operationId": "job-list",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.User"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Job"
+                            }
                         }
                     },
                     "401": {
                         "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
                     }
                 }
             }
         },
-        "/user/{id}": {
+        "/job/run": {
             "get": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Получение юзера со всеми данными по его id.",
+                "description": "Получение истории запусков фоновых задач (сначала новые): экземпляр бэкенда,\nвремя запуска по расписанию, начала и окончания, ошибка неудачного запуска.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1668,31 +1702,84 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "job"
                 ],
-                "summary": "Получение юзера по id. [Только админ]",
-                "operationId": "user-read",
+                "summary": "История запусков фоновых задач. [Только админ]",
+                "operationId": "job-list-runs",
                 "parameters": [
                     {
+                        "maxLength": 64,
+                        "type": "string",
+                        "example": "mail_digest",
+                        "description": "job name to filter runs",
+                        "name": "job",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
                         "type": "integer",
-                        "description": "ID юзера",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "example": 1,
+                        "description": "page pagination param",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "example": 5,
+                        "description": "per page pagination param",
+                        "name": "per-page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.User"
+                            "$ref": "#/definitions/v1.listRunOut"
                         }
                     },
                     "401": {
                         "description": "неверный токен (пустой, истекший или неверный формат)"
                     },
-                    "404": {
-                        "description": "пользователь не найден"
+                    "403": {
+                        "description": "доступ запрещён"
+                    }
+                }
+            }
+        },
+        "/mail/prefs": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение настроек e-mail рассылки текущего пользователя.\nПо умолчанию уведомления присылаются ежедневной сводкой (daily).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mail"
+                ],
+                "summary": "Получение настроек e-mail рассылки. [Преподаватель и ученик]",
+                "operationId": "mail-get-prefs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.MailPref"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
                     }
                 }
             },
@@ -1702,7 +1789,7 @@ const docTemplate = `{
                         "JWTAccess": []
                     }
                 ],
-                "description": "Полное обновление профиля юзера, его группы (если студент) и пароля (опционально) по его id.",
+                "description": "Выбор режима e-mail уведомлений: off - не присылать, immediate - сразу, daily - ежедневной сводкой.\nУченик может включить еженедельный отчёт об успеваемости на почту родителя (parent_summary).\nПисьма отправляются на почту из контактов профиля (отчёт - на почту из контактов родителя).",
                 "consumes": [
                     "application/json"
                 ],
@@ -1710,25 +1797,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "mail"
                 ],
-                "summary": "Обновление юзера по id. [Только админ]",
-                "operationId": "user-update",
+                "summary": "Изменение настроек e-mail рассылки. [Преподаватель и ученик]",
+                "operationId": "mail-update-prefs",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID юзера",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "updateBody",
-                        "name": "updateBody",
+                        "description": "mailPrefBody",
+                        "name": "mailPrefBody",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_app_user_controller_http_v1.updateBody"
+                            "$ref": "#/definitions/v1.mailPrefBody"
                         }
                     }
                 ],
@@ -1736,27 +1816,29 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.User"
+                            "$ref": "#/definitions/entity.MailPref"
                         }
                     },
                     "400": {
-                        "description": "неподдерживаемые данные для роли обновляемого пользователя | группа не найдена | слабый пароль"
+                        "description": "отчёт родителю доступен только ученикам"
                     },
                     "401": {
                         "description": "неверный токен (пустой, истекший или неверный формат)"
                     },
-                    "404": {
-                        "description": "пользователь не найден"
+                    "403": {
+                        "description": "доступ запрещён"
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/notification": {
+            "get": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Удаление юзера и его профиля по его id.",
+                "description": "Получение списка уведомлений текущего пользователя (сначала новые) и количества непрочитанных.\nНовые уведомления также приходят в потоке событий (notification.created).",
                 "consumes": [
                     "application/json"
                 ],
@@ -1764,40 +1846,93 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "notification"
                 ],
-                "summary": "Удаление юзера по id. [Только админ]",
-                "operationId": "user-delete",
+                "summary": "Получение уведомлений. [Преподаватель и ученик]",
+                "operationId": "notification-list",
                 "parameters": [
                     {
+                        "minimum": 1,
                         "type": "integer",
-                        "description": "ID юзера",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "example": 1,
+                        "description": "page pagination param",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "example": 5,
+                        "description": "per page pagination param",
+                        "name": "per-page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "if true, only unread notifications are returned",
+                        "name": "unread",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.listNotificationOut"
+                        }
                     },
                     "401": {
                         "description": "неверный токен (пустой, истекший или неверный формат)"
                     },
-                    "404": {
-                        "description": "пользователь не найден"
+                    "403": {
+                        "description": "доступ запрещён"
                     }
                 }
             }
         },
-        "/user/{id}/password": {
+        "/notification/prefs": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение настроек текущего пользователя для всех типов уведомлений.\nПо умолчанию все типы уведомлений включены.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "Получение настроек уведомлений. [Преподаватель и ученик]",
+                "operationId": "notification-get-prefs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.prefsOut"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    }
+                }
+            },
             "put": {
                 "security": [
                     {
                         "JWTAccess": []
                     }
                 ],
-                "description": "Смена пароля юзера по его id.",
+                "description": "Включение и отключение уведомлений переданных типов для текущего пользователя.\nНастройки для непереданных типов не изменяются.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1805,141 +1940,4191 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "notification"
                 ],
-                "summary": "Смена пароля юзера. [Только админ]",
-                "operationId": "user-password-update",
+                "summary": "Изменение настроек уведомлений. [Преподаватель и ученик]",
+                "operationId": "notification-update-prefs",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID юзера",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "updatePasswordAdminBody",
-                        "name": "updatePasswordAdminBody",
+                        "description": "updatePrefsBody",
+                        "name": "updatePrefsBody",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.updatePasswordAdminBody"
+                            "$ref": "#/definitions/v1.updatePrefsBody"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.prefsOut"
+                        }
+                    },
+                    "400": {
+                        "description": "неверный тип уведомления"
                     },
                     "401": {
                         "description": "неверный токен (пустой, истекший или неверный формат)"
                     },
-                    "404": {
-                        "description": "пользователь не найден"
-                    }
+                    "403": {
+                        "description": "доступ запрещён"
+                    }
+                }
+            }
+        },
+        "/notification/read": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Отметка всех уведомлений текущего пользователя прочитанными.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "Отметка всех уведомлений прочитанными. [Преподаватель и ученик]",
+                "operationId": "notification-mark-all-read",
+                "responses": {
+                    "204": {
+                        "description": "уведомления отмечены прочитанными"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    }
+                }
+            }
+        },
+        "/notification/{id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Отметка уведомления текущего пользователя прочитанным.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "Отметка уведомления прочитанным. [Преподаватель и ученик]",
+                "operationId": "notification-mark-read",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID уведомления",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "уведомление отмечено прочитанным"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "уведомление не найдено"
+                    }
+                }
+            }
+        },
+        "/plagiarism/task/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение подозрительных пар решений своего задания (сначала самые похожие) с учениками и совпадающими фрагментами (файл, строки и текст фрагмента в обоих решениях).\nСравниваются текстовые ответы и текстовые файлы решений, фрагменты описания и файлов задания (например, шаблон кода) не учитываются.\nРешение сравнивается с остальными решениями задания, когда оно переводится в статус \"на проверке\", а все решения задания - по запросу преподавателя (статус последней проверки возвращается в check).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plagiarism"
+                ],
+                "summary": "Отчёт о плагиате в решениях задания. [Только преподаватель]",
+                "operationId": "plagiarism-read-report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 0,
+                        "type": "integer",
+                        "example": 50,
+                        "description": "min similarity of the solution pair (percent)",
+                        "name": "min_similarity",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PlagiarismReport"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "задание не найдено"
+                    }
+                }
+            }
+        },
+        "/plagiarism/task/{id}/check": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Постановка в очередь попарного сравнения всех решений своего задания. Найденные ранее пары заменяются после завершения проверки.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plagiarism"
+                ],
+                "summary": "Проверка решений задания на плагиат. [Только преподаватель]",
+                "operationId": "plagiarism-check",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PlagiarismCheck"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "задание не найдено"
+                    }
+                }
+            }
+        },
+        "/shared-file/{id}": {
+            "get": {
+                "description": "Загрузка файла по короткоживущей подписанной ссылке без авторизации.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "file"
+                ],
+                "summary": "Загрузка файла по подписанной ссылке.",
+                "operationId": "file-download-by-link",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID файла",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1767225600,
+                        "description": "link expiration datetime (unix seconds)",
+                        "name": "exp",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "3a7bd3e2",
+                        "description": "link signature",
+                        "name": "sig",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "файл"
+                    },
+                    "403": {
+                        "description": "неверная ссылка | срок действия ссылки истёк | файл заражён вирусом и помещён в карантин"
+                    },
+                    "404": {
+                        "description": "файл не найден"
+                    },
+                    "423": {
+                        "description": "файл ещё не проверен антивирусом"
+                    }
+                }
+            }
+        },
+        "/solution/for-student": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение списка решений конкретного ученика.\nДля каждого решения возвращается число непрочитанных комментариев и признак обновлений с последнего просмотра.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "solution"
+                ],
+                "summary": "Получение списка решений. [Только ученик]",
+                "operationId": "solution-for-student-list",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "page pagination param",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "example": 5,
+                        "description": "per page pagination param",
+                        "name": "per-page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "HTML",
+                        "description": "case-insensitive substring to filter data by task title only for students\nor by task title or student fullname for teacher",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "example": [
+                            2
+                        ],
+                        "description": "solution status IDs (accepted: 1, 2, 3, 4)",
+                        "name": "status_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "if true, only solutions updated or commented since the last view are returned",
+                        "name": "unread",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.listSolutionOut"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    }
+                }
+            }
+        },
+        "/solution/for-student/{id}": {
+            "patch": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Частичное обновление решения (только переданные поля: статус, кроме \"проверено\", ответ, файл ответа) по его id.\nРешение задания в режиме экзамена можно изменить только во время начатой попытки.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "solution"
+                ],
+                "summary": "Обновление решения. [Только ученик]",
+                "operationId": "solution-for-student-update",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID решения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "new status ID",
+                        "name": "status_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "new answer",
+                        "name": "answer",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "IDs of files to delete from the solution",
+                        "name": "delete_files",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "file"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "new solution files",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "IDs of completed chunked uploads to attach as new solution files",
+                        "name": "uploads",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Solution"
+                        }
+                    },
+                    "400": {
+                        "description": "неверный статус | отправка на проверку возможна только при наличии ответа | загрузка не найдена | загрузка не завершена"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "решение не найдено"
+                    },
+                    "409": {
+                        "description": "статус теста меняется только ответами на вопросы | попытка не начата или время вышло"
+                    }
+                }
+            }
+        },
+        "/solution/for-teacher": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение списка решений для заданий конкретного преподавателя.\nДля каждого решения возвращается число непрочитанных комментариев и признак обновлений с последнего просмотра.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "solution"
+                ],
+                "summary": "Получение списка решений. [Только преподаватель]",
+                "operationId": "solution-for-teacher-list",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "page pagination param",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "example": 5,
+                        "description": "per page pagination param",
+                        "name": "per-page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "HTML",
+                        "description": "case-insensitive substring to filter data by task title only for students\nor by task title or student fullname for teacher",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "example": [
+                            2
+                        ],
+                        "description": "solution status IDs (accepted: 1, 2, 3, 4)",
+                        "name": "status_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "if true, only solutions updated or commented since the last view are returned",
+                        "name": "unread",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.listSolutionOut"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    }
+                }
+            }
+        },
+        "/solution/for-teacher/{id}": {
+            "patch": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Частичное обновление решения (только переданные поля: статус - \"готово\"/\"проверено\" - и оценка) по его id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "solution"
+                ],
+                "summary": "Обновление решения. [Только преподаватель]",
+                "operationId": "solution-for-teacher-update",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID решения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "updateSolutionBody",
+                        "name": "updateSolutionBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.updateSolutionBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Solution"
+                        }
+                    },
+                    "400": {
+                        "description": "статус не найден"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "решение не найдено"
+                    }
+                }
+            }
+        },
+        "/solution/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение всех данных о решении задания (с файлами решения) с полной инфой о задании (с файлами задания) и преподе (ID и полное имя), а также со списком учеников, которые тоже выполняют это задание.\nРешение отмечается прочитанным текущим пользователем.\nЕсли решение тестировалось, возвращается итог последнего запуска автотестов (без результатов по каждому тесту).\nУченику решение задания в режиме экзамена доступно только после начала попытки.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "solution"
+                ],
+                "summary": "Получение решения задания по id. [Преподаватель и ученик]",
+                "operationId": "solution-read",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID решения задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.solutionOut"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "решение задания не найдено"
+                    },
+                    "409": {
+                        "description": "попытка экзамена не начата"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Удаление решения (не задания целиком) со всеми файлами решения по его id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "solution"
+                ],
+                "summary": "Удаление решения по id. [Только преподаватель]",
+                "operationId": "solution-delete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID решения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    }
+                }
+            }
+        },
+        "/solution/{id}/comment": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение списка комментариев для данного решения задания.\nЕсли указан parent_id, возвращаются только ответы на этот комментарий.\nУдалённые комментарии возвращаются без текста, чтобы не разрывать ветки ответов.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "Получение комментариев под решением задания. [Преподаватель и ученик]",
+                "operationId": "comment-list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID решения задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "page pagination param",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 3,
+                        "description": "ID of the parent comment (to get replies only)",
+                        "name": "parent_id",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "example": 5,
+                        "description": "per page pagination param",
+                        "name": "per-page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.listCommentOut"
+                        }
+                    },
+                    "400": {
+                        "description": "решение задания не найдено"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Создание комментария от лица преподавателя или ученика для данного решения задания.\nДля ответа на другой комментарий этого решения нужно указать его ID в parent_id.\nК комментарию можно прикрепить файлы (multipart/form-data) и привязать его к фрагменту файла решения или задания:\nк диапазону строк (anchor_line_from, anchor_line_to) или к странице PDF-документа (anchor_page).\nТекст поддерживает Markdown (CommonMark, формулы LaTeX в $...$ и $$...$$), в ответе он возвращается также в виде безопасного HTML (message_html).\nИзображения могут ссылаться на прикреплённые файлы по имени (![](scheme.png)) или ID (![](file:12)).",
+                "consumes": [
+                    "multipart/form-data",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "Создание комментария под решением задания. [Преподаватель и ученик]",
+                "operationId": "comment-create",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID решения задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "text message",
+                        "name": "message",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the parent comment (for replies)",
+                        "name": "parent_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the solution or task file the comment is pointing at",
+                        "name": "anchor_file_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "first line of the anchor range",
+                        "name": "anchor_line_from",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "last line of the anchor range",
+                        "name": "anchor_line_to",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "anchor page (for PDF documents)",
+                        "name": "anchor_page",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "file"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "comment attachments",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Comment"
+                        }
+                    },
+                    "400": {
+                        "description": "решение задания не найдено | родительский комментарий не найден | неверная привязка к файлу"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    }
+                }
+            }
+        },
+        "/solution/{id}/exam": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение попытки решения задания в режиме экзамена: время начала, дополнительные минуты и время окончания (deadline).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exam"
+                ],
+                "summary": "Получение попытки экзамена.",
+                "operationId": "exam-read-attempt",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID решения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ExamAttempt"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "решение не найдено | попытка не начата"
+                    },
+                    "409": {
+                        "description": "задание не в режиме экзамена"
+                    }
+                }
+            }
+        },
+        "/solution/{id}/exam/begin": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Запуск таймера попытки решения задания в режиме экзамена. Попытку можно начать один раз и только пока экзамен открыт.\nРешение можно изменять до окончания попытки (deadline): через длительность попытки, но не позже закрытия экзамена, плюс дополнительные минуты от преподавателя.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exam"
+                ],
+                "summary": "Начало попытки экзамена. [Только ученик]",
+                "operationId": "exam-begin",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID решения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ExamAttempt"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "решение не найдено"
+                    },
+                    "409": {
+                        "description": "задание не в режиме экзамена | экзамен ещё не открыт или уже закрыт | попытка уже начата"
+                    }
+                }
+            }
+        },
+        "/solution/{id}/exam/extra": {
+            "put": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Установка дополнительных минут попытки ученика для своего задания в режиме экзамена (0 - отмена). Минуты можно добавить и до начала попытки.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exam"
+                ],
+                "summary": "Дополнительное время ученику. [Только преподаватель]",
+                "operationId": "exam-grant-extra",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID решения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "extraBody",
+                        "name": "extraBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.extraBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ExamAttempt"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "решение не найдено"
+                    },
+                    "409": {
+                        "description": "задание не в режиме экзамена"
+                    }
+                }
+            }
+        },
+        "/solution/{id}/quiz": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение вопросов теста с ответами ученика, баллами за каждый ответ и суммой баллов.\nДля ученика правильные ответы не возвращаются.\nУченику тест в режиме экзамена доступен только после начала попытки.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quiz"
+                ],
+                "summary": "Получение теста решения.",
+                "operationId": "quiz-read",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID решения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Quiz"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "решение не найдено"
+                    },
+                    "409": {
+                        "description": "задание не является тестом или попытка экзамена не начата"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Сохранение ответов на вопросы теста (заменяют сохранённые ответы на те же вопросы). Решение переводится из статуса \"не начато\" в статус \"в работе\".\nЕсли передан флаг submit, тест проверяется сразу: за неотвеченные вопросы ставится 0 баллов, решение получает оценку (процент от максимума баллов) и статус \"проверено\".\nОтвет на вопрос с несколькими вариантами засчитывается, только если выбраны все правильные варианты. Короткий ответ сравнивается без учёта регистра и лишних пробелов (или с регулярным выражением целиком).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quiz"
+                ],
+                "summary": "Ответы на вопросы теста. [Только ученик]",
+                "operationId": "quiz-answer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID решения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "answersBody",
+                        "name": "answersBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.answersBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Quiz"
+                        }
+                    },
+                    "400": {
+                        "description": "неверный ответ"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "решение не найдено | вопросы не заданы"
+                    },
+                    "409": {
+                        "description": "задание не является тестом | тест уже проверен | попытка не начата или время вышло"
+                    }
+                }
+            }
+        },
+        "/solution/{id}/quiz/{questionID}": {
+            "patch": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Установка баллов за ответ на вопрос проверенного теста вместо автоматических (null - сброс) с пересчётом оценки решения.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quiz"
+                ],
+                "summary": "Изменение баллов за ответ. [Только преподаватель]",
+                "operationId": "quiz-override",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID решения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID вопроса",
+                        "name": "questionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "overrideBody",
+                        "name": "overrideBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.overrideBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Quiz"
+                        }
+                    },
+                    "400": {
+                        "description": "баллы больше максимума за вопрос"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "решение или ответ не найдены"
+                    },
+                    "409": {
+                        "description": "задание не является тестом | тест ещё не проверен"
+                    }
+                }
+            }
+        },
+        "/solution/{id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Отметка решения задания и всех комментариев под ним прочитанными текущим пользователем (без получения данных решения).",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "solution"
+                ],
+                "summary": "Отметка решения задания прочитанным. [Преподаватель и ученик]",
+                "operationId": "solution-mark-read",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID решения задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "решение отмечено прочитанным"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "решение задания не найдено"
+                    }
+                }
+            }
+        },
+        "/solution/{id}/team/{studentID}": {
+            "delete": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Исключение ученика из командного решения своего задания. Ученик получает новое индивидуальное решение, командное решение остаётся у остальных участников.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Исключение ученика из команды. [Только преподаватель]",
+                "operationId": "team-remove-member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID решения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID ученика",
+                        "name": "studentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "решение не найдено или ученик не участник решения"
+                    },
+                    "409": {
+                        "description": "решение не командное"
+                    }
+                }
+            }
+        },
+        "/solution/{id}/team/{studentID}/grade": {
+            "put": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Установка оценки участника командного решения своего задания вместо оценки решения (null - сброс к оценке решения). Ученик видит только свою оценку.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Индивидуальная оценка участника команды. [Только преподаватель]",
+                "operationId": "team-set-grade",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID решения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID ученика",
+                        "name": "studentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "gradeBody",
+                        "name": "gradeBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.gradeBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Solution"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "решение не найдено или ученик не участник решения"
+                    },
+                    "409": {
+                        "description": "решение не командное"
+                    }
+                }
+            }
+        },
+        "/task": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение списка заданий (с файлами задания и учениками, которые выполняют это задание) конкретного преподавателя.\nС параметром library=true возвращаются только задания библиотеки (без выданных решений).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Получение списка заданий. [Только преподаватель]",
+                "operationId": "task-list",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "true to get library tasks only (tasks without solutions)",
+                        "name": "library",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "page pagination param",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "example": 5,
+                        "description": "per page pagination param",
+                        "name": "per-page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "F26",
+                        "description": "substring to filter data by substring (case-insensitive)",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.listTaskOut"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Создание нового задания (с прикреплением файлов) для переданных учеников и учеников из переданных групп.\nЧерновик (draft=true или дата публикации publish_at в будущем) не виден ученикам до публикации, уведомления отправляются при публикации.\nЗадание привязывается к переданным группам: ученикам, добавленным в группу позже, оно выдаётся автоматически.\nПеред сроком сдачи (deadline) ученикам, не отправившим решение, приходит напоминание.\nОписание поддерживает Markdown (CommonMark, формулы LaTeX в $...$ и $$...$$), в ответе оно возвращается также в виде безопасного HTML (description_html).\nИзображения могут ссылаться на файлы задания по имени (![](scheme.png)) или ID (![](file:12)).\nДля задания-теста (type=quiz) вопросы задаются отдельно, решение ученика - ответы на вопросы с автоматической проверкой.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Создание нового задания. [Только преподаватель]",
+                "operationId": "task-create",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "task title",
+                        "name": "title",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "task description",
+                        "name": "description",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "classes for task solutions (list of class IDs)",
+                        "name": "classes",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "students for task solutions (list of student IDs)",
+                        "name": "students",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "file"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "task files",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "IDs of completed chunked uploads to attach as task files",
+                        "name": "uploads",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "true to create a draft",
+                        "name": "draft",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "datetime to publish the draft automatically (RFC 3339)",
+                        "name": "publish_at",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "datetime students have to send solutions before (RFC 3339)",
+                        "name": "deadline",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "assignment",
+                            "quiz"
+                        ],
+                        "type": "string",
+                        "description": "task type (assignment by default)",
+                        "name": "type",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createTaskOut"
+                        }
+                    },
+                    "400": {
+                        "description": "неверный ученик | группа не найдена | неверный преподаватель | преподаватель не найден | загрузка не найдена | загрузка не завершена | неверная дата публикации | неверный срок сдачи"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    }
+                }
+            }
+        },
+        "/task/shared": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение списка заданий (с файлами и преподавателем-автором), которыми с текущим преподавателем поделились другие преподаватели.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Получение списка заданий, которыми поделились другие преподаватели. [Только преподаватель]",
+                "operationId": "task-list-shared",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "page pagination param",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "example": 5,
+                        "description": "per page pagination param",
+                        "name": "per-page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "F26",
+                        "description": "substring to filter data by substring (case-insensitive)",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.listSharedTaskOut"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    }
+                }
+            }
+        },
+        "/task/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение всех данных о задании (включая метаданные файлов) и преподе (ID и полное имя), а также списка учеников, которые выполняют это задание.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Получение задания по id. [Только преподаватель]",
+                "operationId": "task-read",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID решения задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TaskWithStudents"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "задание не найдено"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Удаление задания целиком (со всеми его файлами и решениями) по его id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Удаление задания по id. [Только преподаватель]",
+                "operationId": "task-delete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Частичное обновление задания (только переданные поля: название, описание, срок сдачи, привязанные ученики, прикреплённые файлы) по его id.\nДату публикации можно изменить только у черновика.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Обновление задания. [Только преподаватель]",
+                "operationId": "task-update",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "task title",
+                        "name": "title",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "task description",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "IDs of students (updated list) for the task",
+                        "name": "students",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "IDs of files to delete from the task",
+                        "name": "delete_files",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "file"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "new task files",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "IDs of completed chunked uploads to attach as new task files",
+                        "name": "uploads",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "new datetime to publish the draft automatically (RFC 3339)",
+                        "name": "publish_at",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "new datetime students have to send solutions before (RFC 3339)",
+                        "name": "deadline",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TaskWithStudents"
+                        }
+                    },
+                    "400": {
+                        "description": "неверный ученик | загрузка не найдена | загрузка не завершена | неверная дата публикации | неверный срок сдачи"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "задание не найдено"
+                    },
+                    "409": {
+                        "description": "задание уже опубликовано"
+                    }
+                }
+            }
+        },
+        "/task/{id}/assign": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Выдача своего задания переданным ученикам и ученикам из переданных групп (создание решений).\nУченики, которым задание уже выдано, пропускаются.\nЗадание привязывается к переданным группам: ученикам, добавленным в группу позже, оно выдаётся автоматически.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Выдача задания ученикам. [Только преподаватель]",
+                "operationId": "task-assign",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "assignTaskBody",
+                        "name": "assignTaskBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.assignTaskBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TaskWithStudents"
+                        }
+                    },
+                    "400": {
+                        "description": "неверный ученик | группа не найдена"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "задание не найдено"
+                    }
+                }
+            }
+        },
+        "/task/{id}/clone": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Создание копии своего задания или задания, которым поделился другой преподаватель. Копируются название, описание, тип, файлы (файлы не дублируются), вопросы теста и автотесты.\nКопия попадает в библиотеку преподавателя без учеников.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Копирование задания в библиотеку. [Только преподаватель]",
+                "operationId": "task-clone",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "cloneTaskBody",
+                        "name": "cloneTaskBody",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/v1.cloneTaskBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Task"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "задание не найдено"
+                    }
+                }
+            }
+        },
+        "/task/{id}/exam": {
+            "put": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Включение режима экзамена для своего задания или изменение его параметров: окно экзамена (opens_at - closes_at) и длительность попытки в минутах.\nУченик начинает попытку сам, после её окончания решение (и ответы на вопросы теста) изменить нельзя.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exam"
+                ],
+                "summary": "Включение режима экзамена. [Только преподаватель]",
+                "operationId": "exam-update",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "examBody",
+                        "name": "examBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.examBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Task"
+                        }
+                    },
+                    "400": {
+                        "description": "экзамен должен закрываться после открытия"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "задание не найдено"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Выключение режима экзамена для своего задания. Попытки сохраняются, но решения можно изменять без них.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exam"
+                ],
+                "summary": "Выключение режима экзамена. [Только преподаватель]",
+                "operationId": "exam-delete",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    }
+                }
+            }
+        },
+        "/task/{id}/exam/attempts": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение попыток всех учеников для своего задания в режиме экзамена (ID решения, время начала, дополнительные минуты и время окончания).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exam"
+                ],
+                "summary": "Получение попыток экзамена. [Только преподаватель]",
+                "operationId": "exam-list-attempts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.ExamAttempt"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "задание не найдено"
+                    },
+                    "409": {
+                        "description": "задание не в режиме экзамена"
+                    }
+                }
+            }
+        },
+        "/task/{id}/publish": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Немедленная публикация черновика задания по его id: задание становится видно ученикам, им отправляются уведомления.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Публикация черновика задания. [Только преподаватель]",
+                "operationId": "task-publish",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Task"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "задание не найдено"
+                    },
+                    "409": {
+                        "description": "задание уже опубликовано"
+                    }
+                }
+            }
+        },
+        "/task/{id}/quiz": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение вопросов своего задания-теста с правильными ответами.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quiz"
+                ],
+                "summary": "Получение вопросов теста. [Только преподаватель]",
+                "operationId": "quiz-read-questions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.QuizQuestion"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "задание не найдено"
+                    },
+                    "409": {
+                        "description": "задание не является тестом"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Полная замена вопросов своего задания-теста (вопросы выводятся в переданном порядке). Вопросы нельзя заменить, если ученики уже ответили на них.\nВиды вопросов: single - один правильный вариант, multiple - несколько правильных вариантов (correct - индексы вариантов с 0),\nshort - короткий ответ (answers - допустимые ответы или регулярные выражения, если regex), numeric - число (value с допустимым отклонением tolerance).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quiz"
+                ],
+                "summary": "Изменение вопросов теста. [Только преподаватель]",
+                "operationId": "quiz-update-questions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "questionsBody",
+                        "name": "questionsBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.questionsBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.QuizQuestion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "неверный вопрос"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "задание не найдено"
+                    },
+                    "409": {
+                        "description": "задание не является тестом | ученики уже ответили на вопросы"
+                    }
+                }
+            }
+        },
+        "/task/{id}/share": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение списка преподавателей (ID и полное имя), которым доступно своё задание.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Получение списка преподавателей, с которыми поделились заданием. [Только преподаватель]",
+                "operationId": "task-read-share",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.shareTaskOut"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "задание не найдено"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Замена списка преподавателей, которым доступно своё задание (для просмотра и копирования в свою библиотеку).\nПустой список закрывает доступ всем преподавателям.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Изменение списка преподавателей, с которыми поделились заданием. [Только преподаватель]",
+                "operationId": "task-update-share",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "shareTaskBody",
+                        "name": "shareTaskBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.shareTaskBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.shareTaskOut"
+                        }
+                    },
+                    "400": {
+                        "description": "неверный преподаватель | преподаватель не найден"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "задание не найдено"
+                    }
+                }
+            }
+        },
+        "/task/{id}/solutions.zip": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Скачивание ZIP-архива со всеми решениями задания: для каждого ученика отдельная папка с текстом ответа и файлами решения.\nВ корне архива лежит manifest.csv со статусом, оценкой и датой последнего обновления каждого решения.\nФайлы, не проверенные антивирусом или заражённые, в архив не попадают.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Скачивание всех решений задания архивом. [Только преподаватель]",
+                "operationId": "task-download-solutions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ZIP-архив с решениями"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "задание не найдено"
+                    }
+                }
+            }
+        },
+        "/task/{id}/team": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение командных решений своего задания (решение, статус, оценка и участники с индивидуальными оценками).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Получение команд задания. [Только преподаватель]",
+                "operationId": "team-list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Solution"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "задание не найдено"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Объединение решений учеников своего задания в одно командное решение. Решение первого ученика становится командным, решения остальных удаляются, поэтому они не должны быть начаты (статус \"не начато\" без ответа, файлов, оценки, комментариев, ответов теста и попытки экзамена).\nКомандное решение может изменять любой участник, преподаватель оценивает его один раз (при необходимости с индивидуальными оценками участников).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Создание команды. [Только преподаватель]",
+                "operationId": "team-create",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID задания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "teamBody",
+                        "name": "teamBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.teamBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Solution"
+                        }
+                    },
+                    "400": {
+                        "description": "в команде должно быть не меньше двух учеников"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "задание не найдено или ученику не выдано задание"
+                    },
+                    "409": {
+                        "description": "ученик уже в команде или решение ученика уже начато"
+                    }
+                }
+            }
+        },
+        "/upload": {
+            "post": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Создание пустой загрузки файла. Далее файл передаётся по частям, а ID завершённой загрузки прикрепляется к заданию или решению вместо файла.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upload"
+                ],
+                "summary": "Создание загрузки файла по частям. [Преподаватель и ученик]",
+                "operationId": "upload-create",
+                "parameters": [
+                    {
+                        "description": "uploadBody",
+                        "name": "uploadBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.uploadBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Upload"
+                        }
+                    },
+                    "400": {
+                        "description": "файл слишком большой"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    }
+                }
+            }
+        },
+        "/upload/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение состояния загрузки (количества полученных байт) для возобновления прерванной загрузки.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upload"
+                ],
+                "summary": "Получение загрузки по id. [Преподаватель и ученик]",
+                "operationId": "upload-read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID загрузки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Upload"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "404": {
+                        "description": "загрузка не найдена"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Отмена загрузки и удаление уже полученной части файла.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upload"
+                ],
+                "summary": "Удаление загрузки по id. [Преподаватель и ученик]",
+                "operationId": "upload-delete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID загрузки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Запись части файла (тело запроса) по смещению из заголовка Upload-Offset. Смещение должно совпадать с количеством уже полученных байт.",
+                "consumes": [
+                    "application/octet-stream"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upload"
+                ],
+                "summary": "Загрузка части файла. [Преподаватель и ученик]",
+                "operationId": "upload-append-chunk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID загрузки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "смещение части файла",
+                        "name": "Upload-Offset",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "часть файла",
+                        "name": "chunk",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Upload"
+                        }
+                    },
+                    "400": {
+                        "description": "неверная часть файла"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "404": {
+                        "description": "загрузка не найдена"
+                    },
+                    "409": {
+                        "description": "неверное смещение | загрузка части файла уже выполняется"
+                    }
+                }
+            }
+        },
+        "/user": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение списка юзеров со всеми данными (с настраиваемой пагинацией).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Получение списка юзеров. [Админ и преподаватель]",
+                "operationId": "user-list",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "return only class-free students (only if param role=student)",
+                        "name": "free",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "page pagination param",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "example": 5,
+                        "description": "per page pagination param",
+                        "name": "per-page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "example": [
+                            "student"
+                        ],
+                        "description": "user roles (accepted: \"teacher\", \"student\")",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "иванов",
+                        "description": "substring to filter users by username and fullname (case-insensitive)",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.listUserOut"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Создание нового юзера и профиля для него со всеми данными.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Создание нового юзера. [Только админ]",
+                "operationId": "user-create",
+                "parameters": [
+                    {
+                        "description": "userBody",
+                        "name": "userBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.userBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.User"
+                        }
+                    },
+                    "400": {
+                        "description": "группа не найдена | слабый пароль"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "409": {
+                        "description": "пользователь с введенным логином уже существует"
+                    }
+                }
+            }
+        },
+        "/user/me": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение всей информации о себе (юзер, профиль).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Получение информации о себе.",
+                "operationId": "user-me-get",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.User"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    }
+                }
+            }
+        },
+        "/user/me/password": {
+            "put": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Смена своего пароля.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Смена своего пароля.",
+                "operationId": "user-me-password-update",
+                "parameters": [
+                    {
+                        "description": "updatePasswordBody",
+                        "name": "updatePasswordBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.updatePasswordBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "неверный старый пароль"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "409": {
+                        "description": "пароли не должны совпадать"
+                    }
+                }
+            }
+        },
+        "/user/me/profile": {
+            "put": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Полное обновление своего профиля.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Обновление своего профиля.",
+                "operationId": "user-me-profile-update",
+                "parameters": [
+                    {
+                        "description": "profileBody",
+                        "name": "profileBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.profileBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.User"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    }
+                }
+            }
+        },
+        "/user/me/telegram": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение списка Telegram-чатов, привязанных к аккаунту текущего пользователя.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "telegram"
+                ],
+                "summary": "Получение привязанных Telegram-чатов. [Преподаватель и ученик]",
+                "operationId": "telegram-list-chats",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.TelegramChat"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение одноразового кода для привязки Telegram-чата к аккаунту текущего пользователя.\nКод нужно отправить боту командой \"/start КОД\" (или открыть ссылку link, если она есть).\nУченик может получить код для чата родителя (for_parent): туда приходят новые задания и оценки.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "telegram"
+                ],
+                "summary": "Получение кода для привязки Telegram. [Преподаватель и ученик]",
+                "operationId": "telegram-new-code",
+                "parameters": [
+                    {
+                        "description": "newCodeBody",
+                        "name": "newCodeBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.newCodeBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TelegramCode"
+                        }
+                    },
+                    "400": {
+                        "description": "чат родителя доступен только ученикам"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    }
+                }
+            }
+        },
+        "/user/me/telegram/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Отвязка Telegram-чата от аккаунта текущего пользователя. Уведомления в чат больше не приходят.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "telegram"
+                ],
+                "summary": "Отвязка Telegram-чата. [Преподаватель и ученик]",
+                "operationId": "telegram-delete-chat",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID привязки чата",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "чат отвязан"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "чат не найден"
+                    }
+                }
+            }
+        },
+        "/user/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение юзера со всеми данными по его id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Получение юзера по id. [Только админ]",
+                "operationId": "user-read",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID юзера",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.User"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "404": {
+                        "description": "пользователь не найден"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Полное обновление профиля юзера, его группы (если студент) и пароля (опционально) по его id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Обновление юзера по id. [Только админ]",
+                "operationId": "user-update",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID юзера",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "updateBody",
+                        "name": "updateBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_user_controller_http_v1.updateBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.User"
+                        }
+                    },
+                    "400": {
+                        "description": "неподдерживаемые данные для роли обновляемого пользователя | группа не найдена | слабый пароль"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "404": {
+                        "description": "пользователь не найден"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Удаление юзера и его профиля по его id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Удаление юзера по id. [Только админ]",
+                "operationId": "user-delete",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID юзера",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "404": {
+                        "description": "пользователь не найден"
+                    }
+                }
+            }
+        },
+        "/user/{id}/password": {
+            "put": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Смена пароля юзера по его id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Смена пароля юзера. [Только админ]",
+                "operationId": "user-password-update",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID юзера",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "updatePasswordAdminBody",
+                        "name": "updatePasswordAdminBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.updatePasswordAdminBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "404": {
+                        "description": "пользователь не найден"
+                    }
+                }
+            }
+        },
+        "/webhook": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение списка всех вебхуков (секреты не возвращаются).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Получение списка вебхуков. [Только админ]",
+                "operationId": "webhook-list",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Webhook"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Создание подписки внешнего URL на события платформы.\nСобытия отправляются POST-запросом с JSON-телом {event, created_at, data}.\nЗапрос подписан заголовком X-Skadi-Signature: \"sha256=\" + hex(HMAC-SHA256(secret, X-Skadi-Timestamp + \".\" + тело)).\nНеудачные доставки (не 2xx) повторяются с экспоненциальной задержкой.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Создание вебхука. [Только админ]",
+                "operationId": "webhook-create",
+                "parameters": [
+                    {
+                        "description": "webhookBody",
+                        "name": "webhookBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.webhookBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Webhook"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    }
+                }
+            }
+        },
+        "/webhook/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение вебхука по его id (секрет не возвращается).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Получение вебхука по id. [Только админ]",
+                "operationId": "webhook-read",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID вебхука",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Webhook"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "вебхук не найден"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Удаление вебхука вместе с журналом доставок по его id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Удаление вебхука по id. [Только админ]",
+                "operationId": "webhook-delete",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID вебхука",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Частичное обновление вебхука (только переданные поля) по его id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Обновление вебхука по id. [Только админ]",
+                "operationId": "webhook-update",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID вебхука",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "updateBody",
+                        "name": "updateBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_app_webhook_controller_http_v1.updateBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Webhook"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "вебхук не найден"
+                    }
+                }
+            }
+        },
+        "/webhook/{id}/delivery": {
+            "get": {
+                "security": [
+                    {
+                        "JWTAccess": []
+                    }
+                ],
+                "description": "Получение журнала доставок вебхука (сначала новые): тело запроса, число попыток,\nкод ответа и ошибка последней попытки, время следующей попытки и доставки.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Журнал доставок вебхука. [Только админ]",
+                "operationId": "webhook-list-deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID вебхука",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "description": "page pagination param",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "example": 5,
+                        "description": "per page pagination param",
+                        "name": "per-page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.listDeliveryOut"
+                        }
+                    },
+                    "401": {
+                        "description": "неверный токен (пустой, истекший или неверный формат)"
+                    },
+                    "403": {
+                        "description": "доступ запрещён"
+                    },
+                    "404": {
+                        "description": "вебхук не найден"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entity.AutotestCase": {
+            "type": "object",
+            "required": [
+                "memory_limit_mb",
+                "time_limit_ms"
+            ],
+            "properties": {
+                "expected": {
+                    "description": "expected program output (trailing spaces and empty lines are ignored)",
+                    "type": "string",
+                    "example": "5"
+                },
+                "hidden": {
+                    "description": "if true, input and output of the test are not shown to students",
+                    "type": "boolean"
+                },
+                "memory_limit_mb": {
+                    "description": "memory limit in megabytes",
+                    "type": "integer",
+                    "example": 64
+                },
+                "position": {
+                    "description": "position of the test in the suite",
+                    "type": "integer",
+                    "example": 1
+                },
+                "stdin": {
+                    "description": "program input",
+                    "type": "string",
+                    "example": "2 3"
+                },
+                "time_limit_ms": {
+                    "description": "time limit in milliseconds",
+                    "type": "integer",
+                    "example": 1000
+                }
+            }
+        },
+        "entity.AutotestResult": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "expected": {
+                    "description": "expected program output (it is null for hidden tests for students)",
+                    "type": "string",
+                    "example": "5"
+                },
+                "hidden": {
+                    "description": "true if the test is hidden from students",
+                    "type": "boolean"
+                },
+                "position": {
+                    "description": "position of the test in the suite",
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "description": "test verdict",
+                    "type": "string",
+                    "example": "ok"
+                },
+                "stderr": {
+                    "description": "program error output (truncated)",
+                    "type": "string"
+                },
+                "stdin": {
+                    "description": "program input (it is null for hidden tests for students)",
+                    "type": "string",
+                    "example": "2 3"
+                },
+                "stdout": {
+                    "description": "program output (truncated)",
+                    "type": "string",
+                    "example": "5"
+                },
+                "time_ms": {
+                    "description": "execution time in milliseconds",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "entity.AutotestRun": {
+            "type": "object",
+            "required": [
+                "created_at",
+                "id",
+                "status"
+            ],
+            "properties": {
+                "created_at": {
+                    "description": "datetime the run was enqueued",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "compiler output or sandbox error",
+                    "type": "string",
+                    "example": "main.go:5:2: undefined: x"
+                },
+                "finished_at": {
+                    "description": "datetime the run was finished",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "run id",
+                    "type": "integer",
+                    "example": 31
+                },
+                "passed": {
+                    "description": "number of passed tests",
+                    "type": "integer",
+                    "example": 4
+                },
+                "results": {
+                    "description": "per-test results (ordered by position)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.AutotestResult"
+                    }
+                },
+                "score": {
+                    "description": "suggested score (percentage of passed tests)",
+                    "type": "integer",
+                    "example": 80
+                },
+                "started_at": {
+                    "description": "datetime the run was started",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "run status",
+                    "type": "string",
+                    "example": "done"
+                },
+                "total": {
+                    "description": "number of tests in the suite",
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "entity.AutotestSuite": {
+            "type": "object",
+            "required": [
+                "cases",
+                "lang",
+                "updated_at"
+            ],
+            "properties": {
+                "cases": {
+                    "description": "test cases (ordered by position)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.AutotestCase"
+                    }
+                },
+                "lang": {
+                    "description": "programming language of the solutions (one of configured languages)",
+                    "type": "string",
+                    "example": "python"
+                },
+                "updated_at": {
+                    "description": "last-update datetime of the suite",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Class": {
+            "type": "object",
+            "required": [
+                "id",
+                "name"
+            ],
+            "properties": {
+                "id": {
+                    "description": "class id",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "class name",
+                    "type": "string"
+                },
+                "schedule": {
+                    "description": "class schedule",
+                    "type": "string"
+                },
+                "students": {
+                    "description": "student objects list",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Profile"
+                    }
+                },
+                "teacher": {
+                    "description": "teacher object",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Profile"
+                        }
+                    ]
+                }
+            }
+        },
+        "entity.Comment": {
+            "type": "object",
+            "required": [
+                "created_at",
+                "id",
+                "message",
+                "role"
+            ],
+            "properties": {
+                "anchor": {
+                    "description": "fragment of the solution or task file the comment is pointing at",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.CommentAnchor"
+                        }
+                    ]
+                },
+                "author": {
+                    "description": "author short profile",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Profile"
+                        }
+                    ]
+                },
+                "created_at": {
+                    "description": "datetime the message was created",
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "datetime the message was deleted",
+                    "type": "string"
+                },
+                "edited_at": {
+                    "description": "datetime the message was edited last time",
+                    "type": "string"
+                },
+                "files": {
+                    "description": "comment attachments",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.File"
+                    }
+                },
+                "id": {
+                    "description": "comment ID",
+                    "type": "integer"
+                },
+                "message": {
+                    "description": "message text (it is empty for deleted comments)",
+                    "type": "string"
+                },
+                "message_html": {
+                    "description": "message text rendered from Markdown to the sanitized HTML (it is empty for deleted comments)",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "ID of the parent comment (for replies)",
+                    "type": "integer",
+                    "example": 3
+                },
+                "role": {
+                    "description": "role (student or teacher)",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.CommentAnchor": {
+            "type": "object",
+            "required": [
+                "file_id"
+            ],
+            "properties": {
+                "file_id": {
+                    "description": "file ID",
+                    "type": "integer",
+                    "example": 5
+                },
+                "line_from": {
+                    "description": "first line of the range (starting from 1)",
+                    "type": "integer",
+                    "example": 42
+                },
+                "line_to": {
+                    "description": "last line of the range (equals to the first line if it is not set)",
+                    "type": "integer",
+                    "example": 45
+                },
+                "page": {
+                    "description": "page number (starting from 1)",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "entity.Contact": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Course": {
+            "type": "object",
+            "required": [
+                "created_at",
+                "id",
+                "title"
+            ],
+            "properties": {
+                "classes": {
+                    "description": "classes linked to the course",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Class"
+                    }
+                },
+                "created_at": {
+                    "description": "datetime the course was created",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "course description",
+                    "type": "string",
+                    "example": "Основы языка Python"
+                },
+                "id": {
+                    "description": "course id",
+                    "type": "integer",
+                    "example": 3
+                },
+                "modules": {
+                    "description": "course modules (ordered by position)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.CourseModule"
+                    }
+                },
+                "progress": {
+                    "description": "progress of the student in the whole course (for student only)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.CourseProgress"
+                        }
+                    ]
+                },
+                "title": {
+                    "description": "course title",
+                    "type": "string",
+                    "example": "Python для начинающих"
+                }
+            }
+        },
+        "entity.CourseModule": {
+            "type": "object",
+            "required": [
+                "id",
+                "title"
+            ],
+            "properties": {
+                "id": {
+                    "description": "module id",
+                    "type": "integer",
+                    "example": 7
+                },
+                "position": {
+                    "description": "position of the module in the course",
+                    "type": "integer",
+                    "example": 1
+                },
+                "progress": {
+                    "description": "progress of the student in the module (for student only)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.CourseProgress"
+                        }
+                    ]
+                },
+                "solutions": {
+                    "description": "student solutions of the module tasks (ordered by task position, for student only)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Solution"
+                    }
+                },
+                "tasks": {
+                    "description": "module tasks (ordered by position, for teacher only)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Task"
+                    }
+                },
+                "title": {
+                    "description": "module title",
+                    "type": "string",
+                    "example": "Циклы"
+                }
+            }
+        },
+        "entity.CourseProgress": {
+            "type": "object",
+            "required": [
+                "backlog",
+                "checked",
+                "in_work",
+                "percent",
+                "ready",
+                "total"
+            ],
+            "properties": {
+                "backlog": {
+                    "description": "number of tasks with status \"backlog\"",
+                    "type": "integer",
+                    "example": 3
+                },
+                "checked": {
+                    "description": "number of tasks with status \"checked\"",
+                    "type": "integer",
+                    "example": 4
+                },
+                "in_work": {
+                    "description": "number of tasks with status \"in work\"",
+                    "type": "integer",
+                    "example": 2
+                },
+                "percent": {
+                    "description": "percent of checked tasks",
+                    "type": "integer",
+                    "example": 40
+                },
+                "ready": {
+                    "description": "number of tasks with status \"ready\"",
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "description": "number of tasks",
+                    "type": "integer",
+                    "example": 10
                 }
             }
-        }
-    },
-    "definitions": {
-        "entity.Class": {
+        },
+        "entity.Event": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "event payload (comment, solution, task or notification object)"
+                },
+                "type": {
+                    "description": "event type",
+                    "type": "string",
+                    "example": "comment.created"
+                }
+            }
+        },
+        "entity.ExamAttempt": {
+            "type": "object",
+            "required": [
+                "solution_id"
+            ],
+            "properties": {
+                "deadline": {
+                    "description": "datetime the attempt ends (solution cannot be changed after it)",
+                    "type": "string"
+                },
+                "extra_minutes": {
+                    "description": "extra minutes granted by the teacher",
+                    "type": "integer",
+                    "example": 15
+                },
+                "solution_id": {
+                    "description": "solution id",
+                    "type": "integer",
+                    "example": 14
+                },
+                "started_at": {
+                    "description": "datetime the attempt was begun (null if it is not begun yet)",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.File": {
             "type": "object",
             "required": [
                 "id",
-                "name"
+                "mime_type",
+                "name",
+                "scan_status",
+                "size"
             ],
             "properties": {
                 "id": {
-                    "description": "class id",
+                    "description": "file ID",
                     "type": "integer"
                 },
+                "mime_type": {
+                    "description": "file MIME-type",
+                    "type": "string"
+                },
                 "name": {
-                    "description": "class name",
+                    "description": "filename",
                     "type": "string"
                 },
-                "schedule": {
-                    "description": "class schedule",
+                "preview_url": {
+                    "description": "URL to the file preview (for images and PDF documents only)",
                     "type": "string"
                 },
-                "students": {
-                    "description": "student objects list",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.Profile"
-                    }
+                "scan_status": {
+                    "description": "antivirus scan status",
+                    "type": "string"
                 },
-                "teacher": {
-                    "description": "teacher object",
+                "size": {
+                    "description": "file size in bytes",
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.FileLink": {
+            "type": "object",
+            "required": [
+                "expires_at",
+                "url"
+            ],
+            "properties": {
+                "expires_at": {
+                    "description": "link expiration datetime",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "link URL",
+                    "type": "string",
+                    "example": "/api/v1/shared-file/2?exp=1767225600\u0026sig=3a7bd3e2"
+                }
+            }
+        },
+        "entity.Job": {
+            "type": "object",
+            "required": [
+                "name",
+                "schedule"
+            ],
+            "properties": {
+                "last_run": {
+                    "description": "last run of the job",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/entity.Profile"
+                            "$ref": "#/definitions/entity.JobRun"
                         }
                     ]
+                },
+                "name": {
+                    "description": "job name",
+                    "type": "string",
+                    "example": "mail_digest"
+                },
+                "next_run_at": {
+                    "description": "datetime of the next run",
+                    "type": "string"
+                },
+                "schedule": {
+                    "description": "cron expression of the job schedule (UTC)",
+                    "type": "string",
+                    "example": "0 18 * * *"
                 }
             }
         },
-        "entity.Comment": {
+        "entity.JobRun": {
             "type": "object",
             "required": [
-                "created_at",
                 "id",
-                "message",
-                "role"
+                "instance",
+                "job",
+                "scheduled_at",
+                "started_at"
             ],
             "properties": {
-                "created_at": {
-                    "description": "datetime the message was created",
+                "error": {
+                    "description": "error of the failed run",
+                    "type": "string",
+                    "example": "get recipients: connection refused"
+                },
+                "finished_at": {
+                    "description": "datetime the run was finished (it is null for running jobs)",
                     "type": "string"
                 },
                 "id": {
-                    "description": "comment ID",
-                    "type": "integer"
+                    "description": "run ID",
+                    "type": "integer",
+                    "example": 120
                 },
-                "message": {
-                    "description": "message text",
+                "instance": {
+                    "description": "backend instance (hostname) the job was run on",
+                    "type": "string",
+                    "example": "backend-7f9c6d"
+                },
+                "job": {
+                    "description": "job name",
+                    "type": "string",
+                    "example": "mail_digest"
+                },
+                "scheduled_at": {
+                    "description": "datetime the job was scheduled to",
                     "type": "string"
                 },
-                "role": {
-                    "description": "role (student or teacher)",
+                "started_at": {
+                    "description": "datetime the run was started",
                     "type": "string"
                 }
             }
         },
-        "entity.Contact": {
+        "entity.MailPref": {
             "type": "object",
+            "required": [
+                "mode"
+            ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "mode": {
+                    "description": "e-mail notifications mode",
+                    "type": "string",
+                    "example": "daily"
                 },
-                "phone": {
-                    "type": "string"
+                "parent_summary": {
+                    "description": "if true, weekly progress summary is sent to the parent contact (for students)",
+                    "type": "boolean"
                 }
             }
         },
-        "entity.File": {
+        "entity.Notification": {
             "type": "object",
             "required": [
+                "created_at",
                 "id",
-                "mime_type",
-                "name",
-                "size"
+                "message",
+                "type"
             ],
             "properties": {
-                "id": {
-                    "description": "file ID",
-                    "type": "integer"
+                "comment_id": {
+                    "description": "related comment ID",
+                    "type": "integer",
+                    "example": 8
                 },
-                "mime_type": {
-                    "description": "file MIME-type",
+                "created_at": {
+                    "description": "datetime the notification was created",
                     "type": "string"
                 },
-                "name": {
-                    "description": "filename",
+                "id": {
+                    "description": "notification ID",
+                    "type": "integer",
+                    "example": 12
+                },
+                "message": {
+                    "description": "notification text",
+                    "type": "string",
+                    "example": "Новый комментарий к решению задания «Циклы»"
+                },
+                "read_at": {
+                    "description": "datetime the notification was read (it is null for unread notifications)",
                     "type": "string"
                 },
-                "size": {
-                    "description": "file size in bytes",
-                    "type": "integer"
+                "solution_id": {
+                    "description": "related solution ID",
+                    "type": "integer",
+                    "example": 5
+                },
+                "task_id": {
+                    "description": "related task ID",
+                    "type": "integer",
+                    "example": 3
+                },
+                "type": {
+                    "description": "notification type",
+                    "type": "string",
+                    "example": "new_comment"
+                }
+            }
+        },
+        "entity.NotificationPref": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "enabled": {
+                    "description": "false if user does not want to get notifications of this type",
+                    "type": "boolean"
+                },
+                "type": {
+                    "description": "notification type",
+                    "type": "string",
+                    "example": "new_comment"
                 }
             }
         },
@@ -1975,6 +6160,172 @@ const docTemplate = `{
                 }
             }
         },
+        "entity.PlagiarismCheck": {
+            "type": "object",
+            "required": [
+                "requested_at",
+                "status"
+            ],
+            "properties": {
+                "error": {
+                    "description": "error of the failed check",
+                    "type": "string"
+                },
+                "finished_at": {
+                    "description": "datetime the check was finished",
+                    "type": "string"
+                },
+                "requested_at": {
+                    "description": "datetime the check was requested",
+                    "type": "string"
+                },
+                "started_at": {
+                    "description": "datetime the check was started",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "check status",
+                    "type": "string",
+                    "example": "done"
+                }
+            }
+        },
+        "entity.PlagiarismFragment": {
+            "type": "object",
+            "required": [
+                "end_line",
+                "other_end_line",
+                "other_start_line",
+                "other_text",
+                "start_line",
+                "text"
+            ],
+            "properties": {
+                "end_line": {
+                    "description": "last line of the fragment",
+                    "type": "integer",
+                    "example": 12
+                },
+                "other_end_line": {
+                    "description": "last line of the other fragment",
+                    "type": "integer",
+                    "example": 14
+                },
+                "other_source": {
+                    "description": "other solution file name (empty for the text answer)",
+                    "type": "string",
+                    "example": "solution.py"
+                },
+                "other_start_line": {
+                    "description": "first line of the other fragment (starting from 1)",
+                    "type": "integer",
+                    "example": 5
+                },
+                "other_text": {
+                    "description": "other fragment text",
+                    "type": "string",
+                    "example": "for i in range(n):"
+                },
+                "source": {
+                    "description": "solution file name (empty for the text answer)",
+                    "type": "string",
+                    "example": "main.py"
+                },
+                "start_line": {
+                    "description": "first line of the fragment (starting from 1)",
+                    "type": "integer",
+                    "example": 3
+                },
+                "text": {
+                    "description": "fragment text",
+                    "type": "string",
+                    "example": "for _ in range(n):"
+                }
+            }
+        },
+        "entity.PlagiarismPair": {
+            "type": "object",
+            "required": [
+                "created_at",
+                "fragments",
+                "id",
+                "other_solution_id",
+                "similarity",
+                "solution_id"
+            ],
+            "properties": {
+                "created_at": {
+                    "description": "datetime the solutions were compared",
+                    "type": "string"
+                },
+                "fragments": {
+                    "description": "matching fragments of the solutions (the longest ones)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PlagiarismFragment"
+                    }
+                },
+                "id": {
+                    "description": "pair id",
+                    "type": "integer",
+                    "example": 12
+                },
+                "other_solution_id": {
+                    "description": "id of the other solution",
+                    "type": "integer",
+                    "example": 35
+                },
+                "other_student": {
+                    "description": "other solution student",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Profile"
+                        }
+                    ]
+                },
+                "similarity": {
+                    "description": "similarity of the solutions (percentage of the matching fingerprints of the smaller solution)",
+                    "type": "integer",
+                    "example": 87
+                },
+                "solution_id": {
+                    "description": "solution id (the less one of the pair)",
+                    "type": "integer",
+                    "example": 31
+                },
+                "student": {
+                    "description": "solution student",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Profile"
+                        }
+                    ]
+                }
+            }
+        },
+        "entity.PlagiarismReport": {
+            "type": "object",
+            "required": [
+                "pairs"
+            ],
+            "properties": {
+                "check": {
+                    "description": "last on-demand check (pairs of solutions sent for review are found without it)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.PlagiarismCheck"
+                        }
+                    ]
+                },
+                "pairs": {
+                    "description": "suspicious solution pairs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PlagiarismPair"
+                    }
+                }
+            }
+        },
         "entity.Profile": {
             "type": "object",
             "required": [
@@ -2005,6 +6356,169 @@ const docTemplate = `{
                 }
             }
         },
+        "entity.Quiz": {
+            "type": "object",
+            "required": [
+                "answers",
+                "max_points",
+                "questions"
+            ],
+            "properties": {
+                "answers": {
+                    "description": "answers of the student",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.QuizAnswer"
+                    }
+                },
+                "graded": {
+                    "description": "true if the quiz was submitted and graded",
+                    "type": "boolean"
+                },
+                "max_points": {
+                    "description": "sum of question points",
+                    "type": "integer",
+                    "example": 10
+                },
+                "points": {
+                    "description": "sum of answer points (with teacher overrides)",
+                    "type": "integer",
+                    "example": 7
+                },
+                "questions": {
+                    "description": "questions (ordered by position)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.QuizQuestion"
+                    }
+                }
+            }
+        },
+        "entity.QuizAnswer": {
+            "type": "object",
+            "required": [
+                "question_id"
+            ],
+            "properties": {
+                "choice": {
+                    "description": "indexes of chosen options starting from 0 (for single and multiple choice)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1
+                    ]
+                },
+                "number": {
+                    "description": "number answer (for numeric)",
+                    "type": "number",
+                    "example": 4
+                },
+                "override_points": {
+                    "description": "points set by the teacher instead of auto-graded ones",
+                    "type": "integer",
+                    "example": 1
+                },
+                "points": {
+                    "description": "auto-graded points (null until the quiz is submitted)",
+                    "type": "integer",
+                    "example": 2
+                },
+                "question_id": {
+                    "description": "question id",
+                    "type": "integer",
+                    "example": 12
+                },
+                "text": {
+                    "description": "text answer (for short answer)",
+                    "type": "string",
+                    "example": "четыре"
+                }
+            }
+        },
+        "entity.QuizQuestion": {
+            "type": "object",
+            "required": [
+                "id",
+                "kind",
+                "points",
+                "text"
+            ],
+            "properties": {
+                "answers": {
+                    "description": "accepted short answers or regex patterns (it is not shown to students)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "четыре"
+                    ]
+                },
+                "correct": {
+                    "description": "indexes of correct options starting from 0 (it is not shown to students)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1
+                    ]
+                },
+                "id": {
+                    "description": "question id",
+                    "type": "integer",
+                    "example": 12
+                },
+                "kind": {
+                    "description": "question kind",
+                    "type": "string",
+                    "example": "single"
+                },
+                "options": {
+                    "description": "answer options (for single and multiple choice)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "3",
+                        "4",
+                        "5"
+                    ]
+                },
+                "points": {
+                    "description": "max points for the correct answer",
+                    "type": "integer",
+                    "example": 2
+                },
+                "position": {
+                    "description": "position of the question in the quiz",
+                    "type": "integer",
+                    "example": 1
+                },
+                "regex": {
+                    "description": "if true, short answers are regex patterns matched with the whole answer (it is not shown to students)",
+                    "type": "boolean"
+                },
+                "text": {
+                    "description": "question text",
+                    "type": "string",
+                    "example": "Сколько будет 2 + 2?"
+                },
+                "tolerance": {
+                    "description": "allowed deviation from the correct number (it is not shown to students)",
+                    "type": "number",
+                    "example": 0.01
+                },
+                "value": {
+                    "description": "correct number (it is not shown to students)",
+                    "type": "number",
+                    "example": 4
+                }
+            }
+        },
         "entity.Solution": {
             "type": "object",
             "required": [
@@ -2017,6 +6531,22 @@ const docTemplate = `{
                     "description": "solution text answer",
                     "type": "string"
                 },
+                "attempt": {
+                    "description": "exam attempt (for tasks in exam mode)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.ExamAttempt"
+                        }
+                    ]
+                },
+                "autotest": {
+                    "description": "last autotest run (without per-test results)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.AutotestRun"
+                        }
+                    ]
+                },
                 "files": {
                     "description": "solution files",
                     "type": "array",
@@ -2028,10 +6558,21 @@ const docTemplate = `{
                     "description": "solution grade",
                     "type": "string"
                 },
+                "has_updates": {
+                    "description": "true if the solution was updated or commented since the last view (for lists only)",
+                    "type": "boolean"
+                },
                 "id": {
                     "description": "solution id",
                     "type": "integer"
                 },
+                "members": {
+                    "description": "students sharing the solution (more than one for team solutions)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.SolutionMember"
+                    }
+                },
                 "status": {
                     "description": "status object",
                     "allOf": [
@@ -2056,12 +6597,38 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "unread_comments": {
+                    "description": "number of comments from other users created since the last view (for lists only)",
+                    "type": "integer",
+                    "example": 2
+                },
                 "updated_at": {
                     "description": "last-update datetime of solution",
                     "type": "string"
                 }
             }
         },
+        "entity.SolutionMember": {
+            "type": "object",
+            "required": [
+                "student"
+            ],
+            "properties": {
+                "grade": {
+                    "description": "individual grade of the student instead of the solution grade",
+                    "type": "string",
+                    "example": "5"
+                },
+                "student": {
+                    "description": "student object",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Profile"
+                        }
+                    ]
+                }
+            }
+        },
         "entity.Status": {
             "type": "object",
             "required": [
@@ -2085,10 +6652,35 @@ const docTemplate = `{
                 "title"
             ],
             "properties": {
+                "deadline": {
+                    "description": "datetime students have to send solutions before (null for no deadline)",
+                    "type": "string"
+                },
                 "description": {
                     "description": "task description",
                     "type": "string"
                 },
+                "description_html": {
+                    "description": "task description rendered from Markdown to the sanitized HTML",
+                    "type": "string"
+                },
+                "draft": {
+                    "description": "true if the task is not published yet (students do not see it)",
+                    "type": "boolean"
+                },
+                "exam_closes_at": {
+                    "description": "datetime the exam closes: attempts cannot be begun and they end at it (null for no restriction)",
+                    "type": "string"
+                },
+                "exam_duration": {
+                    "description": "exam attempt duration in minutes (exam mode is on if it is not null)",
+                    "type": "integer",
+                    "example": 45
+                },
+                "exam_opens_at": {
+                    "description": "datetime students can begin exam attempts from (null for no restriction)",
+                    "type": "string"
+                },
                 "files": {
                     "description": "task files",
                     "type": "array",
@@ -2100,6 +6692,20 @@ const docTemplate = `{
                     "description": "task id",
                     "type": "integer"
                 },
+                "module_id": {
+                    "description": "id of the course module the task belongs to",
+                    "type": "integer",
+                    "example": 7
+                },
+                "position": {
+                    "description": "position of the task in the course module",
+                    "type": "integer",
+                    "example": 2
+                },
+                "publish_at": {
+                    "description": "datetime the draft will be published automatically (null for manual publication)",
+                    "type": "string"
+                },
                 "teacher": {
                     "description": "teacher object",
                     "allOf": [
@@ -2111,6 +6717,11 @@ const docTemplate = `{
                 "title": {
                     "description": "task title",
                     "type": "string"
+                },
+                "type": {
+                    "description": "task type (quiz type is set with the quiz questions)",
+                    "type": "string",
+                    "example": "assignment"
                 }
             }
         },
@@ -2137,6 +6748,104 @@ const docTemplate = `{
                 }
             }
         },
+        "entity.TelegramChat": {
+            "type": "object",
+            "required": [
+                "chat_id",
+                "created_at",
+                "id"
+            ],
+            "properties": {
+                "chat_id": {
+                    "description": "Telegram chat ID",
+                    "type": "integer",
+                    "example": 123456789
+                },
+                "created_at": {
+                    "description": "datetime the chat was linked",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "link ID",
+                    "type": "integer",
+                    "example": 3
+                },
+                "is_parent": {
+                    "description": "true if the chat belongs to the student's parent",
+                    "type": "boolean"
+                }
+            }
+        },
+        "entity.TelegramCode": {
+            "type": "object",
+            "required": [
+                "code",
+                "expires_at"
+            ],
+            "properties": {
+                "code": {
+                    "description": "code to send to the bot",
+                    "type": "string",
+                    "example": "K7Q2MX9D"
+                },
+                "expires_at": {
+                    "description": "code expiration datetime",
+                    "type": "string"
+                },
+                "is_parent": {
+                    "description": "true if the code links the chat of the student's parent",
+                    "type": "boolean"
+                },
+                "link": {
+                    "description": "link to open the bot and send the code at once (it is empty if bot name is not set)",
+                    "type": "string",
+                    "example": "https://t.me/skadi_bot?start=K7Q2MX9D"
+                }
+            }
+        },
+        "entity.Upload": {
+            "type": "object",
+            "required": [
+                "created_at",
+                "expires_at",
+                "id",
+                "mime_type",
+                "name",
+                "size",
+                "uploaded"
+            ],
+            "properties": {
+                "created_at": {
+                    "description": "datetime the upload was created",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "datetime the unfinished upload will be deleted",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "upload ID",
+                    "type": "string",
+                    "example": "9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d"
+                },
+                "mime_type": {
+                    "description": "file MIME-type",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "filename",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "total file size in bytes",
+                    "type": "integer"
+                },
+                "uploaded": {
+                    "description": "number of received bytes (offset for the next chunk)",
+                    "type": "integer"
+                }
+            }
+        },
         "entity.User": {
             "type": "object",
             "required": [
@@ -2170,22 +6879,114 @@ const docTemplate = `{
                     "description": "admin, teacher or student",
                     "type": "string"
                 },
-                "username": {
-                    "description": "user username",
+                "username": {
+                    "description": "user username",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.UserClaims": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "user ID",
+                    "type": "integer"
+                },
+                "role": {
+                    "description": "admin, teacher or student",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Webhook": {
+            "type": "object",
+            "required": [
+                "created_at",
+                "events",
+                "id",
+                "url"
+            ],
+            "properties": {
+                "created_at": {
+                    "description": "datetime the webhook was created",
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "false if deliveries are paused",
+                    "type": "boolean"
+                },
+                "events": {
+                    "description": "event types the webhook is subscribed to",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "solution.graded"
+                    ]
+                },
+                "id": {
+                    "description": "webhook ID",
+                    "type": "integer",
+                    "example": 2
+                },
+                "url": {
+                    "description": "URL to send events to",
+                    "type": "string",
+                    "example": "https://crm.school.ru/skadi/hook"
+                }
+            }
+        },
+        "entity.WebhookDelivery": {
+            "type": "object",
+            "required": [
+                "created_at",
+                "event",
+                "id",
+                "next_attempt_at",
+                "payload"
+            ],
+            "properties": {
+                "attempts": {
+                    "description": "number of failed attempts",
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "description": "datetime the delivery was created",
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "description": "datetime the event was delivered (it is null for undelivered events)",
                     "type": "string"
-                }
-            }
-        },
-        "entity.UserClaims": {
-            "type": "object",
-            "properties": {
+                },
+                "event": {
+                    "description": "event type",
+                    "type": "string",
+                    "example": "solution.graded"
+                },
                 "id": {
-                    "description": "user ID",
-                    "type": "integer"
+                    "description": "delivery ID",
+                    "type": "integer",
+                    "example": 15
                 },
-                "role": {
-                    "description": "admin, teacher or student",
+                "last_error": {
+                    "description": "error of the last failed attempt",
+                    "type": "string",
+                    "example": "unexpected status code 500"
+                },
+                "next_attempt_at": {
+                    "description": "datetime of the next attempt",
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "JSON-body of the request",
                     "type": "string"
+                },
+                "status_code": {
+                    "description": "HTTP status code of the last response",
+                    "type": "integer",
+                    "example": 500
                 }
             }
         },
@@ -2248,6 +7049,126 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_app_webhook_controller_http_v1.updateBody": {
+            "description": "updateBody represents a data to update webhook (only given fields are changed).",
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "false to pause deliveries",
+                    "type": "boolean",
+                    "example": false
+                },
+                "events": {
+                    "description": "event types: task.created, solution.status_changed, solution.graded, comment.created, user.created",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "solution.graded"
+                    ]
+                },
+                "secret": {
+                    "description": "secret to sign deliveries with HMAC-SHA256",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 16,
+                    "example": "very-long-webhook-secret"
+                },
+                "url": {
+                    "description": "URL to send events to",
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://crm.school.ru/skadi/hook"
+                }
+            }
+        },
+        "v1.answerBody": {
+            "description": "answerBody represents a data with answer to the quiz question.",
+            "type": "object",
+            "required": [
+                "question_id"
+            ],
+            "properties": {
+                "choice": {
+                    "description": "indexes of chosen options starting from 0 (for single and multiple choice)",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1
+                    ]
+                },
+                "number": {
+                    "description": "number answer (for numeric)",
+                    "type": "number",
+                    "example": 4
+                },
+                "question_id": {
+                    "description": "question id",
+                    "type": "integer",
+                    "example": 12
+                },
+                "text": {
+                    "description": "text answer (for short answer)",
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "четыре"
+                }
+            }
+        },
+        "v1.answersBody": {
+            "description": "answersBody represents a data with answers to the quiz questions.",
+            "type": "object",
+            "properties": {
+                "answers": {
+                    "description": "answers (replace saved answers to the same questions)",
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "$ref": "#/definitions/v1.answerBody"
+                    }
+                },
+                "submit": {
+                    "description": "if true, the quiz is submitted and graded (answers cannot be changed anymore)",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "v1.assignTaskBody": {
+            "description": "assignTaskBody represents a data to assign task to students.",
+            "type": "object",
+            "properties": {
+                "classes": {
+                    "description": "classes for task solutions",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3,
+                        6,
+                        9
+                    ]
+                },
+                "students": {
+                    "description": "students for task solutions",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        22,
+                        32,
+                        14
+                    ]
+                }
+            }
+        },
         "v1.authBody": {
             "description": "authBody represents a data for user auth (log in).",
             "type": "object",
@@ -2271,6 +7192,46 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.caseBody": {
+            "description": "caseBody represents a data with stdin/stdout test case.",
+            "type": "object",
+            "required": [
+                "memory_limit_mb",
+                "time_limit_ms"
+            ],
+            "properties": {
+                "expected": {
+                    "description": "expected program output (trailing spaces and empty lines are ignored)",
+                    "type": "string",
+                    "maxLength": 1048576,
+                    "example": "5"
+                },
+                "hidden": {
+                    "description": "if true, input and output of the test are not shown to students",
+                    "type": "boolean"
+                },
+                "memory_limit_mb": {
+                    "description": "memory limit in megabytes",
+                    "type": "integer",
+                    "maximum": 1024,
+                    "minimum": 16,
+                    "example": 64
+                },
+                "stdin": {
+                    "description": "program input",
+                    "type": "string",
+                    "maxLength": 1048576,
+                    "example": "2 3"
+                },
+                "time_limit_ms": {
+                    "description": "time limit in milliseconds",
+                    "type": "integer",
+                    "maximum": 30000,
+                    "minimum": 100,
+                    "example": 1000
+                }
+            }
+        },
         "v1.classBody": {
             "description": "classBody represents a data with class.",
             "type": "object",
@@ -2304,17 +7265,15 @@ const docTemplate = `{
                 }
             }
         },
-        "v1.commentBody": {
-            "description": "commentBody represents a data with comment.",
+        "v1.cloneTaskBody": {
+            "description": "cloneTaskBody represents a data with optional body to clone task.",
             "type": "object",
-            "required": [
-                "message"
-            ],
             "properties": {
-                "message": {
-                    "description": "text message",
+                "title": {
+                    "description": "title of the clone (title of the source task by default)",
                     "type": "string",
-                    "example": "Добрый день. Подскажите, пожалуйста..."
+                    "maxLength": 100,
+                    "example": "ООП в Python (копия)"
                 }
             }
         },
@@ -2334,6 +7293,37 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.courseBody": {
+            "description": "courseBody represents a data with course.",
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "classes": {
+                    "description": "IDs of classes linked to the course",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3,
+                        6
+                    ]
+                },
+                "description": {
+                    "description": "course description",
+                    "type": "string",
+                    "example": "Основы языка Python"
+                },
+                "title": {
+                    "description": "course title",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Python для начинающих"
+                }
+            }
+        },
         "v1.createTaskOut": {
             "description": "createTaskOut represents a task data with solutions.",
             "type": "object",
@@ -2358,6 +7348,32 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.examBody": {
+            "description": "examBody represents a data with exam window and attempt duration.",
+            "type": "object",
+            "required": [
+                "duration"
+            ],
+            "properties": {
+                "closes_at": {
+                    "description": "datetime the exam closes: attempts cannot be begun and they end at it (RFC 3339)",
+                    "type": "string",
+                    "example": "2026-12-20T11:00:00+03:00"
+                },
+                "duration": {
+                    "description": "attempt duration in minutes",
+                    "type": "integer",
+                    "maximum": 1440,
+                    "minimum": 1,
+                    "example": 45
+                },
+                "opens_at": {
+                    "description": "datetime students can begin attempts from (RFC 3339)",
+                    "type": "string",
+                    "example": "2026-12-20T09:00:00+03:00"
+                }
+            }
+        },
         "v1.exampleData": {
             "description": "exampleData represents an output data for example endpoints.",
             "type": "object",
@@ -2382,6 +7398,31 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.extraBody": {
+            "description": "extraBody represents a data with extra minutes of the student attempt.",
+            "type": "object",
+            "properties": {
+                "minutes": {
+                    "description": "extra minutes (0 to cancel)",
+                    "type": "integer",
+                    "maximum": 1440,
+                    "minimum": 0,
+                    "example": 15
+                }
+            }
+        },
+        "v1.gradeBody": {
+            "description": "gradeBody represents a data with individual grade of the team member.",
+            "type": "object",
+            "properties": {
+                "grade": {
+                    "description": "individual grade (null to reset it to the team solution grade)",
+                    "type": "string",
+                    "maxLength": 5,
+                    "example": "5+"
+                }
+            }
+        },
         "v1.listClassOut": {
             "description": "listClassOut represents a classes list and pagination params.",
             "type": "object",
@@ -2393,7 +7434,133 @@ const docTemplate = `{
                     "description": "classes list",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.Class"
+                        "$ref": "#/definitions/entity.Class"
+                    }
+                },
+                "pagination": {
+                    "description": "pagination params",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Pagination"
+                        }
+                    ]
+                }
+            }
+        },
+        "v1.listCommentOut": {
+            "description": "listCommentOut represents a comment list and pagination params.",
+            "type": "object",
+            "required": [
+                "data"
+            ],
+            "properties": {
+                "data": {
+                    "description": "comment list",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Comment"
+                    }
+                },
+                "pagination": {
+                    "description": "pagination params",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Pagination"
+                        }
+                    ]
+                }
+            }
+        },
+        "v1.listCourseOut": {
+            "description": "listCourseOut represents a course list data.",
+            "type": "object",
+            "required": [
+                "data"
+            ],
+            "properties": {
+                "data": {
+                    "description": "courses list",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Course"
+                    }
+                },
+                "pagination": {
+                    "description": "pagination params",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Pagination"
+                        }
+                    ]
+                }
+            }
+        },
+        "v1.listDeliveryOut": {
+            "description": "listDeliveryOut represents a webhook delivery log and pagination params.",
+            "type": "object",
+            "required": [
+                "data"
+            ],
+            "properties": {
+                "data": {
+                    "description": "deliveries list (newest first)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.WebhookDelivery"
+                    }
+                },
+                "pagination": {
+                    "description": "pagination params",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Pagination"
+                        }
+                    ]
+                }
+            }
+        },
+        "v1.listNotificationOut": {
+            "description": "listNotificationOut represents a notification list and pagination params.",
+            "type": "object",
+            "required": [
+                "data",
+                "unread"
+            ],
+            "properties": {
+                "data": {
+                    "description": "notification list",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Notification"
+                    }
+                },
+                "pagination": {
+                    "description": "pagination params",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Pagination"
+                        }
+                    ]
+                },
+                "unread": {
+                    "description": "number of all unread user notifications",
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "v1.listRunOut": {
+            "description": "listRunOut represents a job run history and pagination params.",
+            "type": "object",
+            "required": [
+                "data"
+            ],
+            "properties": {
+                "data": {
+                    "description": "runs list (newest first)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.JobRun"
                     }
                 },
                 "pagination": {
@@ -2406,18 +7573,18 @@ const docTemplate = `{
                 }
             }
         },
-        "v1.listCommentOut": {
-            "description": "listCommentOut represents a comment list and pagination params.",
+        "v1.listSharedTaskOut": {
+            "description": "listSharedTaskOut represents a shared task list data.",
             "type": "object",
             "required": [
                 "data"
             ],
             "properties": {
                 "data": {
-                    "description": "comment list",
+                    "description": "tasks list",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.Comment"
+                        "$ref": "#/definitions/entity.Task"
                     }
                 },
                 "pagination": {
@@ -2502,6 +7669,150 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.mailPrefBody": {
+            "description": "mailPrefBody represents a data to update e-mail preferences.",
+            "type": "object",
+            "required": [
+                "mode"
+            ],
+            "properties": {
+                "mode": {
+                    "description": "e-mail notifications mode: off, immediate (every notification at once) or daily (digest)",
+                    "type": "string",
+                    "enum": [
+                        "off",
+                        "immediate",
+                        "daily"
+                    ],
+                    "example": "daily"
+                },
+                "parent_summary": {
+                    "description": "if true, weekly progress summary is sent to the parent contact (for students only)",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "v1.moduleBody": {
+            "description": "moduleBody represents a data with course module.",
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "title": {
+                    "description": "module title",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Циклы"
+                }
+            }
+        },
+        "v1.moduleOrderBody": {
+            "description": "moduleOrderBody represents a data with the new order of the course modules.",
+            "type": "object",
+            "required": [
+                "modules"
+            ],
+            "properties": {
+                "modules": {
+                    "description": "IDs of all course modules in the new order",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        7,
+                        5,
+                        6
+                    ]
+                }
+            }
+        },
+        "v1.moduleTasksBody": {
+            "description": "moduleTasksBody represents a data with tasks of the course module.",
+            "type": "object",
+            "properties": {
+                "tasks": {
+                    "description": "IDs of module tasks (updated list) in the order",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        12,
+                        10,
+                        11
+                    ]
+                }
+            }
+        },
+        "v1.newCodeBody": {
+            "description": "newCodeBody represents a data to get the link code.",
+            "type": "object",
+            "properties": {
+                "for_parent": {
+                    "description": "if true, the code links the chat of the student's parent (for students only)",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "v1.overrideBody": {
+            "description": "overrideBody represents a data with points of the answer set by the teacher.",
+            "type": "object",
+            "properties": {
+                "points": {
+                    "description": "points instead of auto-graded ones (null to reset)",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1
+                }
+            }
+        },
+        "v1.prefBody": {
+            "description": "prefBody represents a user preference for the notification type.",
+            "type": "object",
+            "required": [
+                "enabled",
+                "type"
+            ],
+            "properties": {
+                "enabled": {
+                    "description": "false to disable notifications of this type",
+                    "type": "boolean",
+                    "example": false
+                },
+                "type": {
+                    "description": "notification type",
+                    "type": "string",
+                    "enum": [
+                        "task_assigned",
+                        "solution_submitted",
+                        "solution_checked",
+                        "new_comment",
+                        "deadline_approaching"
+                    ],
+                    "example": "new_comment"
+                }
+            }
+        },
+        "v1.prefsOut": {
+            "description": "prefsOut represents user preferences for all notification types.",
+            "type": "object",
+            "required": [
+                "data"
+            ],
+            "properties": {
+                "data": {
+                    "description": "preferences list",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.NotificationPref"
+                    }
+                }
+            }
+        },
         "v1.profileBody": {
             "description": "profileBody represents a data with user profile.",
             "type": "object",
@@ -2544,6 +7855,145 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.questionBody": {
+            "description": "questionBody represents a data with quiz question and its answer key.",
+            "type": "object",
+            "required": [
+                "answers",
+                "kind",
+                "options",
+                "points",
+                "text"
+            ],
+            "properties": {
+                "answers": {
+                    "description": "accepted answers or regex patterns (for short answer)",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "4",
+                        "четыре"
+                    ]
+                },
+                "correct": {
+                    "description": "indexes of correct options starting from 0 (for single and multiple choice)",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1
+                    ]
+                },
+                "kind": {
+                    "description": "question kind (single, multiple, short or numeric)",
+                    "type": "string",
+                    "enum": [
+                        "single",
+                        "multiple",
+                        "short",
+                        "numeric"
+                    ],
+                    "example": "single"
+                },
+                "options": {
+                    "description": "answer options (for single and multiple choice)",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "3",
+                        "4",
+                        "5"
+                    ]
+                },
+                "points": {
+                    "description": "max points for the correct answer",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 2
+                },
+                "regex": {
+                    "description": "if true, accepted answers are regex patterns matched with the whole answer",
+                    "type": "boolean"
+                },
+                "text": {
+                    "description": "question text",
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "Сколько будет 2 + 2?"
+                },
+                "tolerance": {
+                    "description": "allowed deviation from the correct number (for numeric)",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0.01
+                },
+                "value": {
+                    "description": "correct number (for numeric)",
+                    "type": "number",
+                    "example": 4
+                }
+            }
+        },
+        "v1.questionsBody": {
+            "description": "questionsBody represents a data with quiz questions.",
+            "type": "object",
+            "required": [
+                "questions"
+            ],
+            "properties": {
+                "questions": {
+                    "description": "questions in the order",
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/v1.questionBody"
+                    }
+                }
+            }
+        },
+        "v1.shareTaskBody": {
+            "description": "shareTaskBody represents a data with teachers to share task with.",
+            "type": "object",
+            "properties": {
+                "teachers": {
+                    "description": "IDs of teachers (updated list) the task is shared with",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        4,
+                        7
+                    ]
+                }
+            }
+        },
+        "v1.shareTaskOut": {
+            "description": "shareTaskOut represents a list of teachers the task is shared with.",
+            "type": "object",
+            "required": [
+                "teachers"
+            ],
+            "properties": {
+                "teachers": {
+                    "description": "teachers list",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Profile"
+                    }
+                }
+            }
+        },
         "v1.solutionOut": {
             "description": "solutionOut represents a solution data with students (solving the same task).",
             "type": "object",
@@ -2568,6 +8018,97 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.suiteBody": {
+            "description": "suiteBody represents a data with task test suite.",
+            "type": "object",
+            "required": [
+                "cases",
+                "lang"
+            ],
+            "properties": {
+                "cases": {
+                    "description": "test cases in the order",
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/v1.caseBody"
+                    }
+                },
+                "lang": {
+                    "description": "programming language of the solutions (one of configured languages)",
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "python"
+                }
+            }
+        },
+        "v1.teamBody": {
+            "description": "teamBody represents a data with students of the new team.",
+            "type": "object",
+            "required": [
+                "students"
+            ],
+            "properties": {
+                "students": {
+                    "description": "IDs of students (solution of the first student becomes the team solution)",
+                    "type": "array",
+                    "maxItems": 30,
+                    "minItems": 2,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        22,
+                        32,
+                        14
+                    ]
+                }
+            }
+        },
+        "v1.updateCommentBody": {
+            "description": "updateCommentBody represents a data to update comment.",
+            "type": "object",
+            "required": [
+                "message"
+            ],
+            "properties": {
+                "message": {
+                    "description": "new text message",
+                    "type": "string",
+                    "example": "Добрый день. Подскажите, пожалуйста..."
+                }
+            }
+        },
+        "v1.updateCourseBody": {
+            "description": "updateCourseBody represents a data with optional body to update course.",
+            "type": "object",
+            "properties": {
+                "classes": {
+                    "description": "IDs of classes (updated list) linked to the course",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3,
+                        9
+                    ]
+                },
+                "description": {
+                    "description": "new course description",
+                    "type": "string",
+                    "example": "Декораторы, генераторы и асинхронность"
+                },
+                "title": {
+                    "description": "new course title",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Python. Продвинутый уровень"
+                }
+            }
+        },
         "v1.updatePasswordAdminBody": {
             "description": "updatePasswordAdminBody represents a data to update user password by admin.",
             "type": "object",
@@ -2608,6 +8149,23 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.updatePrefsBody": {
+            "description": "updatePrefsBody represents a data to update notification preferences.",
+            "type": "object",
+            "required": [
+                "prefs"
+            ],
+            "properties": {
+                "prefs": {
+                    "description": "preferences for the notification types (other types are not changed)",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/v1.prefBody"
+                    }
+                }
+            }
+        },
         "v1.updateSolutionBody": {
             "description": "updateSolutionBody represents a data with optional body to update solution.",
             "type": "object",
@@ -2634,6 +8192,42 @@ const docTemplate = `{
                     "description": "new status ID (student and teacher)",
                     "type": "integer",
                     "example": 2
+                },
+                "uploads": {
+                    "description": "IDs of completed chunked uploads to attach as new solution files (student only)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v1.uploadBody": {
+            "description": "uploadBody represents a data with metadata of the file to upload.",
+            "type": "object",
+            "required": [
+                "mime_type",
+                "name",
+                "size"
+            ],
+            "properties": {
+                "mime_type": {
+                    "description": "file MIME-type",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "video/mp4"
+                },
+                "name": {
+                    "description": "filename",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "project.mp4"
+                },
+                "size": {
+                    "description": "total file size in bytes",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 104857600
                 }
             }
         },
@@ -2683,6 +8277,46 @@ const docTemplate = `{
                     "example": "user1"
                 }
             }
+        },
+        "v1.webhookBody": {
+            "description": "webhookBody represents a data to create webhook.",
+            "type": "object",
+            "required": [
+                "events",
+                "secret",
+                "url"
+            ],
+            "properties": {
+                "enabled": {
+                    "description": "false to pause deliveries (true by default)",
+                    "type": "boolean",
+                    "example": true
+                },
+                "events": {
+                    "description": "event types: task.created, solution.status_changed, solution.graded, comment.created, user.created",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "solution.graded"
+                    ]
+                },
+                "secret": {
+                    "description": "secret to sign deliveries with HMAC-SHA256",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 16,
+                    "example": "very-long-webhook-secret"
+                },
+                "url": {
+                    "description": "URL to send events to",
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://crm.school.ru/skadi/hook"
+                }
+            }
         }
     },
     "securityDefinitions": {
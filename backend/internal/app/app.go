@@ -12,17 +12,40 @@ import (
 	"syscall"
 
 	"skadi/backend/config"
+	"skadi/backend/internal/app/service/analyzer"
+	"skadi/backend/internal/app/service/autotester"
 	"skadi/backend/internal/app/service/cmdmanager"
+	"skadi/backend/internal/app/service/dispatcher"
+	"skadi/backend/internal/app/service/eventbus"
+	"skadi/backend/internal/app/service/hooksender"
+	"skadi/backend/internal/app/service/mailer"
+	"skadi/backend/internal/app/service/previewer"
+	"skadi/backend/internal/app/service/scanner"
+	"skadi/backend/internal/app/service/scheduler"
 	"skadi/backend/internal/app/service/server"
+	"skadi/backend/internal/app/service/tgbot"
 
 	"skadi/backend/internal/pkg/cache"
 	"skadi/backend/internal/pkg/db"
 	"skadi/backend/internal/pkg/logger"
+	utilsfile "skadi/backend/internal/pkg/utils/file"
 	"skadi/backend/internal/pkg/validator"
 )
 
-// Ensure server implements interface.
-var _ Service = (*server.Server)(nil)
+// Ensure services implement interface.
+var (
+	_ Service = (*server.Server)(nil)
+	_ Service = (*previewer.Previewer)(nil)
+	_ Service = (*scanner.Scanner)(nil)
+	_ Service = (*eventbus.EventBus)(nil)
+	_ Service = (*mailer.Mailer)(nil)
+	_ Service = (*tgbot.Bot)(nil)
+	_ Service = (*hooksender.HookSender)(nil)
+	_ Service = (*dispatcher.Dispatcher)(nil)
+	_ Service = (*scheduler.Scheduler)(nil)
+	_ Service = (*autotester.Autotester)(nil)
+	_ Service = (*analyzer.Analyzer)(nil)
+)
 
 // Service describes an app service.
 type Service interface {
@@ -78,15 +101,81 @@ func NewServer() (*App, error) {
 		return nil, fmt.Errorf("redis cache: %w", err)
 	}
 
+	// init previewer service
+	filePreviewer, err := previewer.New(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create previewer service: %w", err)
+	}
+
+	// init antivirus scanner service
+	fileScanner, err := scanner.New(cfg, dbStorage)
+	if err != nil {
+		return nil, fmt.Errorf("create scanner service: %w", err)
+	}
+	// uploaded files are processed by all background services
+	fileQueue := utilsfile.Queues{filePreviewer, fileScanner}
+
+	// init real-time events service (fan out through redis pub/sub)
+	eventBus, err := eventbus.New(cfg, cacheStorage)
+	if err != nil {
+		return nil, fmt.Errorf("create event bus service: %w", err)
+	}
+
+	// init mail sender service
+	mailSender, err := mailer.New(cfg, dbStorage)
+	if err != nil {
+		return nil, fmt.Errorf("create mailer service: %w", err)
+	}
+
+	// init telegram bot service
+	tgBot, err := tgbot.New(cfg, dbStorage, cacheStorage)
+	if err != nil {
+		return nil, fmt.Errorf("create telegram bot service: %w", err)
+	}
+
+	// init webhook sender service
+	hookSender, err := hooksender.New(cfg, dbStorage)
+	if err != nil {
+		return nil, fmt.Errorf("create webhook sender service: %w", err)
+	}
+
+	// init outbox dispatcher service (handlers are subscribed by the server service)
+	outboxDispatcher, err := dispatcher.New(cfg, dbStorage)
+	if err != nil {
+		return nil, fmt.Errorf("create outbox dispatcher service: %w", err)
+	}
+
+	// init job scheduler service (runs are locked in redis)
+	jobScheduler, err := scheduler.New(cfg, dbStorage, cacheStorage)
+	if err != nil {
+		return nil, fmt.Errorf("create scheduler service: %w", err)
+	}
+
+	// init autotest service (solutions are tested in the sandbox)
+	autoTester, err := autotester.New(cfg, dbStorage)
+	if err != nil {
+		return nil, fmt.Errorf("create autotester service: %w", err)
+	}
+
+	// init plagiarism analyzer service (solutions are compared in the background)
+	plagiarismAnalyzer, err := analyzer.New(cfg, dbStorage)
+	if err != nil {
+		return nil, fmt.Errorf("create plagiarism analyzer service: %w", err)
+	}
+
 	// init server service
-	srv, err := server.New(cfg, dbStorage, cacheStorage, valid)
+	srv, err := server.New(cfg, dbStorage, cacheStorage, fileQueue, eventBus, tgBot,
+		outboxDispatcher, valid)
 	if err != nil {
 		return nil, fmt.Errorf("create server service: %w", err)
 	}
 
 	return &App{
-		cfg:      cfg,
-		services: []Service{srv},
+		cfg: cfg,
+		services: []Service{
+			srv, filePreviewer, fileScanner, eventBus, mailSender, tgBot, hookSender,
+			outboxDispatcher, jobScheduler, autoTester, plagiarismAnalyzer,
+		},
 	}, nil
 }
 
@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/autotest"
+	"skadi/backend/internal/pkg/httperror"
+	"skadi/backend/internal/pkg/serialize"
+	utilsjwt "skadi/backend/internal/pkg/utils/jwt"
+	"skadi/backend/internal/pkg/validator"
+)
+
+// AutotestController represents a controller for autotest routes accepted for teachers and students.
+type AutotestController struct {
+	valid            validator.Validator
+	autotestUCClient autotest.UsecaseClient
+}
+
+// NewController returns a new instance of [AutotestController].
+func NewController(autotestUCClient autotest.UsecaseClient,
+	valid validator.Validator) *AutotestController {
+
+	return &AutotestController{
+		valid:            valid,
+		autotestUCClient: autotestUCClient,
+	}
+}
+
+// @summary		Получение результатов автотестов решения.
+// @description	Получение последнего запуска автотестов решения с результатами по каждому тесту и предлагаемой оценкой (процент пройденных тестов).
+// @description	Тесты запускаются автоматически, когда решение переводится в статус "на проверке".
+// @description	Для ученика у скрытых тестов не возвращаются входные данные и вывод программы, а у упавшего запуска - ошибка песочницы.
+// @router			/autotest/solution/{id} [get]
+// @id				autotest-read-run
+// @tags			autotest
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id	path		int	true	"ID решения"
+// @success		200	{object}	entity.AutotestRun
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+// @failure		404	"решение не найдено | решение ещё не тестировалось"
+func (c *AutotestController) ReadRun(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &solutionIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	runObj, err := c.autotestUCClient.GetRun(inputPath.ID, userClaims)
+	if errors.Is(err, autotest.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "решение не найдено",
+		}
+	}
+	if errors.Is(err, autotest.ErrNotFoundRun) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "решение ещё не тестировалось",
+		}
+	}
+	if errors.Is(err, autotest.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("read run: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(runObj)
+}
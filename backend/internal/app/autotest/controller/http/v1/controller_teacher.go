@@ -0,0 +1,245 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/autotest"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/pkg/httperror"
+	"skadi/backend/internal/pkg/serialize"
+	utilsjwt "skadi/backend/internal/pkg/utils/jwt"
+	"skadi/backend/internal/pkg/validator"
+)
+
+// AutotestControllerTeacher represents a controller for autotest routes accepted for teachers only.
+type AutotestControllerTeacher struct {
+	valid             validator.Validator
+	autotestUCTeacher autotest.UsecaseTeacher
+}
+
+// NewControllerTeacher returns a new instance of [AutotestControllerTeacher].
+func NewControllerTeacher(autotestUCTeacher autotest.UsecaseTeacher,
+	valid validator.Validator) *AutotestControllerTeacher {
+
+	return &AutotestControllerTeacher{
+		valid:             valid,
+		autotestUCTeacher: autotestUCTeacher,
+	}
+}
+
+// @summary		Получение набора автотестов задания. [Только преподаватель]
+// @description	Получение языка программирования и тестов (ввод, ожидаемый вывод, ограничения времени и памяти) своего задания.
+// @router			/autotest/task/{id} [get]
+// @id				autotest-read-suite
+// @tags			autotest
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id	path		int	true	"ID задания"
+// @success		200	{object}	entity.AutotestSuite
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+// @failure		404	"задание не найдено | автотесты не заданы"
+func (c *AutotestControllerTeacher) ReadSuite(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &taskIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	suiteObj, err := c.autotestUCTeacher.GetSuite(userClaims.ID, inputPath.ID)
+	if errors.Is(err, autotest.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "задание не найдено",
+		}
+	}
+	if errors.Is(err, autotest.ErrNotFoundSuite) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "автотесты не заданы",
+		}
+	}
+	if errors.Is(err, autotest.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("read suite: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(suiteObj)
+}
+
+// @summary		Изменение набора автотестов задания. [Только преподаватель]
+// @description	Полная замена языка программирования и тестов своего задания (тесты выполняются в переданном порядке).
+// @description	Решения тестируются в изолированной песочнице без сети, когда ученик переводит решение в статус "на проверке".
+// @description	Вывод программы сравнивается с ожидаемым без учёта пробелов в концах строк и пустых строк в конце.
+// @router			/autotest/task/{id} [put]
+// @id				autotest-update-suite
+// @tags			autotest
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id			path		int			true	"ID задания"
+// @param			suiteBody	body		suiteBody	true	"suiteBody"
+// @success		200			{object}	entity.AutotestSuite
+// @failure		400			"язык не поддерживается"
+// @failure		401			"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403			"доступ запрещён"
+// @failure		404			"задание не найдено"
+func (c *AutotestControllerTeacher) UpdateSuite(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &taskIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputBody := &suiteBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	suiteObj := &entity.AutotestSuite{
+		TaskID: inputPath.ID,
+		Lang:   inputBody.Lang,
+		Cases:  make([]entity.AutotestCase, 0, len(inputBody.Cases)),
+	}
+	for _, caseObj := range inputBody.Cases {
+		suiteObj.Cases = append(suiteObj.Cases, entity.AutotestCase{
+			Stdin:         caseObj.Stdin,
+			Expected:      caseObj.Expected,
+			TimeLimitMS:   caseObj.TimeLimitMS,
+			MemoryLimitMB: caseObj.MemoryLimitMB,
+			Hidden:        caseObj.Hidden,
+		})
+	}
+	suiteObj, err := c.autotestUCTeacher.SetSuite(userClaims.ID, suiteObj)
+	if errors.Is(err, autotest.ErrInvalidLang) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "язык не поддерживается",
+		}
+	}
+	if errors.Is(err, autotest.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "задание не найдено",
+		}
+	}
+	if errors.Is(err, autotest.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("update suite: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(suiteObj)
+}
+
+// @summary		Удаление набора автотестов задания. [Только преподаватель]
+// @description	Удаление всех тестов своего задания. Результаты прошлых запусков сохраняются.
+// @router			/autotest/task/{id} [delete]
+// @id				autotest-delete-suite
+// @tags			autotest
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id	path	int	true	"ID задания"
+// @success		204	"No Content"
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+func (c *AutotestControllerTeacher) DeleteSuite(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &taskIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	err := c.autotestUCTeacher.DeleteSuite(userClaims.ID, inputPath.ID)
+	if errors.Is(err, autotest.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("delete suite: %w", err)
+	}
+	return ctx.Status(fiber.StatusNoContent).JSON(nil)
+}
+
+// @summary		Перезапуск автотестов решения. [Только преподаватель]
+// @description	Постановка решения своего задания в очередь на тестирование (например, после изменения тестов). Прошлый запуск заменяется.
+// @router			/autotest/solution/{id}/run [post]
+// @id				autotest-rerun
+// @tags			autotest
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id	path		int	true	"ID решения"
+// @success		202	{object}	entity.AutotestRun
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+// @failure		404	"решение не найдено | автотесты не заданы"
+// @failure		409	"автотесты отключены"
+func (c *AutotestControllerTeacher) Rerun(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &solutionIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	runObj, err := c.autotestUCTeacher.Rerun(userClaims.ID, inputPath.ID)
+	if errors.Is(err, autotest.ErrDisabled) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "автотесты отключены",
+		}
+	}
+	if errors.Is(err, autotest.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "решение не найдено",
+		}
+	}
+	if errors.Is(err, autotest.ErrNotFoundSuite) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "автотесты не заданы",
+		}
+	}
+	if errors.Is(err, autotest.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("rerun: %w", err)
+	}
+	return ctx.Status(fiber.StatusAccepted).JSON(runObj)
+}
@@ -0,0 +1,35 @@
+package v1
+
+// @description taskIDPath represents a data with task ID in path params.
+type taskIDPath struct {
+	// task id
+	ID int `params:"id" validate:"required" example:"2"`
+}
+
+// @description solutionIDPath represents a data with solution ID in path params.
+type solutionIDPath struct {
+	// solution id
+	ID int `params:"id" validate:"required" example:"14"`
+}
+
+// @description suiteBody represents a data with task test suite.
+type suiteBody struct {
+	// programming language of the solutions (one of configured languages)
+	Lang string `json:"lang" validate:"required,max=20" example:"python" maxLength:"20"`
+	// test cases in the order
+	Cases []caseBody `json:"cases" validate:"required,min=1,max=100,dive" minItems:"1" maxItems:"100"`
+}
+
+// @description caseBody represents a data with stdin/stdout test case.
+type caseBody struct {
+	// program input
+	Stdin string `json:"stdin" validate:"omitempty,max=1048576" example:"2 3"`
+	// expected program output (trailing spaces and empty lines are ignored)
+	Expected string `json:"expected" validate:"omitempty,max=1048576" example:"5"`
+	// time limit in milliseconds
+	TimeLimitMS int `json:"time_limit_ms" validate:"required,min=100,max=30000" example:"1000" minimum:"100" maximum:"30000"`
+	// memory limit in megabytes
+	MemoryLimitMB int `json:"memory_limit_mb" validate:"required,min=16,max=1024" example:"64" minimum:"16" maximum:"1024"`
+	// if true, input and output of the test are not shown to students
+	Hidden bool `json:"hidden" validate:"omitempty"`
+}
@@ -0,0 +1,26 @@
+// Package http/v1 is a first version of autotest HTTP-controller.
+// It provides registers for autotest HTTP-routes and controllers with handlers for them.
+package v1
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/service/server/middleware"
+)
+
+// RegisterEndpoints registers all autotest endpoints.
+func RegisterEndpoints(router fiber.Router, controller *AutotestController,
+	controllerTeacher *AutotestControllerTeacher,
+	mwJWTAccess fiber.Handler, mwAllow middleware.AllowFunc) {
+
+	mwTeacherOnly := mwAllow(entity.Teacher)
+	mwTeacherStudent := mwAllow(entity.Teacher, entity.Student)
+
+	authGroup := router.Group("/autotest", mwJWTAccess)
+	authGroup.Get("/task/:id", mwTeacherOnly, controllerTeacher.ReadSuite)
+	authGroup.Put("/task/:id", mwTeacherOnly, controllerTeacher.UpdateSuite)
+	authGroup.Delete("/task/:id", mwTeacherOnly, controllerTeacher.DeleteSuite)
+	authGroup.Get("/solution/:id", mwTeacherStudent, controller.ReadRun)
+	authGroup.Post("/solution/:id/run", mwTeacherOnly, controllerTeacher.Rerun)
+}
@@ -0,0 +1,12 @@
+package autotest
+
+import "errors"
+
+var (
+	ErrInvalidLang   = errors.New("unsupported language")   // code 400
+	ErrForbidden     = errors.New("forbidden")              // code 403
+	ErrNotFound      = errors.New("record not found")       // code 404
+	ErrNotFoundSuite = errors.New("test suite not found")   // code 404
+	ErrNotFoundRun   = errors.New("test run not found")     // code 404
+	ErrDisabled      = errors.New("autotests are disabled") // code 409
+)
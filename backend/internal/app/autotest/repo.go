@@ -0,0 +1,34 @@
+package autotest
+
+import (
+	"time"
+
+	"skadi/backend/internal/app/entity"
+)
+
+// RepositoryDB describes all DB methods for test suites and test runs.
+type RepositoryDB interface {
+	// GetSuite returns the task test suite with cases (ordered by position).
+	GetSuite(taskID int) (*entity.AutotestSuite, error)
+	// SetSuite creates or replaces the task test suite with all its cases.
+	SetSuite(suiteObj *entity.AutotestSuite) error
+	// DeleteSuite deletes the task test suite with all its cases.
+	DeleteSuite(taskID int) error
+
+	// Enqueue creates a pending test run for the solution.
+	// The previous run of the solution is deleted with its results.
+	Enqueue(solutionID int) (*entity.AutotestRun, error)
+	// GetRun returns the last test run of the solution with results (ordered by position).
+	GetRun(solutionID int) (*entity.AutotestRun, error)
+	// GetPending returns the oldest pending test runs.
+	GetPending(limit int) ([]entity.AutotestRun, error)
+	// Claim marks the pending run as running.
+	// It returns false if the run was already claimed by another worker.
+	Claim(runObj *entity.AutotestRun, startedAt time.Time) (bool, error)
+	// Finish saves status, counters, score, error and results of the running run.
+	// The run is not saved if it was replaced by a new one while running.
+	Finish(runObj *entity.AutotestRun) error
+	// RestartStale marks runs started before the given time and not finished as pending
+	// (e.g. runs of the crashed instance). It returns the number of restarted runs.
+	RestartStale(before time.Time) (int, error)
+}
@@ -0,0 +1,226 @@
+// Package repository contains autotest.RepositoryDB implementation.
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"skadi/backend/internal/app/autotest"
+	"skadi/backend/internal/app/entity"
+)
+
+const (
+	_preloadCases   = "Cases"   // object field name
+	_preloadResults = "Results" // object field name
+
+	_fieldID         = "id"          // table field name
+	_fieldTaskID     = "task_id"     // table field name
+	_fieldSolutionID = "solution_id" // table field name
+	_fieldLang       = "lang"        // table field name
+	_fieldUpdatedAt  = "updated_at"  // table field name
+	_fieldPosition   = "position"    // table field name
+	_fieldStatus     = "status"      // table field name
+	_fieldPassed     = "passed"      // table field name
+	_fieldTotal      = "total"       // table field name
+	_fieldScore      = "score"       // table field name
+	_fieldError      = "error"       // table field name
+	_fieldCreatedAt  = "created_at"  // table field name
+	_fieldStartedAt  = "started_at"  // table field name
+	_fieldFinishedAt = "finished_at" // table field name
+)
+
+// Ensure RepoDB implements interface.
+var _ autotest.RepositoryDB = (*RepoDB)(nil)
+
+// RepoDB is an autotest DB repo.
+// It implements the [autotest.RepositoryDB] interface.
+type RepoDB struct {
+	dbStorage *gorm.DB
+}
+
+// NewRepoDB returns a new instance of [RepoDB].
+func NewRepoDB(dbStorage *gorm.DB) *RepoDB {
+	return &RepoDB{
+		dbStorage: dbStorage,
+	}
+}
+
+// GetSuite returns the task test suite with cases (ordered by position).
+func (r *RepoDB) GetSuite(taskID int) (*entity.AutotestSuite, error) {
+	var suiteObj entity.AutotestSuite
+	err := r.dbStorage.
+		Preload(_preloadCases, func(db *gorm.DB) *gorm.DB {
+			return db.Order(_fieldPosition)
+		}).
+		Where(_fieldTaskID+" = ?", taskID).
+		First(&suiteObj).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("suite of task %d: %w", taskID, autotest.ErrNotFoundSuite)
+	}
+	return &suiteObj, err // err OR nil
+}
+
+// SetSuite creates or replaces the task test suite with all its cases.
+func (r *RepoDB) SetSuite(suiteObj *entity.AutotestSuite) error {
+	return r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		suiteObj.UpdatedAt = time.Now()
+		// create suite or update its language
+		err := tx.Omit(clause.Associations).
+			Clauses(clause.OnConflict{
+				DoUpdates: clause.AssignmentColumns([]string{_fieldLang, _fieldUpdatedAt}),
+			}).
+			Create(suiteObj).Error
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return fmt.Errorf("task with id: %w", autotest.ErrNotFound)
+		}
+		if err != nil {
+			return err
+		}
+
+		// replace cases
+		if err := tx.Where(_fieldTaskID+" = ?", suiteObj.TaskID).
+			Delete(&entity.AutotestCase{}).Error; err != nil {
+			return fmt.Errorf("delete old cases: %w", err)
+		}
+		if len(suiteObj.Cases) == 0 {
+			return nil
+		}
+		for idx := range suiteObj.Cases {
+			suiteObj.Cases[idx].ID = 0
+			suiteObj.Cases[idx].TaskID = suiteObj.TaskID
+		}
+		if err := tx.Create(&suiteObj.Cases).Error; err != nil {
+			return fmt.Errorf("create cases: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteSuite deletes the task test suite with all its cases.
+// Cases are deleted by the DB (cascade).
+func (r *RepoDB) DeleteSuite(taskID int) error {
+	return r.dbStorage.
+		Where(_fieldTaskID+" = ?", taskID).
+		Delete(&entity.AutotestSuite{}).Error // nil OR error
+}
+
+// Enqueue creates a pending test run for the solution.
+// The previous run of the solution is deleted with its results (cascade).
+func (r *RepoDB) Enqueue(solutionID int) (*entity.AutotestRun, error) {
+	runObj := &entity.AutotestRun{
+		SolutionID: solutionID,
+		Status:     entity.AutotestPending,
+		CreatedAt:  time.Now(),
+	}
+	err := r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(_fieldSolutionID+" = ?", solutionID).
+			Delete(&entity.AutotestRun{}).Error; err != nil {
+			return fmt.Errorf("delete previous run: %w", err)
+		}
+		err := tx.Omit(clause.Associations).Create(runObj).Error
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return fmt.Errorf("solution with id: %w", autotest.ErrNotFound)
+		}
+		return err // err OR nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return runObj, nil
+}
+
+// GetRun returns the last test run of the solution with results (ordered by position).
+func (r *RepoDB) GetRun(solutionID int) (*entity.AutotestRun, error) {
+	var runObj entity.AutotestRun
+	err := r.dbStorage.
+		Preload(_preloadResults, func(db *gorm.DB) *gorm.DB {
+			return db.Order(_fieldPosition)
+		}).
+		Where(_fieldSolutionID+" = ?", solutionID).
+		First(&runObj).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("run of solution %d: %w", solutionID, autotest.ErrNotFoundRun)
+	}
+	return &runObj, err // err OR nil
+}
+
+// GetPending returns the oldest pending test runs.
+func (r *RepoDB) GetPending(limit int) ([]entity.AutotestRun, error) {
+	runs := []entity.AutotestRun{}
+	err := r.dbStorage.
+		Where(_fieldStatus+" = ?", entity.AutotestPending).
+		Order(_fieldCreatedAt).
+		Limit(limit).
+		Find(&runs).Error
+	if err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// Claim marks the pending run as running.
+// It returns false if the run was already claimed by another worker.
+func (r *RepoDB) Claim(runObj *entity.AutotestRun, startedAt time.Time) (bool, error) {
+	res := r.dbStorage.
+		Model(&entity.AutotestRun{}).
+		Where(_fieldID+" = ? AND "+_fieldStatus+" = ?", runObj.ID, entity.AutotestPending).
+		Updates(map[string]any{
+			_fieldStatus:    entity.AutotestRunning,
+			_fieldStartedAt: startedAt,
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	runObj.Status = entity.AutotestRunning
+	runObj.StartedAt = &startedAt
+	return res.RowsAffected == 1, nil
+}
+
+// Finish saves status, counters, score, error and results of the running run.
+// The run is not saved if it was replaced by a new one while running.
+func (r *RepoDB) Finish(runObj *entity.AutotestRun) error {
+	return r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		res := tx.
+			Model(&entity.AutotestRun{}).
+			Where(_fieldID+" = ? AND "+_fieldStatus+" = ?", runObj.ID, entity.AutotestRunning).
+			Updates(map[string]any{
+				_fieldStatus:     runObj.Status,
+				_fieldPassed:     runObj.Passed,
+				_fieldTotal:      runObj.Total,
+				_fieldScore:      runObj.Score,
+				_fieldError:      runObj.Error,
+				_fieldFinishedAt: runObj.FinishedAt,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 || len(runObj.Results) == 0 {
+			return nil
+		}
+		for idx := range runObj.Results {
+			runObj.Results[idx].RunID = runObj.ID
+		}
+		if err := tx.Create(&runObj.Results).Error; err != nil {
+			return fmt.Errorf("create results: %w", err)
+		}
+		return nil
+	})
+}
+
+// RestartStale marks runs started before the given time and not finished as pending
+// (e.g. runs of the crashed instance). It returns the number of restarted runs.
+func (r *RepoDB) RestartStale(before time.Time) (int, error) {
+	res := r.dbStorage.
+		Model(&entity.AutotestRun{}).
+		Where(_fieldStatus+" = ?", entity.AutotestRunning).
+		Where(_fieldStartedAt+" < ?", before).
+		Updates(map[string]any{
+			_fieldStatus:    entity.AutotestPending,
+			_fieldStartedAt: nil,
+		})
+	return int(res.RowsAffected), res.Error
+}
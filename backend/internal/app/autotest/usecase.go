@@ -0,0 +1,37 @@
+// Package autotest contains all repos, usecases and controllers for automated testing
+// of programming task solutions in the sandbox.
+// Sub-package repo contains RepoDB implementation.
+// Sub-package usecase contains UsecaseTeacher, UsecaseClient and UsecaseQueue implementations.
+package autotest
+
+import "skadi/backend/internal/app/entity"
+
+// UsecaseTeacher describes all autotest usecases for teacher.
+type UsecaseTeacher interface {
+	// GetSuite returns the test suite of the own task.
+	GetSuite(teacherID, taskID int) (*entity.AutotestSuite, error)
+	// SetSuite replaces the test suite of the own task with the given one.
+	// It returns the updated suite object.
+	SetSuite(teacherID int, suiteObj *entity.AutotestSuite) (*entity.AutotestSuite, error)
+	// DeleteSuite deletes the test suite of the own task (solutions are not tested anymore).
+	DeleteSuite(teacherID, taskID int) error
+	// Rerun enqueues a new test run for the solution of the own task.
+	// The previous run of the solution is replaced.
+	Rerun(teacherID, solutionID int) (*entity.AutotestRun, error)
+}
+
+// UsecaseClient describes all autotest usecases for teacher and student.
+type UsecaseClient interface {
+	// GetRun returns the last test run of the solution with per-test results.
+	// Input and output of hidden tests are removed for students.
+	GetRun(solutionID int, userClaims *entity.UserClaims) (*entity.AutotestRun, error)
+}
+
+// UsecaseQueue describes usecases to enqueue test runs for solutions sent for review.
+// It is used by the outbox event handler.
+type UsecaseQueue interface {
+	// HandleSolutionUpdated enqueues a test run if the solution was sent for review
+	// and the solution task has a test suite.
+	// It handles the solution.updated outbox event.
+	HandleSolutionUpdated(evtObj *entity.OutboxEvent) error
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/autotest"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/solution"
+)
+
+// Ensure UCClient implements interfaces.
+var _ autotest.UsecaseClient = (*UCClient)(nil)
+
+// UCClient represents an autotest usecase for teacher and student.
+// It implements the [autotest.UsecaseClient] interface.
+type UCClient struct {
+	cfg            *config.Config
+	autotestRepoDB autotest.RepositoryDB
+	solRepoDB      solution.RepositoryDB
+}
+
+// NewUCClient returns a new instance of [UCClient].
+func NewUCClient(cfg *config.Config, autotestRepoDB autotest.RepositoryDB,
+	solRepoDB solution.RepositoryDB) *UCClient {
+
+	return &UCClient{
+		cfg:            cfg,
+		autotestRepoDB: autotestRepoDB,
+		solRepoDB:      solRepoDB,
+	}
+}
+
+// GetRun returns the last test run of the solution with per-test results.
+// Input and output of hidden tests are removed for students.
+func (u *UCClient) GetRun(solutionID int,
+	userClaims *entity.UserClaims) (*entity.AutotestRun, error) {
+
+	// check user rights for this solution
+	err := u.solRepoDB.UserPermit(solutionID, userClaims)
+	if errors.Is(err, solution.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %w", autotest.ErrNotFound, err)
+	}
+	if errors.Is(err, solution.ErrForbidden) {
+		return nil, fmt.Errorf("%w: %w", autotest.ErrForbidden, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("check solution permissions: %w", err)
+	}
+
+	runObj, err := u.autotestRepoDB.GetRun(solutionID)
+	if err != nil {
+		return nil, err
+	}
+	if userClaims.IsStudent() {
+		runObj.HideSecrets()
+	}
+	return runObj, nil
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/autotest"
+	"skadi/backend/internal/app/entity"
+)
+
+const _readyStatusID = 3 // ID of solution status "ready"
+
+// Ensure UCQueue implements interfaces.
+var _ autotest.UsecaseQueue = (*UCQueue)(nil)
+
+// UCQueue represents an autotest usecase to enqueue test runs.
+// It implements the [autotest.UsecaseQueue] interface.
+type UCQueue struct {
+	cfg            *config.Config
+	autotestRepoDB autotest.RepositoryDB
+}
+
+// NewUCQueue returns a new instance of [UCQueue].
+func NewUCQueue(cfg *config.Config, autotestRepoDB autotest.RepositoryDB) *UCQueue {
+	return &UCQueue{
+		cfg:            cfg,
+		autotestRepoDB: autotestRepoDB,
+	}
+}
+
+// HandleSolutionUpdated enqueues a test run if the solution was sent for review
+// and the solution task has a test suite. If autotests are disabled, it does nothing.
+// It handles the solution.updated outbox event.
+func (u *UCQueue) HandleSolutionUpdated(evtObj *entity.OutboxEvent) error {
+	if !u.cfg.Autotest.Enabled {
+		return nil
+	}
+	var data entity.SolutionUpdatedEvent
+	if err := evtObj.Decode(&data); err != nil {
+		return fmt.Errorf("decode event: %w", err)
+	}
+	// solution was not sent for review
+	if data.StatusID != _readyStatusID || data.OldStatusID == _readyStatusID {
+		return nil
+	}
+
+	_, err := u.autotestRepoDB.GetSuite(data.TaskID)
+	// task has no tests
+	if errors.Is(err, autotest.ErrNotFoundSuite) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get suite: %w", err)
+	}
+	_, err = u.autotestRepoDB.Enqueue(data.SolutionID)
+	// solution was deleted
+	if errors.Is(err, autotest.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("enqueue run: %w", err)
+	}
+	return nil
+}
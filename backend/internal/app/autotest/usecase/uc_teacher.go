@@ -0,0 +1,122 @@
+// Package usecase contains autotest.UsecaseTeacher, autotest.UsecaseClient and
+// autotest.UsecaseQueue implementations.
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/autotest"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/solution"
+	"skadi/backend/internal/app/task"
+)
+
+// Ensure UCTeacher implements interfaces.
+var _ autotest.UsecaseTeacher = (*UCTeacher)(nil)
+
+// UCTeacher represents an autotest usecase for teacher.
+// It implements the [autotest.UsecaseTeacher] interface.
+type UCTeacher struct {
+	cfg            *config.Config
+	autotestRepoDB autotest.RepositoryDB
+	taskRepoDB     task.RepositoryDB
+	solRepoDB      solution.RepositoryDB
+}
+
+// NewUCTeacher returns a new instance of [UCTeacher].
+func NewUCTeacher(cfg *config.Config, autotestRepoDB autotest.RepositoryDB,
+	taskRepoDB task.RepositoryDB, solRepoDB solution.RepositoryDB) *UCTeacher {
+
+	return &UCTeacher{
+		cfg:            cfg,
+		autotestRepoDB: autotestRepoDB,
+		taskRepoDB:     taskRepoDB,
+		solRepoDB:      solRepoDB,
+	}
+}
+
+// GetSuite returns the test suite of the own task.
+func (u *UCTeacher) GetSuite(teacherID, taskID int) (*entity.AutotestSuite, error) {
+	if err := u.checkOwnTask(teacherID, taskID); err != nil {
+		return nil, err
+	}
+	return u.autotestRepoDB.GetSuite(taskID)
+}
+
+// SetSuite replaces the test suite of the own task with the given one.
+// Case positions are set by their order. It returns the updated suite object.
+func (u *UCTeacher) SetSuite(teacherID int,
+	suiteObj *entity.AutotestSuite) (*entity.AutotestSuite, error) {
+
+	if _, ok := u.cfg.Autotest.Langs[suiteObj.Lang]; !ok {
+		return nil, fmt.Errorf("%w: %q", autotest.ErrInvalidLang, suiteObj.Lang)
+	}
+	if err := u.checkOwnTask(teacherID, suiteObj.TaskID); err != nil {
+		return nil, err
+	}
+	for idx := range suiteObj.Cases {
+		suiteObj.Cases[idx].Position = idx + 1
+	}
+	if err := u.autotestRepoDB.SetSuite(suiteObj); err != nil {
+		return nil, fmt.Errorf("set suite: %w", err)
+	}
+	return u.autotestRepoDB.GetSuite(suiteObj.TaskID)
+}
+
+// DeleteSuite deletes the test suite of the own task (solutions are not tested anymore).
+// Results of the previous runs are kept.
+func (u *UCTeacher) DeleteSuite(teacherID, taskID int) error {
+	err := u.checkOwnTask(teacherID, taskID)
+	// return nil error if task was not found
+	if errors.Is(err, autotest.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return u.autotestRepoDB.DeleteSuite(taskID)
+}
+
+// Rerun enqueues a new test run for the solution of the own task.
+// The previous run of the solution is replaced.
+func (u *UCTeacher) Rerun(teacherID, solutionID int) (*entity.AutotestRun, error) {
+	if !u.cfg.Autotest.Enabled {
+		return nil, autotest.ErrDisabled
+	}
+	solObj, err := u.solRepoDB.GetByID(solutionID)
+	if errors.Is(err, solution.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %w", autotest.ErrNotFound, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get solution: %w", err)
+	}
+	if solObj.Task.TeacherID != teacherID {
+		return nil, fmt.Errorf("%w: user (teacher) is not a task owner", autotest.ErrForbidden)
+	}
+	// task must have a test suite
+	if _, err := u.autotestRepoDB.GetSuite(solObj.TaskID); err != nil {
+		return nil, err
+	}
+	runObj, err := u.autotestRepoDB.Enqueue(solutionID)
+	if err != nil {
+		return nil, fmt.Errorf("enqueue run: %w", err)
+	}
+	return runObj, nil
+}
+
+// checkOwnTask returns nil error if the task exists and belongs to the teacher.
+func (u *UCTeacher) checkOwnTask(teacherID, taskID int) error {
+	taskObj, err := u.taskRepoDB.GetByID(taskID)
+	if errors.Is(err, task.ErrNotFound) {
+		return fmt.Errorf("%w: %w", autotest.ErrNotFound, err)
+	}
+	if err != nil {
+		return fmt.Errorf("get task: %w", err)
+	}
+	if taskObj.TeacherID != teacherID {
+		return fmt.Errorf("%w: user (teacher) is not a task owner", autotest.ErrForbidden)
+	}
+	return nil
+}
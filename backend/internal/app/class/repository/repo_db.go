@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"skadi/backend/internal/app/class"
 	"skadi/backend/internal/app/entity"
@@ -55,13 +56,12 @@ func (r *RepoDB) Create(classObj *entity.Class, studentIDs []int) error {
 		}
 
 		// update class ID for given students
-		err = tx.Model(&entity.User{}).
-			Where(_fieldID+" IN ?", studentIDs).
-			UpdateColumn(_fieldClassID, classObj.ID).Error
+		events, err := addStudents(tx, classObj.ID, studentIDs)
 		if err != nil {
-			return fmt.Errorf("update students class: %w", err)
+			return err
 		}
-		return nil
+		// issue the class tasks for the new students
+		return writeEvents(tx, events, classObj.ID, studentIDs, nil)
 	})
 }
 
@@ -102,35 +102,67 @@ func (r *RepoDB) Update(classID int, newData *entity.ClassUpdate) error {
 			return fmt.Errorf("class: %w", err)
 		}
 
-		// add students to the class
-		if len(newData.AddStudents) > 0 {
-			err := tx.Model(&entity.User{}).
-				Where(_fieldID+" IN ?", newData.AddStudents).
-				Update(_fieldClassID, classID).Error
+		// add students to the class (they can be moved from other classes)
+		events, err := addStudents(tx, classID, newData.AddStudents)
+		if err != nil {
+			return fmt.Errorf("add students: %w", err)
+		}
+		// delete students from the class
+		if len(newData.DelStudents) > 0 {
+			err = tx.Model(&entity.User{}).
+				Where(_fieldID+" IN ?", newData.DelStudents).
+				Update(_fieldClassID, nil).Error
 			if err != nil {
-				return fmt.Errorf("add students: %w", err)
+				return fmt.Errorf("delete students: %w", err)
 			}
 		}
-		// skip deleting students if del list is empty
-		if len(newData.DelStudents) == 0 {
+		// issue or withdraw the class tasks for changed students
+		return writeEvents(tx, events, classID, newData.AddStudents, newData.DelStudents)
+	})
+}
+
+// DeleteByID deletes class object by given id.
+// It writes the class.members_changed event for the class students (they leave the class).
+func (r *RepoDB) DeleteByID(id int) error {
+	return r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		// get the class students and tasks before the links are deleted with the class
+		var studentIDs []int
+		err := tx.Model(&entity.User{}).
+			Where(_fieldClassID+" = ?", id).
+			Order(_fieldID).
+			Pluck(_fieldID, &studentIDs).Error
+		if err != nil {
+			return fmt.Errorf("get class students: %w", err)
+		}
+		var taskIDs []int
+		err = tx.Model(&entity.TaskClass{}).
+			Where(_fieldClassID+" = ?", id).
+			Order("task_id").
+			Pluck("task_id", &taskIDs).Error
+		if err != nil {
+			return fmt.Errorf("get class tasks: %w", err)
+		}
+
+		if err := tx.Delete(&entity.Class{}, id).Error; err != nil {
+			return err
+		}
+		if len(studentIDs) == 0 || len(taskIDs) == 0 {
 			return nil
 		}
-		// delete students from the class
-		err = tx.Model(&entity.User{}).
-			Where(_fieldID+" IN ?", newData.DelStudents).
-			Update(_fieldClassID, nil).Error
+
+		// withdraw the class tasks from the students
+		evtObj, err := entity.NewOutboxEvent(entity.TopicClassMembersChanged,
+			&entity.ClassMembersChangedEvent{ClassID: id, Left: studentIDs, TaskIDs: taskIDs})
 		if err != nil {
-			return fmt.Errorf("delete students: %w", err)
+			return fmt.Errorf("encode event: %w", err)
+		}
+		if err := tx.Create(evtObj).Error; err != nil {
+			return fmt.Errorf("write event: %w", err)
 		}
 		return nil
 	})
 }
 
-// DeleteByID deletes class object by given id.
-func (r *RepoDB) DeleteByID(id int) error {
-	return r.dbStorage.Delete(&entity.Class{}, id).Error
-}
-
 // ListShort returns slice of class objects (IDs and names only).
 func (r *RepoDB) ListShort(search string, page *entity.Pagination) ([]entity.Class, error) {
 	classes := []entity.Class{}
@@ -198,6 +230,70 @@ func (r *RepoDB) ListFull(search string, page *entity.Pagination) ([]entity.Clas
 	return classes, nil
 }
 
+// addStudents moves the given students to the class. It returns events
+// for the previous classes of the students who left them.
+func addStudents(tx *gorm.DB, classID int, studentIDs []int) ([]*entity.OutboxEvent, error) {
+	if len(studentIDs) == 0 {
+		return nil, nil
+	}
+	// get old classes of the students having ones (lock the user records until the end of the transaction)
+	var oldStuds []entity.User
+	err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Select(_fieldID, _fieldClassID).
+		Where(_fieldID+" IN ? AND "+_fieldClassID+" <> ?", studentIDs, classID).
+		Order(_fieldID).
+		Find(&oldStuds).Error
+	if err != nil {
+		return nil, fmt.Errorf("get old classes: %w", err)
+	}
+
+	err = tx.Model(&entity.User{}).
+		Where(_fieldID+" IN ?", studentIDs).
+		UpdateColumn(_fieldClassID, classID).Error
+	if err != nil {
+		return nil, fmt.Errorf("update students class: %w", err)
+	}
+
+	// group students by old classes
+	var oldClassIDs []int
+	leftByClasses := make(map[int][]int)
+	for _, stud := range oldStuds {
+		if _, ok := leftByClasses[*stud.ClassID]; !ok {
+			oldClassIDs = append(oldClassIDs, *stud.ClassID)
+		}
+		leftByClasses[*stud.ClassID] = append(leftByClasses[*stud.ClassID], stud.ID)
+	}
+	events := make([]*entity.OutboxEvent, 0, len(oldClassIDs))
+	for _, oldClassID := range oldClassIDs {
+		// withdraw the old class tasks from the moved students
+		evtObj, err := entity.NewClassMembersEvent(oldClassID, nil, leftByClasses[oldClassID])
+		if err != nil {
+			return nil, fmt.Errorf("encode event: %w", err)
+		}
+		events = append(events, evtObj)
+	}
+	return events, nil
+}
+
+// writeEvents writes the given events and the class.members_changed event
+// of the class to the outbox.
+func writeEvents(tx *gorm.DB, events []*entity.OutboxEvent, classID int, joined, left []int) error {
+	evtObj, err := entity.NewClassMembersEvent(classID, joined, left)
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+	if evtObj != nil {
+		events = append(events, evtObj)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	if err := tx.Create(&events).Error; err != nil {
+		return fmt.Errorf("write events: %w", err)
+	}
+	return nil
+}
+
 // student represents a short student info (ID and fullname) with his class ID.
 type student struct {
 	*entity.Profile
@@ -2,14 +2,17 @@ package v1
 
 import (
 	"errors"
+	"strings"
 
 	fiber "github.com/gofiber/fiber/v2"
 
+	"skadi/backend/config"
 	"skadi/backend/internal/app/comment"
 	"skadi/backend/internal/app/entity"
 	"skadi/backend/internal/app/solution"
 	"skadi/backend/internal/pkg/httperror"
 	"skadi/backend/internal/pkg/serialize"
+	utilsfile "skadi/backend/internal/pkg/utils/file"
 	utilsjwt "skadi/backend/internal/pkg/utils/jwt"
 	"skadi/backend/internal/pkg/validator"
 )
@@ -18,32 +21,47 @@ import (
 type CommentController struct {
 	valid           validator.Validator
 	commentUCClient comment.UsecaseClient
+	solutionFileDir string
+	fileQueue       utilsfile.Queue
 }
 
 // NewController returns a new instance of [CommentController].
-func NewController(commentUCClient comment.UsecaseClient,
-	valid validator.Validator) *CommentController {
+func NewController(cfg *config.Config, commentUCClient comment.UsecaseClient,
+	fileQueue utilsfile.Queue, valid validator.Validator) *CommentController {
 
 	return &CommentController{
 		valid:           valid,
 		commentUCClient: commentUCClient,
+		solutionFileDir: cfg.Media.SolutionFileDir,
+		fileQueue:       fileQueue,
 	}
 }
 
 // @summary		Создание комментария под решением задания. [Преподаватель и ученик]
 // @description	Создание комментария от лица преподавателя или ученика для данного решения задания.
+// @description	Для ответа на другой комментарий этого решения нужно указать его ID в parent_id.
+// @description	К комментарию можно прикрепить файлы (multipart/form-data) и привязать его к фрагменту файла решения или задания:
+// @description	к диапазону строк (anchor_line_from, anchor_line_to) или к странице PDF-документа (anchor_page).
+// @description	Текст поддерживает Markdown (CommonMark, формулы LaTeX в $...$ и $$...$$), в ответе он возвращается также в виде безопасного HTML (message_html).
+// @description	Изображения могут ссылаться на прикреплённые файлы по имени (![](scheme.png)) или ID (![](file:12)).
 // @router			/solution/{id}/comment [post]
 // @id				comment-create
 // @tags			comment
-// @accept			json
+// @accept			mpfd,json
 // @produce		json
 // @security		JWTAccess
-// @param			id			path		int			true	"ID решения задания"
-// @param			commentBody	body		commentBody	true	"commentBody"
-// @success		201			{object}	entity.Comment
-// @failure		400			"решение задания не найдено"
-// @failure		401			"неверный токен (пустой, истекший или неверный формат)"
-// @failure		403			"доступ запрещён"
+// @param			id					path		int			true	"ID решения задания"
+// @param			message				formData	string		true	"text message"
+// @param			parent_id			formData	int			false	"ID of the parent comment (for replies)"
+// @param			anchor_file_id		formData	int			false	"ID of the solution or task file the comment is pointing at"
+// @param			anchor_line_from	formData	int			false	"first line of the anchor range"
+// @param			anchor_line_to		formData	int			false	"last line of the anchor range"
+// @param			anchor_page			formData	int			false	"anchor page (for PDF documents)"
+// @param			file				formData	[]file		false	"comment attachments"
+// @success		201					{object}	entity.Comment
+// @failure		400					"решение задания не найдено | родительский комментарий не найден | неверная привязка к файлу"
+// @failure		401					"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403					"доступ запрещён"
 func (c *CommentController) Create(ctx *fiber.Ctx) error {
 	// parse user claims
 	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
@@ -57,14 +75,31 @@ func (c *CommentController) Create(ctx *fiber.Ctx) error {
 		return err
 	}
 
+	// save attachments (JSON body has no files)
+	var uploadedFiles entity.Files
+	if strings.HasPrefix(ctx.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+		var err error
+		uploadedFiles, err = utilsfile.ParseAndSaveFiles(ctx, c.solutionFileDir, c.fileQueue)
+		if err != nil {
+			return err
+		}
+	}
+
 	// data reshaping
 	commentObj := &entity.Comment{
 		SolutionID: inputPath.ID,
+		UserID:     &userClaims.ID,
+		ParentID:   inputBody.ParentID,
 		Role:       userClaims.Role,
 		Message:    inputBody.Message,
+		Anchor:     inputBody.ToEntityAnchor(),
+		Files:      uploadedFiles,
 	}
 	// create a new comment
 	err := c.commentUCClient.Create(userClaims, commentObj)
+	if err != nil {
+		uploadedFiles.Cleanup()
+	}
 	if errors.Is(err, solution.ErrNotFound) {
 		return &httperror.HTTPError{
 			CauseErr:   err,
@@ -79,6 +114,20 @@ func (c *CommentController) Create(ctx *fiber.Ctx) error {
 			Message:    "доступ запрещён",
 		}
 	}
+	if errors.Is(err, comment.ErrInvalidParent) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "родительский комментарий не найден",
+		}
+	}
+	if errors.Is(err, comment.ErrInvalidAnchor) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "неверная привязка к файлу",
+		}
+	}
 	if err != nil {
 		return err
 	}
@@ -87,6 +136,8 @@ func (c *CommentController) Create(ctx *fiber.Ctx) error {
 
 // @summary		Получение комментариев под решением задания. [Преподаватель и ученик]
 // @description	Получение списка комментариев для данного решения задания.
+// @description	Если указан parent_id, возвращаются только ответы на этот комментарий.
+// @description	Удалённые комментарии возвращаются без текста, чтобы не разрывать ветки ответов.
 // @router			/solution/{id}/comment [get]
 // @id				comment-list
 // @tags			comment
@@ -115,7 +166,8 @@ func (c *CommentController) List(ctx *fiber.Ctx) error {
 	pageParams := inputQuery.PaginationQuery.ToPagination()
 
 	// get comments
-	commentListResp, err := c.commentUCClient.List(inputPath.ID, userClaims, pageParams)
+	commentListResp, err := c.commentUCClient.List(inputPath.ID, inputQuery.ParentIDOrNil(),
+		userClaims, pageParams)
 	if errors.Is(err, solution.ErrNotFound) {
 		return &httperror.HTTPError{
 			CauseErr:   err,
@@ -139,3 +191,94 @@ func (c *CommentController) List(ctx *fiber.Ctx) error {
 	}
 	return ctx.Status(fiber.StatusOK).JSON(output)
 }
+
+// @summary		Редактирование комментария. [Преподаватель и ученик]
+// @description	Изменение текста своего комментария. Редактировать комментарий можно только в течение ограниченного времени после его создания.
+// @router			/comment/{id} [patch]
+// @id				comment-update
+// @tags			comment
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id					path		int					true	"ID комментария"
+// @param			updateCommentBody	body		updateCommentBody	true	"updateCommentBody"
+// @success		200					{object}	entity.Comment
+// @failure		401					"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403					"доступ запрещён | время редактирования комментария истекло"
+// @failure		404					"комментарий не найден"
+func (c *CommentController) Update(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &commentIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputBody := &updateCommentBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	// update comment
+	commentObj, err := c.commentUCClient.Update(userClaims, inputPath.ID, inputBody.Message)
+	if err := c.handleEditError(err); err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(commentObj)
+}
+
+// @summary		Удаление комментария. [Преподаватель и ученик]
+// @description	Удаление своего комментария. Удалить комментарий можно только в течение ограниченного времени после его создания.
+// @description	Комментарий остаётся в списке без текста, чтобы не разрывать ветку ответов.
+// @router			/comment/{id} [delete]
+// @id				comment-delete
+// @tags			comment
+// @accept			json
+// @security		JWTAccess
+// @param			id	path	int	true	"ID комментария"
+// @success		204	"комментарий удалён"
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён | время редактирования комментария истекло"
+// @failure		404	"комментарий не найден"
+func (c *CommentController) Delete(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &commentIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	// delete comment
+	err := c.commentUCClient.Delete(userClaims, inputPath.ID)
+	if err := c.handleEditError(err); err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusNoContent).JSON(nil)
+}
+
+// handleEditError converts comment editing errors to HTTP errors.
+func (c *CommentController) handleEditError(err error) error {
+	if errors.Is(err, comment.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "комментарий не найден",
+		}
+	}
+	if errors.Is(err, comment.ErrForbidden) || errors.Is(err, solution.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, comment.ErrEditExpired) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "время редактирования комментария истекло",
+		}
+	}
+	return err // err OR nil
+}
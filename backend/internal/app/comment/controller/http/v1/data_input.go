@@ -5,6 +5,35 @@ import "skadi/backend/internal/app/entity"
 // @description commentBody represents a data with comment.
 type commentBody struct {
 	// text message
+	Message string `form:"message" json:"message" validate:"required" example:"Добрый день. Подскажите, пожалуйста..."`
+	// ID of the parent comment (for replies)
+	ParentID *int `form:"parent_id" json:"parent_id,omitempty" validate:"omitempty,min=1" example:"3"`
+	// ID of the solution or task file the comment is pointing at
+	AnchorFileID *int `form:"anchor_file_id" json:"anchor_file_id,omitempty" validate:"omitempty,min=1" example:"5"`
+	// first line of the anchor range
+	AnchorLineFrom *int `form:"anchor_line_from" json:"anchor_line_from,omitempty" validate:"omitempty,min=1" example:"42"`
+	// last line of the anchor range
+	AnchorLineTo *int `form:"anchor_line_to" json:"anchor_line_to,omitempty" validate:"omitempty,min=1" example:"45"`
+	// anchor page (for PDF documents)
+	AnchorPage *int `form:"anchor_page" json:"anchor_page,omitempty" validate:"omitempty,min=1" example:"3"`
+}
+
+// ToEntityAnchor returns comment anchor or nil if anchor file is not set.
+func (c *commentBody) ToEntityAnchor() *entity.CommentAnchor {
+	if c.AnchorFileID == nil {
+		return nil
+	}
+	return &entity.CommentAnchor{
+		FileID:   *c.AnchorFileID,
+		LineFrom: c.AnchorLineFrom,
+		LineTo:   c.AnchorLineTo,
+		Page:     c.AnchorPage,
+	}
+}
+
+// @description updateCommentBody represents a data to update comment.
+type updateCommentBody struct {
+	// new text message
 	Message string `json:"message" validate:"required" example:"Добрый день. Подскажите, пожалуйста..."`
 }
 
@@ -14,8 +43,24 @@ type solutionIDPath struct {
 	ID int `params:"id" validate:"required" example:"2"`
 }
 
+// @description commentIDPath represents a data with comment ID in path params.
+type commentIDPath struct {
+	// comment id
+	ID int `params:"id" validate:"required" example:"3"`
+}
+
 // @description listCommentQuery represents a data with optional query-params to get comment list.
 type listCommentQuery struct {
 	// pagination params
 	entity.PaginationQuery
+	// ID of the parent comment (to get replies only)
+	ParentID int `query:"parent_id,omitempty" json:"parent_id" validate:"omitempty,min=1" example:"3"`
+}
+
+// ParentIDOrNil returns parent comment ID or nil if it is not set.
+func (q *listCommentQuery) ParentIDOrNil() *int {
+	if q.ParentID == 0 {
+		return nil
+	}
+	return &q.ParentID
 }
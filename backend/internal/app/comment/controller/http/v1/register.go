@@ -18,4 +18,8 @@ func RegisterEndpoints(router fiber.Router, controller *CommentController,
 	authGroup := router.Group("/solution/:id/comment", mwJWTAccess, mwTeacherStudent)
 	authGroup.Post("/", controller.Create)
 	authGroup.Get("/", mwTeacherStudent, controller.List)
+
+	commentGroup := router.Group("/comment", mwJWTAccess, mwTeacherStudent)
+	commentGroup.Patch("/:id", controller.Update)
+	commentGroup.Delete("/:id", controller.Delete)
 }
@@ -5,4 +5,8 @@ import "errors"
 var (
 	ErrForbidden = errors.New("forbidden")        // code 403
 	ErrNotFound  = errors.New("record not found") // code 404
+
+	ErrInvalidParent = errors.New("invalid parent comment") // code 400
+	ErrEditExpired   = errors.New("edit window expired")    // code 403
+	ErrInvalidAnchor = errors.New("invalid anchor")         // code 400
 )
@@ -1,11 +1,31 @@
 package comment
 
-import "skadi/backend/internal/app/entity"
+import (
+	"time"
+
+	"skadi/backend/internal/app/entity"
+)
 
 // RepositoryDB describes all DB methods for the comment object.
 type RepositoryDB interface {
 	// Create creates a new comment and fills given struct.
 	Create(commentObj *entity.Comment) error
+	// GetByID returns comment (with author and attachments) by the given ID.
+	GetByID(id int) (*entity.Comment, error)
 	// List returns slice of solution comments.
-	List(solutionID int, page *entity.Pagination) ([]entity.Comment, error)
+	// If parentID is not nil, only replies to this comment are returned.
+	List(solutionID int, parentID *int, page *entity.Pagination) ([]entity.Comment, error)
+	// UpdateMessage sets a new message (with the message rendered to HTML)
+	// and edit datetime for the comment.
+	UpdateMessage(id int, message, messageHTML string, editedAt time.Time) error
+	// SetMessageHTML sets the comment message rendered to HTML.
+	SetMessageHTML(id int, messageHTML string) error
+	// GetUnrendered returns comments (with attachments) which message is not rendered to HTML yet.
+	// Deleted comments are skipped.
+	GetUnrendered(limit int) ([]entity.Comment, error)
+	// SoftDelete marks the comment as deleted and deletes its attachments.
+	SoftDelete(commentObj *entity.Comment, deletedAt time.Time) error
+	// HasSolutionFile returns true if the file is attached to the solution,
+	// its task or one of the solution comments.
+	HasSolutionFile(solutionID, fileID int) (bool, error)
 }
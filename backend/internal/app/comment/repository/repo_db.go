@@ -2,12 +2,29 @@
 package repository
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"gorm.io/gorm"
 
 	"skadi/backend/internal/app/comment"
 	"skadi/backend/internal/app/entity"
 )
 
+const (
+	_preloadAuthor        = "AuthorUser"         // object field name
+	_preloadAuthorProfile = "AuthorUser.Profile" // object field name
+	_preloadFiles         = "Files"              // object field name
+
+	_fieldID       = "id"           // table field name
+	_fieldFullname = "fullname"     // table field name
+	_fieldMessage  = "message"      // table field name
+	_fieldMsgHTML  = "message_html" // table field name
+	_fieldEditedAt = "edited_at"    // table field name
+	_fieldDeleted  = "deleted_at"   // table field name
+)
+
 // Ensure RepoDB implements interface.
 var _ comment.RepositoryDB = (*RepoDB)(nil)
 
@@ -29,22 +46,138 @@ func (r *RepoDB) Create(commentObj *entity.Comment) error {
 	return r.dbStorage.Create(commentObj).Error // nil OR error
 }
 
+// GetByID returns comment (with author and attachments) by the given ID.
+func (r *RepoDB) GetByID(id int) (*entity.Comment, error) {
+	var commentObj entity.Comment
+	err := r.withRelations(r.dbStorage).
+		Where(id).First(&commentObj).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// comment object with such id not found
+		return nil, fmt.Errorf("comment with id: %w", comment.ErrNotFound)
+	}
+	return &commentObj, err // err OR nil
+}
+
 // List returns slice of solution comments.
-func (r *RepoDB) List(solutionID int, page *entity.Pagination) ([]entity.Comment, error) {
+// If parentID is not nil, only replies to this comment are returned.
+func (r *RepoDB) List(solutionID int, parentID *int,
+	page *entity.Pagination) ([]entity.Comment, error) {
+
 	comments := []entity.Comment{}
 	// create query
 	query := r.dbStorage.
 		Model(&entity.Comment{}).
 		Where("solution_id = ?", solutionID).
 		Order("id DESC")
+	if parentID != nil {
+		query = query.Where("parent_id = ?", *parentID)
+	}
 	// apply pagination if it's not nil
 	if page != nil {
 		page.CountTotal(query)
 		query = page.Query(query)
 	}
 	// exec query
-	if err := query.Find(&comments).Error; err != nil {
+	if err := r.withRelations(query).Find(&comments).Error; err != nil {
 		return nil, err
 	}
 	return comments, nil
 }
+
+// UpdateMessage sets a new message and edit datetime for the comment.
+func (r *RepoDB) UpdateMessage(id int, message, messageHTML string, editedAt time.Time) error {
+	return r.dbStorage.
+		Model(&entity.Comment{}).
+		Where(_fieldID+" = ?", id).
+		Updates(map[string]any{
+			_fieldMessage:  message,
+			_fieldMsgHTML:  messageHTML,
+			_fieldEditedAt: editedAt,
+		}).Error // nil OR error
+}
+
+// SetMessageHTML sets the comment message rendered to HTML.
+func (r *RepoDB) SetMessageHTML(id int, messageHTML string) error {
+	return r.dbStorage.
+		Model(&entity.Comment{}).
+		Where(_fieldID+" = ?", id).
+		UpdateColumn(_fieldMsgHTML, messageHTML).Error // nil OR error
+}
+
+// GetUnrendered returns comments (with attachments) which message is not rendered to HTML yet.
+// Deleted comments are skipped.
+func (r *RepoDB) GetUnrendered(limit int) ([]entity.Comment, error) {
+	var comments []entity.Comment
+	err := r.dbStorage.
+		Model(&entity.Comment{}).
+		Preload(_preloadFiles).
+		Where(_fieldMsgHTML + " IS NULL AND " + _fieldDeleted + " IS NULL").
+		Order(_fieldID).
+		Limit(limit).
+		Find(&comments).Error
+	return comments, err // err OR nil
+}
+
+// SoftDelete marks the comment as deleted and deletes its attachments.
+// Comment row is kept to save replies thread.
+func (r *RepoDB) SoftDelete(commentObj *entity.Comment, deletedAt time.Time) error {
+	return r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		// mark comment as deleted
+		err := tx.Model(&entity.Comment{}).
+			Where(_fieldID+" = ?", commentObj.ID).
+			Update(_fieldDeleted, deletedAt).Error
+		if err != nil {
+			return err
+		}
+		// delete files (with comment_file records)
+		for _, file := range commentObj.Files {
+			if err := tx.Delete(&entity.File{}, file.ID).Error; err != nil {
+				return fmt.Errorf("delete file %d: %w", file.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// HasSolutionFile returns true if the file is attached to the solution,
+// its task or one of the solution comments.
+func (r *RepoDB) HasSolutionFile(solutionID, fileID int) (bool, error) {
+	// check solution files
+	var count int64
+	err := r.dbStorage.
+		Table("solution_file").
+		Where("solution_id = ? AND file_id = ?", solutionID, fileID).
+		Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+
+	// check task files
+	err = r.dbStorage.
+		Table("task_file").
+		Joins("INNER JOIN solution ON solution.task_id = task_file.task_id").
+		Where("solution.id = ? AND task_file.file_id = ?", solutionID, fileID).
+		Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+
+	// check files of the solution comments
+	err = r.dbStorage.
+		Table("comment_file").
+		Joins("INNER JOIN comment ON comment.id = comment_file.comment_id").
+		Where("comment.solution_id = ? AND comment_file.file_id = ?", solutionID, fileID).
+		Count(&count).Error
+	return count > 0, err // err OR nil
+}
+
+// withRelations adds preloading of the comment author short profile
+// and attachments to the query.
+func (r *RepoDB) withRelations(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload(_preloadAuthor).
+		Preload(_preloadAuthorProfile, func(db *gorm.DB) *gorm.DB {
+			return db.Select(_fieldID, _fieldFullname) // preload only ID and fullname
+		}).
+		Preload(_preloadFiles)
+}
@@ -1,15 +1,29 @@
 // Package comment contains all repos, usecases and controllers for comment.
 // Sub-package repo contains RepoDB implementation.
-// Sub-package usecase contains UsecaseClient implementation.
+// Sub-package usecase contains UsecaseClient and UsecaseRenderer implementations.
 package comment
 
 import "skadi/backend/internal/app/entity"
 
 // UsecaseClient describes all comment usecases for teacher and student.
 type UsecaseClient interface {
-	// Create creates a new comment and fills the given struct.
+	// Create creates a new comment (with attachments) and fills the given struct.
 	Create(userClaims *entity.UserClaims, commentObj *entity.Comment) error
 	// List returns slice of solution comments.
-	List(solutionID int, userClaims *entity.UserClaims,
+	// If parentID is not nil, only replies to this comment are returned.
+	List(solutionID int, parentID *int, userClaims *entity.UserClaims,
 		page *entity.Pagination) ([]entity.Comment, error)
+	// Update changes message of the comment created by the user.
+	Update(userClaims *entity.UserClaims, commentID int, message string) (*entity.Comment, error)
+	// Delete marks the comment created by the user as deleted.
+	// Comment attachments are deleted completely.
+	Delete(userClaims *entity.UserClaims, commentID int) error
+}
+
+// UsecaseRenderer describes usecases to render comment messages from Markdown to HTML.
+// It is used by the scheduled background job.
+type UsecaseRenderer interface {
+	// RenderPending renders messages of comments which are not rendered yet
+	// (e.g. comments created before Markdown support). It returns the number of rendered comments.
+	RenderPending() (int, error)
 }
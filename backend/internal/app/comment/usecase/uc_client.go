@@ -1,13 +1,19 @@
-// Package usecase contains comment.UsecaseClient implementation.
+// Package usecase contains comment.UsecaseClient and comment.UsecaseRenderer implementations.
 package usecase
 
 import (
 	"fmt"
+	"log/slog"
+	"time"
 
 	"skadi/backend/config"
 	"skadi/backend/internal/app/comment"
 	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/event"
+	"skadi/backend/internal/app/notification"
 	"skadi/backend/internal/app/solution"
+	"skadi/backend/internal/app/webhook"
+	"skadi/backend/internal/pkg/markdown"
 )
 
 // Ensure UCClient implements interfaces.
@@ -19,31 +25,80 @@ type UCClient struct {
 	cfg           *config.Config
 	commentRepoDB comment.RepositoryDB
 	solRepoDB     solution.RepositoryDB
+	evtPub        event.Publisher
+	notifier      notification.Notifier
+	hookEmitter   webhook.Emitter
+	mdRenderer    *markdown.Renderer
 }
 
 // NewUCClient returns a new instance of [UCClient].
 func NewUCClient(cfg *config.Config, commentRepoDB comment.RepositoryDB,
-	solRepoDB solution.RepositoryDB) *UCClient {
+	solRepoDB solution.RepositoryDB, evtPub event.Publisher,
+	notifier notification.Notifier, hookEmitter webhook.Emitter) *UCClient {
 
 	return &UCClient{
 		cfg:           cfg,
 		commentRepoDB: commentRepoDB,
 		solRepoDB:     solRepoDB,
+		evtPub:        evtPub,
+		notifier:      notifier,
+		hookEmitter:   hookEmitter,
+		mdRenderer:    markdown.NewRenderer(),
 	}
 }
 
-// Create creates a new comment and fills the given struct.
+// Create creates a new comment (with attachments) and fills the given struct.
 func (u *UCClient) Create(userClaims *entity.UserClaims, commentObj *entity.Comment) error {
 	// check user rights for this solution
 	if err := u.solRepoDB.UserPermit(commentObj.SolutionID, userClaims); err != nil {
 		return fmt.Errorf("check solution permissions: %w", err)
 	}
+	// check parent comment (it must be from the same solution)
+	if commentObj.ParentID != nil {
+		parentObj, err := u.commentRepoDB.GetByID(*commentObj.ParentID)
+		if err != nil {
+			return fmt.Errorf("%w: get parent: %s", comment.ErrInvalidParent, err.Error())
+		}
+		if parentObj.SolutionID != commentObj.SolutionID || parentObj.IsDeleted() {
+			return fmt.Errorf("%w: comment %d is deleted or belongs to another solution",
+				comment.ErrInvalidParent, parentObj.ID)
+		}
+	}
+	// check anchor (file must be related to the solution)
+	if commentObj.Anchor != nil {
+		if err := u.checkAnchor(commentObj.SolutionID, commentObj.Anchor); err != nil {
+			return err
+		}
+	}
 	// create comment
-	return u.commentRepoDB.Create(commentObj)
+	if err := u.commentRepoDB.Create(commentObj); err != nil {
+		return err
+	}
+	// render message after creating attachments (images are resolved by file IDs)
+	if err := renderMessage(u.mdRenderer, u.commentRepoDB, commentObj); err != nil {
+		return err
+	}
+	// get comment with author profile
+	createdObj, err := u.commentRepoDB.GetByID(commentObj.ID)
+	if err != nil {
+		return fmt.Errorf("get created comment: %w", err)
+	}
+	*commentObj = *createdObj
+	u.notifyCreated(commentObj)
+	u.hookEmitter.Emit(entity.HookCommentCreated, &entity.WebhookCommentData{
+		CommentID:  commentObj.ID,
+		SolutionID: commentObj.SolutionID,
+		ParentID:   commentObj.ParentID,
+		AuthorID:   commentObj.UserID,
+		Role:       commentObj.Role,
+		Message:    commentObj.Message,
+	})
+	return nil
 }
 
 // List returns slice of solution comments.
-func (u *UCClient) List(solutionID int, userClaims *entity.UserClaims,
+// If parentID is not nil, only replies to this comment are returned.
+func (u *UCClient) List(solutionID int, parentID *int, userClaims *entity.UserClaims,
 	page *entity.Pagination) ([]entity.Comment, error) {
 
 	// check user rights for this solution
@@ -51,5 +106,111 @@ func (u *UCClient) List(solutionID int, userClaims *entity.UserClaims,
 		return nil, fmt.Errorf("check solution permissions: %w", err)
 	}
 	// get list of comments
-	return u.commentRepoDB.List(solutionID, page)
+	return u.commentRepoDB.List(solutionID, parentID, page)
+}
+
+// Update changes message of the comment created by the user.
+func (u *UCClient) Update(userClaims *entity.UserClaims, commentID int,
+	message string) (*entity.Comment, error) {
+
+	commentObj, err := u.getEditable(userClaims, commentID)
+	if err != nil {
+		return nil, err
+	}
+	// images can refer to the comment attachments
+	messageHTML, err := u.mdRenderer.Render(message, commentObj.Files.ResolveImage)
+	if err != nil {
+		return nil, fmt.Errorf("render message: %w", err)
+	}
+	// update comment
+	err = u.commentRepoDB.UpdateMessage(commentID, message, messageHTML, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return u.commentRepoDB.GetByID(commentID)
+}
+
+// Delete marks the comment created by the user as deleted.
+// Comment attachments are deleted completely.
+func (u *UCClient) Delete(userClaims *entity.UserClaims, commentID int) error {
+	commentObj, err := u.getEditable(userClaims, commentID)
+	if err != nil {
+		return err
+	}
+	if err := u.commentRepoDB.SoftDelete(commentObj, time.Now()); err != nil {
+		return err
+	}
+	// delete files from the file system
+	commentObj.Files.Cleanup()
+	return nil
+}
+
+// checkAnchor returns nil error if the anchor is valid and its file
+// is attached to the solution, its task or one of the solution comments.
+func (u *UCClient) checkAnchor(solutionID int, anchor *entity.CommentAnchor) error {
+	if !anchor.IsValid() {
+		return fmt.Errorf("%w: either line range or page must be set correctly",
+			comment.ErrInvalidAnchor)
+	}
+	ok, err := u.commentRepoDB.HasSolutionFile(solutionID, anchor.FileID)
+	if err != nil {
+		return fmt.Errorf("check anchor file: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("%w: file %d is not related to solution %d",
+			comment.ErrInvalidAnchor, anchor.FileID, solutionID)
+	}
+	return nil
+}
+
+// getEditable returns comment if the user can edit or delete it:
+// the user is the comment author and the edit window is not expired.
+func (u *UCClient) getEditable(userClaims *entity.UserClaims,
+	commentID int) (*entity.Comment, error) {
+
+	commentObj, err := u.commentRepoDB.GetByID(commentID)
+	if err != nil {
+		return nil, err
+	}
+	if commentObj.IsDeleted() {
+		return nil, fmt.Errorf("comment %d is deleted: %w", commentID, comment.ErrNotFound)
+	}
+	// check user rights for this solution (user could lose access to it)
+	if err := u.solRepoDB.UserPermit(commentObj.SolutionID, userClaims); err != nil {
+		return nil, fmt.Errorf("check solution permissions: %w", err)
+	}
+	if !commentObj.IsAuthor(userClaims.ID) {
+		return nil, fmt.Errorf("%w: user %d is not an author of comment %d",
+			comment.ErrForbidden, userClaims.ID, commentID)
+	}
+	if time.Since(commentObj.CreatedAt) > u.cfg.Comment.EditWindow {
+		return nil, fmt.Errorf("%w: comment %d was created at %s",
+			comment.ErrEditExpired, commentID, commentObj.CreatedAt)
+	}
+	return commentObj, nil
+}
+
+// notifyCreated notifies the solution students (all team members) and the task teacher
+// (apart of the comment author) about the new comment.
+func (u *UCClient) notifyCreated(commentObj *entity.Comment) {
+	solObj, err := u.solRepoDB.GetByID(commentObj.SolutionID)
+	if err != nil {
+		slog.Warn("get comment solution to publish event", "id", commentObj.ID, "error", err)
+		return
+	}
+	recipients := make([]int, 0, len(solObj.Members)+1)
+	for _, userID := range append(solObj.MemberIDs(), solObj.Task.TeacherID) {
+		if !commentObj.IsAuthor(userID) {
+			recipients = append(recipients, userID)
+		}
+	}
+	u.evtPub.Publish(&entity.Event{Type: entity.EventCommentCreated, Data: commentObj},
+		recipients...)
+	u.notifier.Notify(&entity.Notification{
+		Type:       entity.NotifyNewComment,
+		Message:    fmt.Sprintf("Новый комментарий к решению задания «%s»", solObj.Task.Title),
+		TaskID:     &solObj.TaskID,
+		SolutionID: &solObj.ID,
+		CommentID:  &commentObj.ID,
+	}, recipients...)
 }
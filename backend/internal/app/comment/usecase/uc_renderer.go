@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"fmt"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/comment"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/pkg/markdown"
+)
+
+const _renderBatchSize = 100 // number of comments rendered at once
+
+// Ensure UCRenderer implements interface.
+var _ comment.UsecaseRenderer = (*UCRenderer)(nil)
+
+// UCRenderer represents a comment usecase to render messages in background.
+// It implements the [comment.UsecaseRenderer] interface.
+type UCRenderer struct {
+	cfg           *config.Config
+	commentRepoDB comment.RepositoryDB
+	mdRenderer    *markdown.Renderer
+}
+
+// NewUCRenderer returns a new instance of [UCRenderer].
+func NewUCRenderer(cfg *config.Config, commentRepoDB comment.RepositoryDB) *UCRenderer {
+	return &UCRenderer{
+		cfg:           cfg,
+		commentRepoDB: commentRepoDB,
+		mdRenderer:    markdown.NewRenderer(),
+	}
+}
+
+// RenderPending renders messages of comments which are not rendered yet
+// (e.g. comments created before Markdown support). It returns the number of rendered comments.
+func (u *UCRenderer) RenderPending() (int, error) {
+	var count int
+	for {
+		comments, err := u.commentRepoDB.GetUnrendered(_renderBatchSize)
+		if err != nil {
+			return count, fmt.Errorf("get comments: %w", err)
+		}
+		for idx := range comments {
+			if err := renderMessage(u.mdRenderer, u.commentRepoDB, &comments[idx]); err != nil {
+				return count, fmt.Errorf("comment %d: %w", comments[idx].ID, err)
+			}
+			count++
+		}
+		if len(comments) < _renderBatchSize {
+			return count, nil
+		}
+	}
+}
+
+// renderMessage renders the comment message to HTML and saves it.
+// Images can refer to the comment attachments by names or IDs.
+func renderMessage(mdRenderer *markdown.Renderer, commentRepoDB comment.RepositoryDB,
+	commentObj *entity.Comment) error {
+
+	messageHTML, err := mdRenderer.Render(commentObj.Message, commentObj.Files.ResolveImage)
+	if err != nil {
+		return fmt.Errorf("render message: %w", err)
+	}
+	if err := commentRepoDB.SetMessageHTML(commentObj.ID, messageHTML); err != nil {
+		return fmt.Errorf("save message: %w", err)
+	}
+	commentObj.MessageHTML = &messageHTML
+	return nil
+}
@@ -0,0 +1,44 @@
+package v1
+
+import (
+	"fmt"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/course"
+	utilsjwt "skadi/backend/internal/pkg/utils/jwt"
+)
+
+// CourseControllerStudent represents a controller for course routes accepted for students only.
+type CourseControllerStudent struct {
+	courseUCStudent course.UsecaseStudent
+}
+
+// NewControllerStudent returns a new instance of [CourseControllerStudent].
+func NewControllerStudent(courseUCStudent course.UsecaseStudent) *CourseControllerStudent {
+	return &CourseControllerStudent{
+		courseUCStudent: courseUCStudent,
+	}
+}
+
+// @summary		Получение списка курсов ученика. [Только ученик]
+// @description	Получение курсов, привязанных к группе ученика или содержащих его задания.
+// @description	Для каждого модуля возвращаются решения ученика (в порядке заданий) и прогресс по статусам решений, для курса - общий прогресс.
+// @router			/course/for-student [get]
+// @id				course-list-for-student
+// @tags			course
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @success		200	{array}	entity.Course
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+func (c *CourseControllerStudent) List(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	courses, err := c.courseUCStudent.GetMany(userClaims.ID)
+	if err != nil {
+		return fmt.Errorf("list: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(courses)
+}
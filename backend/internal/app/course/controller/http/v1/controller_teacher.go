@@ -0,0 +1,519 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/course"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/pkg/httperror"
+	"skadi/backend/internal/pkg/serialize"
+	utilsjwt "skadi/backend/internal/pkg/utils/jwt"
+	"skadi/backend/internal/pkg/validator"
+)
+
+// CourseControllerTeacher represents a controller for course routes accepted for teachers only.
+type CourseControllerTeacher struct {
+	valid           validator.Validator
+	courseUCTeacher course.UsecaseTeacher
+}
+
+// NewControllerTeacher returns a new instance of [CourseControllerTeacher].
+func NewControllerTeacher(courseUCTeacher course.UsecaseTeacher,
+	valid validator.Validator) *CourseControllerTeacher {
+
+	return &CourseControllerTeacher{
+		valid:           valid,
+		courseUCTeacher: courseUCTeacher,
+	}
+}
+
+// @summary		Создание курса. [Только преподаватель]
+// @description	Создание нового курса преподавателя, привязанного к переданным группам.
+// @description	Курс состоит из упорядоченных модулей, модули - из упорядоченных заданий.
+// @router			/course [post]
+// @id				course-create
+// @tags			course
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			courseBody	body		courseBody	true	"courseBody"
+// @success		201			{object}	entity.Course
+// @failure		400			"группа не найдена"
+// @failure		401			"неверный токен (пустой, истекший или неверный формат)"
+func (c *CourseControllerTeacher) Create(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputBody := &courseBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	courseObj := &entity.Course{
+		Title:     inputBody.Title,
+		Desc:      inputBody.Desc,
+		TeacherID: userClaims.ID,
+	}
+	err := c.courseUCTeacher.Create(courseObj, inputBody.ClassIDs)
+	if errors.Is(err, course.ErrNotFoundClass) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "группа не найдена",
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(courseObj)
+}
+
+// @summary		Получение списка курсов. [Только преподаватель]
+// @description	Получение списка курсов преподавателя (с группами и модулями).
+// @router			/course/for-teacher [get]
+// @id				course-list-for-teacher
+// @tags			course
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			listCourseQuery	query		listCourseQuery	false	"listCourseQuery"
+// @success		200				{object}	listCourseOut
+// @failure		401				"неверный токен (пустой, истекший или неверный формат)"
+func (c *CourseControllerTeacher) List(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputQuery := &listCourseQuery{}
+	if err := serialize.Deserialize(inputQuery, ctx.QueryParser, c.valid.Validate); err != nil {
+		return err
+	}
+	// get pagination object OR nil
+	pageParams := inputQuery.PaginationQuery.ToPagination()
+
+	courses, err := c.courseUCTeacher.GetMany(userClaims.ID, inputQuery.Search, pageParams)
+	if err != nil {
+		return fmt.Errorf("list: %w", err)
+	}
+	output := &listCourseOut{
+		Data:       courses,
+		Pagination: pageParams,
+	}
+	return ctx.Status(fiber.StatusOK).JSON(output)
+}
+
+// @summary		Получение курса по id. [Только преподаватель]
+// @description	Получение своего курса с группами, модулями и заданиями модулей (в порядке их позиций).
+// @router			/course/{id} [get]
+// @id				course-read
+// @tags			course
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id	path		int	true	"ID курса"
+// @success		200	{object}	entity.Course
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+// @failure		404	"курс не найден"
+func (c *CourseControllerTeacher) Read(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &courseIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	courseObj, err := c.courseUCTeacher.GetByID(userClaims.ID, inputPath.ID)
+	if errors.Is(err, course.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "курс не найден",
+		}
+	}
+	if errors.Is(err, course.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("read: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(courseObj)
+}
+
+// @summary		Обновление курса. [Только преподаватель]
+// @description	Частичное обновление своего курса (только переданные поля: название, описание, привязанные группы) по его id.
+// @router			/course/{id} [patch]
+// @id				course-update
+// @tags			course
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id					path		int					true	"ID курса"
+// @param			updateCourseBody	body		updateCourseBody	true	"updateCourseBody"
+// @success		200					{object}	entity.Course
+// @failure		400					"группа не найдена"
+// @failure		401					"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403					"доступ запрещён"
+// @failure		404					"курс не найден"
+func (c *CourseControllerTeacher) Update(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &courseIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputBody := &updateCourseBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	newData := &entity.CourseUpdate{
+		Title:          inputBody.Title,
+		Desc:           inputBody.Desc,
+		NewFullClasses: inputBody.Classes,
+	}
+	courseObj, err := c.courseUCTeacher.Update(userClaims.ID, inputPath.ID, newData)
+	if errors.Is(err, course.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "курс не найден",
+		}
+	}
+	if errors.Is(err, course.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, course.ErrNotFoundClass) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "группа не найдена",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(courseObj)
+}
+
+// @summary		Удаление курса по id. [Только преподаватель]
+// @description	Удаление своего курса с его модулями по его id. Задания модулей не удаляются.
+// @router			/course/{id} [delete]
+// @id				course-delete
+// @tags			course
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id	path	int	true	"ID курса"
+// @success		204	"No Content"
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+func (c *CourseControllerTeacher) Delete(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &courseIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	err := c.courseUCTeacher.DeleteByID(userClaims.ID, inputPath.ID)
+	if errors.Is(err, course.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("delete course: %w", err)
+	}
+	return ctx.Status(fiber.StatusNoContent).JSON(nil)
+}
+
+// @summary		Создание модуля курса. [Только преподаватель]
+// @description	Создание нового модуля в конце своего курса.
+// @router			/course/{id}/module [post]
+// @id				course-create-module
+// @tags			course
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id			path		int			true	"ID курса"
+// @param			moduleBody	body		moduleBody	true	"moduleBody"
+// @success		201			{object}	entity.CourseModule
+// @failure		401			"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403			"доступ запрещён"
+// @failure		404			"курс не найден"
+func (c *CourseControllerTeacher) CreateModule(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &courseIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputBody := &moduleBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	moduleObj := &entity.CourseModule{
+		CourseID: inputPath.ID,
+		Title:    inputBody.Title,
+	}
+	err := c.courseUCTeacher.CreateModule(userClaims.ID, moduleObj)
+	if errors.Is(err, course.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "курс не найден",
+		}
+	}
+	if errors.Is(err, course.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("create module: %w", err)
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(moduleObj)
+}
+
+// @summary		Изменение порядка модулей курса. [Только преподаватель]
+// @description	Изменение порядка модулей своего курса. Должны быть переданы все модули курса (каждый один раз).
+// @router			/course/{id}/module/order [put]
+// @id				course-order-modules
+// @tags			course
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id				path		int				true	"ID курса"
+// @param			moduleOrderBody	body		moduleOrderBody	true	"moduleOrderBody"
+// @success		200				{object}	entity.Course
+// @failure		400				"неверный список модулей"
+// @failure		401				"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403				"доступ запрещён"
+// @failure		404				"курс не найден"
+func (c *CourseControllerTeacher) SetModuleOrder(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &courseIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputBody := &moduleOrderBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	courseObj, err := c.courseUCTeacher.SetModuleOrder(userClaims.ID, inputPath.ID,
+		inputBody.ModuleIDs)
+	if errors.Is(err, course.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "курс не найден",
+		}
+	}
+	if errors.Is(err, course.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, course.ErrInvalidData) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "неверный список модулей",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("order modules: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(courseObj)
+}
+
+// @summary		Обновление модуля курса. [Только преподаватель]
+// @description	Изменение названия модуля своего курса.
+// @router			/course/{id}/module/{moduleID} [patch]
+// @id				course-update-module
+// @tags			course
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id			path		int			true	"ID курса"
+// @param			moduleID	path		int			true	"ID модуля"
+// @param			moduleBody	body		moduleBody	true	"moduleBody"
+// @success		200			{object}	entity.CourseModule
+// @failure		401			"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403			"доступ запрещён"
+// @failure		404			"курс не найден | модуль не найден"
+func (c *CourseControllerTeacher) UpdateModule(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &modulePath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputBody := &moduleBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	moduleObj, err := c.courseUCTeacher.UpdateModule(userClaims.ID, inputPath.ID,
+		inputPath.ModuleID, inputBody.Title)
+	if errors.Is(err, course.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "курс не найден",
+		}
+	}
+	if errors.Is(err, course.ErrNotFoundMod) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "модуль не найден",
+		}
+	}
+	if errors.Is(err, course.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("update module: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(moduleObj)
+}
+
+// @summary		Удаление модуля курса. [Только преподаватель]
+// @description	Удаление модуля своего курса. Задания модуля не удаляются.
+// @router			/course/{id}/module/{moduleID} [delete]
+// @id				course-delete-module
+// @tags			course
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id			path	int	true	"ID курса"
+// @param			moduleID	path	int	true	"ID модуля"
+// @success		204			"No Content"
+// @failure		401			"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403			"доступ запрещён"
+// @failure		404			"курс не найден"
+func (c *CourseControllerTeacher) DeleteModule(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &modulePath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	err := c.courseUCTeacher.DeleteModule(userClaims.ID, inputPath.ID, inputPath.ModuleID)
+	if errors.Is(err, course.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "курс не найден",
+		}
+	}
+	if errors.Is(err, course.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("delete module: %w", err)
+	}
+	return ctx.Status(fiber.StatusNoContent).JSON(nil)
+}
+
+// @summary		Изменение заданий модуля курса. [Только преподаватель]
+// @description	Замена списка заданий модуля своего курса своими заданиями в переданном порядке.
+// @description	Задание может входить только в один модуль, поэтому оно убирается из предыдущего модуля.
+// @description	Задания, отсутствующие в списке, убираются из модуля (но не удаляются).
+// @router			/course/{id}/module/{moduleID}/task [put]
+// @id				course-set-module-tasks
+// @tags			course
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id				path		int				true	"ID курса"
+// @param			moduleID		path		int				true	"ID модуля"
+// @param			moduleTasksBody	body		moduleTasksBody	true	"moduleTasksBody"
+// @success		200				{object}	entity.Course
+// @failure		400				"неверное задание"
+// @failure		401				"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403				"доступ запрещён"
+// @failure		404				"курс не найден | модуль не найден"
+func (c *CourseControllerTeacher) SetModuleTasks(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &modulePath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputBody := &moduleTasksBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	courseObj, err := c.courseUCTeacher.SetModuleTasks(userClaims.ID, inputPath.ID,
+		inputPath.ModuleID, inputBody.TaskIDs)
+	if errors.Is(err, course.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "курс не найден",
+		}
+	}
+	if errors.Is(err, course.ErrNotFoundMod) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "модуль не найден",
+		}
+	}
+	if errors.Is(err, course.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, course.ErrInvalidTask) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "неверное задание",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("set module tasks: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(courseObj)
+}
@@ -0,0 +1,63 @@
+package v1
+
+import "skadi/backend/internal/app/entity"
+
+// @description courseBody represents a data with course.
+type courseBody struct {
+	// course title
+	Title string `json:"title" validate:"required,max=100" example:"Python для начинающих" maxLength:"100"`
+	// course description
+	Desc string `json:"description" validate:"omitempty" example:"Основы языка Python"`
+	// IDs of classes linked to the course
+	ClassIDs []int `json:"classes,omitempty" validate:"omitempty" example:"3,6"`
+}
+
+// @description courseIDPath represents a data with course ID in path params.
+type courseIDPath struct {
+	// course id
+	ID int `params:"id" validate:"required" example:"2"`
+}
+
+// @description updateCourseBody represents a data with optional body to update course.
+type updateCourseBody struct {
+	// new course title
+	Title *string `json:"title,omitempty" validate:"omitempty,max=100" example:"Python. Продвинутый уровень" maxLength:"100"`
+	// new course description
+	Desc *string `json:"description,omitempty" validate:"omitempty" example:"Декораторы, генераторы и асинхронность"`
+	// IDs of classes (updated list) linked to the course
+	Classes []int `json:"classes,omitempty" validate:"omitempty" example:"3,9"`
+}
+
+// @description listCourseQuery represents a data with optional query-params to get courses list.
+type listCourseQuery struct {
+	// substring to filter data by substring (case-insensitive)
+	Search string `query:"search,omitempty" json:"search" example:"Python"`
+	// pagination params
+	entity.PaginationQuery
+}
+
+// @description moduleBody represents a data with course module.
+type moduleBody struct {
+	// module title
+	Title string `json:"title" validate:"required,max=100" example:"Циклы" maxLength:"100"`
+}
+
+// @description modulePath represents a data with course and module IDs in path params.
+type modulePath struct {
+	// course id
+	ID int `params:"id" validate:"required" example:"2"`
+	// module id
+	ModuleID int `params:"moduleID" validate:"required" example:"7"`
+}
+
+// @description moduleOrderBody represents a data with the new order of the course modules.
+type moduleOrderBody struct {
+	// IDs of all course modules in the new order
+	ModuleIDs []int `json:"modules" validate:"required" example:"7,5,6"`
+}
+
+// @description moduleTasksBody represents a data with tasks of the course module.
+type moduleTasksBody struct {
+	// IDs of module tasks (updated list) in the order
+	TaskIDs []int `json:"tasks" validate:"omitempty" example:"12,10,11"`
+}
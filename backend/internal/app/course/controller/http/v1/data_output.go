@@ -0,0 +1,11 @@
+package v1
+
+import "skadi/backend/internal/app/entity"
+
+// @description listCourseOut represents a course list data.
+type listCourseOut struct {
+	// courses list
+	Data []entity.Course `json:"data" validate:"required"`
+	// pagination params
+	Pagination *entity.Pagination `json:"pagination,omitempty" validate:"omitempty"`
+}
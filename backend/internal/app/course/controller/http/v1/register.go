@@ -0,0 +1,32 @@
+// Package http/v1 is a first version of course HTTP-controller.
+// It provides registers for course HTTP-routes and controllers with handlers for them.
+package v1
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/service/server/middleware"
+)
+
+// RegisterEndpoints registers all course endpoints.
+func RegisterEndpoints(router fiber.Router, controllerTeacher *CourseControllerTeacher,
+	controllerStudent *CourseControllerStudent,
+	mwJWTAccess fiber.Handler, mwAllow middleware.AllowFunc) {
+
+	mwTeacherOnly := mwAllow(entity.Teacher)
+	mwStudentOnly := mwAllow(entity.Student)
+
+	authGroup := router.Group("/course", mwJWTAccess)
+	authGroup.Post("/", mwTeacherOnly, controllerTeacher.Create)
+	authGroup.Get("/for-teacher", mwTeacherOnly, controllerTeacher.List)
+	authGroup.Get("/for-student", mwStudentOnly, controllerStudent.List)
+	authGroup.Get("/:id", mwTeacherOnly, controllerTeacher.Read)
+	authGroup.Patch("/:id", mwTeacherOnly, controllerTeacher.Update)
+	authGroup.Delete("/:id", mwTeacherOnly, controllerTeacher.Delete)
+	authGroup.Post("/:id/module", mwTeacherOnly, controllerTeacher.CreateModule)
+	authGroup.Put("/:id/module/order", mwTeacherOnly, controllerTeacher.SetModuleOrder)
+	authGroup.Patch("/:id/module/:moduleID", mwTeacherOnly, controllerTeacher.UpdateModule)
+	authGroup.Delete("/:id/module/:moduleID", mwTeacherOnly, controllerTeacher.DeleteModule)
+	authGroup.Put("/:id/module/:moduleID/task", mwTeacherOnly, controllerTeacher.SetModuleTasks)
+}
@@ -0,0 +1,12 @@
+package course
+
+import "errors"
+
+var (
+	ErrInvalidData   = errors.New("invalid data")     // code 400
+	ErrInvalidTask   = errors.New("invalid task")     // code 400
+	ErrForbidden     = errors.New("forbidden")        // code 403
+	ErrNotFound      = errors.New("record not found") // code 404
+	ErrNotFoundClass = errors.New("class not found")  // code 404
+	ErrNotFoundMod   = errors.New("module not found") // code 404
+)
@@ -0,0 +1,40 @@
+package course
+
+import "skadi/backend/internal/app/entity"
+
+// RepositoryDB describes all DB methods for courses and their modules.
+type RepositoryDB interface {
+	// Create creates a new course linked to the given classes.
+	Create(courseObj *entity.Course, classIDs []int) error
+	// GetByID returns course by the given ID with classes, modules and module tasks.
+	GetByID(id int) (*entity.Course, error)
+	// Update updates course by the given ID with the new data.
+	Update(id int, newData *entity.CourseUpdate) error
+	// Delete deletes course with its modules by the given ID
+	// (module tasks are kept without module).
+	Delete(id int) error
+	// GetMany returns all teacher courses with classes and modules.
+	// Search param appends condition to filter courses by title (substring).
+	GetMany(teacherID int, search string, page *entity.Pagination) ([]entity.Course, error)
+
+	// CreateModule creates a new module at the end of the course.
+	CreateModule(moduleObj *entity.CourseModule) error
+	// GetModule returns course module by the given ID.
+	GetModule(id int) (*entity.CourseModule, error)
+	// UpdateModule sets the new title of the module by the given ID.
+	UpdateModule(id int, title string) error
+	// DeleteModule deletes module by the given ID (module tasks are kept without module).
+	DeleteModule(id int) error
+	// SetModuleOrder sets positions of the course modules in the order of the given IDs.
+	SetModuleOrder(courseID int, moduleIDs []int) error
+	// SetModuleTasks replaces tasks of the module with the given teacher tasks
+	// (positions are set in the order of the given IDs).
+	SetModuleTasks(teacherID, moduleID int, taskIDs []int) error
+
+	// GetManyForStudent returns courses linked to the student class or containing
+	// published tasks with the student solutions (with modules).
+	GetManyForStudent(studentID int) ([]entity.Course, error)
+	// GetStudentSolutions returns the student solutions (with tasks and statuses)
+	// of the published tasks of the given course modules.
+	GetStudentSolutions(studentID int, moduleIDs []int) ([]entity.Solution, error)
+}
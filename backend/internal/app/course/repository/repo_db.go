@@ -0,0 +1,319 @@
+// Package repository contains course.RepositoryDB implementation.
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"skadi/backend/internal/app/course"
+	"skadi/backend/internal/app/entity"
+)
+
+const (
+	_preloadClasses      = "Classes"       // object field name
+	_preloadModules      = "Modules"       // object field name
+	_preloadModulesTasks = "Modules.Tasks" // object field name
+	_preloadTask         = "Task"          // object field name
+	_preloadStatus       = "Status"        // object field name
+
+	_fieldID       = "id"         // table field name
+	_fieldName     = "name"       // table field name
+	_fieldTitle    = "title"      // table field name
+	_fieldModuleID = "module_id"  // table field name
+	_fieldPosition = "position"   // table field name
+	_fieldDraft    = "draft"      // table field name
+	_fieldPubAt    = "publish_at" // table field name
+
+	_orderByIDDESC   = "id DESC"      // condition to order data by id DESC
+	_orderByPosition = "position, id" // condition to order data by position
+)
+
+// Ensure RepoDB implements interface.
+var _ course.RepositoryDB = (*RepoDB)(nil)
+
+// RepoDB is a course DB repo.
+// It implements the [course.RepositoryDB] interface.
+type RepoDB struct {
+	dbStorage *gorm.DB
+}
+
+// NewRepoDB returns a new instance of [RepoDB].
+func NewRepoDB(dbStorage *gorm.DB) *RepoDB {
+	return &RepoDB{
+		dbStorage: dbStorage,
+	}
+}
+
+// Create creates a new course linked to the given classes.
+func (r *RepoDB) Create(courseObj *entity.Course, classIDs []int) error {
+	return r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		// classes are linked manually to avoid creating them
+		err := tx.Omit(_preloadClasses, _preloadModules).Create(courseObj).Error
+		if err != nil {
+			return err
+		}
+		return r.linkClasses(tx, courseObj.ID, classIDs)
+	})
+}
+
+// GetByID returns course by the given ID with classes, modules and module tasks.
+func (r *RepoDB) GetByID(id int) (*entity.Course, error) {
+	var courseObj entity.Course
+	err := r.withModules(r.dbStorage).
+		Preload(_preloadModulesTasks, func(db *gorm.DB) *gorm.DB {
+			// preload only short task info
+			return db.Select(_fieldID, _fieldTitle, _fieldModuleID, _fieldPosition,
+				_fieldDraft, _fieldPubAt).Order(_orderByPosition)
+		}).
+		Where(id).First(&courseObj).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// course object with such id not found
+		return nil, fmt.Errorf("course with id: %w", course.ErrNotFound)
+	}
+	return &courseObj, err // err OR nil
+}
+
+// Update updates course by the given ID with the new data.
+func (r *RepoDB) Update(id int, newData *entity.CourseUpdate) error {
+	return r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.Course{}).
+			Where(_fieldID+" = ?", id).
+			Updates(newData.ToUpdatesMap()).Error
+		if err != nil {
+			return fmt.Errorf("course: %w", err)
+		}
+		// skip updating classes if new list is not given
+		if newData.NewFullClasses == nil {
+			return nil
+		}
+		err = tx.Where("course_id = ?", id).Delete(&entity.CourseClass{}).Error
+		if err != nil {
+			return fmt.Errorf("delete classes: %w", err)
+		}
+		return r.linkClasses(tx, id, newData.NewFullClasses)
+	})
+}
+
+// Delete deletes course with its modules by the given ID
+// (module tasks are kept without module).
+func (r *RepoDB) Delete(id int) error {
+	return r.dbStorage.Delete(&entity.Course{}, id).Error
+}
+
+// GetMany returns all teacher courses with classes and modules.
+// Search param appends condition to filter courses by title (substring).
+func (r *RepoDB) GetMany(teacherID int, search string,
+	page *entity.Pagination) ([]entity.Course, error) {
+
+	courses := make([]entity.Course, 0)
+	query := r.withModules(r.dbStorage.Model(&entity.Course{})).
+		Where("teacher_id = ?", teacherID)
+	if search != "" {
+		query = query.Where("title REGEXP ?", search)
+	}
+	query = query.Order(_orderByIDDESC)
+	// apply pagination if it's not nil
+	if page != nil {
+		page.CountTotal(query)
+		query = page.Query(query)
+	}
+	// exec query
+	if err := query.Find(&courses).Error; err != nil {
+		return nil, err
+	}
+	return courses, nil
+}
+
+// CreateModule creates a new module at the end of the course.
+func (r *RepoDB) CreateModule(moduleObj *entity.CourseModule) error {
+	return r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		var lastPos int
+		err := tx.Model(&entity.CourseModule{}).
+			Select("COALESCE(MAX(position), 0)").
+			Where("course_id = ?", moduleObj.CourseID).
+			Scan(&lastPos).Error
+		if err != nil {
+			return fmt.Errorf("get last position: %w", err)
+		}
+		moduleObj.Position = lastPos + 1
+		err = tx.Create(moduleObj).Error
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			// course with given ID is not found
+			return fmt.Errorf("module: course: %w", course.ErrNotFound)
+		}
+		return err // err OR nil
+	})
+}
+
+// GetModule returns course module by the given ID.
+func (r *RepoDB) GetModule(id int) (*entity.CourseModule, error) {
+	var moduleObj entity.CourseModule
+	err := r.dbStorage.Where(id).First(&moduleObj).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// module object with such id not found
+		return nil, fmt.Errorf("module with id: %w", course.ErrNotFoundMod)
+	}
+	return &moduleObj, err // err OR nil
+}
+
+// UpdateModule sets the new title of the module by the given ID.
+func (r *RepoDB) UpdateModule(id int, title string) error {
+	return r.dbStorage.Model(&entity.CourseModule{}).
+		Where(_fieldID+" = ?", id).
+		Update(_fieldTitle, title).Error // err OR nil
+}
+
+// DeleteModule deletes module by the given ID (module tasks are kept without module).
+func (r *RepoDB) DeleteModule(id int) error {
+	return r.dbStorage.Delete(&entity.CourseModule{}, id).Error
+}
+
+// SetModuleOrder sets positions of the course modules in the order of the given IDs.
+func (r *RepoDB) SetModuleOrder(courseID int, moduleIDs []int) error {
+	return r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		for idx, moduleID := range moduleIDs {
+			err := tx.Model(&entity.CourseModule{}).
+				Where("id = ? AND course_id = ?", moduleID, courseID).
+				Update(_fieldPosition, idx+1).Error
+			if err != nil {
+				return fmt.Errorf("module %d: %w", moduleID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// SetModuleTasks replaces tasks of the module with the given teacher tasks
+// (positions are set in the order of the given IDs).
+func (r *RepoDB) SetModuleTasks(teacherID, moduleID int, taskIDs []int) error {
+	return r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		// check that all tasks belong to the teacher
+		if len(taskIDs) > 0 {
+			var count int64
+			err := tx.Model(&entity.Task{}).
+				Where("id IN ? AND teacher_id = ?", taskIDs, teacherID).
+				Count(&count).Error
+			if err != nil {
+				return fmt.Errorf("count teacher tasks: %w", err)
+			}
+			if int(count) != len(taskIDs) {
+				return fmt.Errorf("%w: task not found or teacher is not its owner",
+					course.ErrInvalidTask)
+			}
+		}
+
+		// unlink tasks missing in the new list
+		query := tx.Model(&entity.Task{}).Where("module_id = ?", moduleID)
+		if len(taskIDs) > 0 {
+			query = query.Where("id NOT IN ?", taskIDs)
+		}
+		err := query.Updates(map[string]any{_fieldModuleID: nil, _fieldPosition: 0}).Error
+		if err != nil {
+			return fmt.Errorf("unlink tasks: %w", err)
+		}
+		// link tasks in the given order
+		for idx, taskID := range taskIDs {
+			err := tx.Model(&entity.Task{}).
+				Where(_fieldID+" = ?", taskID).
+				Updates(map[string]any{_fieldModuleID: moduleID, _fieldPosition: idx + 1}).Error
+			if err != nil {
+				return fmt.Errorf("link task %d: %w", taskID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetManyForStudent returns courses linked to the student class or containing
+// published tasks with the student solutions (with modules).
+func (r *RepoDB) GetManyForStudent(studentID int) ([]entity.Course, error) {
+	// courses linked to the student class
+	byClass := r.dbStorage.Model(&entity.CourseClass{}).
+		Select("course_class.course_id").
+		Joins("INNER JOIN user ON user.class_id = course_class.class_id").
+		Where("user.id = ?", studentID)
+	// courses with the student solutions
+	bySolution := r.dbStorage.Model(&entity.CourseModule{}).
+		Select("course_module.course_id").
+		Joins("INNER JOIN task ON task.module_id = course_module.id").
+		Joins("INNER JOIN solution ON solution.task_id = task.id").
+		Joins("INNER JOIN solution_member ON solution_member.solution_id = solution.id").
+		Where("solution_member.student_id = ?", studentID).
+		Where("task.draft = ?", false)
+
+	courses := make([]entity.Course, 0)
+	err := r.dbStorage.
+		Preload(_preloadModules, func(db *gorm.DB) *gorm.DB {
+			return db.Order(_orderByPosition)
+		}).
+		Where("id IN (?) OR id IN (?)", byClass, bySolution).
+		Order(_orderByIDDESC).
+		Find(&courses).Error
+	if err != nil {
+		return nil, err
+	}
+	return courses, nil
+}
+
+// GetStudentSolutions returns the student solutions (with tasks and statuses)
+// of the published tasks of the given course modules.
+// Individual grades of the student replace the grades of team solutions.
+func (r *RepoDB) GetStudentSolutions(studentID int, moduleIDs []int) ([]entity.Solution, error) {
+	solutions := make([]entity.Solution, 0)
+	if len(moduleIDs) == 0 {
+		return solutions, nil
+	}
+	err := r.dbStorage.Model(&entity.Solution{}).
+		// select all apart of answer, the individual grade replaces the solution one
+		Select("solution.id", "solution.task_id", "solution.student_id", "solution.status_id",
+			"solution.updated_at", "COALESCE(solution_member.grade, solution.grade) AS grade").
+		Preload(_preloadTask, func(db *gorm.DB) *gorm.DB {
+			// preload only short task info
+			return db.Select(_fieldID, _fieldTitle, _fieldModuleID, _fieldPosition)
+		}).
+		Preload(_preloadStatus).
+		Joins("INNER JOIN task ON task.id = solution.task_id").
+		Joins("INNER JOIN solution_member ON solution_member.solution_id = solution.id").
+		Where("solution_member.student_id = ?", studentID).
+		Where("task.module_id IN ?", moduleIDs).
+		Where("task.draft = ?", false). // drafts are not visible for students
+		Order("task.position, task.id").
+		Find(&solutions).Error
+	if err != nil {
+		return nil, err
+	}
+	return solutions, nil
+}
+
+// withModules appends preloading of classes (IDs and names only) and modules to the query.
+func (r *RepoDB) withModules(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload(_preloadClasses, func(db *gorm.DB) *gorm.DB {
+			return db.Select(_fieldID, _fieldName) // preload only ID and name
+		}).
+		Preload(_preloadModules, func(db *gorm.DB) *gorm.DB {
+			return db.Order(_orderByPosition)
+		})
+}
+
+// linkClasses links the course to the given classes.
+func (r *RepoDB) linkClasses(tx *gorm.DB, courseID int, classIDs []int) error {
+	if len(classIDs) == 0 {
+		return nil
+	}
+	links := make([]entity.CourseClass, len(classIDs))
+	for idx, classID := range classIDs {
+		links[idx] = entity.CourseClass{CourseID: courseID, ClassID: classID}
+	}
+	err := tx.Create(links).Error
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		// class with given ID is not found
+		return fmt.Errorf("course: %w", course.ErrNotFoundClass)
+	}
+	if err != nil {
+		return fmt.Errorf("link classes: %w", err)
+	}
+	return nil
+}
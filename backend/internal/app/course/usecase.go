@@ -0,0 +1,43 @@
+// Package course contains all repos, usecases and controllers for courses and their modules.
+// Sub-package repo contains RepoDB implementation.
+// Sub-package usecase contains UsecaseTeacher and UsecaseStudent implementations.
+package course
+
+import "skadi/backend/internal/app/entity"
+
+// UsecaseTeacher describes all course usecases for teacher.
+type UsecaseTeacher interface {
+	// Create creates a new course of the teacher linked to the given classes.
+	Create(courseObj *entity.Course, classIDs []int) error
+	// GetByID returns own course by the given ID with classes, modules and module tasks.
+	GetByID(teacherID, courseID int) (*entity.Course, error)
+	// Update updates own course by the given ID with the new data.
+	// It returns the updated course object.
+	Update(teacherID, courseID int, newData *entity.CourseUpdate) (*entity.Course, error)
+	// DeleteByID deletes own course by the given ID (module tasks are kept).
+	DeleteByID(teacherID, courseID int) error
+	// GetMany returns all teacher courses with classes and modules.
+	// Search param appends condition to filter courses by title (substring).
+	GetMany(teacherID int, search string, page *entity.Pagination) ([]entity.Course, error)
+
+	// CreateModule creates a new module at the end of the own course.
+	CreateModule(teacherID int, moduleObj *entity.CourseModule) error
+	// UpdateModule sets the new title of the own course module.
+	// It returns the updated module object.
+	UpdateModule(teacherID, courseID, moduleID int, title string) (*entity.CourseModule, error)
+	// DeleteModule deletes the own course module (module tasks are kept).
+	DeleteModule(teacherID, courseID, moduleID int) error
+	// SetModuleOrder sets the order of the own course modules.
+	// All course modules must be given. It returns the updated course object.
+	SetModuleOrder(teacherID, courseID int, moduleIDs []int) (*entity.Course, error)
+	// SetModuleTasks replaces tasks of the own course module with the given own tasks
+	// in the given order. It returns the updated course object.
+	SetModuleTasks(teacherID, courseID, moduleID int, taskIDs []int) (*entity.Course, error)
+}
+
+// UsecaseStudent describes all course usecases for student.
+type UsecaseStudent interface {
+	// GetMany returns the student courses with modules, module solutions
+	// and progress computed from the solution statuses.
+	GetMany(studentID int) ([]entity.Course, error)
+}
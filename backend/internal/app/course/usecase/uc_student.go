@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"fmt"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/course"
+	"skadi/backend/internal/app/entity"
+)
+
+const (
+	_backlogStatusID = 1 // ID of solution status "backlog"
+	_inWorkStatusID  = 2 // ID of solution status "in work"
+	_readyStatusID   = 3 // ID of solution status "ready"
+	_checkedStatusID = 4 // ID of solution status "checked"
+)
+
+// Ensure UCStudent implements interfaces.
+var _ course.UsecaseStudent = (*UCStudent)(nil)
+
+// UCStudent represents a course usecase for student.
+// It implements the [course.UsecaseStudent] interface.
+type UCStudent struct {
+	cfg          *config.Config
+	courseRepoDB course.RepositoryDB
+}
+
+// NewUCStudent returns a new instance of [UCStudent].
+func NewUCStudent(cfg *config.Config, courseRepoDB course.RepositoryDB) *UCStudent {
+	return &UCStudent{
+		cfg:          cfg,
+		courseRepoDB: courseRepoDB,
+	}
+}
+
+// GetMany returns the student courses with modules, module solutions
+// and progress computed from the solution statuses.
+func (u *UCStudent) GetMany(studentID int) ([]entity.Course, error) {
+	courses, err := u.courseRepoDB.GetManyForStudent(studentID)
+	if err != nil {
+		return nil, fmt.Errorf("get courses: %w", err)
+	}
+	var moduleIDs []int
+	for idx := range courses {
+		for _, moduleObj := range courses[idx].Modules {
+			moduleIDs = append(moduleIDs, moduleObj.ID)
+		}
+	}
+	solutions, err := u.courseRepoDB.GetStudentSolutions(studentID, moduleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get solutions: %w", err)
+	}
+
+	// group solutions by modules (solutions are ordered by task position)
+	byModule := make(map[int][]entity.Solution, len(moduleIDs))
+	for _, solObj := range solutions {
+		if solObj.Task == nil || solObj.Task.ModuleID == nil {
+			continue
+		}
+		byModule[*solObj.Task.ModuleID] = append(byModule[*solObj.Task.ModuleID], solObj)
+	}
+	for idx := range courses {
+		var courseSolutions []entity.Solution
+		for modIdx := range courses[idx].Modules {
+			moduleObj := &courses[idx].Modules[modIdx]
+			moduleObj.Solutions = byModule[moduleObj.ID]
+			moduleObj.Progress = progress(moduleObj.Solutions)
+			courseSolutions = append(courseSolutions, moduleObj.Solutions...)
+		}
+		courses[idx].Progress = progress(courseSolutions)
+	}
+	return courses, nil
+}
+
+// progress computes the progress by the solution statuses.
+func progress(solutions []entity.Solution) *entity.CourseProgress {
+	progressObj := &entity.CourseProgress{Total: len(solutions)}
+	for _, solObj := range solutions {
+		switch solObj.StatusID {
+		case _backlogStatusID:
+			progressObj.Backlog++
+		case _inWorkStatusID:
+			progressObj.InWork++
+		case _readyStatusID:
+			progressObj.Ready++
+		case _checkedStatusID:
+			progressObj.Checked++
+		}
+	}
+	if progressObj.Total > 0 {
+		progressObj.Percent = progressObj.Checked * 100 / progressObj.Total
+	}
+	return progressObj
+}
@@ -0,0 +1,205 @@
+// Package usecase contains course.UsecaseTeacher and course.UsecaseStudent implementations.
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	goslices "slices"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/course"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/pkg/utils/slices"
+)
+
+// Ensure UCTeacher implements interfaces.
+var _ course.UsecaseTeacher = (*UCTeacher)(nil)
+
+// UCTeacher represents a course usecase for teacher.
+// It implements the [course.UsecaseTeacher] interface.
+type UCTeacher struct {
+	cfg          *config.Config
+	courseRepoDB course.RepositoryDB
+}
+
+// NewUCTeacher returns a new instance of [UCTeacher].
+func NewUCTeacher(cfg *config.Config, courseRepoDB course.RepositoryDB) *UCTeacher {
+	return &UCTeacher{
+		cfg:          cfg,
+		courseRepoDB: courseRepoDB,
+	}
+}
+
+// Create creates a new course of the teacher linked to the given classes.
+// Linked classes are set to the given course object.
+func (u *UCTeacher) Create(courseObj *entity.Course, classIDs []int) error {
+	// delete duplicates from list
+	classIDs = slices.DelDupls(classIDs)
+	if err := u.courseRepoDB.Create(courseObj, classIDs); err != nil {
+		return fmt.Errorf("create course: %w", err)
+	}
+	// get linked classes
+	createdCourse, err := u.courseRepoDB.GetByID(courseObj.ID)
+	if err != nil {
+		return fmt.Errorf("get created course: %w", err)
+	}
+	courseObj.Classes = createdCourse.Classes
+	return nil
+}
+
+// GetByID returns own course by the given ID with classes, modules and module tasks.
+func (u *UCTeacher) GetByID(teacherID, courseID int) (*entity.Course, error) {
+	return u.getOwnCourse(teacherID, courseID)
+}
+
+// Update updates own course by the given ID with the new data.
+// It returns the updated course object.
+func (u *UCTeacher) Update(teacherID, courseID int,
+	newData *entity.CourseUpdate) (*entity.Course, error) {
+
+	if _, err := u.getOwnCourse(teacherID, courseID); err != nil {
+		return nil, err
+	}
+	if newData.NewFullClasses != nil {
+		// delete duplicates from list
+		newData.NewFullClasses = slices.DelDupls(newData.NewFullClasses)
+	}
+	if err := u.courseRepoDB.Update(courseID, newData); err != nil {
+		return nil, fmt.Errorf("update: %w", err)
+	}
+	return u.courseRepoDB.GetByID(courseID)
+}
+
+// DeleteByID deletes own course by the given ID (module tasks are kept).
+func (u *UCTeacher) DeleteByID(teacherID, courseID int) error {
+	_, err := u.getOwnCourse(teacherID, courseID)
+	// return nil error if course was not found
+	if errors.Is(err, course.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return u.courseRepoDB.Delete(courseID)
+}
+
+// GetMany returns all teacher courses with classes and modules.
+// Search param appends condition to filter courses by title (substring).
+func (u *UCTeacher) GetMany(teacherID int, search string,
+	page *entity.Pagination) ([]entity.Course, error) {
+
+	return u.courseRepoDB.GetMany(teacherID, search, page)
+}
+
+// CreateModule creates a new module at the end of the own course.
+func (u *UCTeacher) CreateModule(teacherID int, moduleObj *entity.CourseModule) error {
+	if _, err := u.getOwnCourse(teacherID, moduleObj.CourseID); err != nil {
+		return err
+	}
+	if err := u.courseRepoDB.CreateModule(moduleObj); err != nil {
+		return fmt.Errorf("create module: %w", err)
+	}
+	return nil
+}
+
+// UpdateModule sets the new title of the own course module.
+// It returns the updated module object.
+func (u *UCTeacher) UpdateModule(teacherID, courseID, moduleID int,
+	title string) (*entity.CourseModule, error) {
+
+	moduleObj, err := u.getOwnModule(teacherID, courseID, moduleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.courseRepoDB.UpdateModule(moduleID, title); err != nil {
+		return nil, fmt.Errorf("update module: %w", err)
+	}
+	moduleObj.Title = title
+	return moduleObj, nil
+}
+
+// DeleteModule deletes the own course module (module tasks are kept).
+func (u *UCTeacher) DeleteModule(teacherID, courseID, moduleID int) error {
+	_, err := u.getOwnModule(teacherID, courseID, moduleID)
+	// return nil error if module was not found
+	if errors.Is(err, course.ErrNotFoundMod) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return u.courseRepoDB.DeleteModule(moduleID)
+}
+
+// SetModuleOrder sets the order of the own course modules.
+// All course modules must be given. It returns the updated course object.
+func (u *UCTeacher) SetModuleOrder(teacherID, courseID int,
+	moduleIDs []int) (*entity.Course, error) {
+
+	courseObj, err := u.getOwnCourse(teacherID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	// new order must contain every course module once
+	if len(moduleIDs) != len(courseObj.Modules) ||
+		len(slices.DelDupls(goslices.Clone(moduleIDs))) != len(moduleIDs) {
+		return nil, fmt.Errorf("%w: all course modules must be given once", course.ErrInvalidData)
+	}
+	for idx := range courseObj.Modules {
+		if !goslices.Contains(moduleIDs, courseObj.Modules[idx].ID) {
+			return nil, fmt.Errorf("%w: module %d is missing",
+				course.ErrInvalidData, courseObj.Modules[idx].ID)
+		}
+	}
+
+	if err := u.courseRepoDB.SetModuleOrder(courseID, moduleIDs); err != nil {
+		return nil, fmt.Errorf("set module order: %w", err)
+	}
+	return u.courseRepoDB.GetByID(courseID)
+}
+
+// SetModuleTasks replaces tasks of the own course module with the given own tasks
+// in the given order. It returns the updated course object.
+// Task can belong to one module only, so it is moved from its previous module.
+func (u *UCTeacher) SetModuleTasks(teacherID, courseID, moduleID int,
+	taskIDs []int) (*entity.Course, error) {
+
+	if _, err := u.getOwnModule(teacherID, courseID, moduleID); err != nil {
+		return nil, err
+	}
+	// delete duplicates from list (keep order)
+	taskIDs = slices.DelDuplsFunc(taskIDs, func(id int) int { return id })
+	if err := u.courseRepoDB.SetModuleTasks(teacherID, moduleID, taskIDs); err != nil {
+		return nil, fmt.Errorf("set module tasks: %w", err)
+	}
+	return u.courseRepoDB.GetByID(courseID)
+}
+
+// getOwnCourse returns course by the given ID if the teacher is its owner.
+func (u *UCTeacher) getOwnCourse(teacherID, courseID int) (*entity.Course, error) {
+	courseObj, err := u.courseRepoDB.GetByID(courseID)
+	if err != nil {
+		return nil, fmt.Errorf("get course: %w", err)
+	}
+	if teacherID != courseObj.TeacherID {
+		return nil, fmt.Errorf("%w: user is not a course owner", course.ErrForbidden)
+	}
+	return courseObj, nil
+}
+
+// getOwnModule returns module of the own course by the given IDs.
+func (u *UCTeacher) getOwnModule(teacherID, courseID,
+	moduleID int) (*entity.CourseModule, error) {
+
+	if _, err := u.getOwnCourse(teacherID, courseID); err != nil {
+		return nil, err
+	}
+	moduleObj, err := u.courseRepoDB.GetModule(moduleID)
+	if err != nil {
+		return nil, fmt.Errorf("get module: %w", err)
+	}
+	if moduleObj.CourseID != courseID {
+		return nil, fmt.Errorf("module of the course: %w", course.ErrNotFoundMod)
+	}
+	return moduleObj, nil
+}
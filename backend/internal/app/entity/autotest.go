@@ -0,0 +1,146 @@
+package entity
+
+import "time"
+
+// AutotestRunStatus represents a status of the solution autotest run.
+type AutotestRunStatus string
+
+var (
+	AutotestPending      AutotestRunStatus = "pending"       // run is waiting for the sandbox
+	AutotestRunning      AutotestRunStatus = "running"       // tests are running now
+	AutotestDone         AutotestRunStatus = "done"          // all tests were run
+	AutotestCompileError AutotestRunStatus = "compile_error" // solution was not compiled (tests were not run)
+	AutotestFailed       AutotestRunStatus = "failed"        // sandbox failed (solution is not guilty)
+)
+
+// AutotestStatus represents a verdict of the one test.
+type AutotestStatus string
+
+var (
+	AutotestOK           AutotestStatus = "ok"            // output matches the expected one
+	AutotestWrongAnswer  AutotestStatus = "wrong_answer"  // output differs from the expected one
+	AutotestTimeLimit    AutotestStatus = "time_limit"    // time limit exceeded
+	AutotestMemoryLimit  AutotestStatus = "memory_limit"  // memory limit exceeded
+	AutotestRuntimeError AutotestStatus = "runtime_error" // program exited with non-zero code
+)
+
+// AutotestSuite represents a test suite of the programming task.
+type AutotestSuite struct {
+	// task id
+	TaskID int `gorm:"primaryKey" json:"-"`
+	// programming language of the solutions (one of configured languages)
+	Lang string `json:"lang" validate:"required" example:"python"`
+	// last-update datetime of the suite
+	UpdatedAt time.Time `json:"updated_at" validate:"required"`
+
+	// test cases (ordered by position)
+	Cases []AutotestCase `gorm:"foreignKey:TaskID;references:TaskID" json:"cases" validate:"required"`
+}
+
+// TableName determines DB table name for the autotest suite object.
+func (*AutotestSuite) TableName() string {
+	return "autotest_suite"
+}
+
+// AutotestCase represents a stdin/stdout test case with time and memory limits.
+type AutotestCase struct {
+	// test case id
+	ID int `gorm:"primaryKey" json:"-"`
+	// task id
+	TaskID int `json:"-"`
+	// position of the test in the suite
+	Position int `json:"position" validate:"omitempty" example:"1"`
+	// program input
+	Stdin string `json:"stdin" validate:"omitempty" example:"2 3"`
+	// expected program output (trailing spaces and empty lines are ignored)
+	Expected string `json:"expected" validate:"omitempty" example:"5"`
+	// time limit in milliseconds
+	TimeLimitMS int `gorm:"column:time_limit_ms" json:"time_limit_ms" validate:"required" example:"1000"`
+	// memory limit in megabytes
+	MemoryLimitMB int `gorm:"column:memory_limit_mb" json:"memory_limit_mb" validate:"required" example:"64"`
+	// if true, input and output of the test are not shown to students
+	Hidden bool `json:"hidden" validate:"omitempty"`
+}
+
+// TableName determines DB table name for the autotest case object.
+func (*AutotestCase) TableName() string {
+	return "autotest_case"
+}
+
+// AutotestRun represents a run of the task test suite for the solution.
+// Only the last run of the solution is stored.
+type AutotestRun struct {
+	// run id
+	ID int `gorm:"primaryKey" json:"id" validate:"required" example:"31"`
+	// solution id
+	SolutionID int `json:"-"`
+	// run status
+	Status AutotestRunStatus `json:"status" validate:"required" example:"done"`
+	// number of passed tests
+	Passed int `json:"passed" validate:"omitempty" example:"4"`
+	// number of tests in the suite
+	Total int `json:"total" validate:"omitempty" example:"5"`
+	// suggested score (percentage of passed tests)
+	Score *int `json:"score,omitempty" validate:"omitempty" example:"80"`
+	// compiler output or sandbox error
+	Error *string `json:"error,omitempty" validate:"omitempty" example:"main.go:5:2: undefined: x"`
+	// datetime the run was enqueued
+	CreatedAt time.Time `json:"created_at" validate:"required"`
+	// datetime the run was started
+	StartedAt *time.Time `json:"started_at,omitempty" validate:"omitempty"`
+	// datetime the run was finished
+	FinishedAt *time.Time `json:"finished_at,omitempty" validate:"omitempty"`
+
+	// per-test results (ordered by position)
+	Results []AutotestResult `gorm:"foreignKey:RunID" json:"results,omitempty" validate:"omitempty"`
+}
+
+// TableName determines DB table name for the autotest run object.
+func (*AutotestRun) TableName() string {
+	return "autotest_run"
+}
+
+// HideSecrets removes input, output and sandbox errors of hidden tests (for students).
+func (r *AutotestRun) HideSecrets() {
+	if r.Status == AutotestFailed {
+		r.Error = nil
+	}
+	for idx := range r.Results {
+		if r.Results[idx].Hidden {
+			r.Results[idx].Stdin = nil
+			r.Results[idx].Expected = nil
+			r.Results[idx].Stdout = nil
+			r.Results[idx].Stderr = nil
+		}
+	}
+}
+
+// AutotestResult represents a result of the one test.
+// Test input and expected output are copied from the test case at the run time.
+type AutotestResult struct {
+	// result id
+	ID int `gorm:"primaryKey" json:"-"`
+	// run id
+	RunID int `json:"-"`
+	// position of the test in the suite
+	Position int `json:"position" validate:"omitempty" example:"1"`
+	// true if the test is hidden from students
+	Hidden bool `json:"hidden" validate:"omitempty"`
+	// test verdict
+	Status AutotestStatus `json:"status" validate:"required" example:"ok"`
+	// execution time in milliseconds
+	TimeMS int `gorm:"column:time_ms" json:"time_ms" validate:"omitempty" example:"42"`
+	// program input (it is null for hidden tests for students)
+	Stdin *string `json:"stdin,omitempty" validate:"omitempty" example:"2 3"`
+	// expected program output (it is null for hidden tests for students)
+	Expected *string `json:"expected,omitempty" validate:"omitempty" example:"5"`
+	// program output (truncated)
+	Stdout *string `json:"stdout,omitempty" validate:"omitempty" example:"5"`
+	// program error output (truncated)
+	Stderr *string `json:"stderr,omitempty" validate:"omitempty"`
+}
+
+// TableName determines DB table name for the autotest result object.
+func (*AutotestResult) TableName() string {
+	return "autotest_result"
+}
@@ -1,6 +1,10 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // Comment represents an info about solution comment.
 type Comment struct {
@@ -8,15 +12,111 @@ type Comment struct {
 	ID int `json:"id" validate:"required"`
 	// solution ID
 	SolutionID int `json:"-"`
+	// author user ID (it is null for old comments and comments of deleted users)
+	UserID *int `json:"-"`
+	// ID of the parent comment (for replies)
+	ParentID *int `json:"parent_id,omitempty" validate:"omitempty" example:"3"`
 	// role (student or teacher)
 	Role Role `json:"role" validate:"required"`
-	// message text
+	// message text (it is empty for deleted comments)
 	Message string `json:"message" validate:"required"`
+	// message text rendered from Markdown to the sanitized HTML (it is empty for deleted comments)
+	MessageHTML *string `gorm:"column:message_html" json:"message_html,omitempty" validate:"omitempty"`
 	// datetime the message was created
 	CreatedAt time.Time `json:"created_at" validate:"required"`
+	// datetime the message was edited last time
+	EditedAt *time.Time `json:"edited_at,omitempty" validate:"omitempty"`
+	// datetime the message was deleted
+	DeletedAt *time.Time `json:"deleted_at,omitempty" validate:"omitempty"`
+
+	// anchor file ID
+	AnchorFileID *int `json:"-"`
+	// anchor first line
+	AnchorLineFrom *int `json:"-"`
+	// anchor last line
+	AnchorLineTo *int `json:"-"`
+	// anchor page (for PDF documents)
+	AnchorPage *int `json:"-"`
+
+	// author short profile
+	Author     *Profile `gorm:"-" json:"author,omitempty" validate:"omitempty"`
+	AuthorUser *User    `gorm:"foreignKey:UserID;references:ID" json:"-"`
+	// fragment of the solution or task file the comment is pointing at
+	Anchor *CommentAnchor `gorm:"-" json:"anchor,omitempty" validate:"omitempty"`
+	// comment attachments
+	Files Files `gorm:"many2many:comment_file;foreignKey:ID;References:ID" json:"files,omitempty" validate:"omitempty"`
 }
 
 // TableName determines DB table name for the comment object.
 func (*Comment) TableName() string {
 	return "comment"
 }
+
+// BeforeCreate sets anchor fields before the comment is inserted to DB.
+func (c *Comment) BeforeCreate(*gorm.DB) error {
+	if c.Anchor != nil {
+		c.AnchorFileID = &c.Anchor.FileID
+		c.AnchorLineFrom = c.Anchor.LineFrom
+		c.AnchorLineTo = c.Anchor.LineTo
+		c.AnchorPage = c.Anchor.Page
+	}
+	return nil
+}
+
+// AfterFind sets author profile and anchor and hides content of the deleted comment
+// after the comment is selected from DB.
+func (c *Comment) AfterFind(*gorm.DB) error {
+	if c.AuthorUser != nil {
+		c.Author = c.AuthorUser.Profile
+	}
+	if c.AnchorFileID != nil {
+		c.Anchor = &CommentAnchor{
+			FileID:   *c.AnchorFileID,
+			LineFrom: c.AnchorLineFrom,
+			LineTo:   c.AnchorLineTo,
+			Page:     c.AnchorPage,
+		}
+	}
+	if c.IsDeleted() {
+		c.Message = ""
+		c.MessageHTML = nil
+		c.Anchor = nil
+		c.Files = nil
+	}
+	return nil
+}
+
+// IsDeleted returns true if the comment was deleted.
+// Deleted comments are kept to save replies thread.
+func (c *Comment) IsDeleted() bool {
+	return c.DeletedAt != nil
+}
+
+// IsAuthor returns true if the comment was created by the given user.
+func (c *Comment) IsAuthor(userID int) bool {
+	return c.UserID != nil && *c.UserID == userID
+}
+
+// CommentAnchor represents a fragment of the file the comment is pointing at:
+// a line range for text files or a page for PDF documents.
+type CommentAnchor struct {
+	// file ID
+	FileID int `json:"file_id" validate:"required" example:"5"`
+	// first line of the range (starting from 1)
+	LineFrom *int `json:"line_from,omitempty" validate:"omitempty" example:"42"`
+	// last line of the range (equals to the first line if it is not set)
+	LineTo *int `json:"line_to,omitempty" validate:"omitempty" example:"45"`
+	// page number (starting from 1)
+	Page *int `json:"page,omitempty" validate:"omitempty" example:"3"`
+}
+
+// IsValid returns true if the anchor has either correct line range or page.
+func (a *CommentAnchor) IsValid() bool {
+	if a.Page != nil {
+		return a.LineFrom == nil && a.LineTo == nil && *a.Page >= 1
+	}
+	if a.LineFrom == nil {
+		return a.LineTo == nil // anchor to the whole file
+	}
+	return *a.LineFrom >= 1 && (a.LineTo == nil || *a.LineTo >= *a.LineFrom)
+}
@@ -0,0 +1,107 @@
+package entity
+
+import "time"
+
+// Course represents a course of the teacher.
+// Course consists of ordered modules with ordered tasks and is linked to classes.
+type Course struct {
+	// course id
+	ID int `gorm:"primaryKey" json:"id" validate:"required" example:"3"`
+	// course title
+	Title string `json:"title" validate:"required" example:"Python для начинающих"`
+	// course description
+	Desc string `gorm:"column:description" json:"description,omitempty" validate:"omitempty" example:"Основы языка Python"`
+	// course teacher id
+	TeacherID int `json:"-"`
+	// datetime the course was created
+	CreatedAt time.Time `json:"created_at" validate:"required"`
+
+	// classes linked to the course
+	Classes []Class `gorm:"many2many:course_class" json:"classes,omitempty" validate:"omitempty"`
+	// course modules (ordered by position)
+	Modules []CourseModule `gorm:"foreignKey:CourseID" json:"modules,omitempty" validate:"omitempty"`
+	// progress of the student in the whole course (for student only)
+	Progress *CourseProgress `gorm:"-" json:"progress,omitempty" validate:"omitempty"`
+}
+
+// TableName determines DB table name for the course object.
+func (*Course) TableName() string {
+	return "course"
+}
+
+// CourseClass represents a link of the course to the class.
+type CourseClass struct {
+	// course id
+	CourseID int `gorm:"primaryKey"`
+	// class id
+	ClassID int `gorm:"primaryKey"`
+}
+
+// TableName determines DB table name for the course class object.
+func (*CourseClass) TableName() string {
+	return "course_class"
+}
+
+// CourseUpdate represents a data to update course.
+type CourseUpdate struct {
+	// new course title
+	Title *string
+	// new course description
+	Desc *string
+	// IDs of new classes to completely replace old classes
+	NewFullClasses []int
+}
+
+// ToUpdatesMap generates map to update course object.
+func (c *CourseUpdate) ToUpdatesMap() map[string]any {
+	updates := make(map[string]any)
+	// set new title
+	if c.Title != nil {
+		updates["title"] = *c.Title
+	}
+	// set new description
+	if c.Desc != nil {
+		updates["description"] = *c.Desc
+	}
+	return updates
+}
+
+// CourseModule represents a module of the course (ordered group of tasks).
+type CourseModule struct {
+	// module id
+	ID int `gorm:"primaryKey" json:"id" validate:"required" example:"7"`
+	// course id
+	CourseID int `json:"-"`
+	// module title
+	Title string `json:"title" validate:"required" example:"Циклы"`
+	// position of the module in the course
+	Position int `json:"position" validate:"omitempty" example:"1"`
+
+	// module tasks (ordered by position, for teacher only)
+	Tasks []Task `gorm:"foreignKey:ModuleID" json:"tasks,omitempty" validate:"omitempty"`
+	// student solutions of the module tasks (ordered by task position, for student only)
+	Solutions []Solution `gorm:"-" json:"solutions,omitempty" validate:"omitempty"`
+	// progress of the student in the module (for student only)
+	Progress *CourseProgress `gorm:"-" json:"progress,omitempty" validate:"omitempty"`
+}
+
+// TableName determines DB table name for the course module object.
+func (*CourseModule) TableName() string {
+	return "course_module"
+}
+
+// CourseProgress represents a progress of the student computed from the solution statuses.
+type CourseProgress struct {
+	// number of tasks
+	Total int `json:"total" validate:"required" example:"10"`
+	// number of tasks with status "backlog"
+	Backlog int `json:"backlog" validate:"required" example:"3"`
+	// number of tasks with status "in work"
+	InWork int `json:"in_work" validate:"required" example:"2"`
+	// number of tasks with status "ready"
+	Ready int `json:"ready" validate:"required" example:"1"`
+	// number of tasks with status "checked"
+	Checked int `json:"checked" validate:"required" example:"4"`
+	// percent of checked tasks
+	Percent int `json:"percent" validate:"required" example:"40"`
+}
@@ -0,0 +1,20 @@
+package entity
+
+// EventType represents a type of the real-time user event.
+type EventType string
+
+var (
+	EventCommentCreated  EventType = "comment.created"  // new comment under the user solution
+	EventSolutionUpdated EventType = "solution.updated" // solution status, grade or answer was changed
+	EventTaskAssigned    EventType = "task.assigned"    // new task was assigned to the student
+
+	EventNotificationCreated EventType = "notification.created" // new in-app notification for the user
+)
+
+// Event represents a real-time event pushed to the subscribed users.
+type Event struct {
+	// event type
+	Type EventType `json:"type" example:"comment.created"`
+	// event payload (comment, solution, task or notification object)
+	Data any `json:"data"`
+}
@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+// ExamAttempt represents a timed attempt of the student to solve the task in exam mode.
+type ExamAttempt struct {
+	// solution id
+	SolutionID int `gorm:"primaryKey" json:"solution_id" validate:"required" example:"14"`
+	// datetime the attempt was begun (null if it is not begun yet)
+	StartedAt *time.Time `json:"started_at,omitempty" validate:"omitempty"`
+	// extra minutes granted by the teacher
+	ExtraMinutes int `json:"extra_minutes" validate:"omitempty" example:"15"`
+	// datetime the attempt ends (solution cannot be changed after it)
+	Deadline *time.Time `gorm:"-" json:"deadline,omitempty" validate:"omitempty"`
+}
+
+// TableName determines DB table name for the exam attempt object.
+func (*ExamAttempt) TableName() string {
+	return "exam_attempt"
+}
+
+// EndsAt returns the datetime the begun attempt ends: its duration is limited
+// by the exam closing datetime, extra minutes are added after the limitation.
+func (a *ExamAttempt) EndsAt(taskObj *Task) time.Time {
+	if a.StartedAt == nil || taskObj.ExamDuration == nil {
+		return time.Time{}
+	}
+	endsAt := a.StartedAt.Add(time.Duration(*taskObj.ExamDuration) * time.Minute)
+	if taskObj.ExamClosesAt != nil && taskObj.ExamClosesAt.Before(endsAt) {
+		endsAt = *taskObj.ExamClosesAt
+	}
+	return endsAt.Add(time.Duration(a.ExtraMinutes) * time.Minute)
+}
+
+// SetDeadline sets the deadline of the begun attempt to serialize it.
+func (a *ExamAttempt) SetDeadline(taskObj *Task) {
+	if a.StartedAt == nil {
+		a.Deadline = nil
+		return
+	}
+	deadline := a.EndsAt(taskObj)
+	a.Deadline = &deadline
+}
@@ -1,11 +1,25 @@
 package entity
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"skadi/backend/internal/pkg/preview"
+)
+
+const (
+	_fileURL        = "/api/v1/file/%d"         // URL template to download the file
+	_filePreviewURL = "/api/v1/file/%d/preview" // URL template for the file preview
+	_fileRefPrefix  = "file:"                   // prefix of the file reference by ID in Markdown
 )
 
 // File represents a metadata for the file.
@@ -18,6 +32,10 @@ type File struct {
 	MimeType string `json:"mime_type" validate:"required"`
 	// file size in bytes
 	Size int64 `json:"size" validate:"required"`
+	// URL to the file preview (for images and PDF documents only)
+	PreviewURL string `gorm:"-" json:"preview_url,omitempty" validate:"omitempty"`
+	// antivirus scan status
+	ScanStatus ScanStatus `gorm:"default:not_scanned" json:"scan_status" validate:"required"`
 	// filepath
 	Path string `json:"-"`
 	// prefix for filepath
@@ -54,11 +72,53 @@ func FileWithPathPrefix(s string) FileOption {
 	}
 }
 
-// Remove removes the saved file from the file system.
+// AfterFind sets preview URL after the file is selected from DB.
+func (f *File) AfterFind(*gorm.DB) error {
+	f.setPreviewURL()
+	return nil
+}
+
+// AfterCreate sets preview URL after the file is inserted to DB.
+func (f *File) AfterCreate(*gorm.DB) error {
+	f.setPreviewURL()
+	return nil
+}
+
+// setPreviewURL sets preview URL if the preview can be made for the file.
+func (f *File) setPreviewURL() {
+	if f.ID != 0 && preview.Supported(f.MimeType) {
+		f.PreviewURL = fmt.Sprintf(_filePreviewURL, f.ID)
+	}
+}
+
+// ScanBlocked returns true if the file cannot be downloaded because of its scan status.
+// Infected files are always blocked. Files not scanned yet, waiting for scanning
+// and files with failed scanning are blocked only if scanning is enabled.
+func (f *File) ScanBlocked(scanEnabled bool) bool {
+	switch f.ScanStatus {
+	case ScanInfected:
+		return true
+	case ScanNotScanned, ScanPending, ScanFailed:
+		return scanEnabled
+	default:
+		return false
+	}
+}
+
+// PreviewPath returns a path to the file preview.
+func (f *File) PreviewPath() string {
+	return preview.Path(f.Path)
+}
+
+// Remove removes the saved file (with its preview) from the file system.
 func (f *File) Remove() {
 	if err := os.Remove(f.Path); err != nil {
 		slog.Warn("remove file %s: %w", f.Path, err)
 	}
+	// preview may not exist
+	if err := os.Remove(f.PreviewPath()); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		slog.Warn("remove file preview", "path", f.PreviewPath(), "error", err)
+	}
 	slog.Info("remove file",
 		"filename", f.Name,
 		"mime-type", f.MimeType,
@@ -66,9 +126,40 @@ func (f *File) Remove() {
 		"path", f.Path)
 }
 
+// FileLink represents a signed short-lived link to download the file without auth.
+type FileLink struct {
+	// link URL
+	URL string `json:"url" validate:"required" example:"/api/v1/shared-file/2?exp=1767225600&sig=3a7bd3e2"`
+	// link expiration datetime
+	ExpiresAt time.Time `json:"expires_at" validate:"required"`
+}
+
 // Files represents a slice of File objects.
 type Files []*File
 
+// Downloadable returns files which are not blocked because of their scan status.
+func (f Files) Downloadable(scanEnabled bool) Files {
+	res := make(Files, 0, len(f))
+	for _, fileObj := range f {
+		if !fileObj.ScanBlocked(scanEnabled) {
+			res = append(res, fileObj)
+		}
+	}
+	return res
+}
+
+// ResolveImage returns download URL of the file referenced by the Markdown image destination.
+// File is referenced by its name (e.g. "scheme.png") or by its ID (e.g. "file:12").
+// It returns false if there is no such file in the list.
+func (f Files) ResolveImage(dest string) (string, bool) {
+	for _, fileObj := range f {
+		if fileObj.Name == dest || dest == _fileRefPrefix+strconv.Itoa(fileObj.ID) {
+			return fmt.Sprintf(_fileURL, fileObj.ID), true
+		}
+	}
+	return "", false
+}
+
 // Cleanup removes all files.
 func (f Files) Cleanup() {
 	for idx := range f {
@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// names of the scheduled background jobs
+const (
+	JobMailDigest       = "mail_digest"       // enqueue daily e-mail digests
+	JobParentSummary    = "parent_summary"    // enqueue weekly e-mail summaries for parents
+	JobUploadCleanup    = "upload_cleanup"    // delete expired uploads with their staging files
+	JobHistoryCleanup   = "history_cleanup"   // delete old job run history
+	JobTaskPublish      = "task_publish"      // publish draft tasks on their publication datetime
+	JobMarkdownRender   = "markdown_render"   // render Markdown of old tasks and comments to HTML
+	JobExamReminder     = "exam_reminder"     // remind students of exams closing soon
+	JobDeadlineReminder = "deadline_reminder" // remind students of task deadlines coming soon
+)
+
+// Job represents a scheduled background job.
+type Job struct {
+	// job name
+	Name string `json:"name" validate:"required" example:"mail_digest"`
+	// cron expression of the job schedule (UTC)
+	Schedule string `json:"schedule" validate:"required" example:"0 18 * * *"`
+	// datetime of the next run
+	NextRunAt *time.Time `json:"next_run_at,omitempty" validate:"omitempty"`
+	// last run of the job
+	LastRun *JobRun `json:"last_run,omitempty" validate:"omitempty"`
+}
+
+// JobRun represents a run of the scheduled background job (history record).
+type JobRun struct {
+	// run ID
+	ID int `json:"id" validate:"required" example:"120"`
+	// job name
+	Job string `json:"job" validate:"required" example:"mail_digest"`
+	// backend instance (hostname) the job was run on
+	Instance string `json:"instance" validate:"required" example:"backend-7f9c6d"`
+	// datetime the job was scheduled to
+	ScheduledAt time.Time `json:"scheduled_at" validate:"required"`
+	// datetime the run was started
+	StartedAt time.Time `json:"started_at" validate:"required"`
+	// datetime the run was finished (it is null for running jobs)
+	FinishedAt *time.Time `json:"finished_at,omitempty" validate:"omitempty"`
+	// error of the failed run
+	Error *string `json:"error,omitempty" validate:"omitempty" example:"get recipients: connection refused"`
+}
+
+// TableName determines DB table name for the job run object.
+func (*JobRun) TableName() string {
+	return "job_run"
+}
@@ -0,0 +1,70 @@
+package entity
+
+import "time"
+
+// MailMode represents a mode of e-mail notifications for the user.
+type MailMode string
+
+var (
+	MailOff       MailMode = "off"       // user does not get e-mails
+	MailImmediate MailMode = "immediate" // every notification is sent at once
+	MailDaily     MailMode = "daily"     // notifications are collected to the daily digest
+)
+
+// Mail represents an e-mail in the durable send queue.
+type Mail struct {
+	// mail ID
+	ID int
+	// recipient address
+	Recipient string
+	// mail subject
+	Subject string
+	// mail HTML-body
+	Body string
+	// number of failed send attempts
+	Attempts int
+	// error of the last failed attempt
+	LastError *string
+	// datetime of the next send attempt
+	NextAttemptAt time.Time
+	// datetime the mail was sent (it is null for unsent mails)
+	SentAt *time.Time
+	// datetime the mail was enqueued
+	CreatedAt time.Time
+}
+
+// TableName determines DB table name for the mail object.
+func (*Mail) TableName() string {
+	return "mail_queue"
+}
+
+// MailPref represents user preferences for e-mails.
+type MailPref struct {
+	// user ID
+	UserID int `gorm:"primaryKey" json:"-"`
+	// e-mail notifications mode
+	Mode MailMode `gorm:"default:daily" json:"mode" validate:"required" example:"daily"`
+	// if true, weekly progress summary is sent to the parent contact (for students)
+	ParentSummary bool `json:"parent_summary" validate:"omitempty"`
+	// datetime of the last daily digest
+	DigestSentAt *time.Time `json:"-"`
+	// datetime of the last weekly parent summary
+	SummarySentAt *time.Time `json:"-"`
+}
+
+// TableName determines DB table name for the mail preferences object.
+func (*MailPref) TableName() string {
+	return "mail_pref"
+}
+
+// MailRecipient represents a user with the e-mail address to send mails.
+type MailRecipient struct {
+	// user ID
+	UserID int
+	// user full name
+	Fullname string
+	// e-mail address (user or parent contact)
+	Email string
+	// datetime of the last digest or summary
+	LastSentAt *time.Time
+}
@@ -0,0 +1,65 @@
+package entity
+
+import "time"
+
+// NotificationType represents a type of the in-app notification.
+type NotificationType string
+
+var (
+	NotifyTaskAssigned        NotificationType = "task_assigned"        // new task was assigned to the student
+	NotifySolutionSubmitted   NotificationType = "solution_submitted"   // student sent solution to review
+	NotifySolutionChecked     NotificationType = "solution_checked"     // teacher checked or graded solution
+	NotifyNewComment          NotificationType = "new_comment"          // new comment under the solution
+	NotifyDeadlineApproaching NotificationType = "deadline_approaching" // task deadline is coming soon
+)
+
+// NotificationTypes is a list of all notification types.
+var NotificationTypes = []NotificationType{
+	NotifyTaskAssigned,
+	NotifySolutionSubmitted,
+	NotifySolutionChecked,
+	NotifyNewComment,
+	NotifyDeadlineApproaching,
+}
+
+// Notification represents an in-app notification for the user.
+type Notification struct {
+	// notification ID
+	ID int `json:"id" validate:"required" example:"12"`
+	// recipient user ID
+	UserID int `json:"-"`
+	// notification type
+	Type NotificationType `json:"type" validate:"required" example:"new_comment"`
+	// notification text
+	Message string `json:"message" validate:"required" example:"Новый комментарий к решению задания «Циклы»"`
+	// related task ID
+	TaskID *int `json:"task_id,omitempty" validate:"omitempty" example:"3"`
+	// related solution ID
+	SolutionID *int `json:"solution_id,omitempty" validate:"omitempty" example:"5"`
+	// related comment ID
+	CommentID *int `json:"comment_id,omitempty" validate:"omitempty" example:"8"`
+	// datetime the notification was created
+	CreatedAt time.Time `json:"created_at" validate:"required"`
+	// datetime the notification was read (it is null for unread notifications)
+	ReadAt *time.Time `json:"read_at,omitempty" validate:"omitempty"`
+}
+
+// TableName determines DB table name for the notification object.
+func (*Notification) TableName() string {
+	return "notification"
+}
+
+// NotificationPref represents a user preference for the notification type.
+type NotificationPref struct {
+	// user ID
+	UserID int `gorm:"primaryKey" json:"-"`
+	// notification type
+	Type NotificationType `gorm:"primaryKey" json:"type" validate:"required" example:"new_comment"`
+	// false if user does not want to get notifications of this type
+	Enabled bool `json:"enabled" validate:"omitempty"`
+}
+
+// TableName determines DB table name for the notification preference object.
+func (*NotificationPref) TableName() string {
+	return "notification_pref"
+}
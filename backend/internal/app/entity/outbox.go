@@ -0,0 +1,146 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OutboxTopic represents a type of the domain event written to the outbox.
+type OutboxTopic string
+
+var (
+	TopicTaskCreated         OutboxTopic = "task.created"          // task with solutions was created
+	TopicTaskPublished       OutboxTopic = "task.published"        // draft task was published
+	TopicTaskExamClosing     OutboxTopic = "task.exam_closing"     // task exam closes soon
+	TopicTaskDeadline        OutboxTopic = "task.deadline"         // task deadline is coming soon
+	TopicSolutionUpdated     OutboxTopic = "solution.updated"      // solution was updated
+	TopicClassMembersChanged OutboxTopic = "class.members_changed" // students joined or left the class
+)
+
+// OutboxEvent represents a domain event written to the outbox
+// in the same transaction with the data changes.
+type OutboxEvent struct {
+	// event ID
+	ID int
+	// event type
+	Topic OutboxTopic
+	// JSON-encoded event data
+	Payload string
+	// number of failed dispatch attempts
+	Attempts int
+	// error of the last failed attempt
+	LastError *string
+	// datetime of the next attempt
+	NextAttemptAt time.Time
+	// datetime the event was dispatched to all subscribers
+	DispatchedAt *time.Time
+	// datetime the event was created
+	CreatedAt time.Time
+}
+
+// TableName determines DB table name for the outbox event object.
+func (*OutboxEvent) TableName() string {
+	return "outbox"
+}
+
+// NewOutboxEvent returns a new outbox event with the JSON-encoded data.
+func NewOutboxEvent(topic OutboxTopic, data any) (*OutboxEvent, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &OutboxEvent{
+		Topic:         topic,
+		Payload:       string(payload),
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+// NewClassMembersEvent returns a new class.members_changed outbox event.
+// It returns nil event if both student lists are empty (there is nothing to write).
+func NewClassMembersEvent(classID int, joined, left []int) (*OutboxEvent, error) {
+	if len(joined) == 0 && len(left) == 0 {
+		return nil, nil
+	}
+	return NewOutboxEvent(TopicClassMembersChanged, &ClassMembersChangedEvent{
+		ClassID: classID,
+		Joined:  joined,
+		Left:    left,
+	})
+}
+
+// OutboxHandled represents a successful handling of the outbox event by the subscribed handler.
+// Handled events are not passed to the handler again if another handler failed.
+type OutboxHandled struct {
+	// event ID
+	EventID int `gorm:"primaryKey"`
+	// handler name
+	Handler string `gorm:"primaryKey"`
+	// datetime the event was handled
+	HandledAt time.Time
+}
+
+// TableName determines DB table name for the outbox handling object.
+func (*OutboxHandled) TableName() string {
+	return "outbox_handled"
+}
+
+// Decode decodes the event data to the given object.
+func (e *OutboxEvent) Decode(data any) error {
+	return json.Unmarshal([]byte(e.Payload), data)
+}
+
+// TaskCreatedEvent represents a data of the task.created event.
+type TaskCreatedEvent struct {
+	TaskID     int    `json:"task_id"`
+	TeacherID  int    `json:"teacher_id"`
+	Title      string `json:"title"`
+	StudentIDs []int  `json:"student_ids"`
+}
+
+// TaskPublishedEvent represents a data of the task.published event.
+type TaskPublishedEvent struct {
+	TaskID     int   `json:"task_id"`
+	TeacherID  int   `json:"teacher_id"`
+	StudentIDs []int `json:"student_ids"`
+}
+
+// TaskExamClosingEvent represents a data of the task.exam_closing event.
+type TaskExamClosingEvent struct {
+	TaskID     int   `json:"task_id"`
+	StudentIDs []int `json:"student_ids"`
+}
+
+// TaskDeadlineEvent represents a data of the task.deadline event.
+type TaskDeadlineEvent struct {
+	TaskID     int   `json:"task_id"`
+	StudentIDs []int `json:"student_ids"`
+}
+
+// SolutionUpdatedEvent represents a data of the solution.updated event.
+type SolutionUpdatedEvent struct {
+	SolutionID  int     `json:"solution_id"`
+	TaskID      int     `json:"task_id"`
+	StudentID   int     `json:"student_id"`
+	StatusID    int     `json:"status_id"`
+	OldStatusID int     `json:"old_status_id"`
+	Grade       *string `json:"grade"`
+	// IDs of all students sharing the solution
+	StudentIDs []int `json:"student_ids"`
+	// true if the grade was set by this update
+	Graded bool `json:"graded"`
+}
+
+// ClassMembersChangedEvent represents a data of the class.members_changed event.
+type ClassMembersChangedEvent struct {
+	ClassID int `json:"class_id"`
+	// IDs of students joined the class
+	Joined []int `json:"joined"`
+	// IDs of students left the class
+	Left []int `json:"left"`
+	// IDs of the class tasks, set if the class was deleted
+	// (the task links of the class are deleted with it)
+	TaskIDs []int `json:"task_ids,omitempty"`
+}
@@ -0,0 +1,139 @@
+package entity
+
+import (
+	"time"
+
+	"skadi/backend/internal/pkg/winnow"
+)
+
+// PlagiarismStatus represents a status of the task plagiarism check.
+type PlagiarismStatus string
+
+var (
+	PlagiarismPending PlagiarismStatus = "pending" // check is waiting for the analyzer
+	PlagiarismRunning PlagiarismStatus = "running" // solutions are compared now
+	PlagiarismDone    PlagiarismStatus = "done"    // all solutions were compared
+	PlagiarismFailed  PlagiarismStatus = "failed"  // check failed
+)
+
+// PlagiarismCheck represents an on-demand check of all task solutions for plagiarism.
+// Only the last check of the task is stored.
+type PlagiarismCheck struct {
+	// task id
+	TaskID int `gorm:"primaryKey" json:"-"`
+	// check status
+	Status PlagiarismStatus `json:"status" validate:"required" example:"done"`
+	// error of the failed check
+	Error *string `json:"error,omitempty" validate:"omitempty"`
+	// datetime the check was requested
+	RequestedAt time.Time `json:"requested_at" validate:"required"`
+	// datetime the check was started
+	StartedAt *time.Time `json:"started_at,omitempty" validate:"omitempty"`
+	// datetime the check was finished
+	FinishedAt *time.Time `json:"finished_at,omitempty" validate:"omitempty"`
+}
+
+// TableName determines DB table name for the plagiarism check object.
+func (*PlagiarismCheck) TableName() string {
+	return "plagiarism_check"
+}
+
+// PlagiarismSubmit represents a queued comparison of the solution sent for review
+// with other solutions of the task.
+type PlagiarismSubmit struct {
+	// solution id
+	SolutionID int `gorm:"primaryKey"`
+	// solution task id
+	TaskID int
+	// datetime the solution was sent for review
+	RequestedAt time.Time
+	// datetime the comparison was started (null if it is waiting for the analyzer)
+	StartedAt *time.Time
+}
+
+// TableName determines DB table name for the plagiarism submit object.
+func (*PlagiarismSubmit) TableName() string {
+	return "plagiarism_submit"
+}
+
+// PlagiarismPrint represents cached fingerprints of the solution documents
+// (the text answer and the text files). Fingerprints of the task are not excluded.
+type PlagiarismPrint struct {
+	// solution id
+	SolutionID int `gorm:"primaryKey"`
+	// hash of the fingerprinted solution content and fingerprinting params
+	ContentKey string
+	// fingerprinted documents
+	Docs []PlagiarismDoc `gorm:"serializer:json"`
+	// datetime the solution was fingerprinted
+	CreatedAt time.Time
+}
+
+// TableName determines DB table name for the plagiarism print object.
+func (*PlagiarismPrint) TableName() string {
+	return "plagiarism_print"
+}
+
+// PlagiarismDoc represents fingerprints of one solution document.
+type PlagiarismDoc struct {
+	// solution file id (0 for the text answer)
+	FileID int `json:"file_id"`
+	// fingerprints of the document text
+	Fingerprints []winnow.Fingerprint `json:"fingerprints"`
+}
+
+// PlagiarismPair represents a pair of suspiciously similar solutions of the task.
+type PlagiarismPair struct {
+	// pair id
+	ID int `gorm:"primaryKey" json:"id" validate:"required" example:"12"`
+	// task id
+	TaskID int `json:"-"`
+	// solution id (the less one of the pair)
+	SolutionID int `json:"solution_id" validate:"required" example:"31"`
+	// id of the other solution
+	OtherSolutionID int `json:"other_solution_id" validate:"required" example:"35"`
+	// similarity of the solutions (percentage of the matching fingerprints of the smaller solution)
+	Similarity int `json:"similarity" validate:"required" example:"87"`
+	// matching fragments of the solutions (the longest ones)
+	Fragments []PlagiarismFragment `gorm:"serializer:json" json:"fragments" validate:"required"`
+	// datetime the solutions were compared
+	CreatedAt time.Time `json:"created_at" validate:"required"`
+
+	// solution student
+	Student *Profile `gorm:"-" json:"student,omitempty" validate:"omitempty"`
+	// other solution student
+	OtherStudent *Profile `gorm:"-" json:"other_student,omitempty" validate:"omitempty"`
+}
+
+// TableName determines DB table name for the plagiarism pair object.
+func (*PlagiarismPair) TableName() string {
+	return "plagiarism_pair"
+}
+
+// PlagiarismFragment represents matching fragments of two solutions.
+type PlagiarismFragment struct {
+	// solution file name (empty for the text answer)
+	Source string `json:"source" validate:"omitempty" example:"main.py"`
+	// first line of the fragment (starting from 1)
+	StartLine int `json:"start_line" validate:"required" example:"3"`
+	// last line of the fragment
+	EndLine int `json:"end_line" validate:"required" example:"12"`
+	// fragment text
+	Text string `json:"text" validate:"required" example:"for _ in range(n):"`
+	// other solution file name (empty for the text answer)
+	OtherSource string `json:"other_source" validate:"omitempty" example:"solution.py"`
+	// first line of the other fragment (starting from 1)
+	OtherStartLine int `json:"other_start_line" validate:"required" example:"5"`
+	// last line of the other fragment
+	OtherEndLine int `json:"other_end_line" validate:"required" example:"14"`
+	// other fragment text
+	OtherText string `json:"other_text" validate:"required" example:"for i in range(n):"`
+}
+
+// PlagiarismReport represents suspicious solution pairs of the task (most similar first).
+type PlagiarismReport struct {
+	// last on-demand check (pairs of solutions sent for review are found without it)
+	Check *PlagiarismCheck `json:"check,omitempty" validate:"omitempty"`
+	// suspicious solution pairs
+	Pairs []PlagiarismPair `json:"pairs" validate:"required"`
+}
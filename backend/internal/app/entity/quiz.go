@@ -0,0 +1,234 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// QuizKind represents a kind of the quiz question.
+type QuizKind string
+
+var (
+	QuizSingle   QuizKind = "single"   // one correct option
+	QuizMultiple QuizKind = "multiple" // several correct options (all must be chosen)
+	QuizShort    QuizKind = "short"    // short text answer (exact or regex match)
+	QuizNumeric  QuizKind = "numeric"  // number answer with tolerance
+)
+
+// QuizQuestion represents a question of the quiz task with the answer key.
+type QuizQuestion struct {
+	// question id
+	ID int `gorm:"primaryKey" json:"id" validate:"required" example:"12"`
+	// task id
+	TaskID int `json:"-"`
+	// position of the question in the quiz
+	Position int `json:"position" validate:"omitempty" example:"1"`
+	// question kind
+	Kind QuizKind `json:"kind" validate:"required" example:"single"`
+	// question text
+	Text string `json:"text" validate:"required" example:"Сколько будет 2 + 2?"`
+	// max points for the correct answer
+	Points int `json:"points" validate:"required" example:"2"`
+	// answer options (for single and multiple choice)
+	Options []string `gorm:"serializer:json" json:"options,omitempty" validate:"omitempty" example:"3,4,5"`
+	// indexes of correct options starting from 0 (it is not shown to students)
+	Correct []int `gorm:"serializer:json" json:"correct,omitempty" validate:"omitempty" example:"1"`
+	// accepted short answers or regex patterns (it is not shown to students)
+	Answers []string `gorm:"serializer:json" json:"answers,omitempty" validate:"omitempty" example:"четыре"`
+	// if true, short answers are regex patterns matched with the whole answer (it is not shown to students)
+	Regex bool `json:"regex,omitempty" validate:"omitempty"`
+	// correct number (it is not shown to students)
+	Value *float64 `json:"value,omitempty" validate:"omitempty" example:"4"`
+	// allowed deviation from the correct number (it is not shown to students)
+	Tolerance float64 `json:"tolerance,omitempty" validate:"omitempty" example:"0.01"`
+}
+
+// TableName determines DB table name for the quiz question object.
+func (*QuizQuestion) TableName() string {
+	return "quiz_question"
+}
+
+// Validate returns an error if the answer key does not match the question kind.
+func (q *QuizQuestion) Validate() error {
+	switch q.Kind {
+	case QuizSingle, QuizMultiple:
+		if len(q.Options) < 2 {
+			return errors.New("at least two options are required")
+		}
+		if len(q.Correct) == 0 || q.Kind == QuizSingle && len(q.Correct) != 1 {
+			return errors.New("invalid number of correct options")
+		}
+		for _, idx := range q.Correct {
+			if idx < 0 || idx >= len(q.Options) {
+				return fmt.Errorf("correct option %d is out of range", idx)
+			}
+		}
+	case QuizShort:
+		if len(q.Answers) == 0 {
+			return errors.New("at least one accepted answer is required")
+		}
+		if !q.Regex {
+			return nil
+		}
+		for _, pattern := range q.Answers {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			}
+		}
+	case QuizNumeric:
+		if q.Value == nil {
+			return errors.New("correct number is required")
+		}
+		if q.Tolerance < 0 {
+			return errors.New("tolerance must not be negative")
+		}
+	default:
+		return fmt.Errorf("unknown question kind %q", q.Kind)
+	}
+	return nil
+}
+
+// CheckAnswer returns an error if the answer does not match the question kind.
+func (q *QuizQuestion) CheckAnswer(a *QuizAnswer) error {
+	switch q.Kind {
+	case QuizSingle, QuizMultiple:
+		if a.Text != nil || a.Number != nil {
+			return errors.New("choice is expected")
+		}
+		if q.Kind == QuizSingle && len(a.Choice) > 1 {
+			return errors.New("only one option can be chosen")
+		}
+		for _, idx := range a.Choice {
+			if idx < 0 || idx >= len(q.Options) {
+				return fmt.Errorf("option %d is out of range", idx)
+			}
+		}
+	case QuizShort:
+		if len(a.Choice) != 0 || a.Number != nil {
+			return errors.New("text is expected")
+		}
+	case QuizNumeric:
+		if len(a.Choice) != 0 || a.Text != nil {
+			return errors.New("number is expected")
+		}
+	}
+	return nil
+}
+
+// Grade returns points for the answer. Multiple choice answers are scored
+// all-or-nothing, short answers are compared case-insensitively
+// without extra spaces (if patterns are not regex).
+func (q *QuizQuestion) Grade(a *QuizAnswer) int {
+	correct := false
+	switch q.Kind {
+	case QuizSingle, QuizMultiple:
+		choice := slices.Clone(a.Choice)
+		slices.Sort(choice)
+		choice = slices.Compact(choice)
+		key := slices.Clone(q.Correct)
+		slices.Sort(key)
+		correct = slices.Equal(choice, key)
+	case QuizShort:
+		if a.Text == nil {
+			break
+		}
+		text := strings.Join(strings.Fields(*a.Text), " ")
+		correct = slices.ContainsFunc(q.Answers, func(answer string) bool {
+			if !q.Regex {
+				return strings.EqualFold(text, strings.Join(strings.Fields(answer), " "))
+			}
+			re, err := regexp.Compile("^(?:" + answer + ")$")
+			return err == nil && re.MatchString(text)
+		})
+	case QuizNumeric:
+		correct = a.Number != nil && q.Value != nil &&
+			math.Abs(*a.Number-*q.Value) <= q.Tolerance
+	}
+	if correct {
+		return q.Points
+	}
+	return 0
+}
+
+// HideKey removes the answer key of the question (for students).
+func (q *QuizQuestion) HideKey() {
+	q.Correct = nil
+	q.Answers = nil
+	q.Regex = false
+	q.Value = nil
+	q.Tolerance = 0
+}
+
+// QuizAnswer represents an answer of the student to the quiz question.
+type QuizAnswer struct {
+	// solution id
+	SolutionID int `gorm:"primaryKey" json:"-"`
+	// question id
+	QuestionID int `gorm:"primaryKey" json:"question_id" validate:"required" example:"12"`
+	// indexes of chosen options starting from 0 (for single and multiple choice)
+	Choice []int `gorm:"serializer:json" json:"choice,omitempty" validate:"omitempty" example:"1"`
+	// text answer (for short answer)
+	Text *string `json:"text,omitempty" validate:"omitempty" example:"четыре"`
+	// number answer (for numeric)
+	Number *float64 `json:"number,omitempty" validate:"omitempty" example:"4"`
+	// auto-graded points (null until the quiz is submitted)
+	Points *int `json:"points,omitempty" validate:"omitempty" example:"2"`
+	// points set by the teacher instead of auto-graded ones
+	OverridePoints *int `json:"override_points,omitempty" validate:"omitempty" example:"1"`
+	// last-update datetime of the answer
+	UpdatedAt time.Time `json:"-"`
+}
+
+// TableName determines DB table name for the quiz answer object.
+func (*QuizAnswer) TableName() string {
+	return "quiz_answer"
+}
+
+// Score returns points of the answer: overridden by the teacher or auto-graded ones.
+func (a *QuizAnswer) Score() int {
+	if a.OverridePoints != nil {
+		return *a.OverridePoints
+	}
+	if a.Points != nil {
+		return *a.Points
+	}
+	return 0
+}
+
+// Quiz represents questions of the quiz task with answers of the solution.
+type Quiz struct {
+	// questions (ordered by position)
+	Questions []QuizQuestion `json:"questions" validate:"required"`
+	// answers of the student
+	Answers []QuizAnswer `json:"answers" validate:"required"`
+	// true if the quiz was submitted and graded
+	Graded bool `json:"graded" validate:"omitempty"`
+	// sum of answer points (with teacher overrides)
+	Points int `json:"points" validate:"omitempty" example:"7"`
+	// sum of question points
+	MaxPoints int `json:"max_points" validate:"required" example:"10"`
+}
+
+// Summarize sets max points of the quiz and points of the graded answers.
+func (q *Quiz) Summarize() {
+	q.Points, q.MaxPoints = 0, 0
+	for idx := range q.Questions {
+		q.MaxPoints += q.Questions[idx].Points
+	}
+	for idx := range q.Answers {
+		q.Points += q.Answers[idx].Score()
+	}
+}
+
+// Grade returns the quiz grade as a percentage of max points (e.g. "85%").
+func (q *Quiz) Grade() string {
+	if q.MaxPoints == 0 {
+		return "0%"
+	}
+	return fmt.Sprintf("%d%%", q.Points*100/q.MaxPoints)
+}
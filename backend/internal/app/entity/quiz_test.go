@@ -0,0 +1,142 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestQuizQuestion_Grade(t *testing.T) {
+	t.Log("Grade answers of all question kinds")
+
+	single := QuizQuestion{Kind: QuizSingle, Points: 2, Options: []string{"3", "4", "5"},
+		Correct: []int{1}}
+	multiple := QuizQuestion{Kind: QuizMultiple, Points: 3, Options: []string{"a", "b", "c"},
+		Correct: []int{2, 0}}
+	short := QuizQuestion{Kind: QuizShort, Points: 1, Answers: []string{"Четыре", "four  times"}}
+	regex := QuizQuestion{Kind: QuizShort, Points: 1, Answers: []string{"4|four", `x\d+`},
+		Regex: true}
+	numeric := QuizQuestion{Kind: QuizNumeric, Points: 5, Value: ptr(3.14), Tolerance: 0.01}
+
+	tests := []struct {
+		name     string
+		question *QuizQuestion
+		answer   QuizAnswer
+		points   int
+	}{
+		{"single correct", &single, QuizAnswer{Choice: []int{1}}, 2},
+		{"single wrong", &single, QuizAnswer{Choice: []int{0}}, 0},
+		{"single empty", &single, QuizAnswer{}, 0},
+		{"multiple any order", &multiple, QuizAnswer{Choice: []int{0, 2}}, 3},
+		{"multiple duplicates", &multiple, QuizAnswer{Choice: []int{2, 0, 2}}, 3},
+		{"multiple partial", &multiple, QuizAnswer{Choice: []int{0}}, 0},
+		{"multiple extra", &multiple, QuizAnswer{Choice: []int{0, 1, 2}}, 0},
+		{"short other case", &short, QuizAnswer{Text: ptr("чЕТЫРЕ")}, 1},
+		{"short extra spaces", &short, QuizAnswer{Text: ptr("  Four \t times ")}, 1},
+		{"short wrong", &short, QuizAnswer{Text: ptr("три")}, 0},
+		{"short empty", &short, QuizAnswer{}, 0},
+		{"regex alternative", &regex, QuizAnswer{Text: ptr("four")}, 1},
+		{"regex whole answer", &regex, QuizAnswer{Text: ptr("x42")}, 1},
+		{"regex not anchored prefix", &regex, QuizAnswer{Text: ptr("44")}, 0},
+		{"regex not anchored suffix", &regex, QuizAnswer{Text: ptr("x42y")}, 0},
+		{"numeric exact", &numeric, QuizAnswer{Number: ptr(3.14)}, 5},
+		{"numeric in tolerance", &numeric, QuizAnswer{Number: ptr(3.149)}, 5},
+		{"numeric out of tolerance", &numeric, QuizAnswer{Number: ptr(3.16)}, 0},
+		{"numeric empty", &numeric, QuizAnswer{}, 0},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.points, tt.question.Grade(&tt.answer), tt.name)
+	}
+}
+
+func TestQuizQuestion_Validate(t *testing.T) {
+	t.Log("Validate answer keys of questions")
+
+	tests := []struct {
+		name     string
+		question QuizQuestion
+		valid    bool
+	}{
+		{"single", QuizQuestion{Kind: QuizSingle, Options: []string{"a", "b"},
+			Correct: []int{0}}, true},
+		{"single one option", QuizQuestion{Kind: QuizSingle, Options: []string{"a"},
+			Correct: []int{0}}, false},
+		{"single two correct", QuizQuestion{Kind: QuizSingle, Options: []string{"a", "b"},
+			Correct: []int{0, 1}}, false},
+		{"multiple", QuizQuestion{Kind: QuizMultiple, Options: []string{"a", "b"},
+			Correct: []int{0, 1}}, true},
+		{"multiple out of range", QuizQuestion{Kind: QuizMultiple, Options: []string{"a", "b"},
+			Correct: []int{2}}, false},
+		{"short", QuizQuestion{Kind: QuizShort, Answers: []string{"a"}}, true},
+		{"short no answers", QuizQuestion{Kind: QuizShort}, false},
+		{"regex invalid", QuizQuestion{Kind: QuizShort, Answers: []string{"a("}, Regex: true}, false},
+		{"numeric", QuizQuestion{Kind: QuizNumeric, Value: ptr(1.0)}, true},
+		{"numeric no value", QuizQuestion{Kind: QuizNumeric}, false},
+		{"numeric negative tolerance", QuizQuestion{Kind: QuizNumeric, Value: ptr(1.0),
+			Tolerance: -1}, false},
+		{"unknown kind", QuizQuestion{Kind: "essay"}, false},
+	}
+	for _, tt := range tests {
+		err := tt.question.Validate()
+		if tt.valid {
+			require.NoError(t, err, tt.name)
+		} else {
+			require.Error(t, err, tt.name)
+		}
+	}
+}
+
+func TestQuizQuestion_CheckAnswer(t *testing.T) {
+	t.Log("Check answers match question kinds")
+
+	single := QuizQuestion{Kind: QuizSingle, Options: []string{"a", "b"}}
+	short := QuizQuestion{Kind: QuizShort}
+	numeric := QuizQuestion{Kind: QuizNumeric}
+
+	tests := []struct {
+		name     string
+		question *QuizQuestion
+		answer   QuizAnswer
+		valid    bool
+	}{
+		{"choice", &single, QuizAnswer{Choice: []int{1}}, true},
+		{"two choices for single", &single, QuizAnswer{Choice: []int{0, 1}}, false},
+		{"choice out of range", &single, QuizAnswer{Choice: []int{2}}, false},
+		{"text for choice", &single, QuizAnswer{Text: ptr("a")}, false},
+		{"text", &short, QuizAnswer{Text: ptr("a")}, true},
+		{"number for text", &short, QuizAnswer{Number: ptr(1.0)}, false},
+		{"number", &numeric, QuizAnswer{Number: ptr(1.0)}, true},
+		{"choice for number", &numeric, QuizAnswer{Choice: []int{0}}, false},
+	}
+	for _, tt := range tests {
+		err := tt.question.CheckAnswer(&tt.answer)
+		if tt.valid {
+			require.NoError(t, err, tt.name)
+		} else {
+			require.Error(t, err, tt.name)
+		}
+	}
+}
+
+func TestQuiz_Grade(t *testing.T) {
+	t.Log("Sum answer points with teacher overrides and get the grade")
+
+	quiz := &Quiz{
+		Questions: []QuizQuestion{{Points: 2}, {Points: 3}, {Points: 1}},
+		Answers: []QuizAnswer{
+			{Points: ptr(2)},
+			{Points: ptr(0), OverridePoints: ptr(1)},
+			{},
+		},
+	}
+	quiz.Summarize()
+	require.Equal(t, 3, quiz.Points)
+	require.Equal(t, 6, quiz.MaxPoints)
+	require.Equal(t, "50%", quiz.Grade())
+
+	require.Equal(t, "0%", (&Quiz{}).Grade())
+}
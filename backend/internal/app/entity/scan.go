@@ -0,0 +1,12 @@
+package entity
+
+// ScanStatus represents a status of the file antivirus scanning.
+type ScanStatus string
+
+var (
+	ScanNotScanned ScanStatus = "not_scanned" // file was uploaded while scanning was disabled
+	ScanPending    ScanStatus = "pending"     // file is waiting for scanning
+	ScanClean      ScanStatus = "clean"       // no viruses were found
+	ScanInfected   ScanStatus = "infected"    // virus was found and file was moved to quarantine
+	ScanFailed     ScanStatus = "failed"      // scanning failed (clamd is unavailable etc)
+)
@@ -18,6 +18,10 @@ type Solution struct {
 	Answer *string `json:"answer,omitempty" validate:"omitempty"`
 	// last-update datetime of solution
 	UpdatedAt *time.Time `json:"updated_at,omitempty" validate:"omitempty"`
+	// number of comments from other users created since the last view (for lists only)
+	UnreadComments int `gorm:"-" json:"unread_comments" validate:"omitempty" example:"2"`
+	// true if the solution was updated or commented since the last view (for lists only)
+	HasUpdates bool `gorm:"-" json:"has_updates" validate:"omitempty"`
 
 	// task object
 	Task *Task `gorm:"foreignKey:TaskID;references:ID" json:"task,omitempty" validate:"required"`
@@ -28,6 +32,12 @@ type Solution struct {
 	Status *Status `gorm:"foreignKey:StatusID;references:ID" json:"status" validate:"required"`
 	// solution files
 	Files Files `gorm:"many2many:solution_file;foreignKey:ID;References:ID" json:"files,omitempty" validate:"omitempty"`
+	// last autotest run (without per-test results)
+	Autotest *AutotestRun `gorm:"foreignKey:SolutionID" json:"autotest,omitempty" validate:"omitempty"`
+	// exam attempt (for tasks in exam mode)
+	Attempt *ExamAttempt `gorm:"foreignKey:SolutionID" json:"attempt,omitempty" validate:"omitempty"`
+	// students sharing the solution (more than one for team solutions)
+	Members []SolutionMember `gorm:"foreignKey:SolutionID" json:"members,omitempty" validate:"omitempty"`
 }
 
 // TableName determines DB table name for the solution object.
@@ -35,6 +45,147 @@ func (*Solution) TableName() string {
 	return "solution"
 }
 
+// ExamOpen returns true if the student can change the solution at the given time:
+// the task is not in exam mode or the exam attempt is begun and not over.
+func (s *Solution) ExamOpen(now time.Time) bool {
+	if s.Task == nil || !s.Task.IsExam() {
+		return true
+	}
+	if !s.ExamBegun() {
+		return false
+	}
+	return now.Before(s.Attempt.EndsAt(s.Task))
+}
+
+// ExamBegun returns true if the student can read the task content: the task is not
+// in exam mode or the exam attempt is begun (it stays readable after the attempt is over).
+func (s *Solution) ExamBegun() bool {
+	if s.Task == nil || !s.Task.IsExam() {
+		return true
+	}
+	return s.Attempt != nil && s.Attempt.StartedAt != nil
+}
+
+// HideExamContent replaces the task with the copy without content
+// if the exam attempt is not begun (the source task is not changed).
+func (s *Solution) HideExamContent() {
+	if s.ExamBegun() {
+		return
+	}
+	taskObj := *s.Task
+	taskObj.HideContent()
+	s.Task = &taskObj
+}
+
+// IsMember returns true if the given student shares the solution.
+// The solution owner is the only member if members are not loaded.
+func (s *Solution) IsMember(studID int) bool {
+	if len(s.Members) == 0 {
+		return s.StudentID == studID
+	}
+	for _, member := range s.Members {
+		if member.StudentID == studID {
+			return true
+		}
+	}
+	return false
+}
+
+// SetMemberProfiles sets profiles of the solution members from the preloaded users.
+func (s *Solution) SetMemberProfiles() {
+	for idx := range s.Members {
+		if s.Members[idx].StudentUser != nil {
+			s.Members[idx].Student = s.Members[idx].StudentUser.Profile
+		}
+	}
+}
+
+// IsTeam returns true if the solution is shared by several students.
+func (s *Solution) IsTeam() bool {
+	return len(s.Members) > 1
+}
+
+// MemberIDs returns IDs of all students sharing the solution.
+// The solution owner is the only member if members are not loaded.
+func (s *Solution) MemberIDs() []int {
+	if len(s.Members) == 0 {
+		return []int{s.StudentID}
+	}
+	studentIDs := make([]int, len(s.Members))
+	for idx := range s.Members {
+		studentIDs[idx] = s.Members[idx].StudentID
+	}
+	return studentIDs
+}
+
+// MemberView returns a copy of the solution for the given team member:
+// the individual grade of the student replaces the solution grade
+// and individual grades of other members are hidden.
+// The exam task content is hidden until the attempt is begun.
+func (s *Solution) MemberView(studID int) *Solution {
+	view := *s
+	view.HideExamContent()
+	view.Members = make([]SolutionMember, len(s.Members))
+	copy(view.Members, s.Members)
+	for idx := range view.Members {
+		member := &view.Members[idx]
+		if member.StudentID != studID {
+			member.Grade = nil
+			continue
+		}
+		if member.Grade != nil {
+			view.Grade = member.Grade
+		}
+	}
+	return &view
+}
+
+// SolutionMember represents a student sharing the solution.
+type SolutionMember struct {
+	// solution id
+	SolutionID int `gorm:"primaryKey" json:"-"`
+	// solution task id (the student is a member of one task solution only)
+	TaskID int `json:"-"`
+	// student id
+	StudentID int `gorm:"primaryKey" json:"-"`
+	// individual grade of the student instead of the solution grade
+	Grade *string `json:"grade,omitempty" validate:"omitempty" example:"5"`
+
+	// student object
+	Student     *Profile `gorm:"-" json:"student" validate:"required"`
+	StudentUser *User    `gorm:"foreignKey:StudentID;references:ID" json:"-"`
+}
+
+// TableName determines DB table name for the solution member object.
+func (*SolutionMember) TableName() string {
+	return "solution_member"
+}
+
+// SolutionRead represents a marker of the last solution view by the user.
+type SolutionRead struct {
+	// solution id
+	SolutionID int `gorm:"primaryKey"`
+	// user id
+	UserID int `gorm:"primaryKey"`
+	// datetime of the last view
+	ReadAt time.Time
+}
+
+// TableName determines DB table name for the solution read marker object.
+func (*SolutionRead) TableName() string {
+	return "solution_read"
+}
+
+// SolutionActivity represents unread activity of the solution for the user.
+type SolutionActivity struct {
+	// solution id
+	SolutionID int
+	// number of comments from other users created since the last view
+	UnreadComments int
+	// true if the solution was updated or commented since the last view
+	HasUpdates bool
+}
+
 // SolutionUpdate represents a data to update solution.
 type SolutionUpdate struct {
 	// new status ID
@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolution_Members(t *testing.T) {
+	t.Log("Check members and member IDs of individual and team solutions")
+
+	single := Solution{StudentID: 1}
+	loaded := Solution{StudentID: 1, Members: []SolutionMember{{StudentID: 1}}}
+	team := Solution{StudentID: 1, Members: []SolutionMember{{StudentID: 1}, {StudentID: 2}}}
+
+	tests := []struct {
+		name    string
+		sol     *Solution
+		studID  int
+		member  bool
+		ids     []int
+		hasTeam bool
+	}{
+		{"owner without loaded members", &single, 1, true, []int{1}, false},
+		{"other without loaded members", &single, 2, false, []int{1}, false},
+		{"owner with loaded members", &loaded, 1, true, []int{1}, false},
+		{"team member", &team, 2, true, []int{1, 2}, true},
+		{"not a team member", &team, 3, false, []int{1, 2}, true},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.member, tt.sol.IsMember(tt.studID), tt.name)
+		require.Equal(t, tt.ids, tt.sol.MemberIDs(), tt.name)
+		require.Equal(t, tt.hasTeam, tt.sol.IsTeam(), tt.name)
+	}
+}
+
+func TestSolution_MemberView(t *testing.T) {
+	t.Log("Replace the solution grade with the individual grade of the member")
+
+	sol := &Solution{
+		Grade: ptr("4"),
+		Members: []SolutionMember{
+			{StudentID: 1, Grade: ptr("5")},
+			{StudentID: 2, Grade: ptr("3")},
+			{StudentID: 3},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		studID int
+		grade  string
+		grades []*string
+	}{
+		{"own grade", 1, "5", []*string{ptr("5"), nil, nil}},
+		{"other own grade", 2, "3", []*string{nil, ptr("3"), nil}},
+		{"no own grade", 3, "4", []*string{nil, nil, nil}},
+	}
+	for _, tt := range tests {
+		view := sol.MemberView(tt.studID)
+		require.Equal(t, tt.grade, *view.Grade, tt.name)
+		for idx, member := range view.Members {
+			require.Equal(t, tt.grades[idx], member.Grade, tt.name)
+		}
+	}
+	// the source solution is not changed
+	require.Equal(t, "4", *sol.Grade)
+	require.Equal(t, "3", *sol.Members[1].Grade)
+}
+
+func TestSolution_SetMemberProfiles(t *testing.T) {
+	t.Log("Set profiles of the members from the preloaded users")
+
+	profile := &Profile{ID: ptr(1), Fullname: "Иванов Иван"}
+	sol := &Solution{
+		Members: []SolutionMember{
+			{StudentID: 1, StudentUser: &User{Profile: profile}},
+			{StudentID: 2},
+		},
+	}
+	sol.SetMemberProfiles()
+	require.Equal(t, profile, sol.Members[0].Student)
+	require.Nil(t, sol.Members[1].Student)
+}
+
+func TestSolution_HideExamContent(t *testing.T) {
+	t.Log("Hide the exam task content until the attempt is begun")
+
+	now := time.Now()
+	newTask := func(duration *int) *Task {
+		return &Task{Desc: "описание", DescHTML: ptr("<p>описание</p>"),
+			Files: Files{{ID: 1}}, ExamDuration: duration}
+	}
+
+	tests := []struct {
+		name   string
+		sol    *Solution
+		hidden bool
+	}{
+		{"not exam", &Solution{Task: newTask(nil)}, false},
+		{"attempt not created", &Solution{Task: newTask(ptr(45))}, true},
+		{"attempt not begun", &Solution{Task: newTask(ptr(45)), Attempt: &ExamAttempt{}}, true},
+		{"attempt begun", &Solution{Task: newTask(ptr(45)),
+			Attempt: &ExamAttempt{StartedAt: &now}}, false},
+	}
+	for _, tt := range tests {
+		srcTask := tt.sol.Task
+		view := tt.sol.MemberView(1)
+		require.Equal(t, tt.hidden, view.Task.Desc == "", tt.name)
+		require.Equal(t, tt.hidden, view.Task.DescHTML == nil, tt.name)
+		require.Equal(t, tt.hidden, view.Task.Files == nil, tt.name)
+		// the source task is not changed
+		require.Equal(t, "описание", srcTask.Desc, tt.name)
+		require.Len(t, srcTask.Files, 1, tt.name)
+	}
+}
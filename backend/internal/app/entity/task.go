@@ -4,6 +4,14 @@ import (
 	"time"
 )
 
+// TaskType represents a type of the task.
+type TaskType string
+
+var (
+	TaskAssignment TaskType = "assignment" // solution is a text answer and files
+	TaskQuiz       TaskType = "quiz"       // solution is answers to the auto-graded questions
+)
+
 // Task represents a task data.
 type Task struct {
 	// task id
@@ -12,8 +20,32 @@ type Task struct {
 	Title string `json:"title" validate:"required"`
 	// task description
 	Desc string `gorm:"column:description" json:"description,omitempty" validate:"omitempty"`
+	// task description rendered from Markdown to the sanitized HTML
+	DescHTML *string `gorm:"column:description_html" json:"description_html,omitempty" validate:"omitempty"`
 	// task teacher id
 	TeacherID int `json:"-"`
+	// task type (quiz type is set with the quiz questions)
+	Type TaskType `json:"type,omitempty" validate:"omitempty" example:"assignment"`
+	// id of the course module the task belongs to
+	ModuleID *int `json:"module_id,omitempty" validate:"omitempty" example:"7"`
+	// position of the task in the course module
+	Position int `json:"position,omitempty" validate:"omitempty" example:"2"`
+	// true if the task is not published yet (students do not see it)
+	Draft bool `json:"draft" validate:"omitempty"`
+	// datetime the draft will be published automatically (null for manual publication)
+	PublishAt *time.Time `json:"publish_at,omitempty" validate:"omitempty"`
+	// datetime students have to send solutions before (null for no deadline)
+	Deadline *time.Time `json:"deadline,omitempty" validate:"omitempty"`
+	// true if students were reminded that the deadline is coming soon
+	DeadlineReminded bool `json:"-"`
+	// datetime students can begin exam attempts from (null for no restriction)
+	ExamOpensAt *time.Time `json:"exam_opens_at,omitempty" validate:"omitempty"`
+	// datetime the exam closes: attempts cannot be begun and they end at it (null for no restriction)
+	ExamClosesAt *time.Time `json:"exam_closes_at,omitempty" validate:"omitempty"`
+	// exam attempt duration in minutes (exam mode is on if it is not null)
+	ExamDuration *int `json:"exam_duration,omitempty" validate:"omitempty" example:"45"`
+	// true if students were reminded that the exam closes soon
+	ExamReminded bool `json:"-"`
 	// task creating datetime
 	CreatedAt time.Time `json:"-"`
 
@@ -22,6 +54,10 @@ type Task struct {
 	TeacherUser *User    `gorm:"foreignKey:TeacherID;references:ID" json:"-"`
 	// task files
 	Files Files `gorm:"many2many:task_file;foreignKey:ID;References:ID" json:"files,omitempty" validate:"omitempty"`
+	// quiz questions (to create them with the task only)
+	Questions []QuizQuestion `gorm:"foreignKey:TaskID" json:"-"`
+	// autotest suite with cases (to create it with the task only)
+	Suite *AutotestSuite `gorm:"foreignKey:TaskID" json:"-"`
 }
 
 // TableName determines DB table name for the task object.
@@ -29,6 +65,67 @@ func (*Task) TableName() string {
 	return "task"
 }
 
+// IsExam returns true if the task is in exam mode (students solve it in timed attempts).
+func (t *Task) IsExam() bool {
+	return t.ExamDuration != nil
+}
+
+// AssignedView returns the task to send to the students it was assigned to.
+// Exam tasks are stripped to the title and exam settings (the content is hidden
+// until the attempt is begun).
+func (t *Task) AssignedView() *Task {
+	if !t.IsExam() {
+		return t
+	}
+	return &Task{
+		ID:           t.ID,
+		Title:        t.Title,
+		Type:         t.Type,
+		ExamOpensAt:  t.ExamOpensAt,
+		ExamClosesAt: t.ExamClosesAt,
+		ExamDuration: t.ExamDuration,
+	}
+}
+
+// HideContent clears the task content (description and files) not to show it to students.
+func (t *Task) HideContent() {
+	t.Desc = ""
+	t.DescHTML = nil
+	t.Files = nil
+}
+
+// TaskShare represents an access of the teacher to the task of another teacher
+// (shared library item can be viewed and cloned).
+type TaskShare struct {
+	// task id
+	TaskID int `gorm:"primaryKey"`
+	// id of the teacher the task is shared with
+	TeacherID int `gorm:"primaryKey"`
+	// datetime the task was shared
+	CreatedAt time.Time
+}
+
+// TableName determines DB table name for the task share object.
+func (*TaskShare) TableName() string {
+	return "task_share"
+}
+
+// TaskClass represents a link of the task to the class it was issued to.
+// Students joined the class later get the task solutions too.
+type TaskClass struct {
+	// task id
+	TaskID int `gorm:"primaryKey"`
+	// class id
+	ClassID int `gorm:"primaryKey"`
+	// datetime the task was issued to the class
+	CreatedAt time.Time
+}
+
+// TableName determines DB table name for the task class object.
+func (*TaskClass) TableName() string {
+	return "task_class"
+}
+
 // TaskWithStudents represents a task data with students linked to it.
 type TaskWithStudents struct {
 	// task object
@@ -43,6 +140,10 @@ type TaskUpdate struct {
 	Title *string
 	// new task description
 	Desc *string
+	// new datetime of the draft publication
+	PublishAt *time.Time
+	// new deadline to send solutions
+	Deadline *time.Time
 
 	// IDs of new students to completely replace old students
 	NewFullStudents []int
@@ -50,12 +151,16 @@ type TaskUpdate struct {
 	AddStudents []int
 	// IDs of students to delete their task solutions
 	DelStudents []int
+	// IDs of classes to link the task to (students joined them later get the task)
+	AddClasses []int
 
 	// List of files to append to the task
 	AddFiles Files
 	// IDs of files to delete from the task
 	DelFilesIDs []int
-	// List of files to delete from the task
+	// List of files to delete from the task.
+	// After update it contains only files to remove from the file system
+	// (files linked to other tasks, e.g. to clones, are kept).
 	DelFiles Files
 }
 
@@ -70,5 +175,14 @@ func (t *TaskUpdate) ToUpdatesMap() map[string]any {
 	if t.Desc != nil {
 		updates["description"] = t.Desc
 	}
+	// set new publication datetime
+	if t.PublishAt != nil {
+		updates["publish_at"] = *t.PublishAt
+	}
+	// set new deadline (students are reminded of it again)
+	if t.Deadline != nil {
+		updates["deadline"] = *t.Deadline
+		updates["deadline_reminded"] = false
+	}
 	return updates
 }
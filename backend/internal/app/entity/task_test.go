@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTask_AssignedView(t *testing.T) {
+	t.Log("Strip the exam task content sent to assigned students")
+
+	tests := []struct {
+		name     string
+		task     *Task
+		stripped bool
+	}{
+		{"not exam", &Task{ID: 1, Title: "Задание", Desc: "описание"}, false},
+		{"exam", &Task{ID: 2, Title: "Экзамен", Desc: "описание", ExamDuration: ptr(45)}, true},
+	}
+	for _, tt := range tests {
+		view := tt.task.AssignedView()
+		require.Equal(t, tt.task.ID, view.ID, tt.name)
+		require.Equal(t, tt.task.Title, view.Title, tt.name)
+		require.Equal(t, tt.task.ExamDuration, view.ExamDuration, tt.name)
+		require.Equal(t, tt.stripped, view.Desc == "", tt.name)
+		// the source task is not changed
+		require.Equal(t, "описание", tt.task.Desc, tt.name)
+	}
+}
@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+// TelegramChat represents a Telegram chat linked with the user account.
+type TelegramChat struct {
+	// link ID
+	ID int `json:"id" validate:"required" example:"3"`
+	// linked user ID
+	UserID int `json:"-"`
+	// Telegram chat ID
+	ChatID int64 `json:"chat_id" validate:"required" example:"123456789"`
+	// true if the chat belongs to the student's parent
+	IsParent bool `json:"is_parent" validate:"omitempty"`
+	// datetime the chat was linked
+	CreatedAt time.Time `json:"created_at" validate:"required"`
+
+	// linked user object
+	User *User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// TableName determines DB table name for the telegram chat object.
+func (*TelegramChat) TableName() string {
+	return "telegram_chat"
+}
+
+// TelegramCode represents a one-time code to link the Telegram chat with the user account.
+type TelegramCode struct {
+	// code to send to the bot
+	Code string `json:"code" validate:"required" example:"K7Q2MX9D"`
+	// link to open the bot and send the code at once (it is empty if bot name is not set)
+	Link string `json:"link,omitempty" validate:"omitempty" example:"https://t.me/skadi_bot?start=K7Q2MX9D"`
+	// true if the code links the chat of the student's parent
+	IsParent bool `json:"is_parent" validate:"omitempty"`
+	// code expiration datetime
+	ExpiresAt time.Time `json:"expires_at" validate:"required"`
+}
+
+// TelegramMessage represents a text message to the Telegram chat.
+type TelegramMessage struct {
+	// Telegram chat ID
+	ChatID int64
+	// message text
+	Text string
+}
@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+// Upload represents a resumable chunked upload of the file to the staging storage.
+type Upload struct {
+	// upload ID
+	ID string `gorm:"primaryKey" json:"id" validate:"required" example:"9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d"`
+	// owner (user) ID
+	UserID int `json:"-"`
+	// filename
+	Name string `json:"name" validate:"required"`
+	// file MIME-type
+	MimeType string `json:"mime_type" validate:"required"`
+	// total file size in bytes
+	Size int64 `json:"size" validate:"required"`
+	// number of received bytes (offset for the next chunk)
+	Uploaded int64 `json:"uploaded" validate:"required"`
+	// path to the staging file
+	Path string `json:"-"`
+	// datetime the upload was created
+	CreatedAt time.Time `json:"created_at" validate:"required"`
+	// datetime the unfinished upload will be deleted
+	ExpiresAt time.Time `json:"expires_at" validate:"required"`
+}
+
+// TableName determines DB table name for the upload object.
+func (*Upload) TableName() string {
+	return "upload"
+}
+
+// Completed returns true if all file bytes were received.
+func (u *Upload) Completed() bool {
+	return u.Uploaded == u.Size
+}
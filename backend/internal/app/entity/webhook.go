@@ -0,0 +1,137 @@
+package entity
+
+import "time"
+
+// WebhookEvent represents a type of the domain event sent to the webhooks.
+type WebhookEvent string
+
+var (
+	HookTaskCreated           WebhookEvent = "task.created"            // teacher created task
+	HookSolutionStatusChanged WebhookEvent = "solution.status_changed" // solution status was changed
+	HookSolutionGraded        WebhookEvent = "solution.graded"         // teacher graded solution
+	HookCommentCreated        WebhookEvent = "comment.created"         // new comment under the solution
+	HookUserCreated           WebhookEvent = "user.created"            // admin created user
+)
+
+// WebhookEvents is a list of all webhook event types.
+var WebhookEvents = []WebhookEvent{
+	HookTaskCreated,
+	HookSolutionStatusChanged,
+	HookSolutionGraded,
+	HookCommentCreated,
+	HookUserCreated,
+}
+
+// Webhook represents a subscription of the external URL to the domain events.
+type Webhook struct {
+	// webhook ID
+	ID int `json:"id" validate:"required" example:"2"`
+	// URL to send events to
+	URL string `json:"url" validate:"required" example:"https://crm.school.ru/skadi/hook"`
+	// secret to sign deliveries (it is not returned)
+	Secret string `json:"-"`
+	// event types the webhook is subscribed to
+	Events []WebhookEvent `gorm:"serializer:json" json:"events" validate:"required" example:"solution.graded"`
+	// false if deliveries are paused
+	Enabled bool `json:"enabled" validate:"omitempty"`
+	// datetime the webhook was created
+	CreatedAt time.Time `json:"created_at" validate:"required"`
+}
+
+// TableName determines DB table name for the webhook object.
+func (*Webhook) TableName() string {
+	return "webhook"
+}
+
+// WebhookUpdate represents a data to update webhook.
+type WebhookUpdate struct {
+	// new URL
+	URL *string
+	// new secret
+	Secret *string
+	// new event types
+	Events []WebhookEvent
+	// new enabled state
+	Enabled *bool
+}
+
+// WebhookDelivery represents a delivery of the event to the webhook (log record).
+type WebhookDelivery struct {
+	// delivery ID
+	ID int `json:"id" validate:"required" example:"15"`
+	// webhook ID
+	WebhookID int `json:"-"`
+	// event type
+	Event WebhookEvent `json:"event" validate:"required" example:"solution.graded"`
+	// JSON-body of the request
+	Payload string `json:"payload" validate:"required"`
+	// number of failed attempts
+	Attempts int `json:"attempts" validate:"omitempty" example:"1"`
+	// HTTP status code of the last response
+	StatusCode *int `json:"status_code,omitempty" validate:"omitempty" example:"500"`
+	// error of the last failed attempt
+	LastError *string `json:"last_error,omitempty" validate:"omitempty" example:"unexpected status code 500"`
+	// datetime of the next attempt
+	NextAttemptAt time.Time `json:"next_attempt_at" validate:"required"`
+	// datetime the event was delivered (it is null for undelivered events)
+	DeliveredAt *time.Time `json:"delivered_at,omitempty" validate:"omitempty"`
+	// datetime the delivery was created
+	CreatedAt time.Time `json:"created_at" validate:"required"`
+
+	// webhook object
+	Webhook *Webhook `gorm:"foreignKey:WebhookID" json:"-"`
+}
+
+// TableName determines DB table name for the webhook delivery object.
+func (*WebhookDelivery) TableName() string {
+	return "webhook_delivery"
+}
+
+// WebhookPayload represents a JSON-body of the webhook request.
+type WebhookPayload struct {
+	// event type
+	Event WebhookEvent `json:"event"`
+	// datetime the event happened
+	CreatedAt time.Time `json:"created_at"`
+	// event data
+	Data any `json:"data"`
+}
+
+// WebhookTaskData represents an event data about the task.
+type WebhookTaskData struct {
+	TaskID     int    `json:"task_id"`
+	TeacherID  int    `json:"teacher_id"`
+	Title      string `json:"title"`
+	StudentIDs []int  `json:"student_ids"`
+}
+
+// WebhookSolutionData represents an event data about the solution.
+type WebhookSolutionData struct {
+	SolutionID  int     `json:"solution_id"`
+	TaskID      int     `json:"task_id"`
+	StudentID   int     `json:"student_id"`
+	StatusID    int     `json:"status_id"`
+	OldStatusID int     `json:"old_status_id"`
+	Grade       *string `json:"grade"`
+	// IDs of all students sharing the solution
+	StudentIDs []int `json:"student_ids"`
+}
+
+// WebhookCommentData represents an event data about the comment.
+type WebhookCommentData struct {
+	CommentID  int    `json:"comment_id"`
+	SolutionID int    `json:"solution_id"`
+	ParentID   *int   `json:"parent_id"`
+	AuthorID   *int   `json:"author_id"`
+	Role       Role   `json:"role"`
+	Message    string `json:"message"`
+}
+
+// WebhookUserData represents an event data about the user.
+type WebhookUserData struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+	Role     Role   `json:"role"`
+	Fullname string `json:"fullname"`
+	ClassID  *int   `json:"class_id"`
+}
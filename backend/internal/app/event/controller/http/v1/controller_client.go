@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"bufio"
+	"log/slog"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/event"
+	utilsjwt "skadi/backend/internal/pkg/utils/jwt"
+)
+
+// EventController represents a controller for the event routes.
+type EventController struct {
+	subscriber event.Subscriber
+	heartbeat  time.Duration
+}
+
+// NewController returns a new instance of [EventController].
+func NewController(cfg *config.Config, subscriber event.Subscriber) *EventController {
+	return &EventController{
+		subscriber: subscriber,
+		heartbeat:  cfg.Event.Heartbeat,
+	}
+}
+
+// @summary		Поток событий в реальном времени. [Преподаватель и ученик]
+// @description	Server-Sent Events поток событий для текущего пользователя.
+// @description	Каждое событие передаётся в поле data в виде JSON с типом (type) и данными (data):
+// @description	comment.created - новый комментарий к решению (данные - комментарий),
+// @description	solution.updated - изменение статуса, оценки или ответа решения (данные - решение),
+// @description	task.assigned - новое задание для ученика (данные - задание).
+// @description	Для поддержания соединения периодически отправляются комментарии (": ping").
+// @description	После разрыва соединения клиент должен переподключиться (EventSource делает это сам).
+// @router			/events [get]
+// @id				event-stream
+// @tags			event
+// @produce		text/event-stream
+// @security		JWTAccess
+// @success		200	{object}	entity.Event
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+func (c *EventController) Stream(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	ctx.Set(fiber.HeaderContentType, "text/event-stream")
+	ctx.Set(fiber.HeaderCacheControl, "no-cache")
+	ctx.Set(fiber.HeaderConnection, "keep-alive")
+	ctx.Set("X-Accel-Buffering", "no") // disable nginx buffering
+
+	evtChan, unsubscribe := c.subscriber.Subscribe(userClaims.ID)
+	heartbeat := c.heartbeat
+	// stream writer is called after the handler returns
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+
+		// the first message lets the client know the stream is open
+		if err := writeAndFlush(w, ": connected\n\n"); err != nil {
+			return
+		}
+		for {
+			var err error
+			select {
+			case evtData, ok := <-evtChan:
+				if !ok { // event bus is stopped
+					return
+				}
+				err = writeAndFlush(w, "data: "+string(evtData)+"\n\n")
+			case <-ticker.C:
+				err = writeAndFlush(w, ": ping\n\n")
+			}
+			// client has disconnected
+			if err != nil {
+				slog.Debug("event stream closed", "user_id", userClaims.ID, "error", err)
+				return
+			}
+		}
+	})
+	return nil
+}
+
+// writeAndFlush writes the message to the stream and flushes it to the client.
+func writeAndFlush(w *bufio.Writer, msg string) error {
+	if _, err := w.WriteString(msg); err != nil {
+		return err
+	}
+	return w.Flush()
+}
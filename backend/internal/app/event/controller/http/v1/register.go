@@ -0,0 +1,17 @@
+// Package http/v1 is a first version of event HTTP-controller.
+// It provides registers for event HTTP-routes and controller with handlers for them.
+package v1
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/service/server/middleware"
+)
+
+// RegisterEndpoints registers all event endpoints.
+func RegisterEndpoints(router fiber.Router, controller *EventController,
+	mwJWTAccess fiber.Handler, mwAllow middleware.AllowFunc) {
+
+	router.Get("/events", mwJWTAccess, mwAllow(entity.Teacher, entity.Student), controller.Stream)
+}
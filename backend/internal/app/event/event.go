@@ -0,0 +1,36 @@
+// Package event contains interfaces and controllers for real-time user events.
+// Sub-package controller contains SSE-controller to stream events to users.
+// Events are published by usecases of other domains
+// and fanned out to all backend instances by event bus service.
+package event
+
+import "skadi/backend/internal/app/entity"
+
+// Publisher describes a sender of real-time events.
+type Publisher interface {
+	// Publish sends the event to all subscribed sessions of the given users.
+	// The event is published to the cache storage synchronously (the caller waits for it),
+	// but the method never fails: errors are only logged.
+	Publish(evt *entity.Event, userIDs ...int)
+}
+
+// Subscriber describes a receiver of real-time events.
+type Subscriber interface {
+	// Subscribe returns a chan with JSON-encoded events for the given user
+	// and a func to unsubscribe. The chan is closed after unsubscribing.
+	Subscribe(userID int) (<-chan []byte, func())
+}
+
+// PublishToMembers sends the solution event to all members of the solution
+// apart of the given user (e.g. the author of changes). Every member gets
+// the solution with the own individual grade.
+func PublishToMembers(pub Publisher, evtType entity.EventType,
+	solObj *entity.Solution, exceptID int) {
+
+	for _, studID := range solObj.MemberIDs() {
+		if studID == exceptID {
+			continue
+		}
+		pub.Publish(&entity.Event{Type: evtType, Data: solObj.MemberView(studID)}, studID)
+	}
+}
@@ -0,0 +1,86 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/exam"
+	"skadi/backend/internal/pkg/httperror"
+	"skadi/backend/internal/pkg/serialize"
+	utilsjwt "skadi/backend/internal/pkg/utils/jwt"
+	"skadi/backend/internal/pkg/validator"
+)
+
+// ExamController represents a controller for exam routes accepted for teachers and students.
+type ExamController struct {
+	valid        validator.Validator
+	examUCClient exam.UsecaseClient
+}
+
+// NewController returns a new instance of [ExamController].
+func NewController(examUCClient exam.UsecaseClient, valid validator.Validator) *ExamController {
+	return &ExamController{
+		valid:        valid,
+		examUCClient: examUCClient,
+	}
+}
+
+// @summary		Получение попытки экзамена.
+// @description	Получение попытки решения задания в режиме экзамена: время начала, дополнительные минуты и время окончания (deadline).
+// @router			/solution/{id}/exam [get]
+// @id				exam-read-attempt
+// @tags			exam
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id	path		int	true	"ID решения"
+// @success		200	{object}	entity.ExamAttempt
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+// @failure		404	"решение не найдено | попытка не начата"
+// @failure		409	"задание не в режиме экзамена"
+func (c *ExamController) ReadAttempt(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &solutionIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	attemptObj, err := c.examUCClient.GetAttempt(inputPath.ID, userClaims)
+	if errors.Is(err, exam.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "решение не найдено",
+		}
+	}
+	if errors.Is(err, exam.ErrNotFoundAttempt) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "попытка не начата",
+		}
+	}
+	if errors.Is(err, exam.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, exam.ErrNotExam) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "задание не в режиме экзамена",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("read attempt: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(attemptObj)
+}
@@ -0,0 +1,96 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/exam"
+	"skadi/backend/internal/pkg/httperror"
+	"skadi/backend/internal/pkg/serialize"
+	utilsjwt "skadi/backend/internal/pkg/utils/jwt"
+	"skadi/backend/internal/pkg/validator"
+)
+
+// ExamControllerStudent represents a controller for exam routes accepted for students only.
+type ExamControllerStudent struct {
+	valid         validator.Validator
+	examUCStudent exam.UsecaseStudent
+}
+
+// NewControllerStudent returns a new instance of [ExamControllerStudent].
+func NewControllerStudent(examUCStudent exam.UsecaseStudent,
+	valid validator.Validator) *ExamControllerStudent {
+
+	return &ExamControllerStudent{
+		valid:         valid,
+		examUCStudent: examUCStudent,
+	}
+}
+
+// @summary		Начало попытки экзамена. [Только ученик]
+// @description	Запуск таймера попытки решения задания в режиме экзамена. Попытку можно начать один раз и только пока экзамен открыт.
+// @description	Решение можно изменять до окончания попытки (deadline): через длительность попытки, но не позже закрытия экзамена, плюс дополнительные минуты от преподавателя.
+// @router			/solution/{id}/exam/begin [post]
+// @id				exam-begin
+// @tags			exam
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id	path		int	true	"ID решения"
+// @success		201	{object}	entity.ExamAttempt
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+// @failure		404	"решение не найдено"
+// @failure		409	"задание не в режиме экзамена | экзамен ещё не открыт или уже закрыт | попытка уже начата"
+func (c *ExamControllerStudent) Begin(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &solutionIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	attemptObj, err := c.examUCStudent.Begin(userClaims.ID, inputPath.ID)
+	if errors.Is(err, exam.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, exam.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "решение не найдено",
+		}
+	}
+	if errors.Is(err, exam.ErrNotExam) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "задание не в режиме экзамена",
+		}
+	}
+	if errors.Is(err, exam.ErrNotOpen) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "экзамен ещё не открыт или уже закрыт",
+		}
+	}
+	if errors.Is(err, exam.ErrStarted) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "попытка уже начата",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("begin attempt: %w", err)
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(attemptObj)
+}
@@ -0,0 +1,241 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/exam"
+	"skadi/backend/internal/pkg/httperror"
+	"skadi/backend/internal/pkg/serialize"
+	utilsjwt "skadi/backend/internal/pkg/utils/jwt"
+	"skadi/backend/internal/pkg/validator"
+)
+
+// ExamControllerTeacher represents a controller for exam routes accepted for teachers only.
+type ExamControllerTeacher struct {
+	valid         validator.Validator
+	examUCTeacher exam.UsecaseTeacher
+}
+
+// NewControllerTeacher returns a new instance of [ExamControllerTeacher].
+func NewControllerTeacher(examUCTeacher exam.UsecaseTeacher,
+	valid validator.Validator) *ExamControllerTeacher {
+
+	return &ExamControllerTeacher{
+		valid:         valid,
+		examUCTeacher: examUCTeacher,
+	}
+}
+
+// @summary		Включение режима экзамена. [Только преподаватель]
+// @description	Включение режима экзамена для своего задания или изменение его параметров: окно экзамена (opens_at - closes_at) и длительность попытки в минутах.
+// @description	Ученик начинает попытку сам, после её окончания решение (и ответы на вопросы теста) изменить нельзя.
+// @router			/task/{id}/exam [put]
+// @id				exam-update
+// @tags			exam
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id			path		int			true	"ID задания"
+// @param			examBody	body		examBody	true	"examBody"
+// @success		200			{object}	entity.Task
+// @failure		400			"экзамен должен закрываться после открытия"
+// @failure		401			"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403			"доступ запрещён"
+// @failure		404			"задание не найдено"
+func (c *ExamControllerTeacher) Update(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &taskIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputBody := &examBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	var opensAt, closesAt *time.Time
+	// datetime format is already validated
+	if inputBody.OpensAt != nil {
+		parsed, _ := time.Parse(time.RFC3339, *inputBody.OpensAt)
+		opensAt = &parsed
+	}
+	if inputBody.ClosesAt != nil {
+		parsed, _ := time.Parse(time.RFC3339, *inputBody.ClosesAt)
+		closesAt = &parsed
+	}
+	taskObj, err := c.examUCTeacher.SetExam(userClaims.ID, inputPath.ID, opensAt, closesAt,
+		inputBody.Duration)
+	if errors.Is(err, exam.ErrInvalidData) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "экзамен должен закрываться после открытия",
+		}
+	}
+	if errors.Is(err, exam.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "задание не найдено",
+		}
+	}
+	if errors.Is(err, exam.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("update exam: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(taskObj)
+}
+
+// @summary		Выключение режима экзамена. [Только преподаватель]
+// @description	Выключение режима экзамена для своего задания. Попытки сохраняются, но решения можно изменять без них.
+// @router			/task/{id}/exam [delete]
+// @id				exam-delete
+// @tags			exam
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id	path	int	true	"ID задания"
+// @success		204	"No Content"
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+func (c *ExamControllerTeacher) Delete(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &taskIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	err := c.examUCTeacher.DeleteExam(userClaims.ID, inputPath.ID)
+	if errors.Is(err, exam.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("delete exam: %w", err)
+	}
+	return ctx.Status(fiber.StatusNoContent).JSON(nil)
+}
+
+// @summary		Получение попыток экзамена. [Только преподаватель]
+// @description	Получение попыток всех учеников для своего задания в режиме экзамена (ID решения, время начала, дополнительные минуты и время окончания).
+// @router			/task/{id}/exam/attempts [get]
+// @id				exam-list-attempts
+// @tags			exam
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id	path	int	true	"ID задания"
+// @success		200	{array}	entity.ExamAttempt
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+// @failure		404	"задание не найдено"
+// @failure		409	"задание не в режиме экзамена"
+func (c *ExamControllerTeacher) ListAttempts(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &taskIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	attempts, err := c.examUCTeacher.GetAttempts(userClaims.ID, inputPath.ID)
+	if errors.Is(err, exam.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "задание не найдено",
+		}
+	}
+	if errors.Is(err, exam.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, exam.ErrNotExam) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "задание не в режиме экзамена",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("list attempts: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(attempts)
+}
+
+// @summary		Дополнительное время ученику. [Только преподаватель]
+// @description	Установка дополнительных минут попытки ученика для своего задания в режиме экзамена (0 - отмена). Минуты можно добавить и до начала попытки.
+// @router			/solution/{id}/exam/extra [put]
+// @id				exam-grant-extra
+// @tags			exam
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id			path		int			true	"ID решения"
+// @param			extraBody	body		extraBody	true	"extraBody"
+// @success		200			{object}	entity.ExamAttempt
+// @failure		401			"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403			"доступ запрещён"
+// @failure		404			"решение не найдено"
+// @failure		409			"задание не в режиме экзамена"
+func (c *ExamControllerTeacher) GrantExtra(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &solutionIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputBody := &extraBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	attemptObj, err := c.examUCTeacher.GrantExtra(userClaims.ID, inputPath.ID,
+		inputBody.Minutes)
+	if errors.Is(err, exam.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "решение не найдено",
+		}
+	}
+	if errors.Is(err, exam.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, exam.ErrNotExam) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "задание не в режиме экзамена",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("grant extra time: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(attemptObj)
+}
@@ -0,0 +1,29 @@
+package v1
+
+// @description taskIDPath represents a data with task ID in path params.
+type taskIDPath struct {
+	// task id
+	ID int `params:"id" validate:"required" example:"2"`
+}
+
+// @description solutionIDPath represents a data with solution ID in path params.
+type solutionIDPath struct {
+	// solution id
+	ID int `params:"id" validate:"required" example:"14"`
+}
+
+// @description examBody represents a data with exam window and attempt duration.
+type examBody struct {
+	// datetime students can begin attempts from (RFC 3339)
+	OpensAt *string `json:"opens_at,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2026-12-20T09:00:00+03:00"`
+	// datetime the exam closes: attempts cannot be begun and they end at it (RFC 3339)
+	ClosesAt *string `json:"closes_at,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2026-12-20T11:00:00+03:00"`
+	// attempt duration in minutes
+	Duration int `json:"duration" validate:"required,min=1,max=1440" example:"45" minimum:"1" maximum:"1440"`
+}
+
+// @description extraBody represents a data with extra minutes of the student attempt.
+type extraBody struct {
+	// extra minutes (0 to cancel)
+	Minutes int `json:"minutes" validate:"omitempty,min=0,max=1440" example:"15" minimum:"0" maximum:"1440"`
+}
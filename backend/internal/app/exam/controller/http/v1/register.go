@@ -0,0 +1,30 @@
+// Package http/v1 is a first version of exam HTTP-controller.
+// It provides registers for exam HTTP-routes and controllers with handlers for them.
+package v1
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/service/server/middleware"
+)
+
+// RegisterEndpoints registers all exam endpoints.
+func RegisterEndpoints(router fiber.Router, controller *ExamController,
+	controllerStudent *ExamControllerStudent, controllerTeacher *ExamControllerTeacher,
+	mwJWTAccess fiber.Handler, mwAllow middleware.AllowFunc) {
+
+	mwTeacherOnly := mwAllow(entity.Teacher)
+	mwStudentOnly := mwAllow(entity.Student)
+	mwTeacherStudent := mwAllow(entity.Teacher, entity.Student)
+
+	taskGroup := router.Group("/task/:id/exam", mwJWTAccess, mwTeacherOnly)
+	taskGroup.Put("/", controllerTeacher.Update)
+	taskGroup.Delete("/", controllerTeacher.Delete)
+	taskGroup.Get("/attempts", controllerTeacher.ListAttempts)
+
+	solGroup := router.Group("/solution/:id/exam", mwJWTAccess)
+	solGroup.Get("/", mwTeacherStudent, controller.ReadAttempt)
+	solGroup.Post("/begin", mwStudentOnly, controllerStudent.Begin)
+	solGroup.Put("/extra", mwTeacherOnly, controllerTeacher.GrantExtra)
+}
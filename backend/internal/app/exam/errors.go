@@ -0,0 +1,13 @@
+package exam
+
+import "errors"
+
+var (
+	ErrInvalidData     = errors.New("invalid data")             // code 400
+	ErrForbidden       = errors.New("forbidden")                // code 403
+	ErrNotFound        = errors.New("record not found")         // code 404
+	ErrNotFoundAttempt = errors.New("attempt not found")        // code 404
+	ErrNotExam         = errors.New("task is not in exam mode") // code 409
+	ErrNotOpen         = errors.New("exam is not open")         // code 409
+	ErrStarted         = errors.New("attempt is already begun") // code 409
+)
@@ -0,0 +1,24 @@
+package exam
+
+import (
+	"time"
+
+	"skadi/backend/internal/app/entity"
+)
+
+// RepositoryDB describes all DB methods for exam settings and attempts.
+type RepositoryDB interface {
+	// SetExam saves exam window and attempt duration of the task
+	// (null duration turns off exam mode). Students will be reminded of the new closing datetime.
+	SetExam(taskObj *entity.Task) error
+
+	// GetAttempt returns the attempt of the solution.
+	GetAttempt(solutionID int) (*entity.ExamAttempt, error)
+	// GetAttempts returns attempts of all task solutions.
+	GetAttempts(taskID int) ([]entity.ExamAttempt, error)
+	// Begin sets the start datetime of the solution attempt.
+	// It returns false if the attempt is already begun.
+	Begin(solutionID int, startedAt time.Time) (bool, error)
+	// SetExtra sets extra minutes of the solution attempt.
+	SetExtra(solutionID, minutes int) error
+}
@@ -0,0 +1,136 @@
+// Package repository contains exam.RepositoryDB implementation.
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/exam"
+)
+
+const (
+	_fieldID           = "id"             // table field name
+	_fieldSolutionID   = "solution_id"    // table field name
+	_fieldStartedAt    = "started_at"     // table field name
+	_fieldExtraMinutes = "extra_minutes"  // table field name
+	_fieldOpensAt      = "exam_opens_at"  // table field name
+	_fieldClosesAt     = "exam_closes_at" // table field name
+	_fieldDuration     = "exam_duration"  // table field name
+	_fieldReminded     = "exam_reminded"  // table field name
+)
+
+// Ensure RepoDB implements interface.
+var _ exam.RepositoryDB = (*RepoDB)(nil)
+
+// RepoDB is an exam DB repo.
+// It implements the [exam.RepositoryDB] interface.
+type RepoDB struct {
+	dbStorage *gorm.DB
+}
+
+// NewRepoDB returns a new instance of [RepoDB].
+func NewRepoDB(dbStorage *gorm.DB) *RepoDB {
+	return &RepoDB{
+		dbStorage: dbStorage,
+	}
+}
+
+// SetExam saves exam window and attempt duration of the task
+// (null duration turns off exam mode). Students will be reminded of the new closing datetime.
+func (r *RepoDB) SetExam(taskObj *entity.Task) error {
+	return r.dbStorage.
+		Model(&entity.Task{}).
+		Where(_fieldID+" = ?", taskObj.ID).
+		Updates(map[string]any{
+			_fieldOpensAt:  taskObj.ExamOpensAt,
+			_fieldClosesAt: taskObj.ExamClosesAt,
+			_fieldDuration: taskObj.ExamDuration,
+			_fieldReminded: false,
+		}).Error // nil OR error
+}
+
+// GetAttempt returns the attempt of the solution.
+func (r *RepoDB) GetAttempt(solutionID int) (*entity.ExamAttempt, error) {
+	var attemptObj entity.ExamAttempt
+	err := r.dbStorage.
+		Where(_fieldSolutionID+" = ?", solutionID).
+		First(&attemptObj).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("attempt of solution %d: %w", solutionID, exam.ErrNotFoundAttempt)
+	}
+	return &attemptObj, err // err OR nil
+}
+
+// GetAttempts returns attempts of all task solutions.
+func (r *RepoDB) GetAttempts(taskID int) ([]entity.ExamAttempt, error) {
+	attempts := []entity.ExamAttempt{}
+	err := r.dbStorage.
+		Joins("JOIN solution ON solution.id = exam_attempt.solution_id").
+		Where("solution.task_id = ?", taskID).
+		Order(_fieldSolutionID).
+		Find(&attempts).Error
+	if err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+// Begin sets the start datetime of the solution attempt.
+// It returns false if the attempt is already begun.
+func (r *RepoDB) Begin(solutionID int, startedAt time.Time) (bool, error) {
+	begun := false
+	err := r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		// lock the attempt record until the end of the transaction
+		var attemptObj entity.ExamAttempt
+		err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where(_fieldSolutionID+" = ?", solutionID).
+			First(&attemptObj).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = tx.Create(&entity.ExamAttempt{
+				SolutionID: solutionID,
+				StartedAt:  &startedAt,
+			}).Error
+			if errors.Is(err, gorm.ErrForeignKeyViolated) {
+				return fmt.Errorf("solution with id: %w", exam.ErrNotFound)
+			}
+			// attempt was begun concurrently
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return nil
+			}
+			begun = err == nil
+			return err // err OR nil
+		}
+		if err != nil {
+			return err
+		}
+		if attemptObj.StartedAt != nil {
+			return nil
+		}
+		// begin the attempt with extra minutes granted before its start
+		err = tx.Model(&entity.ExamAttempt{}).
+			Where(_fieldSolutionID+" = ?", solutionID).
+			Update(_fieldStartedAt, startedAt).Error
+		begun = err == nil
+		return err // err OR nil
+	})
+	return begun, err
+}
+
+// SetExtra sets extra minutes of the solution attempt.
+// The attempt is created (not begun) if it does not exist.
+func (r *RepoDB) SetExtra(solutionID, minutes int) error {
+	err := r.dbStorage.
+		Clauses(clause.OnConflict{
+			DoUpdates: clause.AssignmentColumns([]string{_fieldExtraMinutes}),
+		}).
+		Create(&entity.ExamAttempt{SolutionID: solutionID, ExtraMinutes: minutes}).Error
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return fmt.Errorf("solution with id: %w", exam.ErrNotFound)
+	}
+	return err // err OR nil
+}
@@ -0,0 +1,39 @@
+// Package exam contains all repos, usecases and controllers for tasks in exam mode
+// (timed attempts of students within the exam window).
+// Sub-package repo contains RepoDB implementation.
+// Sub-package usecase contains UsecaseTeacher, UsecaseStudent and UsecaseClient implementations.
+package exam
+
+import (
+	"time"
+
+	"skadi/backend/internal/app/entity"
+)
+
+// UsecaseTeacher describes all exam usecases for teacher.
+type UsecaseTeacher interface {
+	// SetExam turns on exam mode of the own task with the given window and attempt duration
+	// (or changes them). It returns the updated task object.
+	SetExam(teacherID, taskID int, opensAt, closesAt *time.Time,
+		duration int) (*entity.Task, error)
+	// DeleteExam turns off exam mode of the own task. Attempts are kept.
+	DeleteExam(teacherID, taskID int) error
+	// GetAttempts returns attempts of all students for the own task in exam mode.
+	GetAttempts(teacherID, taskID int) ([]entity.ExamAttempt, error)
+	// GrantExtra sets extra minutes of the student attempt (it can be set before the attempt).
+	// It returns the updated attempt.
+	GrantExtra(teacherID, solutionID, minutes int) (*entity.ExamAttempt, error)
+}
+
+// UsecaseStudent describes all exam usecases for student.
+type UsecaseStudent interface {
+	// Begin begins the attempt of the own solution (the attempt timer is started).
+	// It returns the begun attempt with the deadline.
+	Begin(studID, solutionID int) (*entity.ExamAttempt, error)
+}
+
+// UsecaseClient describes all exam usecases for teacher and student.
+type UsecaseClient interface {
+	// GetAttempt returns the attempt of the solution with the deadline.
+	GetAttempt(solutionID int, userClaims *entity.UserClaims) (*entity.ExamAttempt, error)
+}
@@ -0,0 +1,82 @@
+// Package usecase contains exam.UsecaseTeacher, exam.UsecaseStudent and
+// exam.UsecaseClient implementations.
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/exam"
+	"skadi/backend/internal/app/solution"
+)
+
+const (
+	_backlogStatusID = 1 // ID of solution status "backlog"
+	_inWorkStatusID  = 2 // ID of solution status "in-work"
+)
+
+// Ensure UCClient implements interfaces.
+var _ exam.UsecaseClient = (*UCClient)(nil)
+
+// UCClient represents an exam usecase for teacher and student.
+// It implements the [exam.UsecaseClient] interface.
+type UCClient struct {
+	cfg        *config.Config
+	examRepoDB exam.RepositoryDB
+	solRepoDB  solution.RepositoryDB
+}
+
+// NewUCClient returns a new instance of [UCClient].
+func NewUCClient(cfg *config.Config, examRepoDB exam.RepositoryDB,
+	solRepoDB solution.RepositoryDB) *UCClient {
+
+	return &UCClient{
+		cfg:        cfg,
+		examRepoDB: examRepoDB,
+		solRepoDB:  solRepoDB,
+	}
+}
+
+// GetAttempt returns the attempt of the solution with the deadline.
+func (u *UCClient) GetAttempt(solutionID int,
+	userClaims *entity.UserClaims) (*entity.ExamAttempt, error) {
+
+	// check user rights for this solution
+	err := u.solRepoDB.UserPermit(solutionID, userClaims)
+	if errors.Is(err, solution.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %w", exam.ErrNotFound, err)
+	}
+	if errors.Is(err, solution.ErrForbidden) {
+		return nil, fmt.Errorf("%w: %w", exam.ErrForbidden, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("check solution permissions: %w", err)
+	}
+
+	solObj, err := getSolution(u.solRepoDB, solutionID)
+	if err != nil {
+		return nil, err
+	}
+	if solObj.Attempt == nil {
+		return nil, fmt.Errorf("attempt of solution %d: %w", solutionID, exam.ErrNotFoundAttempt)
+	}
+	solObj.Attempt.SetDeadline(solObj.Task)
+	return solObj.Attempt, nil
+}
+
+// getSolution returns the solution (with task and attempt) of the task in exam mode.
+func getSolution(solRepoDB solution.RepositoryDB, solutionID int) (*entity.Solution, error) {
+	solObj, err := solRepoDB.GetByID(solutionID)
+	if errors.Is(err, solution.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %w", exam.ErrNotFound, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get solution: %w", err)
+	}
+	if !solObj.Task.IsExam() {
+		return nil, fmt.Errorf("task %d: %w", solObj.TaskID, exam.ErrNotExam)
+	}
+	return solObj, nil
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/event"
+	"skadi/backend/internal/app/exam"
+	"skadi/backend/internal/app/solution"
+)
+
+// Ensure UCStudent implements interfaces.
+var _ exam.UsecaseStudent = (*UCStudent)(nil)
+
+// UCStudent represents an exam usecase for student.
+// It implements the [exam.UsecaseStudent] interface.
+type UCStudent struct {
+	cfg        *config.Config
+	examRepoDB exam.RepositoryDB
+	solRepoDB  solution.RepositoryDB
+	evtPub     event.Publisher
+}
+
+// NewUCStudent returns a new instance of [UCStudent].
+func NewUCStudent(cfg *config.Config, examRepoDB exam.RepositoryDB,
+	solRepoDB solution.RepositoryDB, evtPub event.Publisher) *UCStudent {
+
+	return &UCStudent{
+		cfg:        cfg,
+		examRepoDB: examRepoDB,
+		solRepoDB:  solRepoDB,
+		evtPub:     evtPub,
+	}
+}
+
+// Begin begins the attempt of the own solution (the attempt timer is started).
+// The attempt can be begun once and only while the exam is open.
+// The solution status is changed from "backlog" to "in-work".
+// It returns the begun attempt with the deadline.
+func (u *UCStudent) Begin(studID, solutionID int) (*entity.ExamAttempt, error) {
+	solObj, err := getSolution(u.solRepoDB, solutionID)
+	if err != nil {
+		return nil, err
+	}
+	if !solObj.IsMember(studID) {
+		return nil, fmt.Errorf("%w: user (student) is not a solution member", exam.ErrForbidden)
+	}
+	// drafts are not visible for students
+	if solObj.Task.Draft {
+		return nil, fmt.Errorf("solution with id: %w: task is a draft", exam.ErrNotFound)
+	}
+	if solObj.Attempt != nil && solObj.Attempt.StartedAt != nil {
+		return nil, fmt.Errorf("solution %d: %w", solutionID, exam.ErrStarted)
+	}
+	now := time.Now()
+	if solObj.Task.ExamOpensAt != nil && now.Before(*solObj.Task.ExamOpensAt) {
+		return nil, fmt.Errorf("%w: exam opens at %s", exam.ErrNotOpen, solObj.Task.ExamOpensAt)
+	}
+	if solObj.Task.ExamClosesAt != nil && !now.Before(*solObj.Task.ExamClosesAt) {
+		return nil, fmt.Errorf("%w: exam closed at %s", exam.ErrNotOpen, solObj.Task.ExamClosesAt)
+	}
+
+	begun, err := u.examRepoDB.Begin(solutionID, now)
+	if err != nil {
+		return nil, fmt.Errorf("begin attempt: %w", err)
+	}
+	if !begun {
+		return nil, fmt.Errorf("solution %d: %w", solutionID, exam.ErrStarted)
+	}
+	attemptObj, err := u.examRepoDB.GetAttempt(solutionID)
+	if err != nil {
+		return nil, fmt.Errorf("get attempt: %w", err)
+	}
+	attemptObj.SetDeadline(solObj.Task)
+	solObj.Attempt = attemptObj
+
+	if solObj.StatusID == _backlogStatusID {
+		statusID := _inWorkStatusID
+		newData := &entity.SolutionUpdate{StatusID: &statusID}
+		if err := u.solRepoDB.Update(solutionID, newData); err != nil {
+			return nil, fmt.Errorf("update solution: %w", err)
+		}
+		solObj.StatusID = statusID
+		solObj.UpdatedAt = newData.UpdatedAt
+	}
+	// notify teacher and other team members that the attempt (shared by the team) was begun
+	u.evtPub.Publish(&entity.Event{Type: entity.EventSolutionUpdated, Data: solObj},
+		solObj.Task.TeacherID)
+	event.PublishToMembers(u.evtPub, entity.EventSolutionUpdated, solObj, studID)
+	return attemptObj, nil
+}
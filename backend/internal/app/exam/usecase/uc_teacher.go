@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/event"
+	"skadi/backend/internal/app/exam"
+	"skadi/backend/internal/app/solution"
+	"skadi/backend/internal/app/task"
+)
+
+// Ensure UCTeacher implements interfaces.
+var _ exam.UsecaseTeacher = (*UCTeacher)(nil)
+
+// UCTeacher represents an exam usecase for teacher.
+// It implements the [exam.UsecaseTeacher] interface.
+type UCTeacher struct {
+	cfg        *config.Config
+	examRepoDB exam.RepositoryDB
+	taskRepoDB task.RepositoryDB
+	solRepoDB  solution.RepositoryDB
+	evtPub     event.Publisher
+}
+
+// NewUCTeacher returns a new instance of [UCTeacher].
+func NewUCTeacher(cfg *config.Config, examRepoDB exam.RepositoryDB,
+	taskRepoDB task.RepositoryDB, solRepoDB solution.RepositoryDB,
+	evtPub event.Publisher) *UCTeacher {
+
+	return &UCTeacher{
+		cfg:        cfg,
+		examRepoDB: examRepoDB,
+		taskRepoDB: taskRepoDB,
+		solRepoDB:  solRepoDB,
+		evtPub:     evtPub,
+	}
+}
+
+// SetExam turns on exam mode of the own task with the given window and attempt duration
+// (or changes them). Begun attempts get the new duration too.
+// It returns the updated task object.
+func (u *UCTeacher) SetExam(teacherID, taskID int, opensAt, closesAt *time.Time,
+	duration int) (*entity.Task, error) {
+
+	if opensAt != nil && closesAt != nil && !closesAt.After(*opensAt) {
+		return nil, fmt.Errorf("%w: exam must close after it opens", exam.ErrInvalidData)
+	}
+	taskObj, err := u.getOwnTask(teacherID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	taskObj.ExamOpensAt = opensAt
+	taskObj.ExamClosesAt = closesAt
+	taskObj.ExamDuration = &duration
+	if err := u.examRepoDB.SetExam(taskObj); err != nil {
+		return nil, fmt.Errorf("set exam: %w", err)
+	}
+	return taskObj, nil
+}
+
+// DeleteExam turns off exam mode of the own task. Attempts are kept,
+// but solutions can be changed without them.
+func (u *UCTeacher) DeleteExam(teacherID, taskID int) error {
+	taskObj, err := u.getOwnTask(teacherID, taskID)
+	// return nil error if task was not found
+	if errors.Is(err, exam.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	taskObj.ExamOpensAt = nil
+	taskObj.ExamClosesAt = nil
+	taskObj.ExamDuration = nil
+	return u.examRepoDB.SetExam(taskObj)
+}
+
+// GetAttempts returns attempts of all students for the own task in exam mode.
+func (u *UCTeacher) GetAttempts(teacherID, taskID int) ([]entity.ExamAttempt, error) {
+	taskObj, err := u.getOwnTask(teacherID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if !taskObj.IsExam() {
+		return nil, fmt.Errorf("task %d: %w", taskID, exam.ErrNotExam)
+	}
+	attempts, err := u.examRepoDB.GetAttempts(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("get attempts: %w", err)
+	}
+	for idx := range attempts {
+		attempts[idx].SetDeadline(taskObj)
+	}
+	return attempts, nil
+}
+
+// GrantExtra sets extra minutes of the student attempt (it can be set before the attempt).
+// It returns the updated attempt.
+func (u *UCTeacher) GrantExtra(teacherID, solutionID,
+	minutes int) (*entity.ExamAttempt, error) {
+
+	solObj, err := getSolution(u.solRepoDB, solutionID)
+	if err != nil {
+		return nil, err
+	}
+	if teacherID != solObj.Task.TeacherID {
+		return nil, fmt.Errorf("%w: user (teacher) is not a task owner", exam.ErrForbidden)
+	}
+	if err := u.examRepoDB.SetExtra(solutionID, minutes); err != nil {
+		return nil, fmt.Errorf("set extra minutes: %w", err)
+	}
+	attemptObj, err := u.examRepoDB.GetAttempt(solutionID)
+	if err != nil {
+		return nil, fmt.Errorf("get attempt: %w", err)
+	}
+	attemptObj.SetDeadline(solObj.Task)
+	solObj.Attempt = attemptObj
+
+	// notify students (all team members) about the new deadline
+	event.PublishToMembers(u.evtPub, entity.EventSolutionUpdated, solObj, teacherID)
+	return attemptObj, nil
+}
+
+// getOwnTask returns the task if it exists and belongs to the teacher.
+func (u *UCTeacher) getOwnTask(teacherID, taskID int) (*entity.Task, error) {
+	taskObj, err := u.taskRepoDB.GetByID(taskID)
+	if errors.Is(err, task.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %w", exam.ErrNotFound, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get task: %w", err)
+	}
+	if taskObj.TeacherID != teacherID {
+		return nil, fmt.Errorf("%w: user (teacher) is not a task owner", exam.ErrForbidden)
+	}
+	return taskObj, nil
+}
@@ -3,9 +3,13 @@ package v1
 import (
 	"errors"
 	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
 
 	fiber "github.com/gofiber/fiber/v2"
 
+	"skadi/backend/config"
 	"skadi/backend/internal/app/file"
 	"skadi/backend/internal/pkg/httperror"
 	"skadi/backend/internal/pkg/serialize"
@@ -17,15 +21,19 @@ import (
 type FileController struct {
 	valid        validator.Validator
 	fileUCClient file.UsecaseClient
+	accelPrefix  string
+	accelRoot    string
 }
 
 // NewController returns a new instance of [FileController].
-func NewController(fileUCClient file.UsecaseClient,
+func NewController(cfg *config.Config, fileUCClient file.UsecaseClient,
 	valid validator.Validator) *FileController {
 
 	return &FileController{
 		valid:        valid,
 		fileUCClient: fileUCClient,
+		accelPrefix:  cfg.Media.FileLink.AccelPrefix,
+		accelRoot:    cfg.Media.FileLink.AccelRoot,
 	}
 }
 
@@ -39,8 +47,9 @@ func NewController(fileUCClient file.UsecaseClient,
 // @param			id	path	int	true	"ID файла"
 // @success		200	"файл"
 // @failure		401	"неверный токен (пустой, истекший или неверный формат)"
-// @failure		403	"доступ запрещён"
+// @failure		403	"доступ запрещён | файл заражён вирусом и помещён в карантин"
 // @failure		404	"файл не найден"
+// @failure		423	"файл ещё не проверен антивирусом"
 func (c *FileController) Download(ctx *fiber.Ctx) error {
 	// parse user claims
 	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
@@ -66,6 +75,9 @@ func (c *FileController) Download(ctx *fiber.Ctx) error {
 			Message:    "файл не найден",
 		}
 	}
+	if httpErr := scanError(err); httpErr != nil {
+		return httpErr
+	}
 	if err != nil {
 		return err
 	}
@@ -77,3 +89,209 @@ func (c *FileController) Download(ctx *fiber.Ctx) error {
 	// send file
 	return ctx.SendFile(fileObj.Path)
 }
+
+// @summary		Загрузка превью файла по id. [Преподаватель и ученик]
+// @description	Загрузка превью (JPEG) для изображения или первой страницы PDF-документа по id файла.
+// @description	Превью создаётся в фоне после загрузки файла, поэтому может быть ещё не готово.
+// @router			/file/{id}/preview [get]
+// @id				file-preview
+// @tags			file
+// @accept			json
+// @produce		jpeg
+// @security		JWTAccess
+// @param			id	path	int	true	"ID файла"
+// @success		200	"превью файла"
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён | файл заражён вирусом и помещён в карантин"
+// @failure		404	"файл не найден | превью не найдено"
+// @failure		423	"файл ещё не проверен антивирусом"
+func (c *FileController) Preview(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &fileIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	// get file with existing preview from DB
+	fileObj, err := c.fileUCClient.GetPreviewByID(inputPath.ID, userClaims)
+	if errors.Is(err, file.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, file.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "файл не найден",
+		}
+	}
+	if httpErr := scanError(err); httpErr != nil {
+		return httpErr
+	}
+	if errors.Is(err, file.ErrPreviewNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "превью не найдено",
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	// set MIME-type
+	ctx.Set("Content-Type", "image/jpeg")
+	// send preview
+	return ctx.SendFile(fileObj.PreviewPath())
+}
+
+// @summary		Получение подписанной ссылки на файл. [Преподаватель и ученик]
+// @description	Получение короткоживущей подписанной ссылки для загрузки файла без авторизации (для внешних просмотрщиков и мобильных приложений).
+// @router			/file/{id}/link [get]
+// @id				file-link
+// @tags			file
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id	path		int	true	"ID файла"
+// @success		200	{object}	entity.FileLink
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён | файл заражён вирусом и помещён в карантин"
+// @failure		404	"файл не найден"
+// @failure		423	"файл ещё не проверен антивирусом"
+func (c *FileController) Link(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &fileIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	// get signed link with permission check
+	fileLink, err := c.fileUCClient.GetLinkByID(inputPath.ID, userClaims)
+	if errors.Is(err, file.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, file.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "файл не найден",
+		}
+	}
+	if httpErr := scanError(err); httpErr != nil {
+		return httpErr
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(fileLink)
+}
+
+// @summary		Загрузка файла по подписанной ссылке.
+// @description	Загрузка файла по короткоживущей подписанной ссылке без авторизации.
+// @router			/shared-file/{id} [get]
+// @id				file-download-by-link
+// @tags			file
+// @accept			json
+// @param			id				path	int				true	"ID файла"
+// @param			fileLinkQuery	query	fileLinkQuery	true	"fileLinkQuery"
+// @success		200				"файл"
+// @failure		403				"неверная ссылка | срок действия ссылки истёк | файл заражён вирусом и помещён в карантин"
+// @failure		404				"файл не найден"
+// @failure		423				"файл ещё не проверен антивирусом"
+func (c *FileController) DownloadByLink(ctx *fiber.Ctx) error {
+	inputPath := &fileIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputQuery := &fileLinkQuery{}
+	if err := serialize.Deserialize(inputQuery, ctx.QueryParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	// get file by signed link
+	fileObj, err := c.fileUCClient.GetByLink(inputPath.ID, inputQuery.Exp, inputQuery.Sig)
+	if errors.Is(err, file.ErrInvalidLink) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "неверная ссылка",
+		}
+	}
+	if errors.Is(err, file.ErrLinkExpired) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "срок действия ссылки истёк",
+		}
+	}
+	if errors.Is(err, file.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "файл не найден",
+		}
+	}
+	if httpErr := scanError(err); httpErr != nil {
+		return httpErr
+	}
+	if err != nil {
+		return err
+	}
+
+	// set filename
+	ctx.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileObj.Name))
+	// set MIME-type
+	ctx.Set("Content-Type", fileObj.MimeType)
+	// hand off sending file to nginx if it is enabled
+	if accelPath, ok := c.accelPath(fileObj.Path); ok {
+		ctx.Set("X-Accel-Redirect", accelPath)
+		return ctx.SendStatus(fiber.StatusOK)
+	}
+	// send file
+	return ctx.SendFile(fileObj.Path)
+}
+
+// accelPath returns a path for the X-Accel-Redirect header.
+// It returns false if X-Accel-Redirect is disabled or file is out of the accel root dir.
+func (c *FileController) accelPath(filePath string) (string, bool) {
+	if c.accelPrefix == "" {
+		return "", false
+	}
+	relPath, err := filepath.Rel(c.accelRoot, filePath)
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		return "", false
+	}
+	return path.Join(c.accelPrefix, filepath.ToSlash(relPath)), true
+}
+
+// scanError converts errors of the file antivirus scanning to HTTP errors.
+// It returns nil if the error is not related to scanning.
+func scanError(err error) *httperror.HTTPError {
+	if errors.Is(err, file.ErrNotScanned) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusLocked,
+			Message:    "файл ещё не проверен антивирусом",
+		}
+	}
+	if errors.Is(err, file.ErrInfected) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "файл заражён вирусом и помещён в карантин",
+		}
+	}
+	return nil
+}
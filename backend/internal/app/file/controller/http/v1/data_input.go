@@ -5,3 +5,11 @@ type fileIDPath struct {
 	// file id
 	ID int `params:"id" validate:"required" example:"2"`
 }
+
+// @description fileLinkQuery represents a data with signed file link params in query.
+type fileLinkQuery struct {
+	// link expiration datetime (unix seconds)
+	Exp int64 `query:"exp" validate:"required" example:"1767225600"`
+	// link signature
+	Sig string `query:"sig" validate:"required,hexadecimal" example:"3a7bd3e2"`
+}
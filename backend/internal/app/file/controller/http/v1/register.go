@@ -15,6 +15,11 @@ func RegisterEndpoints(router fiber.Router, controller *FileController,
 
 	mwTeacherStudent := mwAllow(entity.Teacher, entity.Student)
 
+	// public (access is checked by the link signature)
+	router.Get("/shared-file/:id", controller.DownloadByLink)
+	// authenticated only
 	authGroup := router.Group("/file", mwJWTAccess)
 	authGroup.Get("/:id", mwTeacherStudent, controller.Download)
+	authGroup.Get("/:id/preview", mwTeacherStudent, controller.Preview)
+	authGroup.Get("/:id/link", mwTeacherStudent, controller.Link)
 }
@@ -5,4 +5,12 @@ import "errors"
 var (
 	ErrForbidden = errors.New("forbidden")        // code 403
 	ErrNotFound  = errors.New("record not found") // code 404
+
+	ErrPreviewNotFound = errors.New("preview not found") // code 404
+
+	ErrInvalidLink = errors.New("invalid link") // code 403
+	ErrLinkExpired = errors.New("link expired") // code 403
+
+	ErrNotScanned = errors.New("file is not scanned") // code 423
+	ErrInfected   = errors.New("file is infected")    // code 403
 )
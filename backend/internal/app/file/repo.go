@@ -10,4 +10,8 @@ type RepositoryDB interface {
 	TeacherPermit(teacherID, fileID int) error
 	// StudentPermit returns nil error if student has rights to the given file.
 	StudentPermit(studentID, fileID int) error
+	// GetManyByScanStatus returns files (not more than limit) with one of the given scan statuses.
+	GetManyByScanStatus(limit int, statuses ...entity.ScanStatus) (entity.Files, error)
+	// UpdateScanStatus sets scan status and new path for the file with the given path.
+	UpdateScanStatus(path string, status entity.ScanStatus, newPath string) error
 }
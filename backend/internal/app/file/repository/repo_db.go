@@ -63,6 +63,30 @@ func (r *RepoDB) TeacherPermit(teacherID, fileID int) error {
 		return nil
 	}
 
+	// check tasks shared with the teacher
+	/*
+		task:
+		    task_share:
+		        teacher_id
+		    files
+	*/
+	var sharedTaskIDs []int
+	err = r.dbStorage.
+		Model(&entity.Task{}).
+		Select("task.id").
+		Joins("INNER JOIN task_share ON task_share.task_id = task.id").
+		Joins("INNER JOIN task_file ON task_file.task_id = task.id").
+		Where("task_share.teacher_id = ?", teacherID).
+		Where("task_file.file_id = ?", fileID).
+		Scan(&sharedTaskIDs).Error
+	if err != nil {
+		return fmt.Errorf("get shared tasks with file: %w", err)
+	}
+	// if the file was found in one of the tasks shared with the teacher
+	if len(sharedTaskIDs) > 0 {
+		return nil
+	}
+
 	// check solutions
 	/*
 		solution:
@@ -87,25 +111,56 @@ func (r *RepoDB) TeacherPermit(teacherID, fileID int) error {
 		return nil
 	}
 
+	// check solution comments
+	/*
+		comment:
+		    solution:
+		        task:
+		            teacher_id
+		    files
+	*/
+	var commentIDs []int
+	err = r.dbStorage.
+		Model(&entity.Comment{}).
+		Select("comment.id").
+		Joins("INNER JOIN solution ON solution.id = comment.solution_id").
+		Joins("INNER JOIN task ON task.id = solution.task_id").
+		Joins("INNER JOIN comment_file ON comment_file.comment_id = comment.id").
+		Where("task.teacher_id = ?", teacherID).
+		Where("comment_file.file_id = ?", fileID).
+		Scan(&commentIDs).Error
+	if err != nil {
+		return fmt.Errorf("get comments with file with teacher tasks: %w", err)
+	}
+	// if the file was found in a comment to the solution linked to one of the teacher tasks
+	if len(commentIDs) > 0 {
+		return nil
+	}
+
 	// forbidden error
 	return fmt.Errorf("%w: user %d (teacher) has no one relationship with file %d",
 		file.ErrForbidden, teacherID, fileID)
 }
 
 // StudentPermit returns nil error if student has rights to the given file.
+// Files of the draft tasks and their solutions are not available for students.
+// Files of the exam tasks are not available before the exam attempt is begun.
 func (r *RepoDB) StudentPermit(studentID, fileID int) error {
 	// check solutions
 	/*
 		solution:
-		    student_id
+		    members
 		    files
 	*/
 	var solIDs []int
 	err := r.dbStorage.
 		Model(&entity.Solution{}).
 		Select("solution.id").
+		Joins("INNER JOIN task ON task.id = solution.task_id").
 		Joins("INNER JOIN solution_file ON solution_file.solution_id = solution.id").
-		Where("solution.student_id = ?", studentID).
+		Joins("INNER JOIN solution_member ON solution_member.solution_id = solution.id").
+		Where("solution_member.student_id = ?", studentID).
+		Where("task.draft = ?", false).
 		Where("solution_file.file_id = ?", fileID).
 		Scan(&solIDs).Error
 	if err != nil {
@@ -119,7 +174,8 @@ func (r *RepoDB) StudentPermit(studentID, fileID int) error {
 	// check tasks
 	/*
 		solution:
-		    student_id
+		    members
+		    exam attempt
 		    task:
 		        files
 	*/
@@ -129,7 +185,11 @@ func (r *RepoDB) StudentPermit(studentID, fileID int) error {
 		Select("task.id").
 		Joins("INNER JOIN solution ON solution.task_id = task.id").
 		Joins("INNER JOIN task_file ON task_file.task_id = task.id").
-		Where("solution.student_id = ?", studentID).
+		Joins("INNER JOIN solution_member ON solution_member.solution_id = solution.id").
+		Joins("LEFT JOIN exam_attempt ON exam_attempt.solution_id = solution.id").
+		Where("solution_member.student_id = ?", studentID).
+		Where("task.draft = ?", false).
+		Where("task.exam_duration IS NULL OR exam_attempt.started_at IS NOT NULL").
 		Where("task_file.file_id = ?", fileID).
 		Scan(&taskIDs).Error
 	if err != nil {
@@ -140,7 +200,67 @@ func (r *RepoDB) StudentPermit(studentID, fileID int) error {
 		return nil
 	}
 
+	// check solution comments
+	/*
+		comment:
+		    solution:
+		        student_id
+		    files
+	*/
+	var commentIDs []int
+	err = r.dbStorage.
+		Model(&entity.Comment{}).
+		Select("comment.id").
+		Joins("INNER JOIN solution ON solution.id = comment.solution_id").
+		Joins("INNER JOIN task ON task.id = solution.task_id").
+		Joins("INNER JOIN comment_file ON comment_file.comment_id = comment.id").
+		Joins("INNER JOIN solution_member ON solution_member.solution_id = solution.id").
+		Where("solution_member.student_id = ?", studentID).
+		Where("task.draft = ?", false).
+		Where("comment_file.file_id = ?", fileID).
+		Scan(&commentIDs).Error
+	if err != nil {
+		return fmt.Errorf("get comments with file for student solutions: %w", err)
+	}
+	// if the file was found in a comment to one of the student solutions
+	if len(commentIDs) > 0 {
+		return nil
+	}
+
 	// forbidden error
 	return fmt.Errorf("%w: user %d (stud) has no one relationship with file %d",
 		file.ErrForbidden, studentID, fileID)
 }
+
+// GetManyByScanStatus returns files (not more than limit) with one of the given scan statuses.
+func (r *RepoDB) GetManyByScanStatus(limit int,
+	statuses ...entity.ScanStatus) (entity.Files, error) {
+
+	var files entity.Files
+	err := r.dbStorage.
+		Where("scan_status IN ?", statuses).
+		Order("id").
+		Limit(limit).
+		Find(&files).Error
+	return files, err // err OR nil
+}
+
+// UpdateScanStatus sets scan status and new path for the file with the given path.
+// It returns file.ErrNotFound if there is no file with such path
+// (e.g. file object is not inserted to DB yet).
+func (r *RepoDB) UpdateScanStatus(path string, status entity.ScanStatus, newPath string) error {
+	result := r.dbStorage.
+		Model(&entity.File{}).
+		Where("path = ?", path).
+		Updates(map[string]any{
+			"scan_status": status,
+			"path":        newPath,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("file with path %s: %w", path, file.ErrNotFound)
+	}
+	return nil
+}
@@ -9,4 +9,10 @@ import "skadi/backend/internal/app/entity"
 type UsecaseClient interface {
 	// GetByID returns file metadata by the given ID.
 	GetByID(fileID int, userClaims *entity.UserClaims) (*entity.File, error)
+	// GetPreviewByID returns file metadata by the given ID if the file preview exists.
+	GetPreviewByID(fileID int, userClaims *entity.UserClaims) (*entity.File, error)
+	// GetLinkByID returns a signed short-lived link to download the file without auth.
+	GetLinkByID(fileID int, userClaims *entity.UserClaims) (*entity.FileLink, error)
+	// GetByLink returns file metadata by the given ID if the link signature is valid.
+	GetByLink(fileID int, exp int64, signature string) (*entity.File, error)
 }
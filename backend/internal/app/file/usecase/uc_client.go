@@ -2,11 +2,20 @@
 package usecase
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
 	"skadi/backend/config"
 	"skadi/backend/internal/app/entity"
 	"skadi/backend/internal/app/file"
+	"skadi/backend/internal/pkg/urlsign"
 )
 
+const _fileLinkURL = "/api/v1/shared-file/%d?exp=%d&sig=%s" // URL template for the signed file link
+
 // Ensure UCClient implements interfaces.
 var _ file.UsecaseClient = (*UCClient)(nil)
 
@@ -15,6 +24,7 @@ var _ file.UsecaseClient = (*UCClient)(nil)
 type UCClient struct {
 	cfg        *config.Config
 	fileRepoDB file.RepositoryDB
+	linkSigner *urlsign.Signer
 }
 
 // NewUCClient returns a new instance of [UCClient].
@@ -22,6 +32,7 @@ func NewUCClient(cfg *config.Config, fileRepoDB file.RepositoryDB) *UCClient {
 	return &UCClient{
 		cfg:        cfg,
 		fileRepoDB: fileRepoDB,
+		linkSigner: urlsign.NewSigner(cfg.Media.FileLink.Secret),
 	}
 }
 
@@ -44,5 +55,83 @@ func (u *UCClient) GetByID(fileID int, userClaims *entity.UserClaims) (*entity.F
 	if err := checkPermit(userClaims.ID, fileID); err != nil {
 		return nil, err
 	}
+	if err := u.checkScanStatus(fileObj); err != nil {
+		return nil, err
+	}
+	return fileObj, nil
+}
+
+// GetPreviewByID returns file metadata by the given ID if the file preview exists.
+func (u *UCClient) GetPreviewByID(fileID int,
+	userClaims *entity.UserClaims) (*entity.File, error) {
+
+	// get file with permission check
+	fileObj, err := u.GetByID(fileID, userClaims)
+	if err != nil {
+		return nil, err
+	}
+	// preview could be not supported for the file or not made yet
+	if fileObj.PreviewURL == "" {
+		return nil, fmt.Errorf("%w: file %d has unsupported MIME-type %s",
+			file.ErrPreviewNotFound, fileID, fileObj.MimeType)
+	}
+	_, err = os.Stat(fileObj.PreviewPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: preview for file %d is not ready",
+			file.ErrPreviewNotFound, fileID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("stat preview: %w", err)
+	}
+	return fileObj, nil
+}
+
+// GetLinkByID returns a signed short-lived link to download the file without auth.
+func (u *UCClient) GetLinkByID(fileID int,
+	userClaims *entity.UserClaims) (*entity.FileLink, error) {
+
+	// check file existence and permission
+	if _, err := u.GetByID(fileID, userClaims); err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(u.cfg.Media.FileLink.TTL).Truncate(time.Second)
+	signature := u.linkSigner.Sign(fileID, expiresAt)
+	return &entity.FileLink{
+		URL:       fmt.Sprintf(_fileLinkURL, fileID, expiresAt.Unix(), signature),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// GetByLink returns file metadata by the given ID if the link signature is valid.
+func (u *UCClient) GetByLink(fileID int, exp int64, signature string) (*entity.File, error) {
+	// check signature
+	err := u.linkSigner.Verify(fileID, exp, signature)
+	if errors.Is(err, urlsign.ErrExpired) {
+		return nil, fmt.Errorf("%w: file %d", file.ErrLinkExpired, fileID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: file %d: %s", file.ErrInvalidLink, fileID, err.Error())
+	}
+	// get file
+	fileObj, err := u.fileRepoDB.GetByID(fileID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.checkScanStatus(fileObj); err != nil {
+		return nil, err
+	}
 	return fileObj, nil
 }
+
+// checkScanStatus returns error if the file cannot be downloaded because of its scan status.
+func (u *UCClient) checkScanStatus(fileObj *entity.File) error {
+	if !fileObj.ScanBlocked(u.cfg.Media.Scan.Enabled) {
+		return nil
+	}
+	if fileObj.ScanStatus == entity.ScanInfected {
+		return fmt.Errorf("%w: file %d", file.ErrInfected, fileObj.ID)
+	}
+	return fmt.Errorf("%w: file %d has scan status %s",
+		file.ErrNotScanned, fileObj.ID, fileObj.ScanStatus)
+}
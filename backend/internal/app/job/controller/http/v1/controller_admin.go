@@ -0,0 +1,76 @@
+package v1
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/job"
+	"skadi/backend/internal/pkg/serialize"
+	"skadi/backend/internal/pkg/validator"
+)
+
+// JobControllerAdmin represents a controller for job routes accepted for admin only.
+type JobControllerAdmin struct {
+	valid      validator.Validator
+	jobUCAdmin job.UsecaseAdmin
+}
+
+// NewControllerAdmin returns a new instance of [JobControllerAdmin].
+func NewControllerAdmin(jobUCAdmin job.UsecaseAdmin,
+	valid validator.Validator) *JobControllerAdmin {
+
+	return &JobControllerAdmin{
+		valid:      valid,
+		jobUCAdmin: jobUCAdmin,
+	}
+}
+
+// @summary		Список фоновых задач. [Только админ]
+// @description	Получение списка фоновых задач планировщика с расписанием (cron, UTC),
+// @description	временем следующего запуска и последним запуском.
+// @router			/job [get]
+// @id				job-list
+// @tags			job
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @success		200	{array}	entity.Job
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+func (c *JobControllerAdmin) List(ctx *fiber.Ctx) error {
+	jobs, err := c.jobUCAdmin.List()
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(jobs)
+}
+
+// @summary		История запусков фоновых задач. [Только админ]
+// @description	Получение истории запусков фоновых задач (сначала новые): экземпляр бэкенда,
+// @description	время запуска по расписанию, начала и окончания, ошибка неудачного запуска.
+// @router			/job/run [get]
+// @id				job-list-runs
+// @tags			job
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			listRunQuery	query		listRunQuery	false	"listRunQuery"
+// @success		200				{object}	listRunOut
+// @failure		401				"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403				"доступ запрещён"
+func (c *JobControllerAdmin) ListRuns(ctx *fiber.Ctx) error {
+	inputQuery := &listRunQuery{}
+	if err := serialize.Deserialize(inputQuery, ctx.QueryParser, c.valid.Validate); err != nil {
+		return err
+	}
+	pageParams := inputQuery.ToPagination()
+
+	runs, err := c.jobUCAdmin.ListRuns(inputQuery.Job, pageParams)
+	if err != nil {
+		return err
+	}
+	output := &listRunOut{
+		Data:       runs,
+		Pagination: pageParams,
+	}
+	return ctx.Status(fiber.StatusOK).JSON(output)
+}
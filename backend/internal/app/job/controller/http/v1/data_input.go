@@ -0,0 +1,11 @@
+package v1
+
+import "skadi/backend/internal/app/entity"
+
+// @description listRunQuery represents a data with optional query-params to get job run history.
+type listRunQuery struct {
+	// job name to filter runs
+	Job string `query:"job,omitempty" json:"job" validate:"omitempty,max=64" example:"mail_digest"`
+	// pagination params
+	entity.PaginationQuery
+}
@@ -0,0 +1,11 @@
+package v1
+
+import "skadi/backend/internal/app/entity"
+
+// @description listRunOut represents a job run history and pagination params.
+type listRunOut struct {
+	// runs list (newest first)
+	Data []entity.JobRun `json:"data" validate:"required"`
+	// pagination params
+	Pagination *entity.Pagination `json:"pagination,omitempty" validate:"omitempty"`
+}
@@ -0,0 +1,19 @@
+// Package http/v1 is a first version of job HTTP-controller.
+// It provides registers for job HTTP-routes and controller with handlers for them.
+package v1
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/service/server/middleware"
+)
+
+// RegisterEndpoints registers all job endpoints.
+func RegisterEndpoints(router fiber.Router, controllerAdmin *JobControllerAdmin,
+	mwJWTAccess fiber.Handler, mwAllow middleware.AllowFunc) {
+
+	authGroup := router.Group("/job", mwJWTAccess, mwAllow(entity.Admin))
+	authGroup.Get("/", controllerAdmin.List)
+	authGroup.Get("/run", controllerAdmin.ListRuns)
+}
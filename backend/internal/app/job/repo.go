@@ -0,0 +1,22 @@
+package job
+
+import (
+	"time"
+
+	"skadi/backend/internal/app/entity"
+)
+
+// RepositoryDB describes all DB methods for job run history.
+type RepositoryDB interface {
+	// CreateRun creates a new job run and fills given struct.
+	CreateRun(runObj *entity.JobRun) error
+	// FinishRun sets the finish datetime and the error (nil for successful run) of the job run.
+	FinishRun(id int, finishedAt time.Time, runErr *string) error
+	// GetRuns returns job runs (newest first).
+	// Job param appends condition to filter runs by the job name.
+	GetRuns(job string, page *entity.Pagination) ([]entity.JobRun, error)
+	// GetLastRuns returns the last run of every job.
+	GetLastRuns() ([]entity.JobRun, error)
+	// DeleteRuns deletes job runs started before the given time.
+	DeleteRuns(before time.Time) (int64, error)
+}
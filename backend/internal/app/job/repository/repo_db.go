@@ -0,0 +1,98 @@
+// Package repository contains job.RepositoryDB implementation.
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/job"
+)
+
+const (
+	_fieldID         = "id"          // table field name
+	_fieldJob        = "job"         // table field name
+	_fieldStartedAt  = "started_at"  // table field name
+	_fieldFinishedAt = "finished_at" // table field name
+	_fieldError      = "error"       // table field name
+
+	_orderByIDDESC = "id DESC" // condition to order data by id DESC
+)
+
+// Ensure RepoDB implements interface.
+var _ job.RepositoryDB = (*RepoDB)(nil)
+
+// RepoDB is a job DB repo.
+// It implements the [job.RepositoryDB] interface.
+type RepoDB struct {
+	dbStorage *gorm.DB
+}
+
+// NewRepoDB returns a new instance of [RepoDB].
+func NewRepoDB(dbStorage *gorm.DB) *RepoDB {
+	return &RepoDB{
+		dbStorage: dbStorage,
+	}
+}
+
+// CreateRun creates a new job run and fills given struct.
+func (r *RepoDB) CreateRun(runObj *entity.JobRun) error {
+	return r.dbStorage.Create(runObj).Error // nil OR error
+}
+
+// FinishRun sets the finish datetime and the error (nil for successful run) of the job run.
+func (r *RepoDB) FinishRun(id int, finishedAt time.Time, runErr *string) error {
+	return r.dbStorage.
+		Model(&entity.JobRun{}).
+		Where(_fieldID+" = ?", id).
+		Updates(map[string]any{
+			_fieldFinishedAt: finishedAt,
+			_fieldError:      runErr,
+		}).Error // nil OR error
+}
+
+// GetRuns returns job runs (newest first).
+// Job param appends condition to filter runs by the job name.
+func (r *RepoDB) GetRuns(job string, page *entity.Pagination) ([]entity.JobRun, error) {
+	runs := []entity.JobRun{}
+	// create query
+	query := r.dbStorage.Model(&entity.JobRun{})
+	if job != "" {
+		query = query.Where(_fieldJob+" = ?", job)
+	}
+	query = query.Order(_orderByIDDESC)
+	// apply pagination if it's not nil
+	if page != nil {
+		page.CountTotal(query)
+		query = page.Query(query)
+	}
+	// exec query
+	if err := query.Find(&runs).Error; err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// GetLastRuns returns the last run of every job.
+func (r *RepoDB) GetLastRuns() ([]entity.JobRun, error) {
+	runs := []entity.JobRun{}
+	err := r.dbStorage.
+		Where(_fieldID+" IN (?)", r.dbStorage.
+			Model(&entity.JobRun{}).
+			Select("MAX("+_fieldID+")").
+			Group(_fieldJob)).
+		Find(&runs).Error
+	if err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// DeleteRuns deletes job runs started before the given time.
+func (r *RepoDB) DeleteRuns(before time.Time) (int64, error) {
+	res := r.dbStorage.
+		Where(_fieldStartedAt+" < ?", before).
+		Delete(&entity.JobRun{})
+	return res.RowsAffected, res.Error
+}
@@ -0,0 +1,15 @@
+// Package job contains all repos, usecases and controllers for scheduled background jobs.
+// Sub-package repo contains RepoDB implementation.
+// Sub-package usecase contains UsecaseAdmin implementation.
+package job
+
+import "skadi/backend/internal/app/entity"
+
+// UsecaseAdmin describes all job usecases for admin.
+type UsecaseAdmin interface {
+	// List returns all scheduled jobs with next run time and the last run.
+	List() ([]entity.Job, error)
+	// ListRuns returns job run history (newest first).
+	// Job param appends condition to filter runs by the job name.
+	ListRuns(job string, page *entity.Pagination) ([]entity.JobRun, error)
+}
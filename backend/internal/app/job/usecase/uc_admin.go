@@ -0,0 +1,71 @@
+// Package usecase contains job.UsecaseAdmin implementation.
+package usecase
+
+import (
+	"fmt"
+	goslices "slices"
+	"strings"
+	"time"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/job"
+	"skadi/backend/internal/pkg/cron"
+)
+
+// Ensure UCAdmin implements interfaces.
+var _ job.UsecaseAdmin = (*UCAdmin)(nil)
+
+// UCAdmin represents a job usecase for admin.
+// It implements the [job.UsecaseAdmin] interface.
+type UCAdmin struct {
+	cfg       *config.Config
+	jobRepoDB job.RepositoryDB
+}
+
+// NewUCAdmin returns a new instance of [UCAdmin].
+func NewUCAdmin(cfg *config.Config, jobRepoDB job.RepositoryDB) *UCAdmin {
+	return &UCAdmin{
+		cfg:       cfg,
+		jobRepoDB: jobRepoDB,
+	}
+}
+
+// List returns all scheduled jobs (sorted by name) with next run time and the last run.
+// Next run time is empty for disabled jobs or if the scheduler is disabled.
+func (u *UCAdmin) List() ([]entity.Job, error) {
+	lastRuns, err := u.jobRepoDB.GetLastRuns()
+	if err != nil {
+		return nil, fmt.Errorf("get last runs: %w", err)
+	}
+	lastRunMap := make(map[string]*entity.JobRun, len(lastRuns))
+	for idx := range lastRuns {
+		lastRunMap[lastRuns[idx].Job] = &lastRuns[idx]
+	}
+
+	now := time.Now().UTC()
+	jobs := make([]entity.Job, 0, len(u.cfg.Scheduler.Jobs))
+	for name, spec := range u.cfg.Scheduler.Jobs {
+		jobObj := entity.Job{
+			Name:     name,
+			Schedule: spec,
+			LastRun:  lastRunMap[name],
+		}
+		if sched, err := cron.Parse(spec); err == nil && u.cfg.Scheduler.Enabled {
+			if next := sched.Next(now); !next.IsZero() {
+				jobObj.NextRunAt = &next
+			}
+		}
+		jobs = append(jobs, jobObj)
+	}
+	goslices.SortFunc(jobs, func(a, b entity.Job) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return jobs, nil
+}
+
+// ListRuns returns job run history (newest first).
+// Job param appends condition to filter runs by the job name.
+func (u *UCAdmin) ListRuns(job string, page *entity.Pagination) ([]entity.JobRun, error) {
+	return u.jobRepoDB.GetRuns(job, page)
+}
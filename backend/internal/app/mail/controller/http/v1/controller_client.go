@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"errors"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/mail"
+	"skadi/backend/internal/pkg/httperror"
+	"skadi/backend/internal/pkg/serialize"
+	utilsjwt "skadi/backend/internal/pkg/utils/jwt"
+	"skadi/backend/internal/pkg/validator"
+)
+
+// MailController represents a controller for the mail routes.
+type MailController struct {
+	valid        validator.Validator
+	mailUCClient mail.UsecaseClient
+}
+
+// NewController returns a new instance of [MailController].
+func NewController(mailUCClient mail.UsecaseClient, valid validator.Validator) *MailController {
+	return &MailController{
+		valid:        valid,
+		mailUCClient: mailUCClient,
+	}
+}
+
+// @summary		Получение настроек e-mail рассылки. [Преподаватель и ученик]
+// @description	Получение настроек e-mail рассылки текущего пользователя.
+// @description	По умолчанию уведомления присылаются ежедневной сводкой (daily).
+// @router			/mail/prefs [get]
+// @id				mail-get-prefs
+// @tags			mail
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @success		200	{object}	entity.MailPref
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+func (c *MailController) GetPref(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	pref, err := c.mailUCClient.GetPref(userClaims.ID)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(pref)
+}
+
+// @summary		Изменение настроек e-mail рассылки. [Преподаватель и ученик]
+// @description	Выбор режима e-mail уведомлений: off - не присылать, immediate - сразу, daily - ежедневной сводкой.
+// @description	Ученик может включить еженедельный отчёт об успеваемости на почту родителя (parent_summary).
+// @description	Письма отправляются на почту из контактов профиля (отчёт - на почту из контактов родителя).
+// @router			/mail/prefs [put]
+// @id				mail-update-prefs
+// @tags			mail
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			mailPrefBody	body		mailPrefBody	true	"mailPrefBody"
+// @success		200				{object}	entity.MailPref
+// @failure		400				"отчёт родителю доступен только ученикам"
+// @failure		401				"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403				"доступ запрещён"
+func (c *MailController) UpdatePref(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputBody := &mailPrefBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	pref, err := c.mailUCClient.UpdatePref(userClaims, inputBody.ToEntity())
+	if errors.Is(err, mail.ErrInvalidData) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "отчёт родителю доступен только ученикам",
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(pref)
+}
@@ -0,0 +1,19 @@
+package v1
+
+import "skadi/backend/internal/app/entity"
+
+// @description mailPrefBody represents a data to update e-mail preferences.
+type mailPrefBody struct {
+	// e-mail notifications mode: off, immediate (every notification at once) or daily (digest)
+	Mode string `json:"mode" validate:"required,oneof=off immediate daily" example:"daily"`
+	// if true, weekly progress summary is sent to the parent contact (for students only)
+	ParentSummary bool `json:"parent_summary" example:"true"`
+}
+
+// ToEntity returns e-mail preferences object.
+func (b *mailPrefBody) ToEntity() *entity.MailPref {
+	return &entity.MailPref{
+		Mode:          entity.MailMode(b.Mode),
+		ParentSummary: b.ParentSummary,
+	}
+}
@@ -0,0 +1,19 @@
+// Package http/v1 is a first version of mail HTTP-controller.
+// It provides registers for mail HTTP-routes and controller with handlers for them.
+package v1
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/service/server/middleware"
+)
+
+// RegisterEndpoints registers all mail endpoints.
+func RegisterEndpoints(router fiber.Router, controller *MailController,
+	mwJWTAccess fiber.Handler, mwAllow middleware.AllowFunc) {
+
+	authGroup := router.Group("/mail", mwJWTAccess, mwAllow(entity.Teacher, entity.Student))
+	authGroup.Get("/prefs", controller.GetPref)
+	authGroup.Put("/prefs", controller.UpdatePref)
+}
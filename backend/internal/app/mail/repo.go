@@ -0,0 +1,49 @@
+package mail
+
+import (
+	"time"
+
+	"skadi/backend/internal/app/entity"
+)
+
+// RepositoryDB describes all DB methods for e-mails.
+type RepositoryDB interface {
+	// Enqueue puts the given mails to the send queue.
+	Enqueue(mails []entity.Mail) error
+	// GetDue returns unsent mails which next attempt time has come.
+	GetDue(now time.Time, maxAttempts, limit int) ([]entity.Mail, error)
+	// Claim postpones the next attempt of the mail until the given time.
+	// It returns false if the mail was already claimed by another worker.
+	Claim(mailObj *entity.Mail, until time.Time) (bool, error)
+	// MarkSent sets send datetime for the mail.
+	MarkSent(id int, sentAt time.Time) error
+	// MarkFailed saves the failed attempt of the mail and the next attempt time.
+	MarkFailed(id, attempts int, nextAttemptAt time.Time, lastErr string) error
+
+	// GetPref returns saved user preferences for e-mails.
+	GetPref(userID int) (*entity.MailPref, error)
+	// SavePref creates or updates user preferences for e-mails.
+	SavePref(pref *entity.MailPref) error
+
+	// GetRecipients returns the given users with e-mails and the given mail mode.
+	GetRecipients(userIDs []int, mode entity.MailMode) ([]entity.MailRecipient, error)
+	// GetDigestRecipients returns users with daily mode and notifications
+	// created since their last digest (or since the given time for the first digest).
+	GetDigestRecipients(since time.Time) ([]entity.MailRecipient, error)
+	// EnqueueDigest puts the digest mail to the send queue and sets the digest datetime
+	// for the user in one transaction if the last digest was sent before the given time.
+	// It returns false if the digest was already sent (e.g. by another backend instance).
+	EnqueueDigest(mailObj *entity.Mail, userID int, sentAt, before time.Time) (bool, error)
+	// GetNotifications returns user notifications created in the given period.
+	GetNotifications(userID int, from, to time.Time) ([]entity.Notification, error)
+
+	// GetSummaryRecipients returns students with enabled parent summaries
+	// and e-mails of their parent contacts, whose last summary was sent before the given time.
+	GetSummaryRecipients(before time.Time) ([]entity.MailRecipient, error)
+	// EnqueueSummary puts the summary mail to the send queue and sets the summary datetime
+	// for the student in one transaction if the last summary was sent before the given time.
+	// It returns false if the summary was already sent (e.g. by another backend instance).
+	EnqueueSummary(mailObj *entity.Mail, userID int, sentAt, before time.Time) (bool, error)
+	// GetStudentSolutions returns all student solutions (drafts are skipped) with tasks and statuses.
+	GetStudentSolutions(studentID int) ([]entity.Solution, error)
+}
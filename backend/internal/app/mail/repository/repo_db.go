@@ -0,0 +1,283 @@
+// Package repository contains mail.RepositoryDB implementation.
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/mail"
+)
+
+const (
+	_preloadTask   = "Task"   // object field name
+	_preloadStatus = "Status" // object field name
+
+	_fieldID            = "id"              // table field name
+	_fieldUserID        = "user_id"         // table field name
+	_fieldMode          = "mode"            // table field name
+	_fieldParentSummary = "parent_summary"  // table field name
+	_fieldAttempts      = "attempts"        // table field name
+	_fieldLastError     = "last_error"      // table field name
+	_fieldNextAttemptAt = "next_attempt_at" // table field name
+	_fieldSentAt        = "sent_at"         // table field name
+	_fieldDigestSentAt  = "digest_sent_at"  // table field name
+	_fieldSummarySentAt = "summary_sent_at" // table field name
+
+	// select recipient fields (profile, contact and mail_pref must be joined)
+	_selectRecipient = "profile.id AS user_id, profile.fullname, contact.email"
+	// join the user contact with e-mail
+	_joinContact = "INNER JOIN contact ON contact.id = profile.contact_id " +
+		"AND contact.email IS NOT NULL AND contact.email <> ''"
+	// join the parent contact with e-mail (as contact)
+	_joinParentContact = "INNER JOIN contact ON contact.id = profile.parent_contact_id " +
+		"AND contact.email IS NOT NULL AND contact.email <> ''"
+	// join the user mail preferences (they may not exist)
+	_joinMailPref = "LEFT JOIN mail_pref ON mail_pref.user_id = profile.id"
+	// join the membership of the student (student ID is a query param)
+	_joinMember = "INNER JOIN solution_member ON solution_member.solution_id = solution.id " +
+		"AND solution_member.student_id = ?"
+	// condition for the user mail mode (default mode and mode are query params)
+	_condMode = "COALESCE(mail_pref.mode, ?) = ?"
+	// condition for the user notifications created since the last digest
+	// (first digest time is a query param)
+	_condNewNotifications = "EXISTS (SELECT 1 FROM notification WHERE notification.user_id = profile.id " +
+		"AND notification.created_at > COALESCE(mail_pref.digest_sent_at, ?))"
+)
+
+// Ensure RepoDB implements interface.
+var _ mail.RepositoryDB = (*RepoDB)(nil)
+
+// RepoDB is a mail DB repo.
+// It implements the [mail.RepositoryDB] interface.
+type RepoDB struct {
+	dbStorage *gorm.DB
+}
+
+// NewRepoDB returns a new instance of [RepoDB].
+func NewRepoDB(dbStorage *gorm.DB) *RepoDB {
+	return &RepoDB{
+		dbStorage: dbStorage,
+	}
+}
+
+// Enqueue puts the given mails to the send queue.
+func (r *RepoDB) Enqueue(mails []entity.Mail) error {
+	if len(mails) == 0 {
+		return nil
+	}
+	return r.dbStorage.Create(&mails).Error // nil OR error
+}
+
+// GetDue returns unsent mails which next attempt time has come.
+func (r *RepoDB) GetDue(now time.Time, maxAttempts, limit int) ([]entity.Mail, error) {
+	mails := []entity.Mail{}
+	err := r.dbStorage.
+		Where(_fieldSentAt+" IS NULL").
+		Where(_fieldNextAttemptAt+" <= ?", now).
+		Where(_fieldAttempts+" < ?", maxAttempts).
+		Order(_fieldNextAttemptAt).
+		Limit(limit).
+		Find(&mails).Error
+	if err != nil {
+		return nil, err
+	}
+	return mails, nil
+}
+
+// Claim postpones the next attempt of the mail until the given time.
+// It returns false if the mail was already claimed by another worker.
+func (r *RepoDB) Claim(mailObj *entity.Mail, until time.Time) (bool, error) {
+	res := r.dbStorage.
+		Model(&entity.Mail{}).
+		Where(_fieldID+" = ? AND "+_fieldSentAt+" IS NULL", mailObj.ID).
+		Where(_fieldNextAttemptAt+" = ?", mailObj.NextAttemptAt).
+		Update(_fieldNextAttemptAt, until)
+	return res.RowsAffected == 1, res.Error
+}
+
+// MarkSent sets send datetime for the mail.
+func (r *RepoDB) MarkSent(id int, sentAt time.Time) error {
+	return r.dbStorage.
+		Model(&entity.Mail{}).
+		Where(_fieldID+" = ?", id).
+		Update(_fieldSentAt, sentAt).Error // nil OR error
+}
+
+// MarkFailed saves the failed attempt of the mail and the next attempt time.
+func (r *RepoDB) MarkFailed(id, attempts int, nextAttemptAt time.Time, lastErr string) error {
+	return r.dbStorage.
+		Model(&entity.Mail{}).
+		Where(_fieldID+" = ?", id).
+		Updates(map[string]any{
+			_fieldAttempts:      attempts,
+			_fieldNextAttemptAt: nextAttemptAt,
+			_fieldLastError:     lastErr,
+		}).Error // nil OR error
+}
+
+// GetPref returns saved user preferences for e-mails.
+func (r *RepoDB) GetPref(userID int) (*entity.MailPref, error) {
+	var pref entity.MailPref
+	err := r.dbStorage.Where(_fieldUserID+" = ?", userID).First(&pref).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// preferences were not saved yet
+		return nil, fmt.Errorf("mail pref with user id: %w", mail.ErrNotFound)
+	}
+	return &pref, err // err OR nil
+}
+
+// SavePref creates or updates user preferences for e-mails.
+func (r *RepoDB) SavePref(pref *entity.MailPref) error {
+	return r.dbStorage.
+		Clauses(clause.OnConflict{
+			DoUpdates: clause.AssignmentColumns([]string{_fieldMode, _fieldParentSummary}),
+		}).
+		Create(pref).Error // nil OR error
+}
+
+// GetRecipients returns the given users with e-mails and the given mail mode.
+func (r *RepoDB) GetRecipients(userIDs []int,
+	mode entity.MailMode) ([]entity.MailRecipient, error) {
+
+	recipients := []entity.MailRecipient{}
+	if len(userIDs) == 0 {
+		return recipients, nil
+	}
+	err := r.dbStorage.
+		Table("profile").
+		Select(_selectRecipient).
+		Joins(_joinContact).
+		Joins(_joinMailPref).
+		Where("profile.id IN ?", userIDs).
+		Where(_condMode, entity.MailDaily, mode).
+		Scan(&recipients).Error
+	if err != nil {
+		return nil, err
+	}
+	return recipients, nil
+}
+
+// GetDigestRecipients returns users with daily mode and notifications
+// created since their last digest (or since the given time for the first digest).
+func (r *RepoDB) GetDigestRecipients(since time.Time) ([]entity.MailRecipient, error) {
+	recipients := []entity.MailRecipient{}
+	err := r.dbStorage.
+		Table("profile").
+		Select(_selectRecipient+", mail_pref.digest_sent_at AS last_sent_at").
+		Joins(_joinContact).
+		Joins(_joinMailPref).
+		Where(_condMode, entity.MailDaily, entity.MailDaily).
+		Where(_condNewNotifications, since).
+		Scan(&recipients).Error
+	if err != nil {
+		return nil, err
+	}
+	return recipients, nil
+}
+
+// EnqueueDigest puts the digest mail to the send queue and sets the digest datetime
+// for the user in one transaction if the last digest was sent before the given time.
+// It returns false if the digest was already sent (e.g. by another backend instance).
+func (r *RepoDB) EnqueueDigest(mailObj *entity.Mail, userID int, sentAt, before time.Time) (bool, error) {
+	return r.claim(mailObj, _fieldDigestSentAt, userID, sentAt, before)
+}
+
+// GetNotifications returns user notifications created in the given period.
+func (r *RepoDB) GetNotifications(userID int, from, to time.Time) ([]entity.Notification, error) {
+	notifList := []entity.Notification{}
+	err := r.dbStorage.
+		Where(_fieldUserID+" = ?", userID).
+		Where("created_at > ? AND created_at <= ?", from, to).
+		Order(_fieldID).
+		Find(&notifList).Error
+	if err != nil {
+		return nil, err
+	}
+	return notifList, nil
+}
+
+// GetSummaryRecipients returns students with enabled parent summaries
+// and e-mails of their parent contacts, whose last summary was sent before the given time.
+func (r *RepoDB) GetSummaryRecipients(before time.Time) ([]entity.MailRecipient, error) {
+	recipients := []entity.MailRecipient{}
+	err := r.dbStorage.
+		Table("profile").
+		Select(_selectRecipient+", mail_pref.summary_sent_at AS last_sent_at").
+		Joins(_joinParentContact).
+		Joins(_joinMailPref).
+		Where("mail_pref."+_fieldParentSummary+" = ?", true).
+		Where("mail_pref."+_fieldSummarySentAt+" IS NULL OR mail_pref."+_fieldSummarySentAt+" < ?",
+			before).
+		Scan(&recipients).Error
+	if err != nil {
+		return nil, err
+	}
+	return recipients, nil
+}
+
+// EnqueueSummary puts the summary mail to the send queue and sets the summary datetime
+// for the student in one transaction if the last summary was sent before the given time.
+// It returns false if the summary was already sent (e.g. by another backend instance).
+func (r *RepoDB) EnqueueSummary(mailObj *entity.Mail, userID int, sentAt, before time.Time) (bool, error) {
+	return r.claim(mailObj, _fieldSummarySentAt, userID, sentAt, before)
+}
+
+// GetStudentSolutions returns all student solutions (drafts are skipped) with tasks and statuses.
+// Individual grades of the student replace the grades of team solutions.
+func (r *RepoDB) GetStudentSolutions(studentID int) ([]entity.Solution, error) {
+	solutions := []entity.Solution{}
+	err := r.dbStorage.
+		// the individual grade of the student replaces the solution one
+		Select("solution.id", "solution.task_id", "solution.student_id", "solution.status_id",
+			"solution.answer", "solution.updated_at",
+			"COALESCE(solution_member.grade, solution.grade) AS grade").
+		Preload(_preloadTask).
+		Preload(_preloadStatus).
+		Joins(_joinMember, studentID).
+		Where("solution.task_id NOT IN (SELECT id FROM task WHERE draft)"). // skip drafts
+		Order("solution.id").
+		Find(&solutions).Error
+	if err != nil {
+		return nil, err
+	}
+	return solutions, nil
+}
+
+// claim sets the given datetime field of the user preferences
+// if its value is null or before the given time and puts the mail to the send queue.
+// Preferences are created with default values if they do not exist.
+func (r *RepoDB) claim(mailObj *entity.Mail, field string, userID int,
+	sentAt, before time.Time) (bool, error) {
+
+	var claimed bool
+	err := r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&entity.MailPref{UserID: userID}).Error
+		if err != nil {
+			return fmt.Errorf("create default pref: %w", err)
+		}
+		res := tx.
+			Model(&entity.MailPref{}).
+			Where(_fieldUserID+" = ?", userID).
+			Where(field+" IS NULL OR "+field+" < ?", before).
+			Update(field, sentAt)
+		if res.Error != nil {
+			return fmt.Errorf("update pref: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		if err := tx.Create(mailObj).Error; err != nil {
+			return fmt.Errorf("enqueue mail: %w", err)
+		}
+		claimed = true
+		return nil
+	})
+	return claimed, err
+}
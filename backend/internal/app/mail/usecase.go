@@ -0,0 +1,29 @@
+// Package mail contains all repos, usecases and controllers for e-mails.
+// Sub-package repo contains RepoDB implementation.
+// Sub-package usecase contains UsecaseClient and UsecaseMailer implementations.
+package mail
+
+import (
+	"time"
+
+	"skadi/backend/internal/app/entity"
+)
+
+// UsecaseClient describes all mail usecases for teacher and student.
+type UsecaseClient interface {
+	// GetPref returns user preferences for e-mails.
+	GetPref(userID int) (*entity.MailPref, error)
+	// UpdatePref saves user preferences for e-mails.
+	UpdatePref(userClaims *entity.UserClaims, pref *entity.MailPref) (*entity.MailPref, error)
+}
+
+// UsecaseMailer describes usecases to compose e-mails and put them to the send queue.
+type UsecaseMailer interface {
+	// EnqueueNotifications enqueues e-mails with the given notifications
+	// for users who want to get them immediately.
+	EnqueueNotifications(notifList []entity.Notification) error
+	// EnqueueDigests enqueues daily digests with notifications created since the last digest.
+	EnqueueDigests(now time.Time) error
+	// EnqueueParentSummaries enqueues weekly progress summaries to the parent contacts of students.
+	EnqueueParentSummaries(now time.Time) error
+}
@@ -0,0 +1,58 @@
+// Package usecase contains mail.UsecaseClient and mail.UsecaseMailer implementations.
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/mail"
+)
+
+// Ensure UCClient implements interfaces.
+var _ mail.UsecaseClient = (*UCClient)(nil)
+
+// UCClient represents a mail usecase for teacher and student.
+// It implements the [mail.UsecaseClient] interface.
+type UCClient struct {
+	cfg        *config.Config
+	mailRepoDB mail.RepositoryDB
+}
+
+// NewUCClient returns a new instance of [UCClient].
+func NewUCClient(cfg *config.Config, mailRepoDB mail.RepositoryDB) *UCClient {
+	return &UCClient{
+		cfg:        cfg,
+		mailRepoDB: mailRepoDB,
+	}
+}
+
+// GetPref returns user preferences for e-mails.
+// If preferences were not saved yet, default ones are returned.
+func (u *UCClient) GetPref(userID int) (*entity.MailPref, error) {
+	pref, err := u.mailRepoDB.GetPref(userID)
+	if errors.Is(err, mail.ErrNotFound) {
+		return &entity.MailPref{UserID: userID, Mode: entity.MailDaily}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pref, nil
+}
+
+// UpdatePref saves user preferences for e-mails.
+// Parent summaries are available for students only.
+func (u *UCClient) UpdatePref(userClaims *entity.UserClaims,
+	pref *entity.MailPref) (*entity.MailPref, error) {
+
+	if pref.ParentSummary && !userClaims.IsStudent() {
+		return nil, fmt.Errorf("%w: parent summary is available for students only",
+			mail.ErrInvalidData)
+	}
+	pref.UserID = userClaims.ID
+	if err := u.mailRepoDB.SavePref(pref); err != nil {
+		return nil, fmt.Errorf("save pref: %w", err)
+	}
+	return u.GetPref(userClaims.ID)
+}
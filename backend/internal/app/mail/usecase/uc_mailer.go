@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"embed"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/mail"
+	pkgmail "skadi/backend/internal/pkg/mail"
+)
+
+const (
+	_tmplNotification  = "notification"   // template name of the immediate notification
+	_tmplDigest        = "digest"         // template name of the daily digest
+	_tmplParentSummary = "parent_summary" // template name of the weekly parent summary
+
+	_dateLayout     = "02.01.2006"       // date format in e-mails
+	_digestPeriod   = 24 * time.Hour     // period of the daily digest
+	_summaryPeriod  = 7 * 24 * time.Hour // period of the weekly parent summary
+	_checkedStatus  = 4                  // ID of solution status "checked"
+	_claimTolerance = time.Hour          // tolerance of the schedule to claim a digest or summary
+)
+
+//go:embed templates/*.html
+var _templatesFS embed.FS
+
+// e-mail templates are embedded to the binary, so they are parsed once at startup
+var _templates = func() *pkgmail.Templates {
+	templates, err := pkgmail.ParseTemplates(_templatesFS,
+		"templates/[^_]*.html", "templates/_layout.html")
+	if err != nil {
+		panic(fmt.Sprintf("parse mail templates: %v", err))
+	}
+	return templates
+}()
+
+// Ensure UCMailer implements interfaces.
+var _ mail.UsecaseMailer = (*UCMailer)(nil)
+
+// UCMailer represents a usecase to compose e-mails and put them to the send queue.
+// If e-mails are disabled in config, nothing is enqueued.
+// It implements the [mail.UsecaseMailer] interface.
+type UCMailer struct {
+	cfg        *config.Config
+	mailRepoDB mail.RepositoryDB
+}
+
+// NewUCMailer returns a new instance of [UCMailer].
+func NewUCMailer(cfg *config.Config, mailRepoDB mail.RepositoryDB) *UCMailer {
+	return &UCMailer{
+		cfg:        cfg,
+		mailRepoDB: mailRepoDB,
+	}
+}
+
+// EnqueueNotifications enqueues e-mails with the given notifications
+// for users who want to get them immediately.
+func (u *UCMailer) EnqueueNotifications(notifList []entity.Notification) error {
+	if !u.cfg.Mail.Enabled || len(notifList) == 0 {
+		return nil
+	}
+	userIDs := make([]int, len(notifList))
+	for idx := range notifList {
+		userIDs[idx] = notifList[idx].UserID
+	}
+	recipients, err := u.mailRepoDB.GetRecipients(userIDs, entity.MailImmediate)
+	if err != nil {
+		return fmt.Errorf("get recipients: %w", err)
+	}
+
+	mails := make([]entity.Mail, 0, len(recipients))
+	for _, recipient := range recipients {
+		for idx := range notifList {
+			if notifList[idx].UserID != recipient.UserID {
+				continue
+			}
+			mailObj, err := u.render(_tmplNotification, recipient.Email, map[string]any{
+				"SiteURL":      u.cfg.Mail.SiteURL,
+				"Fullname":     recipient.Fullname,
+				"Notification": &notifList[idx],
+			})
+			if err != nil {
+				return err
+			}
+			mails = append(mails, *mailObj)
+		}
+	}
+	return u.mailRepoDB.Enqueue(mails)
+}
+
+// EnqueueDigests enqueues daily digests with notifications created since the last digest.
+// Every user gets at most one digest a day even if several backend instances are running.
+// Failed digests are logged and the rest of users still get their digests.
+func (u *UCMailer) EnqueueDigests(now time.Time) error {
+	if !u.cfg.Mail.Enabled {
+		return nil
+	}
+	firstSince := now.Add(-_digestPeriod)
+	recipients, err := u.mailRepoDB.GetDigestRecipients(firstSince)
+	if err != nil {
+		return fmt.Errorf("get digest recipients: %w", err)
+	}
+
+	var failed int
+	for _, recipient := range recipients {
+		if err := u.enqueueDigest(&recipient, firstSince, now); err != nil {
+			slog.Error("enqueue digest", "user_id", recipient.UserID, "error", err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d digests failed", failed, len(recipients))
+	}
+	return nil
+}
+
+// enqueueDigest composes the digest for the recipient and puts it to the send queue.
+// The digest is skipped if it was already sent by another backend instance.
+func (u *UCMailer) enqueueDigest(recipient *entity.MailRecipient, firstSince, now time.Time) error {
+	since := firstSince
+	if recipient.LastSentAt != nil {
+		since = *recipient.LastSentAt
+	}
+	notifList, err := u.mailRepoDB.GetNotifications(recipient.UserID, since, now)
+	if err != nil {
+		return fmt.Errorf("get notifications: %w", err)
+	}
+	if len(notifList) == 0 {
+		return nil
+	}
+	mailObj, err := u.render(_tmplDigest, recipient.Email, map[string]any{
+		"SiteURL":       u.cfg.Mail.SiteURL,
+		"Fullname":      recipient.Fullname,
+		"Date":          now.Format(_dateLayout),
+		"Notifications": notifList,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = u.mailRepoDB.EnqueueDigest(mailObj, recipient.UserID, now,
+		now.Add(-_digestPeriod+_claimTolerance))
+	return err
+}
+
+// EnqueueParentSummaries enqueues weekly progress summaries to the parent contacts of students.
+// Every student parent gets at most one summary a week even if several backend instances are running.
+// Failed summaries are logged and the rest of parents still get their summaries.
+func (u *UCMailer) EnqueueParentSummaries(now time.Time) error {
+	if !u.cfg.Mail.Enabled {
+		return nil
+	}
+	before := now.Add(-_summaryPeriod + _claimTolerance)
+	recipients, err := u.mailRepoDB.GetSummaryRecipients(before)
+	if err != nil {
+		return fmt.Errorf("get summary recipients: %w", err)
+	}
+
+	var failed int
+	for _, recipient := range recipients {
+		mailObj, err := u.parentSummary(&recipient, now)
+		if err == nil {
+			_, err = u.mailRepoDB.EnqueueSummary(mailObj, recipient.UserID, now, before)
+		}
+		if err != nil {
+			slog.Error("enqueue parent summary", "student_id", recipient.UserID, "error", err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d summaries failed", failed, len(recipients))
+	}
+	return nil
+}
+
+// statusCount represents a number of student solutions with the status.
+type statusCount struct {
+	Name  string
+	Count int
+}
+
+// parentSummary composes a weekly progress summary of the student for the parent.
+func (u *UCMailer) parentSummary(recipient *entity.MailRecipient, now time.Time) (*entity.Mail, error) {
+	solutions, err := u.mailRepoDB.GetStudentSolutions(recipient.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("get solutions: %w", err)
+	}
+
+	from := now.Add(-_summaryPeriod)
+	var assigned, checked []entity.Solution
+	var statuses []statusCount
+	for _, sol := range solutions {
+		if sol.Task != nil && sol.Task.CreatedAt.After(from) {
+			assigned = append(assigned, sol)
+		}
+		if sol.StatusID == _checkedStatus && sol.UpdatedAt != nil && sol.UpdatedAt.After(from) {
+			checked = append(checked, sol)
+		}
+		if sol.Status == nil {
+			continue
+		}
+		found := false
+		for idx := range statuses {
+			if statuses[idx].Name == sol.Status.Name {
+				statuses[idx].Count++
+				found = true
+			}
+		}
+		if !found {
+			statuses = append(statuses, statusCount{Name: sol.Status.Name, Count: 1})
+		}
+	}
+
+	return u.render(_tmplParentSummary, recipient.Email, map[string]any{
+		"SiteURL":  u.cfg.Mail.SiteURL,
+		"Student":  recipient.Fullname,
+		"From":     from.Format(_dateLayout),
+		"To":       now.Format(_dateLayout),
+		"Assigned": assigned,
+		"Checked":  checked,
+		"Statuses": statuses,
+	})
+}
+
+// render renders the template and returns a mail object to enqueue.
+func (u *UCMailer) render(name, to string, data any) (*entity.Mail, error) {
+	msg, err := _templates.Render(name, to, data)
+	if err != nil {
+		return nil, fmt.Errorf("render %s: %w", name, err)
+	}
+	return &entity.Mail{
+		Recipient:     msg.To,
+		Subject:       msg.Subject,
+		Body:          msg.HTML,
+		NextAttemptAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,180 @@
+package v1
+
+import (
+	"errors"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/notification"
+	"skadi/backend/internal/pkg/httperror"
+	"skadi/backend/internal/pkg/serialize"
+	utilsjwt "skadi/backend/internal/pkg/utils/jwt"
+	"skadi/backend/internal/pkg/validator"
+)
+
+// NotificationController represents a controller for the notification routes.
+type NotificationController struct {
+	valid         validator.Validator
+	notifUCClient notification.UsecaseClient
+}
+
+// NewController returns a new instance of [NotificationController].
+func NewController(notifUCClient notification.UsecaseClient,
+	valid validator.Validator) *NotificationController {
+
+	return &NotificationController{
+		valid:         valid,
+		notifUCClient: notifUCClient,
+	}
+}
+
+// @summary		Получение уведомлений. [Преподаватель и ученик]
+// @description	Получение списка уведомлений текущего пользователя (сначала новые) и количества непрочитанных.
+// @description	Новые уведомления также приходят в потоке событий (notification.created).
+// @router			/notification [get]
+// @id				notification-list
+// @tags			notification
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			listNotificationQuery	query		listNotificationQuery	false	"listNotificationQuery"
+// @success		200						{object}	listNotificationOut
+// @failure		401						"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403						"доступ запрещён"
+func (c *NotificationController) List(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputQuery := &listNotificationQuery{}
+	if err := serialize.Deserialize(inputQuery, ctx.QueryParser, c.valid.Validate); err != nil {
+		return err
+	}
+	// get pagination object OR nil
+	pageParams := inputQuery.PaginationQuery.ToPagination()
+
+	notifList, unread, err := c.notifUCClient.List(userClaims.ID, inputQuery.Unread, pageParams)
+	if err != nil {
+		return err
+	}
+	output := &listNotificationOut{
+		Data:       notifList,
+		Unread:     unread,
+		Pagination: pageParams,
+	}
+	return ctx.Status(fiber.StatusOK).JSON(output)
+}
+
+// @summary		Отметка уведомления прочитанным. [Преподаватель и ученик]
+// @description	Отметка уведомления текущего пользователя прочитанным.
+// @router			/notification/{id}/read [post]
+// @id				notification-mark-read
+// @tags			notification
+// @accept			json
+// @security		JWTAccess
+// @param			id	path	int	true	"ID уведомления"
+// @success		204	"уведомление отмечено прочитанным"
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+// @failure		404	"уведомление не найдено"
+func (c *NotificationController) MarkRead(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &notificationIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	err := c.notifUCClient.MarkRead(userClaims.ID, inputPath.ID)
+	if errors.Is(err, notification.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "уведомление не найдено",
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusNoContent).JSON(nil)
+}
+
+// @summary		Отметка всех уведомлений прочитанными. [Преподаватель и ученик]
+// @description	Отметка всех уведомлений текущего пользователя прочитанными.
+// @router			/notification/read [post]
+// @id				notification-mark-all-read
+// @tags			notification
+// @accept			json
+// @security		JWTAccess
+// @success		204	"уведомления отмечены прочитанными"
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+func (c *NotificationController) MarkAllRead(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	if err := c.notifUCClient.MarkAllRead(userClaims.ID); err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusNoContent).JSON(nil)
+}
+
+// @summary		Получение настроек уведомлений. [Преподаватель и ученик]
+// @description	Получение настроек текущего пользователя для всех типов уведомлений.
+// @description	По умолчанию все типы уведомлений включены.
+// @router			/notification/prefs [get]
+// @id				notification-get-prefs
+// @tags			notification
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @success		200	{object}	prefsOut
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+func (c *NotificationController) GetPrefs(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	prefs, err := c.notifUCClient.GetPrefs(userClaims.ID)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(&prefsOut{Data: prefs})
+}
+
+// @summary		Изменение настроек уведомлений. [Преподаватель и ученик]
+// @description	Включение и отключение уведомлений переданных типов для текущего пользователя.
+// @description	Настройки для непереданных типов не изменяются.
+// @router			/notification/prefs [put]
+// @id				notification-update-prefs
+// @tags			notification
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			updatePrefsBody	body		updatePrefsBody	true	"updatePrefsBody"
+// @success		200				{object}	prefsOut
+// @failure		400				"неверный тип уведомления"
+// @failure		401				"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403				"доступ запрещён"
+func (c *NotificationController) UpdatePrefs(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputBody := &updatePrefsBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	prefs, err := c.notifUCClient.UpdatePrefs(userClaims.ID, inputBody.ToEntity())
+	if errors.Is(err, notification.ErrInvalidType) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "неверный тип уведомления",
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(&prefsOut{Data: prefs})
+}
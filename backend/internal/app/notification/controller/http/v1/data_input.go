@@ -0,0 +1,43 @@
+package v1
+
+import "skadi/backend/internal/app/entity"
+
+// @description listNotificationQuery represents a data with optional query-params to get notification list.
+type listNotificationQuery struct {
+	// if true, only unread notifications are returned
+	Unread bool `query:"unread,omitempty" json:"unread" example:"true"`
+	// pagination params
+	entity.PaginationQuery
+}
+
+// @description notificationIDPath represents a data with notification ID in path params.
+type notificationIDPath struct {
+	// notification id
+	ID int `params:"id" validate:"required" example:"12"`
+}
+
+// @description prefBody represents a user preference for the notification type.
+type prefBody struct {
+	// notification type
+	Type string `json:"type" validate:"required,oneof=task_assigned solution_submitted solution_checked new_comment deadline_approaching" example:"new_comment"`
+	// false to disable notifications of this type
+	Enabled *bool `json:"enabled" validate:"required" example:"false"`
+}
+
+// @description updatePrefsBody represents a data to update notification preferences.
+type updatePrefsBody struct {
+	// preferences for the notification types (other types are not changed)
+	Prefs []prefBody `json:"prefs" validate:"required,min=1,dive"`
+}
+
+// ToEntity returns a slice of notification preferences.
+func (b *updatePrefsBody) ToEntity() []entity.NotificationPref {
+	prefs := make([]entity.NotificationPref, len(b.Prefs))
+	for idx, pref := range b.Prefs {
+		prefs[idx] = entity.NotificationPref{
+			Type:    entity.NotificationType(pref.Type),
+			Enabled: *pref.Enabled,
+		}
+	}
+	return prefs
+}
@@ -0,0 +1,19 @@
+package v1
+
+import "skadi/backend/internal/app/entity"
+
+// @description listNotificationOut represents a notification list and pagination params.
+type listNotificationOut struct {
+	// notification list
+	Data []entity.Notification `json:"data" validate:"required"`
+	// number of all unread user notifications
+	Unread int64 `json:"unread" validate:"required" example:"4"`
+	// pagination params
+	Pagination *entity.Pagination `json:"pagination,omitempty" validate:"omitempty"`
+}
+
+// @description prefsOut represents user preferences for all notification types.
+type prefsOut struct {
+	// preferences list
+	Data []entity.NotificationPref `json:"data" validate:"required"`
+}
@@ -0,0 +1,22 @@
+// Package http/v1 is a first version of notification HTTP-controller.
+// It provides registers for notification HTTP-routes and controller with handlers for them.
+package v1
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/service/server/middleware"
+)
+
+// RegisterEndpoints registers all notification endpoints.
+func RegisterEndpoints(router fiber.Router, controller *NotificationController,
+	mwJWTAccess fiber.Handler, mwAllow middleware.AllowFunc) {
+
+	authGroup := router.Group("/notification", mwJWTAccess, mwAllow(entity.Teacher, entity.Student))
+	authGroup.Get("/", controller.List)
+	authGroup.Post("/read", controller.MarkAllRead)
+	authGroup.Post("/:id/read", controller.MarkRead)
+	authGroup.Get("/prefs", controller.GetPrefs)
+	authGroup.Put("/prefs", controller.UpdatePrefs)
+}
@@ -0,0 +1,8 @@
+package notification
+
+import "errors"
+
+var (
+	ErrNotFound    = errors.New("record not found") // code 404
+	ErrInvalidType = errors.New("invalid type")     // code 400
+)
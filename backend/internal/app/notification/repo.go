@@ -0,0 +1,30 @@
+package notification
+
+import (
+	"time"
+
+	"skadi/backend/internal/app/entity"
+)
+
+// RepositoryDB describes all DB methods for the notification object.
+type RepositoryDB interface {
+	// CreateMany creates the given notifications and fills their IDs.
+	CreateMany(notifList []entity.Notification) error
+	// GetByID returns notification by the given ID.
+	GetByID(id int) (*entity.Notification, error)
+	// GetMany returns slice of user notifications (newest first).
+	// UnreadOnly param appends condition to filter unread notifications.
+	GetMany(userID int, unreadOnly bool, page *entity.Pagination) ([]entity.Notification, error)
+	// CountUnread returns number of unread user notifications.
+	CountUnread(userID int) (int64, error)
+	// MarkRead sets read datetime for the given unread notifications of the user.
+	// If no IDs are given, all unread user notifications are marked.
+	MarkRead(userID int, readAt time.Time, ids ...int) error
+	// GetPrefs returns saved user preferences.
+	GetPrefs(userID int) ([]entity.NotificationPref, error)
+	// SavePrefs creates or updates the given preferences.
+	SavePrefs(prefs []entity.NotificationPref) error
+	// GetDisabledUserIDs returns IDs of the given users
+	// who have disabled notifications of the given type.
+	GetDisabledUserIDs(notifType entity.NotificationType, userIDs []int) ([]int, error)
+}
@@ -0,0 +1,149 @@
+// Package repository contains notification.RepositoryDB implementation.
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/notification"
+)
+
+const (
+	_fieldID      = "id"      // table field name
+	_fieldUserID  = "user_id" // table field name
+	_fieldType    = "type"    // table field name
+	_fieldEnabled = "enabled" // table field name
+	_fieldReadAt  = "read_at" // table field name
+)
+
+// Ensure RepoDB implements interface.
+var _ notification.RepositoryDB = (*RepoDB)(nil)
+
+// RepoDB is a notification DB repo.
+// It implements the [notification.RepositoryDB] interface.
+type RepoDB struct {
+	dbStorage *gorm.DB
+}
+
+// NewRepoDB returns a new instance of [RepoDB].
+func NewRepoDB(dbStorage *gorm.DB) *RepoDB {
+	return &RepoDB{
+		dbStorage: dbStorage,
+	}
+}
+
+// CreateMany creates the given notifications and fills their IDs.
+func (r *RepoDB) CreateMany(notifList []entity.Notification) error {
+	if len(notifList) == 0 {
+		return nil
+	}
+	return r.dbStorage.Create(&notifList).Error // nil OR error
+}
+
+// GetByID returns notification by the given ID.
+func (r *RepoDB) GetByID(id int) (*entity.Notification, error) {
+	var notifObj entity.Notification
+	err := r.dbStorage.Where(id).First(&notifObj).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// notification object with such id not found
+		return nil, fmt.Errorf("notification with id: %w", notification.ErrNotFound)
+	}
+	return &notifObj, err // err OR nil
+}
+
+// GetMany returns slice of user notifications (newest first).
+// UnreadOnly param appends condition to filter unread notifications.
+func (r *RepoDB) GetMany(userID int, unreadOnly bool,
+	page *entity.Pagination) ([]entity.Notification, error) {
+
+	notifList := []entity.Notification{}
+	// create query
+	query := r.dbStorage.
+		Model(&entity.Notification{}).
+		Where(_fieldUserID+" = ?", userID).
+		Order("id DESC")
+	if unreadOnly {
+		query = query.Where(_fieldReadAt + " IS NULL")
+	}
+	// apply pagination if it's not nil
+	if page != nil {
+		page.CountTotal(query)
+		query = page.Query(query)
+	}
+	// exec query
+	if err := query.Find(&notifList).Error; err != nil {
+		return nil, err
+	}
+	return notifList, nil
+}
+
+// CountUnread returns number of unread user notifications.
+func (r *RepoDB) CountUnread(userID int) (int64, error) {
+	var count int64
+	err := r.dbStorage.
+		Model(&entity.Notification{}).
+		Where(_fieldUserID+" = ? AND "+_fieldReadAt+" IS NULL", userID).
+		Count(&count).Error
+	return count, err // err OR nil
+}
+
+// MarkRead sets read datetime for the given unread notifications of the user.
+// If no IDs are given, all unread user notifications are marked.
+func (r *RepoDB) MarkRead(userID int, readAt time.Time, ids ...int) error {
+	query := r.dbStorage.
+		Model(&entity.Notification{}).
+		Where(_fieldUserID+" = ? AND "+_fieldReadAt+" IS NULL", userID)
+	if len(ids) != 0 {
+		query = query.Where(_fieldID+" IN ?", ids)
+	}
+	return query.Update(_fieldReadAt, readAt).Error // nil OR error
+}
+
+// GetPrefs returns saved user preferences.
+func (r *RepoDB) GetPrefs(userID int) ([]entity.NotificationPref, error) {
+	prefs := []entity.NotificationPref{}
+	err := r.dbStorage.
+		Where(_fieldUserID+" = ?", userID).
+		Find(&prefs).Error
+	if err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// SavePrefs creates or updates the given preferences.
+func (r *RepoDB) SavePrefs(prefs []entity.NotificationPref) error {
+	if len(prefs) == 0 {
+		return nil
+	}
+	return r.dbStorage.
+		Clauses(clause.OnConflict{
+			DoUpdates: clause.AssignmentColumns([]string{_fieldEnabled}),
+		}).
+		Create(&prefs).Error // nil OR error
+}
+
+// GetDisabledUserIDs returns IDs of the given users
+// who have disabled notifications of the given type.
+func (r *RepoDB) GetDisabledUserIDs(notifType entity.NotificationType,
+	userIDs []int) ([]int, error) {
+
+	disabled := []int{}
+	if len(userIDs) == 0 {
+		return disabled, nil
+	}
+	err := r.dbStorage.
+		Model(&entity.NotificationPref{}).
+		Where(_fieldType+" = ? AND "+_fieldEnabled+" = ?", notifType, false).
+		Where(_fieldUserID+" IN ?", userIDs).
+		Pluck(_fieldUserID, &disabled).Error
+	if err != nil {
+		return nil, err
+	}
+	return disabled, nil
+}
@@ -0,0 +1,31 @@
+// Package notification contains all repos, usecases and controllers for notification.
+// Sub-package repo contains RepoDB implementation.
+// Sub-package usecase contains UsecaseClient and Notifier implementations.
+package notification
+
+import "skadi/backend/internal/app/entity"
+
+// UsecaseClient describes all notification usecases for teacher and student.
+type UsecaseClient interface {
+	// List returns slice of user notifications (newest first) and number of unread ones.
+	// UnreadOnly param appends condition to filter unread notifications.
+	List(userID int, unreadOnly bool,
+		page *entity.Pagination) ([]entity.Notification, int64, error)
+	// MarkRead marks the user notification as read.
+	MarkRead(userID, notificationID int) error
+	// MarkAllRead marks all user notifications as read.
+	MarkAllRead(userID int) error
+	// GetPrefs returns user preferences for all notification types.
+	GetPrefs(userID int) ([]entity.NotificationPref, error)
+	// UpdatePrefs saves the given user preferences and returns preferences for all types.
+	UpdatePrefs(userID int, prefs []entity.NotificationPref) ([]entity.NotificationPref, error)
+}
+
+// Notifier describes a sender of in-app notifications.
+// It is used by usecases of other domains.
+type Notifier interface {
+	// Notify creates a copy of the notification for every given user
+	// who has not disabled notifications of this type.
+	// This method never fails: errors are only logged.
+	Notify(notifObj *entity.Notification, userIDs ...int)
+}
@@ -0,0 +1,99 @@
+// Package usecase contains notification.UsecaseClient and notification.Notifier implementations.
+package usecase
+
+import (
+	"fmt"
+	goslices "slices"
+	"time"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/notification"
+)
+
+// Ensure UCClient implements interfaces.
+var _ notification.UsecaseClient = (*UCClient)(nil)
+
+// UCClient represents a notification usecase for teacher and student.
+// It implements the [notification.UsecaseClient] interface.
+type UCClient struct {
+	cfg         *config.Config
+	notifRepoDB notification.RepositoryDB
+}
+
+// NewUCClient returns a new instance of [UCClient].
+func NewUCClient(cfg *config.Config, notifRepoDB notification.RepositoryDB) *UCClient {
+	return &UCClient{
+		cfg:         cfg,
+		notifRepoDB: notifRepoDB,
+	}
+}
+
+// List returns slice of user notifications (newest first) and number of unread ones.
+// UnreadOnly param appends condition to filter unread notifications.
+func (u *UCClient) List(userID int, unreadOnly bool,
+	page *entity.Pagination) ([]entity.Notification, int64, error) {
+
+	notifList, err := u.notifRepoDB.GetMany(userID, unreadOnly, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("get notifications: %w", err)
+	}
+	unread, err := u.notifRepoDB.CountUnread(userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("count unread: %w", err)
+	}
+	return notifList, unread, nil
+}
+
+// MarkRead marks the user notification as read.
+func (u *UCClient) MarkRead(userID, notificationID int) error {
+	notifObj, err := u.notifRepoDB.GetByID(notificationID)
+	if err != nil {
+		return err
+	}
+	// notifications of other users are not found for the user
+	if notifObj.UserID != userID {
+		return fmt.Errorf("%w: notification of another user", notification.ErrNotFound)
+	}
+	return u.notifRepoDB.MarkRead(userID, time.Now(), notificationID)
+}
+
+// MarkAllRead marks all user notifications as read.
+func (u *UCClient) MarkAllRead(userID int) error {
+	return u.notifRepoDB.MarkRead(userID, time.Now())
+}
+
+// GetPrefs returns user preferences for all notification types.
+// Types without saved preferences are enabled.
+func (u *UCClient) GetPrefs(userID int) ([]entity.NotificationPref, error) {
+	saved, err := u.notifRepoDB.GetPrefs(userID)
+	if err != nil {
+		return nil, fmt.Errorf("get prefs: %w", err)
+	}
+	prefs := make([]entity.NotificationPref, len(entity.NotificationTypes))
+	for idx, notifType := range entity.NotificationTypes {
+		prefs[idx] = entity.NotificationPref{UserID: userID, Type: notifType, Enabled: true}
+		for _, savedPref := range saved {
+			if savedPref.Type == notifType {
+				prefs[idx].Enabled = savedPref.Enabled
+			}
+		}
+	}
+	return prefs, nil
+}
+
+// UpdatePrefs saves the given user preferences and returns preferences for all types.
+func (u *UCClient) UpdatePrefs(userID int,
+	prefs []entity.NotificationPref) ([]entity.NotificationPref, error) {
+
+	for idx := range prefs {
+		if !goslices.Contains(entity.NotificationTypes, prefs[idx].Type) {
+			return nil, fmt.Errorf("%w: %q", notification.ErrInvalidType, prefs[idx].Type)
+		}
+		prefs[idx].UserID = userID
+	}
+	if err := u.notifRepoDB.SavePrefs(prefs); err != nil {
+		return nil, fmt.Errorf("save prefs: %w", err)
+	}
+	return u.GetPrefs(userID)
+}
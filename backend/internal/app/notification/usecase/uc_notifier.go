@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"log/slog"
+	goslices "slices"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/event"
+	"skadi/backend/internal/app/mail"
+	"skadi/backend/internal/app/notification"
+	"skadi/backend/internal/app/telegram"
+)
+
+// Ensure UCNotifier implements interfaces.
+var _ notification.Notifier = (*UCNotifier)(nil)
+
+// UCNotifier represents a notification usecase for other usecases to notify users.
+// Created notifications are pushed to users as real-time events,
+// sent by e-mail to users who want to get them immediately
+// and sent to the linked Telegram chats.
+// It implements the [notification.Notifier] interface.
+type UCNotifier struct {
+	cfg         *config.Config
+	notifRepoDB notification.RepositoryDB
+	evtPub      event.Publisher
+	mailUC      mail.UsecaseMailer
+	tgQueue     telegram.Queue
+}
+
+// NewUCNotifier returns a new instance of [UCNotifier].
+func NewUCNotifier(cfg *config.Config, notifRepoDB notification.RepositoryDB,
+	evtPub event.Publisher, mailUC mail.UsecaseMailer, tgQueue telegram.Queue) *UCNotifier {
+
+	return &UCNotifier{
+		cfg:         cfg,
+		notifRepoDB: notifRepoDB,
+		evtPub:      evtPub,
+		mailUC:      mailUC,
+		tgQueue:     tgQueue,
+	}
+}
+
+// Notify creates a copy of the notification for every given user
+// who has not disabled notifications of this type.
+// Errors are only logged.
+func (u *UCNotifier) Notify(notifObj *entity.Notification, userIDs ...int) {
+	if len(userIDs) == 0 {
+		return
+	}
+	disabled, err := u.notifRepoDB.GetDisabledUserIDs(notifObj.Type, userIDs)
+	if err != nil {
+		slog.Warn("get users with disabled notifications", "type", notifObj.Type, "error", err)
+		return
+	}
+
+	notifList := make([]entity.Notification, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if goslices.Contains(disabled, userID) {
+			continue
+		}
+		userNotif := *notifObj
+		userNotif.UserID = userID
+		notifList = append(notifList, userNotif)
+	}
+	if err := u.notifRepoDB.CreateMany(notifList); err != nil {
+		slog.Warn("create notifications", "type", notifObj.Type, "error", err)
+		return
+	}
+
+	// push notifications to online users
+	for idx := range notifList {
+		u.evtPub.Publish(&entity.Event{
+			Type: entity.EventNotificationCreated,
+			Data: &notifList[idx],
+		}, notifList[idx].UserID)
+	}
+	if err := u.mailUC.EnqueueNotifications(notifList); err != nil {
+		slog.Warn("enqueue notification mails", "type", notifObj.Type, "error", err)
+	}
+	u.tgQueue.Enqueue(notifList)
+}
@@ -0,0 +1,19 @@
+// Package outbox contains the transactional outbox of the domain events.
+// Repositories write events to the outbox in the same transaction with the data changes
+// and the dispatcher service delivers them to the in-process subscribers at least once.
+package outbox
+
+import "skadi/backend/internal/app/entity"
+
+// Handler handles the domain event. Failed handler gets the event again,
+// but handlers succeeded with the same event are not called again.
+// The event still can be handled more than once (e.g. if the backend stopped
+// before the handling was saved), so handlers should tolerate duplicates.
+type Handler func(evt *entity.OutboxEvent) error
+
+// Bus describes methods to subscribe to the domain events.
+type Bus interface {
+	// Subscribe registers the handler with the unique name for the given event topics.
+	// The name is saved with handled events, so it must not be changed.
+	Subscribe(name string, handler Handler, topics ...entity.OutboxTopic)
+}
@@ -0,0 +1,26 @@
+package outbox
+
+import (
+	"time"
+
+	"skadi/backend/internal/app/entity"
+)
+
+// RepositoryDB describes all DB methods for outbox events.
+type RepositoryDB interface {
+	// GetDue returns not dispatched events which next attempt time has come.
+	GetDue(now time.Time, maxAttempts, limit int) ([]entity.OutboxEvent, error)
+	// Claim postpones the next attempt of the event until the given time.
+	// It returns false if the event was already claimed by another worker.
+	Claim(evtObj *entity.OutboxEvent, until time.Time) (bool, error)
+	// GetHandled returns names of the handlers which have already handled the event.
+	GetHandled(eventID int) ([]string, error)
+	// MarkHandled saves the successful handling of the event by the handler.
+	MarkHandled(eventID int, handler string, handledAt time.Time) error
+	// MarkDispatched sets the dispatch datetime of the event.
+	MarkDispatched(id int, dispatchedAt time.Time) error
+	// MarkFailed saves the failed attempt of the event and the next attempt time.
+	MarkFailed(id, attempts int, nextAttemptAt time.Time, lastErr string) error
+	// DeleteDispatched deletes events dispatched before the given time.
+	DeleteDispatched(before time.Time) (int64, error)
+}
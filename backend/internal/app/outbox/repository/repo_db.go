@@ -0,0 +1,119 @@
+// Package repository contains outbox.RepositoryDB implementation.
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/outbox"
+)
+
+const (
+	_fieldID            = "id"              // table field name
+	_fieldAttempts      = "attempts"        // table field name
+	_fieldLastError     = "last_error"      // table field name
+	_fieldNextAttemptAt = "next_attempt_at" // table field name
+	_fieldDispatchedAt  = "dispatched_at"   // table field name
+	_fieldEventID       = "event_id"        // table field name
+	_fieldHandler       = "handler"         // table field name
+)
+
+// Ensure RepoDB implements interface.
+var _ outbox.RepositoryDB = (*RepoDB)(nil)
+
+// RepoDB is an outbox DB repo.
+// It implements the [outbox.RepositoryDB] interface.
+type RepoDB struct {
+	dbStorage *gorm.DB
+}
+
+// NewRepoDB returns a new instance of [RepoDB].
+func NewRepoDB(dbStorage *gorm.DB) *RepoDB {
+	return &RepoDB{
+		dbStorage: dbStorage,
+	}
+}
+
+// GetDue returns not dispatched events which next attempt time has come.
+// Events are ordered by ID, so they are dispatched in the creation order.
+func (r *RepoDB) GetDue(now time.Time, maxAttempts, limit int) ([]entity.OutboxEvent, error) {
+	events := []entity.OutboxEvent{}
+	err := r.dbStorage.
+		Where(_fieldDispatchedAt+" IS NULL").
+		Where(_fieldNextAttemptAt+" <= ?", now).
+		Where(_fieldAttempts+" < ?", maxAttempts).
+		Order(_fieldID).
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// Claim postpones the next attempt of the event until the given time.
+// It returns false if the event was already claimed by another worker.
+func (r *RepoDB) Claim(evtObj *entity.OutboxEvent, until time.Time) (bool, error) {
+	res := r.dbStorage.
+		Model(&entity.OutboxEvent{}).
+		Where(_fieldID+" = ? AND "+_fieldDispatchedAt+" IS NULL", evtObj.ID).
+		Where(_fieldNextAttemptAt+" = ?", evtObj.NextAttemptAt).
+		Update(_fieldNextAttemptAt, until)
+	return res.RowsAffected == 1, res.Error
+}
+
+// GetHandled returns names of the handlers which have already handled the event.
+func (r *RepoDB) GetHandled(eventID int) ([]string, error) {
+	handlers := []string{}
+	err := r.dbStorage.
+		Model(&entity.OutboxHandled{}).
+		Where(_fieldEventID+" = ?", eventID).
+		Pluck(_fieldHandler, &handlers).Error
+	if err != nil {
+		return nil, err
+	}
+	return handlers, nil
+}
+
+// MarkHandled saves the successful handling of the event by the handler.
+// The repeated handling is ignored.
+func (r *RepoDB) MarkHandled(eventID int, handler string, handledAt time.Time) error {
+	return r.dbStorage.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&entity.OutboxHandled{
+			EventID:   eventID,
+			Handler:   handler,
+			HandledAt: handledAt,
+		}).Error // nil OR error
+}
+
+// MarkDispatched sets the dispatch datetime of the event.
+func (r *RepoDB) MarkDispatched(id int, dispatchedAt time.Time) error {
+	return r.dbStorage.
+		Model(&entity.OutboxEvent{}).
+		Where(_fieldID+" = ?", id).
+		Update(_fieldDispatchedAt, dispatchedAt).Error // nil OR error
+}
+
+// MarkFailed saves the failed attempt of the event and the next attempt time.
+func (r *RepoDB) MarkFailed(id, attempts int, nextAttemptAt time.Time, lastErr string) error {
+	return r.dbStorage.
+		Model(&entity.OutboxEvent{}).
+		Where(_fieldID+" = ?", id).
+		Updates(map[string]any{
+			_fieldAttempts:      attempts,
+			_fieldNextAttemptAt: nextAttemptAt,
+			_fieldLastError:     lastErr,
+		}).Error // nil OR error
+}
+
+// DeleteDispatched deletes events dispatched before the given time.
+func (r *RepoDB) DeleteDispatched(before time.Time) (int64, error) {
+	res := r.dbStorage.
+		Where(_fieldDispatchedAt+" < ?", before).
+		Delete(&entity.OutboxEvent{})
+	return res.RowsAffected, res.Error
+}
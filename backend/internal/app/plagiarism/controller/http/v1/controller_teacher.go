@@ -0,0 +1,124 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/plagiarism"
+	"skadi/backend/internal/pkg/httperror"
+	"skadi/backend/internal/pkg/serialize"
+	utilsjwt "skadi/backend/internal/pkg/utils/jwt"
+	"skadi/backend/internal/pkg/validator"
+)
+
+// PlagiarismControllerTeacher represents a controller for plagiarism routes accepted for teachers only.
+type PlagiarismControllerTeacher struct {
+	valid               validator.Validator
+	plagiarismUCTeacher plagiarism.UsecaseTeacher
+}
+
+// NewControllerTeacher returns a new instance of [PlagiarismControllerTeacher].
+func NewControllerTeacher(plagiarismUCTeacher plagiarism.UsecaseTeacher,
+	valid validator.Validator) *PlagiarismControllerTeacher {
+
+	return &PlagiarismControllerTeacher{
+		valid:               valid,
+		plagiarismUCTeacher: plagiarismUCTeacher,
+	}
+}
+
+// @summary		Отчёт о плагиате в решениях задания. [Только преподаватель]
+// @description	Получение подозрительных пар решений своего задания (сначала самые похожие) с учениками и совпадающими фрагментами (файл, строки и текст фрагмента в обоих решениях).
+// @description	Сравниваются текстовые ответы и текстовые файлы решений, фрагменты описания и файлов задания (например, шаблон кода) не учитываются.
+// @description	Решение сравнивается с остальными решениями задания, когда оно переводится в статус "на проверке", а все решения задания - по запросу преподавателя (статус последней проверки возвращается в check).
+// @router			/plagiarism/task/{id} [get]
+// @id				plagiarism-read-report
+// @tags			plagiarism
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id			path		int			true	"ID задания"
+// @param			reportQuery	query		reportQuery	false	"reportQuery"
+// @success		200			{object}	entity.PlagiarismReport
+// @failure		401			"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403			"доступ запрещён"
+// @failure		404			"задание не найдено"
+func (c *PlagiarismControllerTeacher) ReadReport(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &taskIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputQuery := &reportQuery{}
+	if err := serialize.Deserialize(inputQuery, ctx.QueryParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	reportObj, err := c.plagiarismUCTeacher.GetReport(userClaims.ID, inputPath.ID,
+		inputQuery.MinSimilarity)
+	if errors.Is(err, plagiarism.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "задание не найдено",
+		}
+	}
+	if errors.Is(err, plagiarism.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("read report: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(reportObj)
+}
+
+// @summary		Проверка решений задания на плагиат. [Только преподаватель]
+// @description	Постановка в очередь попарного сравнения всех решений своего задания. Найденные ранее пары заменяются после завершения проверки.
+// @router			/plagiarism/task/{id}/check [post]
+// @id				plagiarism-check
+// @tags			plagiarism
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id	path		int	true	"ID задания"
+// @success		202	{object}	entity.PlagiarismCheck
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+// @failure		404	"задание не найдено"
+func (c *PlagiarismControllerTeacher) Check(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &taskIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	checkObj, err := c.plagiarismUCTeacher.Check(userClaims.ID, inputPath.ID)
+	if errors.Is(err, plagiarism.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "задание не найдено",
+		}
+	}
+	if errors.Is(err, plagiarism.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("check plagiarism: %w", err)
+	}
+	return ctx.Status(fiber.StatusAccepted).JSON(checkObj)
+}
@@ -0,0 +1,14 @@
+package v1
+
+// @description taskIDPath represents a data with task ID in path params.
+type taskIDPath struct {
+	// task id
+	ID int `params:"id" validate:"required" example:"2"`
+}
+
+// @description reportQuery represents a data with
+// optional query-params to get plagiarism report.
+type reportQuery struct {
+	// min similarity of the solution pair (percent)
+	MinSimilarity int `query:"min_similarity,omitempty" json:"min_similarity" validate:"omitempty,min=0,max=100" example:"50" minimum:"0" maximum:"100"`
+}
@@ -0,0 +1,21 @@
+// Package http/v1 is a first version of plagiarism HTTP-controller.
+// It provides registers for plagiarism HTTP-routes and controllers with handlers for them.
+package v1
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/service/server/middleware"
+)
+
+// RegisterEndpoints registers all plagiarism endpoints.
+func RegisterEndpoints(router fiber.Router, controllerTeacher *PlagiarismControllerTeacher,
+	mwJWTAccess fiber.Handler, mwAllow middleware.AllowFunc) {
+
+	mwTeacherOnly := mwAllow(entity.Teacher)
+
+	authGroup := router.Group("/plagiarism", mwJWTAccess)
+	authGroup.Get("/task/:id", mwTeacherOnly, controllerTeacher.ReadReport)
+	authGroup.Post("/task/:id/check", mwTeacherOnly, controllerTeacher.Check)
+}
@@ -0,0 +1,9 @@
+package plagiarism
+
+import "errors"
+
+var (
+	ErrForbidden     = errors.New("forbidden")        // code 403
+	ErrNotFound      = errors.New("record not found") // code 404
+	ErrNotFoundCheck = errors.New("check not found")  // code 404
+)
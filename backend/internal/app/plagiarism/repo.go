@@ -0,0 +1,51 @@
+package plagiarism
+
+import (
+	"time"
+
+	"skadi/backend/internal/app/entity"
+)
+
+// RepositoryDB describes all DB methods for plagiarism checks and solution pairs.
+type RepositoryDB interface {
+	// Request creates a pending check of the task (the previous check is replaced).
+	// The check is run by the analyzer service.
+	Request(taskID int) (*entity.PlagiarismCheck, error)
+	// GetCheck returns the last check of the task.
+	GetCheck(taskID int) (*entity.PlagiarismCheck, error)
+	// GetPendingChecks returns the oldest pending checks.
+	GetPendingChecks(limit int) ([]entity.PlagiarismCheck, error)
+	// ClaimCheck marks the pending check as running.
+	// It returns false if the check was already claimed by another worker.
+	ClaimCheck(checkObj *entity.PlagiarismCheck, startedAt time.Time) (bool, error)
+	// FinishCheck saves status, error and finish datetime of the running check.
+	FinishCheck(checkObj *entity.PlagiarismCheck) error
+
+	// EnqueueSubmit enqueues comparing of the solution sent for review
+	// with other solutions of the task (the queued comparison is restarted).
+	EnqueueSubmit(solutionID, taskID int) error
+	// GetPendingSubmits returns the oldest pending comparisons of solutions.
+	GetPendingSubmits(limit int) ([]entity.PlagiarismSubmit, error)
+	// ClaimSubmit marks the pending comparison as running.
+	// It returns false if the comparison was already claimed by another worker.
+	ClaimSubmit(submitObj *entity.PlagiarismSubmit, startedAt time.Time) (bool, error)
+	// FinishSubmit deletes the running comparison from the queue.
+	FinishSubmit(submitObj *entity.PlagiarismSubmit) error
+
+	// RestartStale marks checks and comparisons started before the given time
+	// and not finished as pending. It returns the number of restarted ones.
+	RestartStale(before time.Time) (int, error)
+
+	// GetPrints returns cached fingerprints of the given solutions.
+	GetPrints(solutionIDs []int) ([]entity.PlagiarismPrint, error)
+	// SavePrints replaces cached fingerprints of the solutions with the given ones.
+	SavePrints(prints []entity.PlagiarismPrint) error
+
+	// GetPairs returns solution pairs of the task (most similar first).
+	// MinSimilarity param filters pairs by similarity (percent).
+	GetPairs(taskID, minSimilarity int) ([]entity.PlagiarismPair, error)
+	// ReplacePairs replaces all solution pairs of the task with the given ones.
+	ReplacePairs(taskID int, pairs []entity.PlagiarismPair) error
+	// ReplaceSolutionPairs replaces pairs of the solution with the given ones.
+	ReplaceSolutionPairs(solutionID int, pairs []entity.PlagiarismPair) error
+}
@@ -0,0 +1,301 @@
+// Package repository contains plagiarism.RepositoryDB implementation.
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/plagiarism"
+)
+
+const (
+	_fieldTaskID          = "task_id"           // table field name
+	_fieldSolutionID      = "solution_id"       // table field name
+	_fieldOtherSolutionID = "other_solution_id" // table field name
+	_fieldSimilarity      = "similarity"        // table field name
+	_fieldStatus          = "status"            // table field name
+	_fieldError           = "error"             // table field name
+	_fieldRequestedAt     = "requested_at"      // table field name
+	_fieldStartedAt       = "started_at"        // table field name
+	_fieldFinishedAt      = "finished_at"       // table field name
+	_fieldContentKey      = "content_key"       // table field name
+	_fieldDocs            = "docs"              // table field name
+	_fieldCreatedAt       = "created_at"        // table field name
+)
+
+// Ensure RepoDB implements interface.
+var _ plagiarism.RepositoryDB = (*RepoDB)(nil)
+
+// RepoDB is a plagiarism DB repo.
+// It implements the [plagiarism.RepositoryDB] interface.
+type RepoDB struct {
+	dbStorage *gorm.DB
+}
+
+// NewRepoDB returns a new instance of [RepoDB].
+func NewRepoDB(dbStorage *gorm.DB) *RepoDB {
+	return &RepoDB{
+		dbStorage: dbStorage,
+	}
+}
+
+// Request creates a pending check of the task (the previous check is replaced).
+// The check is run by the analyzer service.
+func (r *RepoDB) Request(taskID int) (*entity.PlagiarismCheck, error) {
+	checkObj := &entity.PlagiarismCheck{
+		TaskID:      taskID,
+		Status:      entity.PlagiarismPending,
+		RequestedAt: time.Now(),
+	}
+	err := r.dbStorage.Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{
+			_fieldStatus, _fieldError, _fieldRequestedAt, _fieldStartedAt, _fieldFinishedAt,
+		}),
+	}).Create(checkObj).Error
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return nil, fmt.Errorf("task with id: %w", plagiarism.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return checkObj, nil
+}
+
+// GetCheck returns the last check of the task.
+func (r *RepoDB) GetCheck(taskID int) (*entity.PlagiarismCheck, error) {
+	var checkObj entity.PlagiarismCheck
+	err := r.dbStorage.
+		Where(_fieldTaskID+" = ?", taskID).
+		First(&checkObj).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("check of task %d: %w", taskID, plagiarism.ErrNotFoundCheck)
+	}
+	return &checkObj, err // err OR nil
+}
+
+// GetPendingChecks returns the oldest pending checks.
+func (r *RepoDB) GetPendingChecks(limit int) ([]entity.PlagiarismCheck, error) {
+	checks := []entity.PlagiarismCheck{}
+	err := r.dbStorage.
+		Where(_fieldStatus+" = ?", entity.PlagiarismPending).
+		Order(_fieldRequestedAt).
+		Limit(limit).
+		Find(&checks).Error
+	if err != nil {
+		return nil, err
+	}
+	return checks, nil
+}
+
+// ClaimCheck marks the pending check as running.
+// It returns false if the check was already claimed by another worker.
+func (r *RepoDB) ClaimCheck(checkObj *entity.PlagiarismCheck, startedAt time.Time) (bool, error) {
+	res := r.dbStorage.
+		Model(&entity.PlagiarismCheck{}).
+		Where(_fieldTaskID+" = ? AND "+_fieldStatus+" = ?",
+			checkObj.TaskID, entity.PlagiarismPending).
+		Updates(map[string]any{
+			_fieldStatus:    entity.PlagiarismRunning,
+			_fieldStartedAt: startedAt,
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	checkObj.Status = entity.PlagiarismRunning
+	checkObj.StartedAt = &startedAt
+	return res.RowsAffected == 1, nil
+}
+
+// FinishCheck saves status, error and finish datetime of the running check.
+// The check is not saved if it was requested again while running.
+func (r *RepoDB) FinishCheck(checkObj *entity.PlagiarismCheck) error {
+	return r.dbStorage.
+		Model(&entity.PlagiarismCheck{}).
+		Where(_fieldTaskID+" = ? AND "+_fieldStatus+" = ?",
+			checkObj.TaskID, entity.PlagiarismRunning).
+		Updates(map[string]any{
+			_fieldStatus:     checkObj.Status,
+			_fieldError:      checkObj.Error,
+			_fieldFinishedAt: checkObj.FinishedAt,
+		}).Error // nil OR error
+}
+
+// EnqueueSubmit enqueues comparing of the solution sent for review
+// with other solutions of the task (the queued comparison is restarted).
+func (r *RepoDB) EnqueueSubmit(solutionID, taskID int) error {
+	submitObj := &entity.PlagiarismSubmit{
+		SolutionID:  solutionID,
+		TaskID:      taskID,
+		RequestedAt: time.Now(),
+	}
+	err := r.dbStorage.Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{_fieldRequestedAt, _fieldStartedAt}),
+	}).Create(submitObj).Error
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return fmt.Errorf("task or solution with id: %w", plagiarism.ErrNotFound)
+	}
+	return err // err OR nil
+}
+
+// GetPendingSubmits returns the oldest pending comparisons of solutions.
+func (r *RepoDB) GetPendingSubmits(limit int) ([]entity.PlagiarismSubmit, error) {
+	submits := []entity.PlagiarismSubmit{}
+	err := r.dbStorage.
+		Where(_fieldStartedAt + " IS NULL").
+		Order(_fieldRequestedAt).
+		Limit(limit).
+		Find(&submits).Error
+	if err != nil {
+		return nil, err
+	}
+	return submits, nil
+}
+
+// ClaimSubmit marks the pending comparison as running.
+// It returns false if the comparison was already claimed by another worker.
+func (r *RepoDB) ClaimSubmit(submitObj *entity.PlagiarismSubmit,
+	startedAt time.Time) (bool, error) {
+
+	res := r.dbStorage.
+		Model(&entity.PlagiarismSubmit{}).
+		Where(_fieldSolutionID+" = ? AND "+_fieldStartedAt+" IS NULL", submitObj.SolutionID).
+		Update(_fieldStartedAt, startedAt)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	submitObj.StartedAt = &startedAt
+	return res.RowsAffected == 1, nil
+}
+
+// FinishSubmit deletes the running comparison from the queue.
+// The comparison is kept if the solution was sent for review again while running.
+func (r *RepoDB) FinishSubmit(submitObj *entity.PlagiarismSubmit) error {
+	return r.dbStorage.
+		Where(_fieldSolutionID+" = ? AND "+_fieldStartedAt+" IS NOT NULL", submitObj.SolutionID).
+		Delete(&entity.PlagiarismSubmit{}).Error // nil OR error
+}
+
+// RestartStale marks checks and comparisons started before the given time
+// and not finished as pending (e.g. ones of the crashed instance).
+// It returns the number of restarted ones.
+func (r *RepoDB) RestartStale(before time.Time) (int, error) {
+	restarted := 0
+	err := r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		res := tx.
+			Model(&entity.PlagiarismCheck{}).
+			Where(_fieldStatus+" = ?", entity.PlagiarismRunning).
+			Where(_fieldStartedAt+" < ?", before).
+			Updates(map[string]any{
+				_fieldStatus:    entity.PlagiarismPending,
+				_fieldStartedAt: nil,
+			})
+		if res.Error != nil {
+			return fmt.Errorf("restart checks: %w", res.Error)
+		}
+		restarted += int(res.RowsAffected)
+
+		res = tx.
+			Model(&entity.PlagiarismSubmit{}).
+			Where(_fieldStartedAt+" < ?", before).
+			Update(_fieldStartedAt, nil)
+		if res.Error != nil {
+			return fmt.Errorf("restart submits: %w", res.Error)
+		}
+		restarted += int(res.RowsAffected)
+		return nil
+	})
+	return restarted, err
+}
+
+// GetPrints returns cached fingerprints of the given solutions.
+func (r *RepoDB) GetPrints(solutionIDs []int) ([]entity.PlagiarismPrint, error) {
+	prints := []entity.PlagiarismPrint{}
+	if len(solutionIDs) == 0 {
+		return prints, nil
+	}
+	err := r.dbStorage.
+		Where(_fieldSolutionID+" IN ?", solutionIDs).
+		Find(&prints).Error
+	if err != nil {
+		return nil, err
+	}
+	return prints, nil
+}
+
+// SavePrints replaces cached fingerprints of the solutions with the given ones.
+func (r *RepoDB) SavePrints(prints []entity.PlagiarismPrint) error {
+	if len(prints) == 0 {
+		return nil
+	}
+	err := r.dbStorage.Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{
+			_fieldContentKey, _fieldDocs, _fieldCreatedAt,
+		}),
+	}).Create(&prints).Error
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return fmt.Errorf("solution with id: %w", plagiarism.ErrNotFound)
+	}
+	return err // err OR nil
+}
+
+// GetPairs returns solution pairs of the task (most similar first).
+// MinSimilarity param filters pairs by similarity (percent).
+func (r *RepoDB) GetPairs(taskID, minSimilarity int) ([]entity.PlagiarismPair, error) {
+	pairs := []entity.PlagiarismPair{}
+	err := r.dbStorage.
+		Where(_fieldTaskID+" = ? AND "+_fieldSimilarity+" >= ?", taskID, minSimilarity).
+		Order(_fieldSimilarity + " DESC").
+		Order(_fieldSolutionID).
+		Order(_fieldOtherSolutionID).
+		Find(&pairs).Error
+	if err != nil {
+		return nil, err
+	}
+	return pairs, nil
+}
+
+// ReplacePairs replaces all solution pairs of the task with the given ones.
+func (r *RepoDB) ReplacePairs(taskID int, pairs []entity.PlagiarismPair) error {
+	return r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(_fieldTaskID+" = ?", taskID).
+			Delete(&entity.PlagiarismPair{}).Error; err != nil {
+			return fmt.Errorf("delete old pairs: %w", err)
+		}
+		return createPairs(tx, pairs)
+	})
+}
+
+// ReplaceSolutionPairs replaces pairs of the solution with the given ones.
+func (r *RepoDB) ReplaceSolutionPairs(solutionID int, pairs []entity.PlagiarismPair) error {
+	return r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(_fieldSolutionID+" = ? OR "+_fieldOtherSolutionID+" = ?",
+			solutionID, solutionID).
+			Delete(&entity.PlagiarismPair{}).Error; err != nil {
+			return fmt.Errorf("delete old pairs: %w", err)
+		}
+		return createPairs(tx, pairs)
+	})
+}
+
+// createPairs creates the given solution pairs in the transaction.
+func createPairs(tx *gorm.DB, pairs []entity.PlagiarismPair) error {
+	if len(pairs) == 0 {
+		return nil
+	}
+	for idx := range pairs {
+		pairs[idx].ID = 0
+	}
+	err := tx.Create(&pairs).Error
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return fmt.Errorf("task or solution with id: %w", plagiarism.ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("create pairs: %w", err)
+	}
+	return nil
+}
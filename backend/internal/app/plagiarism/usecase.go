@@ -0,0 +1,31 @@
+// Package plagiarism contains all repos, usecases and controllers for similarity detection
+// across solutions of the task (token-based fingerprinting by winnowing).
+// Sub-package repo contains RepoDB implementation.
+// Sub-package usecase contains UsecaseTeacher and UsecaseAnalyzer implementations.
+package plagiarism
+
+import "skadi/backend/internal/app/entity"
+
+// UsecaseTeacher describes all plagiarism usecases for teacher.
+type UsecaseTeacher interface {
+	// Check requests comparison of all solutions of the own task with each other.
+	// The previous check of the task is replaced. It returns the pending check.
+	Check(teacherID, taskID int) (*entity.PlagiarismCheck, error)
+	// GetReport returns the last check and suspicious solution pairs of the own task
+	// (most similar first). MinSimilarity param filters pairs by similarity (percent).
+	GetReport(teacherID, taskID, minSimilarity int) (*entity.PlagiarismReport, error)
+}
+
+// UsecaseAnalyzer describes usecases to compare solutions in the background.
+// Solutions are compared by the analyzer service.
+type UsecaseAnalyzer interface {
+	// HandleSolutionUpdated enqueues comparing of the solution sent for review with other
+	// solutions of the task. It handles the solution.updated outbox event.
+	HandleSolutionUpdated(evtObj *entity.OutboxEvent) error
+	// CheckTask compares all solutions of the task with each other
+	// and replaces all solution pairs of the task.
+	CheckTask(taskID int) error
+	// CheckSolution compares the solution with other solutions of the task
+	// and replaces pairs of the solution.
+	CheckSolution(taskID, solutionID int) error
+}
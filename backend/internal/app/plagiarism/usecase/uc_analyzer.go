@@ -0,0 +1,417 @@
+package usecase
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/plagiarism"
+	"skadi/backend/internal/app/solution"
+	"skadi/backend/internal/app/task"
+	"skadi/backend/internal/pkg/winnow"
+)
+
+const (
+	_readyStatusID  = 3    // ID of solution status "ready"
+	_maxFragmentLen = 4096 // max length of the saved fragment text
+	_answerSource   = ""   // source name of the solution text answer
+)
+
+// Ensure UCAnalyzer implements interfaces.
+var _ plagiarism.UsecaseAnalyzer = (*UCAnalyzer)(nil)
+
+// document represents a compared text of the solution (the text answer or the text file).
+// Text of the cached file document is read only to save matching fragments.
+type document struct {
+	source string
+	fileID int
+	path   string
+	text   *string
+	fps    []winnow.Fingerprint
+}
+
+// content returns the document text. The file of the cached document is read once.
+func (d *document) content() (string, error) {
+	if d.text == nil {
+		content, err := os.ReadFile(d.path)
+		if err != nil {
+			return "", err
+		}
+		text := string(content)
+		d.text = &text
+	}
+	return *d.text, nil
+}
+
+// solutionPrint represents fingerprints of all solution documents.
+type solutionPrint struct {
+	solObj *entity.Solution
+	docs   []document
+	// fingerprints of all documents
+	fps []winnow.Fingerprint
+}
+
+// UCAnalyzer represents a plagiarism usecase to compare solutions in the background.
+// It implements the [plagiarism.UsecaseAnalyzer] interface.
+type UCAnalyzer struct {
+	cfg              *config.Config
+	plagiarismRepoDB plagiarism.RepositoryDB
+	taskRepoDB       task.RepositoryDB
+	solRepoDB        solution.RepositoryDB
+	fingerprinter    *winnow.Fingerprinter
+}
+
+// NewUCAnalyzer returns a new instance of [UCAnalyzer].
+func NewUCAnalyzer(cfg *config.Config, plagiarismRepoDB plagiarism.RepositoryDB,
+	taskRepoDB task.RepositoryDB, solRepoDB solution.RepositoryDB) *UCAnalyzer {
+
+	return &UCAnalyzer{
+		cfg:              cfg,
+		plagiarismRepoDB: plagiarismRepoDB,
+		taskRepoDB:       taskRepoDB,
+		solRepoDB:        solRepoDB,
+		fingerprinter:    winnow.New(cfg.Plagiarism.KGram, cfg.Plagiarism.Window),
+	}
+}
+
+// HandleSolutionUpdated enqueues comparing of the solution sent for review with other
+// solutions of the task (the analyzer service compares them). If comparing on submit
+// is disabled, it does nothing. It handles the solution.updated outbox event.
+func (u *UCAnalyzer) HandleSolutionUpdated(evtObj *entity.OutboxEvent) error {
+	if !u.cfg.Plagiarism.OnSubmit {
+		return nil
+	}
+	var data entity.SolutionUpdatedEvent
+	if err := evtObj.Decode(&data); err != nil {
+		return fmt.Errorf("decode event: %w", err)
+	}
+	// solution was not sent for review
+	if data.StatusID != _readyStatusID || data.OldStatusID == _readyStatusID {
+		return nil
+	}
+
+	err := u.plagiarismRepoDB.EnqueueSubmit(data.SolutionID, data.TaskID)
+	// task or solution was deleted
+	if errors.Is(err, plagiarism.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("enqueue submit: %w", err)
+	}
+	return nil
+}
+
+// CheckTask compares all solutions of the task with each other
+// and replaces all solution pairs of the task.
+func (u *UCAnalyzer) CheckTask(taskID int) error {
+	prints, err := u.fingerprintTask(taskID)
+	if err != nil {
+		return err
+	}
+	var pairs []entity.PlagiarismPair
+	for idx := range prints {
+		for _, other := range prints[idx+1:] {
+			if pairObj := u.compare(prints[idx], other); pairObj != nil {
+				pairs = append(pairs, *pairObj)
+			}
+		}
+	}
+	if err := u.plagiarismRepoDB.ReplacePairs(taskID, pairs); err != nil {
+		return fmt.Errorf("replace pairs: %w", err)
+	}
+	return nil
+}
+
+// CheckSolution compares the solution with other solutions of the task
+// and replaces pairs of the solution. It does nothing if the solution was deleted.
+func (u *UCAnalyzer) CheckSolution(taskID, solutionID int) error {
+	prints, err := u.fingerprintTask(taskID)
+	if err != nil {
+		return err
+	}
+	idx := slices.IndexFunc(prints, func(p *solutionPrint) bool {
+		return p.solObj.ID == solutionID
+	})
+	// solution was deleted
+	if idx == -1 {
+		return nil
+	}
+	var pairs []entity.PlagiarismPair
+	for otherIdx, other := range prints {
+		if otherIdx == idx {
+			continue
+		}
+		// the less solution ID is the first one of the pair
+		first, second := prints[idx], other
+		if second.solObj.ID < first.solObj.ID {
+			first, second = second, first
+		}
+		if pairObj := u.compare(first, second); pairObj != nil {
+			pairs = append(pairs, *pairObj)
+		}
+	}
+	if err := u.plagiarismRepoDB.ReplaceSolutionPairs(solutionID, pairs); err != nil {
+		return fmt.Errorf("replace solution pairs: %w", err)
+	}
+	return nil
+}
+
+// fingerprintTask returns fingerprints of all task solutions (ordered by solution ID).
+// Fragments of the task description and the task text files (e.g. the code template)
+// are excluded from the solution fingerprints. Solution fingerprints are cached,
+// so only solutions changed since the last comparison are fingerprinted.
+func (u *UCAnalyzer) fingerprintTask(taskID int) ([]*solutionPrint, error) {
+	taskObj, err := u.taskRepoDB.GetByID(taskID)
+	if errors.Is(err, task.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %w", plagiarism.ErrNotFound, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get task: %w", err)
+	}
+	var base []winnow.Fingerprint
+	taskDocs, _ := u.documents(&taskObj.Desc, u.comparedFiles(taskObj.Files))
+	for _, doc := range taskDocs {
+		base = append(base, doc.fps...)
+	}
+
+	solList, err := u.solRepoDB.GetManyByTask(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("get solutions: %w", err)
+	}
+	solIDs := make([]int, len(solList))
+	for idx := range solList {
+		solIDs[idx] = solList[idx].ID
+	}
+	cached, err := u.plagiarismRepoDB.GetPrints(solIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get cached prints: %w", err)
+	}
+	cachedByID := make(map[int]*entity.PlagiarismPrint, len(cached))
+	for idx := range cached {
+		cachedByID[cached[idx].SolutionID] = &cached[idx]
+	}
+
+	var fresh []entity.PlagiarismPrint
+	prints := make([]*solutionPrint, len(solList))
+	for idx := range solList {
+		solObj := &solList[idx]
+		files := u.comparedFiles(solObj.Files)
+		key := u.contentKey(solObj.Answer, files)
+		p := &solutionPrint{solObj: solObj}
+		if printObj, ok := cachedByID[solObj.ID]; ok && printObj.ContentKey == key {
+			p.docs = cachedDocuments(solObj.Answer, files, printObj.Docs)
+		} else {
+			var complete bool
+			p.docs, complete = u.documents(solObj.Answer, files)
+			// unreadable files are fingerprinted next time
+			if complete {
+				fresh = append(fresh, newPrint(solObj.ID, key, p.docs))
+			}
+		}
+		for docIdx := range p.docs {
+			p.docs[docIdx].fps = winnow.Exclude(p.docs[docIdx].fps, base)
+			p.fps = append(p.fps, p.docs[docIdx].fps...)
+		}
+		prints[idx] = p
+	}
+	// solutions are compared without the cache
+	if err := u.plagiarismRepoDB.SavePrints(fresh); err != nil {
+		slog.Warn("save solution prints", "task_id", taskID, "error", err)
+	}
+	return prints, nil
+}
+
+// comparedFiles returns the files to compare: not blocked by antivirus and not too large.
+func (u *UCAnalyzer) comparedFiles(files entity.Files) entity.Files {
+	res := make(entity.Files, 0, len(files))
+	for _, fileObj := range files.Downloadable(u.cfg.Media.Scan.Enabled) {
+		if fileObj.Size <= u.cfg.Plagiarism.MaxFileSize {
+			res = append(res, fileObj)
+		}
+	}
+	return res
+}
+
+// contentKey returns a hash of the text answer, IDs of the compared files
+// and fingerprinting params. Files are not changed after upload, so their IDs are hashed only.
+func (u *UCAnalyzer) contentKey(answer *string, files entity.Files) string {
+	fileIDs := make([]int, len(files))
+	for idx, fileObj := range files {
+		fileIDs[idx] = fileObj.ID
+	}
+	slices.Sort(fileIDs)
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%d %d %v\n", u.cfg.Plagiarism.KGram, u.cfg.Plagiarism.Window, fileIDs)
+	if answer != nil {
+		hash.Write([]byte(*answer))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// documents returns fingerprinted documents of the text answer and the text files.
+// Binary and unreadable files are skipped. It returns false if some file was unreadable.
+func (u *UCAnalyzer) documents(answer *string, files entity.Files) ([]document, bool) {
+	var res []document
+	if answer != nil && strings.TrimSpace(*answer) != "" {
+		res = append(res, document{
+			source: _answerSource,
+			text:   answer,
+			fps:    u.fingerprinter.Fingerprints(*answer),
+		})
+	}
+	complete := true
+	for _, fileObj := range files {
+		content, err := os.ReadFile(fileObj.Path)
+		if err != nil {
+			slog.Warn("read file to compare", "id", fileObj.ID, "error", err)
+			complete = false
+			continue
+		}
+		// binary file
+		if !utf8.Valid(content) || bytes.IndexByte(content, 0) != -1 {
+			continue
+		}
+		text := string(content)
+		res = append(res, document{
+			source: fileObj.Name,
+			fileID: fileObj.ID,
+			path:   fileObj.Path,
+			text:   &text,
+			fps:    u.fingerprinter.Fingerprints(text),
+		})
+	}
+	return res, complete
+}
+
+// cachedDocuments returns documents of the text answer and the text files
+// with the cached fingerprints. File texts are not read.
+func cachedDocuments(answer *string, files entity.Files, docs []entity.PlagiarismDoc) []document {
+	res := make([]document, 0, len(docs))
+	for _, doc := range docs {
+		if doc.FileID == 0 {
+			res = append(res, document{source: _answerSource, text: answer, fps: doc.Fingerprints})
+			continue
+		}
+		idx := slices.IndexFunc(files, func(fileObj *entity.File) bool {
+			return fileObj.ID == doc.FileID
+		})
+		if idx == -1 {
+			continue
+		}
+		res = append(res, document{
+			source: files[idx].Name,
+			fileID: files[idx].ID,
+			path:   files[idx].Path,
+			fps:    doc.Fingerprints,
+		})
+	}
+	return res
+}
+
+// newPrint returns the cached fingerprints of the solution documents.
+func newPrint(solutionID int, key string, docs []document) entity.PlagiarismPrint {
+	printObj := entity.PlagiarismPrint{
+		SolutionID: solutionID,
+		ContentKey: key,
+		Docs:       make([]entity.PlagiarismDoc, len(docs)),
+		CreatedAt:  time.Now(),
+	}
+	for idx, doc := range docs {
+		printObj.Docs[idx] = entity.PlagiarismDoc{FileID: doc.fileID, Fingerprints: doc.fps}
+	}
+	return printObj
+}
+
+// compare returns the pair of solutions with the longest matching fragments.
+// It returns nil if the solutions are less similar than the configured min similarity.
+func (u *UCAnalyzer) compare(a, b *solutionPrint) *entity.PlagiarismPair {
+	similarity := int(math.Round(winnow.Similarity(a.fps, b.fps) * 100))
+	if similarity == 0 || similarity < u.cfg.Plagiarism.MinSimilarity {
+		return nil
+	}
+
+	fragments := []entity.PlagiarismFragment{}
+	for idxA := range a.docs {
+		docA := &a.docs[idxA]
+		for idxB := range b.docs {
+			docB := &b.docs[idxB]
+			matches := winnow.Match(docA.fps, docB.fps)
+			if len(matches) == 0 {
+				continue
+			}
+			textA, textB, ok := documentTexts(docA, docB)
+			if !ok {
+				continue
+			}
+			for _, match := range matches {
+				// file was changed after fingerprinting
+				if match.A.End > len(textA) || match.B.End > len(textB) {
+					continue
+				}
+				startLine, endLine := match.A.Lines(textA)
+				otherStartLine, otherEndLine := match.B.Lines(textB)
+				fragments = append(fragments, entity.PlagiarismFragment{
+					Source:         docA.source,
+					StartLine:      startLine,
+					EndLine:        endLine,
+					Text:           excerpt(textA, match.A),
+					OtherSource:    docB.source,
+					OtherStartLine: otherStartLine,
+					OtherEndLine:   otherEndLine,
+					OtherText:      excerpt(textB, match.B),
+				})
+			}
+		}
+	}
+	// keep the longest fragments
+	slices.SortStableFunc(fragments, func(x, y entity.PlagiarismFragment) int {
+		return cmp.Compare(len(y.Text), len(x.Text))
+	})
+	fragments = fragments[:min(len(fragments), u.cfg.Plagiarism.MaxFragments)]
+
+	return &entity.PlagiarismPair{
+		TaskID:          a.solObj.TaskID,
+		SolutionID:      a.solObj.ID,
+		OtherSolutionID: b.solObj.ID,
+		Similarity:      similarity,
+		Fragments:       fragments,
+	}
+}
+
+// documentTexts returns texts of both documents.
+// It returns false if one of the files was unreadable.
+func documentTexts(docA, docB *document) (string, string, bool) {
+	textA, err := docA.content()
+	if err != nil {
+		slog.Warn("read file to compare", "id", docA.fileID, "error", err)
+		return "", "", false
+	}
+	textB, err := docB.content()
+	if err != nil {
+		slog.Warn("read file to compare", "id", docB.fileID, "error", err)
+		return "", "", false
+	}
+	return textA, textB, true
+}
+
+// excerpt returns the text of the span cut to the max fragment length.
+func excerpt(text string, span winnow.Span) string {
+	res := text[span.Start:span.End]
+	if len(res) > _maxFragmentLen {
+		res = strings.ToValidUTF8(res[:_maxFragmentLen], "")
+	}
+	return res
+}
@@ -0,0 +1,109 @@
+// Package usecase contains plagiarism.UsecaseTeacher and plagiarism.UsecaseAnalyzer implementations.
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/plagiarism"
+	"skadi/backend/internal/app/solution"
+	"skadi/backend/internal/app/task"
+)
+
+// Ensure UCTeacher implements interfaces.
+var _ plagiarism.UsecaseTeacher = (*UCTeacher)(nil)
+
+// UCTeacher represents a plagiarism usecase for teacher.
+// It implements the [plagiarism.UsecaseTeacher] interface.
+type UCTeacher struct {
+	cfg              *config.Config
+	plagiarismRepoDB plagiarism.RepositoryDB
+	taskRepoDB       task.RepositoryDB
+	solRepoDB        solution.RepositoryDB
+}
+
+// NewUCTeacher returns a new instance of [UCTeacher].
+func NewUCTeacher(cfg *config.Config, plagiarismRepoDB plagiarism.RepositoryDB,
+	taskRepoDB task.RepositoryDB, solRepoDB solution.RepositoryDB) *UCTeacher {
+
+	return &UCTeacher{
+		cfg:              cfg,
+		plagiarismRepoDB: plagiarismRepoDB,
+		taskRepoDB:       taskRepoDB,
+		solRepoDB:        solRepoDB,
+	}
+}
+
+// Check requests comparison of all solutions of the own task with each other.
+// Solutions are compared in the background. The previous check of the task is replaced.
+// It returns the pending check.
+func (u *UCTeacher) Check(teacherID, taskID int) (*entity.PlagiarismCheck, error) {
+	if err := u.checkOwnTask(teacherID, taskID); err != nil {
+		return nil, err
+	}
+	checkObj, err := u.plagiarismRepoDB.Request(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("request check: %w", err)
+	}
+	return checkObj, nil
+}
+
+// GetReport returns the last check and suspicious solution pairs of the own task
+// (most similar first) with students of the pairs. Only pairs with similarity
+// not less than configured min similarity are saved, minSimilarity param filters them further.
+func (u *UCTeacher) GetReport(teacherID, taskID,
+	minSimilarity int) (*entity.PlagiarismReport, error) {
+
+	if err := u.checkOwnTask(teacherID, taskID); err != nil {
+		return nil, err
+	}
+	checkObj, err := u.plagiarismRepoDB.GetCheck(taskID)
+	// task was not checked on demand
+	if errors.Is(err, plagiarism.ErrNotFoundCheck) {
+		checkObj, err = nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get check: %w", err)
+	}
+	pairs, err := u.plagiarismRepoDB.GetPairs(taskID, minSimilarity)
+	if err != nil {
+		return nil, fmt.Errorf("get pairs: %w", err)
+	}
+
+	// set students of the pairs
+	if len(pairs) > 0 {
+		solList, err := u.solRepoDB.GetManyByTask(taskID)
+		if err != nil {
+			return nil, fmt.Errorf("get solutions: %w", err)
+		}
+		students := make(map[int]*entity.Profile, len(solList))
+		for _, solObj := range solList {
+			students[solObj.ID] = solObj.Student
+		}
+		for idx := range pairs {
+			pairs[idx].Student = students[pairs[idx].SolutionID]
+			pairs[idx].OtherStudent = students[pairs[idx].OtherSolutionID]
+		}
+	}
+	return &entity.PlagiarismReport{
+		Check: checkObj,
+		Pairs: pairs,
+	}, nil
+}
+
+// checkOwnTask returns nil error if the task exists and belongs to the teacher.
+func (u *UCTeacher) checkOwnTask(teacherID, taskID int) error {
+	taskObj, err := u.taskRepoDB.GetByID(taskID)
+	if errors.Is(err, task.ErrNotFound) {
+		return fmt.Errorf("%w: %w", plagiarism.ErrNotFound, err)
+	}
+	if err != nil {
+		return fmt.Errorf("get task: %w", err)
+	}
+	if taskObj.TeacherID != teacherID {
+		return fmt.Errorf("%w: user (teacher) is not a task owner", plagiarism.ErrForbidden)
+	}
+	return nil
+}
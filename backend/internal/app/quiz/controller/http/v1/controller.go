@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/quiz"
+	"skadi/backend/internal/pkg/httperror"
+	"skadi/backend/internal/pkg/serialize"
+	utilsjwt "skadi/backend/internal/pkg/utils/jwt"
+	"skadi/backend/internal/pkg/validator"
+)
+
+// QuizController represents a controller for quiz routes accepted for teachers and students.
+type QuizController struct {
+	valid        validator.Validator
+	quizUCClient quiz.UsecaseClient
+}
+
+// NewController returns a new instance of [QuizController].
+func NewController(quizUCClient quiz.UsecaseClient, valid validator.Validator) *QuizController {
+	return &QuizController{
+		valid:        valid,
+		quizUCClient: quizUCClient,
+	}
+}
+
+// @summary		Получение теста решения.
+// @description	Получение вопросов теста с ответами ученика, баллами за каждый ответ и суммой баллов.
+// @description	Для ученика правильные ответы не возвращаются.
+// @description	Ученику тест в режиме экзамена доступен только после начала попытки.
+// @router			/solution/{id}/quiz [get]
+// @id				quiz-read
+// @tags			quiz
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id	path		int	true	"ID решения"
+// @success		200	{object}	entity.Quiz
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+// @failure		404	"решение не найдено"
+// @failure		409	"задание не является тестом или попытка экзамена не начата"
+func (c *QuizController) Read(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &solutionIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	quizObj, err := c.quizUCClient.Get(inputPath.ID, userClaims)
+	if errors.Is(err, quiz.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "решение не найдено",
+		}
+	}
+	if errors.Is(err, quiz.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, quiz.ErrNotQuiz) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "задание не является тестом",
+		}
+	}
+	if errors.Is(err, quiz.ErrExamNotBegun) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "попытка экзамена не начата",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("read quiz: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(quizObj)
+}
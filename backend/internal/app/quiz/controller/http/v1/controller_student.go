@@ -0,0 +1,118 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/quiz"
+	"skadi/backend/internal/pkg/httperror"
+	"skadi/backend/internal/pkg/serialize"
+	utilsjwt "skadi/backend/internal/pkg/utils/jwt"
+	"skadi/backend/internal/pkg/validator"
+)
+
+// QuizControllerStudent represents a controller for quiz routes accepted for students only.
+type QuizControllerStudent struct {
+	valid         validator.Validator
+	quizUCStudent quiz.UsecaseStudent
+}
+
+// NewControllerStudent returns a new instance of [QuizControllerStudent].
+func NewControllerStudent(quizUCStudent quiz.UsecaseStudent,
+	valid validator.Validator) *QuizControllerStudent {
+
+	return &QuizControllerStudent{
+		valid:         valid,
+		quizUCStudent: quizUCStudent,
+	}
+}
+
+// @summary		Ответы на вопросы теста. [Только ученик]
+// @description	Сохранение ответов на вопросы теста (заменяют сохранённые ответы на те же вопросы). Решение переводится из статуса "не начато" в статус "в работе".
+// @description	Если передан флаг submit, тест проверяется сразу: за неотвеченные вопросы ставится 0 баллов, решение получает оценку (процент от максимума баллов) и статус "проверено".
+// @description	Ответ на вопрос с несколькими вариантами засчитывается, только если выбраны все правильные варианты. Короткий ответ сравнивается без учёта регистра и лишних пробелов (или с регулярным выражением целиком).
+// @router			/solution/{id}/quiz [put]
+// @id				quiz-answer
+// @tags			quiz
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id			path		int			true	"ID решения"
+// @param			answersBody	body		answersBody	true	"answersBody"
+// @success		200			{object}	entity.Quiz
+// @failure		400			"неверный ответ"
+// @failure		401			"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403			"доступ запрещён"
+// @failure		404			"решение не найдено | вопросы не заданы"
+// @failure		409			"задание не является тестом | тест уже проверен | попытка не начата или время вышло"
+func (c *QuizControllerStudent) Answer(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &solutionIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputBody := &answersBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	quizObj, err := c.quizUCStudent.Answer(userClaims.ID, inputPath.ID,
+		inputBody.ToEntityAnswers(), inputBody.Submit)
+	if errors.Is(err, quiz.ErrInvalidData) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "неверный ответ",
+		}
+	}
+	if errors.Is(err, quiz.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, quiz.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "решение не найдено",
+		}
+	}
+	if errors.Is(err, quiz.ErrNotFoundQuestions) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "вопросы не заданы",
+		}
+	}
+	if errors.Is(err, quiz.ErrNotQuiz) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "задание не является тестом",
+		}
+	}
+	if errors.Is(err, quiz.ErrGraded) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "тест уже проверен",
+		}
+	}
+	if errors.Is(err, quiz.ErrExamClosed) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "попытка не начата или время вышло",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("answer quiz: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(quizObj)
+}
@@ -0,0 +1,228 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/quiz"
+	"skadi/backend/internal/pkg/httperror"
+	"skadi/backend/internal/pkg/serialize"
+	utilsjwt "skadi/backend/internal/pkg/utils/jwt"
+	"skadi/backend/internal/pkg/validator"
+)
+
+// QuizControllerTeacher represents a controller for quiz routes accepted for teachers only.
+type QuizControllerTeacher struct {
+	valid         validator.Validator
+	quizUCTeacher quiz.UsecaseTeacher
+}
+
+// NewControllerTeacher returns a new instance of [QuizControllerTeacher].
+func NewControllerTeacher(quizUCTeacher quiz.UsecaseTeacher,
+	valid validator.Validator) *QuizControllerTeacher {
+
+	return &QuizControllerTeacher{
+		valid:         valid,
+		quizUCTeacher: quizUCTeacher,
+	}
+}
+
+// @summary		Получение вопросов теста. [Только преподаватель]
+// @description	Получение вопросов своего задания-теста с правильными ответами.
+// @router			/task/{id}/quiz [get]
+// @id				quiz-read-questions
+// @tags			quiz
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id	path	int	true	"ID задания"
+// @success		200	{array}	entity.QuizQuestion
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+// @failure		404	"задание не найдено"
+// @failure		409	"задание не является тестом"
+func (c *QuizControllerTeacher) ReadQuestions(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &taskIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	questions, err := c.quizUCTeacher.GetQuestions(userClaims.ID, inputPath.ID)
+	if errors.Is(err, quiz.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "задание не найдено",
+		}
+	}
+	if errors.Is(err, quiz.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, quiz.ErrNotQuiz) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "задание не является тестом",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("read questions: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(questions)
+}
+
+// @summary		Изменение вопросов теста. [Только преподаватель]
+// @description	Полная замена вопросов своего задания-теста (вопросы выводятся в переданном порядке). Вопросы нельзя заменить, если ученики уже ответили на них.
+// @description	Виды вопросов: single - один правильный вариант, multiple - несколько правильных вариантов (correct - индексы вариантов с 0),
+// @description	short - короткий ответ (answers - допустимые ответы или регулярные выражения, если regex), numeric - число (value с допустимым отклонением tolerance).
+// @router			/task/{id}/quiz [put]
+// @id				quiz-update-questions
+// @tags			quiz
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id				path	int				true	"ID задания"
+// @param			questionsBody	body	questionsBody	true	"questionsBody"
+// @success		200				{array}	entity.QuizQuestion
+// @failure		400				"неверный вопрос"
+// @failure		401				"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403				"доступ запрещён"
+// @failure		404				"задание не найдено"
+// @failure		409				"задание не является тестом | ученики уже ответили на вопросы"
+func (c *QuizControllerTeacher) UpdateQuestions(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &taskIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputBody := &questionsBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	questions, err := c.quizUCTeacher.SetQuestions(userClaims.ID, inputPath.ID,
+		inputBody.ToEntityQuestions())
+	if errors.Is(err, quiz.ErrInvalidData) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "неверный вопрос",
+		}
+	}
+	if errors.Is(err, quiz.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "задание не найдено",
+		}
+	}
+	if errors.Is(err, quiz.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, quiz.ErrNotQuiz) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "задание не является тестом",
+		}
+	}
+	if errors.Is(err, quiz.ErrHasAnswers) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "ученики уже ответили на вопросы",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("update questions: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(questions)
+}
+
+// @summary		Изменение баллов за ответ. [Только преподаватель]
+// @description	Установка баллов за ответ на вопрос проверенного теста вместо автоматических (null - сброс) с пересчётом оценки решения.
+// @router			/solution/{id}/quiz/{questionID} [patch]
+// @id				quiz-override
+// @tags			quiz
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id				path		int				true	"ID решения"
+// @param			questionID		path		int				true	"ID вопроса"
+// @param			overrideBody	body		overrideBody	true	"overrideBody"
+// @success		200				{object}	entity.Quiz
+// @failure		400				"баллы больше максимума за вопрос"
+// @failure		401				"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403				"доступ запрещён"
+// @failure		404				"решение или ответ не найдены"
+// @failure		409				"задание не является тестом | тест ещё не проверен"
+func (c *QuizControllerTeacher) Override(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &answerPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputBody := &overrideBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	quizObj, err := c.quizUCTeacher.Override(userClaims.ID, inputPath.ID,
+		inputPath.QuestionID, inputBody.Points)
+	if errors.Is(err, quiz.ErrInvalidData) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "баллы больше максимума за вопрос",
+		}
+	}
+	if errors.Is(err, quiz.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "решение или ответ не найдены",
+		}
+	}
+	if errors.Is(err, quiz.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, quiz.ErrNotQuiz) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "задание не является тестом",
+		}
+	}
+	if errors.Is(err, quiz.ErrNotGraded) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "тест ещё не проверен",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("override points: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(quizObj)
+}
@@ -0,0 +1,110 @@
+package v1
+
+import "skadi/backend/internal/app/entity"
+
+// @description taskIDPath represents a data with task ID in path params.
+type taskIDPath struct {
+	// task id
+	ID int `params:"id" validate:"required" example:"2"`
+}
+
+// @description solutionIDPath represents a data with solution ID in path params.
+type solutionIDPath struct {
+	// solution id
+	ID int `params:"id" validate:"required" example:"14"`
+}
+
+// @description answerPath represents a data with solution ID and question ID in path params.
+type answerPath struct {
+	// solution id
+	ID int `params:"id" validate:"required" example:"14"`
+	// question id
+	QuestionID int `params:"questionID" validate:"required" example:"12"`
+}
+
+// @description questionsBody represents a data with quiz questions.
+type questionsBody struct {
+	// questions in the order
+	Questions []questionBody `json:"questions" validate:"required,min=1,max=100,dive" minItems:"1" maxItems:"100"`
+}
+
+// ToEntityQuestions converts body to the list of question objects.
+func (q *questionsBody) ToEntityQuestions() []entity.QuizQuestion {
+	questions := make([]entity.QuizQuestion, 0, len(q.Questions))
+	for _, questionObj := range q.Questions {
+		questions = append(questions, entity.QuizQuestion{
+			Kind:      questionObj.Kind,
+			Text:      questionObj.Text,
+			Points:    questionObj.Points,
+			Options:   questionObj.Options,
+			Correct:   questionObj.Correct,
+			Answers:   questionObj.Answers,
+			Regex:     questionObj.Regex,
+			Value:     questionObj.Value,
+			Tolerance: questionObj.Tolerance,
+		})
+	}
+	return questions
+}
+
+// @description questionBody represents a data with quiz question and its answer key.
+type questionBody struct {
+	// question kind (single, multiple, short or numeric)
+	Kind entity.QuizKind `json:"kind" validate:"required,oneof=single multiple short numeric" example:"single" enums:"single,multiple,short,numeric"`
+	// question text
+	Text string `json:"text" validate:"required,max=2000" example:"Сколько будет 2 + 2?" maxLength:"2000"`
+	// max points for the correct answer
+	Points int `json:"points" validate:"required,min=1,max=100" example:"2" minimum:"1" maximum:"100"`
+	// answer options (for single and multiple choice)
+	Options []string `json:"options,omitempty" validate:"omitempty,max=20,dive,required,max=500" maxItems:"20" example:"3,4,5"`
+	// indexes of correct options starting from 0 (for single and multiple choice)
+	Correct []int `json:"correct,omitempty" validate:"omitempty,max=20,dive,min=0" maxItems:"20" example:"1"`
+	// accepted answers or regex patterns (for short answer)
+	Answers []string `json:"answers,omitempty" validate:"omitempty,max=20,dive,required,max=500" maxItems:"20" example:"4,четыре"`
+	// if true, accepted answers are regex patterns matched with the whole answer
+	Regex bool `json:"regex,omitempty" validate:"omitempty"`
+	// correct number (for numeric)
+	Value *float64 `json:"value,omitempty" validate:"omitempty" example:"4"`
+	// allowed deviation from the correct number (for numeric)
+	Tolerance float64 `json:"tolerance,omitempty" validate:"omitempty,min=0" example:"0.01" minimum:"0"`
+}
+
+// @description answersBody represents a data with answers to the quiz questions.
+type answersBody struct {
+	// answers (replace saved answers to the same questions)
+	Answers []answerBody `json:"answers" validate:"omitempty,max=100,dive" maxItems:"100"`
+	// if true, the quiz is submitted and graded (answers cannot be changed anymore)
+	Submit bool `json:"submit" validate:"omitempty" example:"true"`
+}
+
+// ToEntityAnswers converts body to the list of answer objects.
+func (a *answersBody) ToEntityAnswers() []entity.QuizAnswer {
+	answers := make([]entity.QuizAnswer, 0, len(a.Answers))
+	for _, answerObj := range a.Answers {
+		answers = append(answers, entity.QuizAnswer{
+			QuestionID: answerObj.QuestionID,
+			Choice:     answerObj.Choice,
+			Text:       answerObj.Text,
+			Number:     answerObj.Number,
+		})
+	}
+	return answers
+}
+
+// @description answerBody represents a data with answer to the quiz question.
+type answerBody struct {
+	// question id
+	QuestionID int `json:"question_id" validate:"required" example:"12"`
+	// indexes of chosen options starting from 0 (for single and multiple choice)
+	Choice []int `json:"choice,omitempty" validate:"omitempty,max=20,dive,min=0" maxItems:"20" example:"1"`
+	// text answer (for short answer)
+	Text *string `json:"text,omitempty" validate:"omitempty,max=1000" example:"четыре" maxLength:"1000"`
+	// number answer (for numeric)
+	Number *float64 `json:"number,omitempty" validate:"omitempty" example:"4"`
+}
+
+// @description overrideBody represents a data with points of the answer set by the teacher.
+type overrideBody struct {
+	// points instead of auto-graded ones (null to reset)
+	Points *int `json:"points" validate:"omitempty,min=0" example:"1" minimum:"0"`
+}
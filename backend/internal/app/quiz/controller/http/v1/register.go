@@ -0,0 +1,29 @@
+// Package http/v1 is a first version of quiz HTTP-controller.
+// It provides registers for quiz HTTP-routes and controllers with handlers for them.
+package v1
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/service/server/middleware"
+)
+
+// RegisterEndpoints registers all quiz endpoints.
+func RegisterEndpoints(router fiber.Router, controller *QuizController,
+	controllerStudent *QuizControllerStudent, controllerTeacher *QuizControllerTeacher,
+	mwJWTAccess fiber.Handler, mwAllow middleware.AllowFunc) {
+
+	mwTeacherOnly := mwAllow(entity.Teacher)
+	mwStudentOnly := mwAllow(entity.Student)
+	mwTeacherStudent := mwAllow(entity.Teacher, entity.Student)
+
+	taskGroup := router.Group("/task/:id/quiz", mwJWTAccess, mwTeacherOnly)
+	taskGroup.Get("/", controllerTeacher.ReadQuestions)
+	taskGroup.Put("/", controllerTeacher.UpdateQuestions)
+
+	solGroup := router.Group("/solution/:id/quiz", mwJWTAccess)
+	solGroup.Get("/", mwTeacherStudent, controller.Read)
+	solGroup.Put("/", mwStudentOnly, controllerStudent.Answer)
+	solGroup.Patch("/:questionID", mwTeacherOnly, controllerTeacher.Override)
+}
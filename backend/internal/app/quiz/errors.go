@@ -0,0 +1,16 @@
+package quiz
+
+import "errors"
+
+var (
+	ErrInvalidData       = errors.New("invalid data")               // code 400
+	ErrForbidden         = errors.New("forbidden")                  // code 403
+	ErrNotFound          = errors.New("record not found")           // code 404
+	ErrNotFoundQuestions = errors.New("quiz has no questions")      // code 404
+	ErrNotQuiz           = errors.New("task is not a quiz")         // code 409
+	ErrHasAnswers        = errors.New("quiz already has answers")   // code 409
+	ErrGraded            = errors.New("quiz is already graded")     // code 409
+	ErrNotGraded         = errors.New("quiz is not graded yet")     // code 409
+	ErrExamClosed        = errors.New("exam attempt is not active") // code 409
+	ErrExamNotBegun      = errors.New("exam attempt is not begun")  // code 409
+)
@@ -0,0 +1,20 @@
+package quiz
+
+import "skadi/backend/internal/app/entity"
+
+// RepositoryDB describes all DB methods for quiz questions and answers.
+type RepositoryDB interface {
+	// GetQuestions returns questions of the task (ordered by position).
+	GetQuestions(taskID int) ([]entity.QuizQuestion, error)
+	// SetQuestions replaces questions of the task with the given ones.
+	// Questions cannot be replaced if students have already answered them.
+	SetQuestions(taskID int, questions []entity.QuizQuestion) error
+
+	// GetAnswers returns answers of the solution.
+	GetAnswers(solutionID int) ([]entity.QuizAnswer, error)
+	// SaveAnswers replaces answers of the solution with the given ones.
+	SaveAnswers(solutionID int, answers []entity.QuizAnswer) error
+	// SetOverride sets points of the answer instead of auto-graded ones
+	// (nil points reset the override).
+	SetOverride(solutionID, questionID int, points *int) error
+}
@@ -0,0 +1,133 @@
+// Package repository contains quiz.RepositoryDB implementation.
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/quiz"
+)
+
+const (
+	_fieldTaskID         = "task_id"         // table field name
+	_fieldSolutionID     = "solution_id"     // table field name
+	_fieldQuestionID     = "question_id"     // table field name
+	_fieldPosition       = "position"        // table field name
+	_fieldOverridePoints = "override_points" // table field name
+)
+
+// Ensure RepoDB implements interface.
+var _ quiz.RepositoryDB = (*RepoDB)(nil)
+
+// RepoDB is a quiz DB repo.
+// It implements the [quiz.RepositoryDB] interface.
+type RepoDB struct {
+	dbStorage *gorm.DB
+}
+
+// NewRepoDB returns a new instance of [RepoDB].
+func NewRepoDB(dbStorage *gorm.DB) *RepoDB {
+	return &RepoDB{
+		dbStorage: dbStorage,
+	}
+}
+
+// GetQuestions returns questions of the task (ordered by position).
+func (r *RepoDB) GetQuestions(taskID int) ([]entity.QuizQuestion, error) {
+	questions := []entity.QuizQuestion{}
+	err := r.dbStorage.
+		Where(_fieldTaskID+" = ?", taskID).
+		Order(_fieldPosition).
+		Find(&questions).Error
+	if err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+// SetQuestions replaces questions of the task with the given ones.
+// Questions cannot be replaced if students have already answered them
+// (answers would be deleted with the old questions).
+func (r *RepoDB) SetQuestions(taskID int, questions []entity.QuizQuestion) error {
+	return r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		var answers int64
+		err := tx.Model(&entity.QuizAnswer{}).
+			Joins("JOIN quiz_question ON quiz_question.id = quiz_answer.question_id").
+			Where("quiz_question."+_fieldTaskID+" = ?", taskID).
+			Count(&answers).Error
+		if err != nil {
+			return fmt.Errorf("count answers: %w", err)
+		}
+		if answers > 0 {
+			return fmt.Errorf("task %d: %w", taskID, quiz.ErrHasAnswers)
+		}
+
+		if err := tx.Where(_fieldTaskID+" = ?", taskID).
+			Delete(&entity.QuizQuestion{}).Error; err != nil {
+			return fmt.Errorf("delete old questions: %w", err)
+		}
+		if len(questions) == 0 {
+			return nil
+		}
+		for idx := range questions {
+			questions[idx].ID = 0
+			questions[idx].TaskID = taskID
+		}
+		err = tx.Create(&questions).Error
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return fmt.Errorf("task with id: %w", quiz.ErrNotFound)
+		}
+		if err != nil {
+			return fmt.Errorf("create questions: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetAnswers returns answers of the solution.
+func (r *RepoDB) GetAnswers(solutionID int) ([]entity.QuizAnswer, error) {
+	answers := []entity.QuizAnswer{}
+	err := r.dbStorage.
+		Where(_fieldSolutionID+" = ?", solutionID).
+		Find(&answers).Error
+	if err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+// SaveAnswers replaces answers of the solution with the given ones.
+func (r *RepoDB) SaveAnswers(solutionID int, answers []entity.QuizAnswer) error {
+	return r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(_fieldSolutionID+" = ?", solutionID).
+			Delete(&entity.QuizAnswer{}).Error; err != nil {
+			return fmt.Errorf("delete old answers: %w", err)
+		}
+		if len(answers) == 0 {
+			return nil
+		}
+		for idx := range answers {
+			answers[idx].SolutionID = solutionID
+		}
+		err := tx.Create(&answers).Error
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return fmt.Errorf("solution or question with id: %w", quiz.ErrNotFound)
+		}
+		if err != nil {
+			return fmt.Errorf("create answers: %w", err)
+		}
+		return nil
+	})
+}
+
+// SetOverride sets points of the answer instead of auto-graded ones
+// (nil points reset the override).
+func (r *RepoDB) SetOverride(solutionID, questionID int, points *int) error {
+	return r.dbStorage.
+		Model(&entity.QuizAnswer{}).
+		Where(_fieldSolutionID+" = ? AND "+_fieldQuestionID+" = ?", solutionID, questionID).
+		Update(_fieldOverridePoints, points).Error // nil OR error
+}
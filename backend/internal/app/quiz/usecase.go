@@ -0,0 +1,37 @@
+// Package quiz contains all repos, usecases and controllers for quiz tasks
+// with auto-graded questions.
+// Sub-package repo contains RepoDB implementation.
+// Sub-package usecase contains UsecaseTeacher, UsecaseStudent and UsecaseClient implementations.
+package quiz
+
+import "skadi/backend/internal/app/entity"
+
+// UsecaseTeacher describes all quiz usecases for teacher.
+type UsecaseTeacher interface {
+	// GetQuestions returns questions of the own quiz task with the answer key.
+	GetQuestions(teacherID, taskID int) ([]entity.QuizQuestion, error)
+	// SetQuestions replaces questions of the own quiz task with the given ones.
+	// It returns the updated questions.
+	SetQuestions(teacherID, taskID int,
+		questions []entity.QuizQuestion) ([]entity.QuizQuestion, error)
+	// Override sets points of the answer to the question instead of auto-graded ones
+	// (nil points reset the override) and updates the solution grade.
+	// It returns the updated quiz of the solution.
+	Override(teacherID, solutionID, questionID int, points *int) (*entity.Quiz, error)
+}
+
+// UsecaseStudent describes all quiz usecases for student.
+type UsecaseStudent interface {
+	// Answer saves answers of the own solution. If submit is true,
+	// the quiz is graded immediately and the solution is marked as checked.
+	// It returns the updated quiz of the solution.
+	Answer(studID, solutionID int, answers []entity.QuizAnswer,
+		submit bool) (*entity.Quiz, error)
+}
+
+// UsecaseClient describes all quiz usecases for teacher and student.
+type UsecaseClient interface {
+	// Get returns questions of the solution quiz with answers.
+	// The answer key is removed for students.
+	Get(solutionID int, userClaims *entity.UserClaims) (*entity.Quiz, error)
+}
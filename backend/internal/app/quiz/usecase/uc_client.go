@@ -0,0 +1,109 @@
+// Package usecase contains quiz.UsecaseTeacher, quiz.UsecaseStudent and
+// quiz.UsecaseClient implementations.
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/quiz"
+	"skadi/backend/internal/app/solution"
+)
+
+const (
+	_backlogStatusID = 1 // ID of solution status "backlog"
+	_inWorkStatusID  = 2 // ID of solution status "in-work"
+	_checkedStatusID = 4 // ID of solution status "checked"
+)
+
+// Ensure UCClient implements interfaces.
+var _ quiz.UsecaseClient = (*UCClient)(nil)
+
+// UCClient represents a quiz usecase for teacher and student.
+// It implements the [quiz.UsecaseClient] interface.
+type UCClient struct {
+	cfg        *config.Config
+	quizRepoDB quiz.RepositoryDB
+	solRepoDB  solution.RepositoryDB
+}
+
+// NewUCClient returns a new instance of [UCClient].
+func NewUCClient(cfg *config.Config, quizRepoDB quiz.RepositoryDB,
+	solRepoDB solution.RepositoryDB) *UCClient {
+
+	return &UCClient{
+		cfg:        cfg,
+		quizRepoDB: quizRepoDB,
+		solRepoDB:  solRepoDB,
+	}
+}
+
+// Get returns questions of the solution quiz with answers.
+// The answer key is removed for students.
+func (u *UCClient) Get(solutionID int, userClaims *entity.UserClaims) (*entity.Quiz, error) {
+	// check user rights for this solution
+	err := u.solRepoDB.UserPermit(solutionID, userClaims)
+	if errors.Is(err, solution.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %w", quiz.ErrNotFound, err)
+	}
+	if errors.Is(err, solution.ErrForbidden) {
+		return nil, fmt.Errorf("%w: %w", quiz.ErrForbidden, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("check solution permissions: %w", err)
+	}
+
+	solObj, err := getSolution(u.solRepoDB, solutionID)
+	if err != nil {
+		return nil, err
+	}
+	// exam questions are not visible for students before the attempt is begun
+	if userClaims.IsStudent() && !solObj.ExamBegun() {
+		return nil, fmt.Errorf("%w: questions are hidden", quiz.ErrExamNotBegun)
+	}
+	return getQuiz(u.quizRepoDB, solObj, userClaims.IsStudent())
+}
+
+// getSolution returns the solution (with task) of the quiz task.
+func getSolution(solRepoDB solution.RepositoryDB, solutionID int) (*entity.Solution, error) {
+	solObj, err := solRepoDB.GetByID(solutionID)
+	if errors.Is(err, solution.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %w", quiz.ErrNotFound, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get solution: %w", err)
+	}
+	if solObj.Task.Type != entity.TaskQuiz {
+		return nil, fmt.Errorf("task %d: %w", solObj.TaskID, quiz.ErrNotQuiz)
+	}
+	return solObj, nil
+}
+
+// getQuiz returns questions of the solution quiz with answers and points.
+// If hideKey is true, the answer key is removed from questions.
+func getQuiz(quizRepoDB quiz.RepositoryDB, solObj *entity.Solution,
+	hideKey bool) (*entity.Quiz, error) {
+
+	questions, err := quizRepoDB.GetQuestions(solObj.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("get questions: %w", err)
+	}
+	answers, err := quizRepoDB.GetAnswers(solObj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get answers: %w", err)
+	}
+	quizObj := &entity.Quiz{
+		Questions: questions,
+		Answers:   answers,
+		Graded:    solObj.StatusID == _checkedStatusID,
+	}
+	quizObj.Summarize()
+	if hideKey {
+		for idx := range quizObj.Questions {
+			quizObj.Questions[idx].HideKey()
+		}
+	}
+	return quizObj, nil
+}
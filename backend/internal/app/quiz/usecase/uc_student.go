@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/event"
+	"skadi/backend/internal/app/notification"
+	"skadi/backend/internal/app/quiz"
+	"skadi/backend/internal/app/solution"
+)
+
+// Ensure UCStudent implements interfaces.
+var _ quiz.UsecaseStudent = (*UCStudent)(nil)
+
+// UCStudent represents a quiz usecase for student.
+// It implements the [quiz.UsecaseStudent] interface.
+type UCStudent struct {
+	cfg        *config.Config
+	quizRepoDB quiz.RepositoryDB
+	solRepoDB  solution.RepositoryDB
+	evtPub     event.Publisher
+	notifier   notification.Notifier
+}
+
+// NewUCStudent returns a new instance of [UCStudent].
+func NewUCStudent(cfg *config.Config, quizRepoDB quiz.RepositoryDB,
+	solRepoDB solution.RepositoryDB, evtPub event.Publisher,
+	notifier notification.Notifier) *UCStudent {
+
+	return &UCStudent{
+		cfg:        cfg,
+		quizRepoDB: quizRepoDB,
+		solRepoDB:  solRepoDB,
+		evtPub:     evtPub,
+		notifier:   notifier,
+	}
+}
+
+// Answer saves answers of the own solution (given answers replace the saved ones
+// to the same questions). Any member of the team solution can answer. The solution status is changed from "backlog" to "in-work".
+// If submit is true, all questions are graded immediately (unanswered ones get zero points),
+// the solution gets the grade as a percentage of max points and the "checked" status.
+// Answers of the quiz in exam mode are saved during the begun attempt only.
+// It returns the updated quiz of the solution.
+func (u *UCStudent) Answer(studID, solutionID int, answers []entity.QuizAnswer,
+	submit bool) (*entity.Quiz, error) {
+
+	solObj, err := getSolution(u.solRepoDB, solutionID)
+	if err != nil {
+		return nil, err
+	}
+	if !solObj.IsMember(studID) {
+		return nil, fmt.Errorf("%w: user (student) is not a solution member", quiz.ErrForbidden)
+	}
+	// drafts are not visible for students
+	if solObj.Task.Draft {
+		return nil, fmt.Errorf("solution with id: %w: task is a draft", quiz.ErrNotFound)
+	}
+	if solObj.StatusID == _checkedStatusID {
+		return nil, fmt.Errorf("solution %d: %w", solutionID, quiz.ErrGraded)
+	}
+	// answers of the exam are saved during the attempt only
+	if !solObj.ExamOpen(time.Now()) {
+		return nil, fmt.Errorf("%w: attempt is not begun or it is over", quiz.ErrExamClosed)
+	}
+
+	questions, err := u.quizRepoDB.GetQuestions(solObj.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("get questions: %w", err)
+	}
+	if len(questions) == 0 {
+		return nil, fmt.Errorf("task %d: %w", solObj.TaskID, quiz.ErrNotFoundQuestions)
+	}
+	savedAnswers, err := u.quizRepoDB.GetAnswers(solutionID)
+	if err != nil {
+		return nil, fmt.Errorf("get answers: %w", err)
+	}
+
+	// merge saved and new answers
+	questionByID := make(map[int]*entity.QuizQuestion, len(questions))
+	for idx := range questions {
+		questionByID[questions[idx].ID] = &questions[idx]
+	}
+	answerByID := make(map[int]entity.QuizAnswer, len(savedAnswers)+len(answers))
+	for _, answerObj := range savedAnswers {
+		answerByID[answerObj.QuestionID] = answerObj
+	}
+	for _, answerObj := range answers {
+		questionObj, ok := questionByID[answerObj.QuestionID]
+		if !ok {
+			return nil, fmt.Errorf("%w: question %d is not in the quiz",
+				quiz.ErrInvalidData, answerObj.QuestionID)
+		}
+		if err := questionObj.CheckAnswer(&answerObj); err != nil {
+			return nil, fmt.Errorf("%w: question %d: %s",
+				quiz.ErrInvalidData, answerObj.QuestionID, err.Error())
+		}
+		answerByID[answerObj.QuestionID] = answerObj
+	}
+
+	// keep answers in the question order, grade all questions on submit
+	newAnswers := make([]entity.QuizAnswer, 0, len(questions))
+	for idx := range questions {
+		answerObj, ok := answerByID[questions[idx].ID]
+		if !ok && !submit {
+			continue
+		}
+		answerObj.QuestionID = questions[idx].ID
+		answerObj.Points = nil
+		answerObj.OverridePoints = nil
+		if submit {
+			points := questions[idx].Grade(&answerObj)
+			answerObj.Points = &points
+		}
+		newAnswers = append(newAnswers, answerObj)
+	}
+	if err := u.quizRepoDB.SaveAnswers(solutionID, newAnswers); err != nil {
+		return nil, fmt.Errorf("save answers: %w", err)
+	}
+
+	quizObj := &entity.Quiz{
+		Questions: questions,
+		Answers:   newAnswers,
+		Graded:    submit,
+	}
+	quizObj.Summarize()
+	for idx := range quizObj.Questions {
+		quizObj.Questions[idx].HideKey()
+	}
+
+	newData := &entity.SolutionUpdate{}
+	switch {
+	case submit:
+		statusID, grade := _checkedStatusID, quizObj.Grade()
+		newData.StatusID, newData.Grade = &statusID, &grade
+	case solObj.StatusID == _backlogStatusID:
+		statusID := _inWorkStatusID
+		newData.StatusID = &statusID
+	default:
+		return quizObj, nil
+	}
+	if err := u.solRepoDB.Update(solutionID, newData); err != nil {
+		return nil, fmt.Errorf("update solution: %w", err)
+	}
+	solObj.StatusID = *newData.StatusID
+	if newData.Grade != nil {
+		solObj.Grade = newData.Grade
+	}
+	solObj.UpdatedAt = newData.UpdatedAt
+	// own changes are not unread updates
+	if err := u.solRepoDB.MarkRead(solutionID, studID, time.Now()); err != nil {
+		return nil, fmt.Errorf("mark read: %w", err)
+	}
+
+	// notify teacher and other team members about the new status or grade
+	u.evtPub.Publish(&entity.Event{Type: entity.EventSolutionUpdated, Data: solObj},
+		solObj.Task.TeacherID)
+	event.PublishToMembers(u.evtPub, entity.EventSolutionUpdated, solObj, studID)
+	// notify teacher that quiz was submitted
+	if submit {
+		u.notifier.Notify(&entity.Notification{
+			Type: entity.NotifySolutionSubmitted,
+			Message: fmt.Sprintf("Тест «%s» пройден, оценка: %s",
+				solObj.Task.Title, *solObj.Grade),
+			TaskID:     &solObj.TaskID,
+			SolutionID: &solObj.ID,
+		}, solObj.Task.TeacherID)
+	}
+	return quizObj, nil
+}
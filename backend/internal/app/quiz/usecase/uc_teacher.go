@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/event"
+	"skadi/backend/internal/app/notification"
+	"skadi/backend/internal/app/quiz"
+	"skadi/backend/internal/app/solution"
+	"skadi/backend/internal/app/task"
+)
+
+// Ensure UCTeacher implements interfaces.
+var _ quiz.UsecaseTeacher = (*UCTeacher)(nil)
+
+// UCTeacher represents a quiz usecase for teacher.
+// It implements the [quiz.UsecaseTeacher] interface.
+type UCTeacher struct {
+	cfg        *config.Config
+	quizRepoDB quiz.RepositoryDB
+	taskRepoDB task.RepositoryDB
+	solRepoDB  solution.RepositoryDB
+	evtPub     event.Publisher
+	notifier   notification.Notifier
+}
+
+// NewUCTeacher returns a new instance of [UCTeacher].
+func NewUCTeacher(cfg *config.Config, quizRepoDB quiz.RepositoryDB,
+	taskRepoDB task.RepositoryDB, solRepoDB solution.RepositoryDB,
+	evtPub event.Publisher, notifier notification.Notifier) *UCTeacher {
+
+	return &UCTeacher{
+		cfg:        cfg,
+		quizRepoDB: quizRepoDB,
+		taskRepoDB: taskRepoDB,
+		solRepoDB:  solRepoDB,
+		evtPub:     evtPub,
+		notifier:   notifier,
+	}
+}
+
+// GetQuestions returns questions of the own quiz task with the answer key.
+func (u *UCTeacher) GetQuestions(teacherID, taskID int) ([]entity.QuizQuestion, error) {
+	if err := u.checkOwnQuiz(teacherID, taskID); err != nil {
+		return nil, err
+	}
+	return u.quizRepoDB.GetQuestions(taskID)
+}
+
+// SetQuestions replaces questions of the own quiz task with the given ones.
+// Question positions are set by their order. Questions cannot be replaced
+// if students have already answered them. It returns the updated questions.
+func (u *UCTeacher) SetQuestions(teacherID, taskID int,
+	questions []entity.QuizQuestion) ([]entity.QuizQuestion, error) {
+
+	if err := u.checkOwnQuiz(teacherID, taskID); err != nil {
+		return nil, err
+	}
+	for idx := range questions {
+		if err := questions[idx].Validate(); err != nil {
+			return nil, fmt.Errorf("%w: question %d: %s", quiz.ErrInvalidData, idx+1, err.Error())
+		}
+		questions[idx].Position = idx + 1
+	}
+	if err := u.quizRepoDB.SetQuestions(taskID, questions); err != nil {
+		return nil, fmt.Errorf("set questions: %w", err)
+	}
+	return u.quizRepoDB.GetQuestions(taskID)
+}
+
+// Override sets points of the answer to the question instead of auto-graded ones
+// (nil points reset the override) and updates the solution grade.
+// Points can be overridden for the graded quiz only. It returns the updated quiz of the solution.
+func (u *UCTeacher) Override(teacherID, solutionID, questionID int,
+	points *int) (*entity.Quiz, error) {
+
+	solObj, err := getSolution(u.solRepoDB, solutionID)
+	if err != nil {
+		return nil, err
+	}
+	if teacherID != solObj.Task.TeacherID {
+		return nil, fmt.Errorf("%w: user (teacher) is not a task owner", quiz.ErrForbidden)
+	}
+	if solObj.StatusID != _checkedStatusID {
+		return nil, fmt.Errorf("solution %d: %w", solutionID, quiz.ErrNotGraded)
+	}
+
+	quizObj, err := getQuiz(u.quizRepoDB, solObj, false)
+	if err != nil {
+		return nil, err
+	}
+	qIdx := slices.IndexFunc(quizObj.Questions, func(q entity.QuizQuestion) bool {
+		return q.ID == questionID
+	})
+	aIdx := slices.IndexFunc(quizObj.Answers, func(a entity.QuizAnswer) bool {
+		return a.QuestionID == questionID
+	})
+	if qIdx == -1 || aIdx == -1 {
+		return nil, fmt.Errorf("answer to question %d: %w", questionID, quiz.ErrNotFound)
+	}
+	if points != nil && *points > quizObj.Questions[qIdx].Points {
+		return nil, fmt.Errorf("%w: points are greater than max points of the question",
+			quiz.ErrInvalidData)
+	}
+	if err := u.quizRepoDB.SetOverride(solutionID, questionID, points); err != nil {
+		return nil, fmt.Errorf("set override: %w", err)
+	}
+	quizObj.Answers[aIdx].OverridePoints = points
+	quizObj.Summarize()
+
+	grade := quizObj.Grade()
+	newData := &entity.SolutionUpdate{Grade: &grade}
+	if err := u.solRepoDB.Update(solutionID, newData); err != nil {
+		return nil, fmt.Errorf("update solution: %w", err)
+	}
+	solObj.Grade = &grade
+	solObj.UpdatedAt = newData.UpdatedAt
+	// own changes are not unread updates
+	if err := u.solRepoDB.MarkRead(solutionID, teacherID, time.Now()); err != nil {
+		return nil, fmt.Errorf("mark read: %w", err)
+	}
+
+	// notify students (all team members) about the new grade
+	event.PublishToMembers(u.evtPub, entity.EventSolutionUpdated, solObj, teacherID)
+	u.notifier.Notify(&entity.Notification{
+		Type:       entity.NotifySolutionChecked,
+		Message:    fmt.Sprintf("Оценка за тест «%s» изменена: %s", solObj.Task.Title, grade),
+		TaskID:     &solObj.TaskID,
+		SolutionID: &solObj.ID,
+	}, solObj.MemberIDs()...)
+	return quizObj, nil
+}
+
+// checkOwnQuiz returns nil error if the task exists, belongs to the teacher and it is a quiz.
+func (u *UCTeacher) checkOwnQuiz(teacherID, taskID int) error {
+	taskObj, err := u.taskRepoDB.GetByID(taskID)
+	if errors.Is(err, task.ErrNotFound) {
+		return fmt.Errorf("%w: %w", quiz.ErrNotFound, err)
+	}
+	if err != nil {
+		return fmt.Errorf("get task: %w", err)
+	}
+	if taskObj.TeacherID != teacherID {
+		return fmt.Errorf("%w: user (teacher) is not a task owner", quiz.ErrForbidden)
+	}
+	if taskObj.Type != entity.TaskQuiz {
+		return fmt.Errorf("task %d: %w", taskID, quiz.ErrNotQuiz)
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+// Package analyzer provides a background service to compare solutions for plagiarism.
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/plagiarism"
+	plagiarismrepo "skadi/backend/internal/app/plagiarism/repository"
+	plagiarismuc "skadi/backend/internal/app/plagiarism/usecase"
+	solrepo "skadi/backend/internal/app/solution/repository"
+	taskrepo "skadi/backend/internal/app/task/repository"
+	"skadi/backend/internal/pkg/retry"
+)
+
+// item represents a queued check of the task or comparison of the solution sent for review.
+type item struct {
+	checkObj  *entity.PlagiarismCheck
+	submitObj *entity.PlagiarismSubmit
+}
+
+// Analyzer represents a background service with workers comparing solutions for plagiarism
+// (all solutions of the task on demand and solutions sent for review with other ones).
+// Checks are taken from the DB queue, so every check is run by one backend instance.
+type Analyzer struct {
+	// will be closed if the service was completely started and is ready-to-use now
+	ready            chan struct{}
+	cfg              *config.Config
+	plagiarismRepoDB plagiarism.RepositoryDB
+	ucAnalyzer       plagiarism.UsecaseAnalyzer
+	queue            chan item
+}
+
+// New returns a new instance of [Analyzer].
+func New(cfg *config.Config, dbStorage *gorm.DB) (*Analyzer, error) {
+	plagiarismRepoDB := plagiarismrepo.NewRepoDB(dbStorage)
+	return &Analyzer{
+		ready:            make(chan struct{}),
+		cfg:              cfg,
+		plagiarismRepoDB: plagiarismRepoDB,
+		ucAnalyzer: plagiarismuc.NewUCAnalyzer(cfg, plagiarismRepoDB,
+			taskrepo.NewRepoDB(dbStorage), solrepo.NewRepoDB(dbStorage)),
+		queue: make(chan item),
+	}, nil
+}
+
+// StartWithShutdown starts compare workers, checks the queue
+// and waits for context is done for gracefully shutdown.
+// This method is blocking.
+func (s *Analyzer) StartWithShutdown(ctx context.Context) error {
+	slog.Info("start plagiarism analyzer...")
+	defer slog.Info("stop plagiarism analyzer: ok")
+
+	var wg sync.WaitGroup
+	for range s.cfg.Plagiarism.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.work(ctx)
+		}()
+	}
+
+	ticker := time.NewTicker(s.cfg.Plagiarism.PollInterval)
+	defer ticker.Stop()
+	// notify that service is ready-to-use
+	close(s.ready)
+	for {
+		s.enqueuePending(ctx)
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Ready signals that the service is ready-to-use.
+func (s *Analyzer) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// enqueuePending restarts stale checks and passes pending ones to the workers.
+func (s *Analyzer) enqueuePending(ctx context.Context) {
+	restarted, err := s.plagiarismRepoDB.RestartStale(
+		time.Now().Add(-s.cfg.Plagiarism.StaleAfter))
+	if err != nil {
+		slog.Warn("restart stale plagiarism checks", "error", err)
+	}
+	if restarted > 0 {
+		slog.Warn("restart stale plagiarism checks", "count", restarted)
+	}
+
+	var items []item
+	checks, err := s.plagiarismRepoDB.GetPendingChecks(s.cfg.Plagiarism.Workers)
+	if err != nil {
+		slog.Warn("get pending plagiarism checks", "error", err)
+	}
+	for idx := range checks {
+		items = append(items, item{checkObj: &checks[idx]})
+	}
+	submits, err := s.plagiarismRepoDB.GetPendingSubmits(s.cfg.Plagiarism.Workers)
+	if err != nil {
+		slog.Warn("get pending plagiarism submits", "error", err)
+	}
+	for idx := range submits {
+		items = append(items, item{submitObj: &submits[idx]})
+	}
+
+	for _, it := range items {
+		select {
+		case <-ctx.Done():
+			return
+		case s.queue <- it:
+		}
+	}
+}
+
+// work compares solutions from the queue until context is done.
+func (s *Analyzer) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case it := <-s.queue:
+			if it.checkObj != nil {
+				s.checkTask(it.checkObj)
+			} else {
+				s.checkSolution(it.submitObj)
+			}
+		}
+	}
+}
+
+// checkTask claims the check, compares all solutions of the task and saves the check status.
+// The check is claimed before comparing, so other backend instances skip it.
+func (s *Analyzer) checkTask(checkObj *entity.PlagiarismCheck) {
+	claimed, err := s.plagiarismRepoDB.ClaimCheck(checkObj, time.Now())
+	if err != nil {
+		slog.Warn("claim plagiarism check", "task_id", checkObj.TaskID, "error", err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	err = s.ucAnalyzer.CheckTask(checkObj.TaskID)
+	// task was deleted
+	if errors.Is(err, plagiarism.ErrNotFound) {
+		return
+	}
+	finishedAt := time.Now()
+	checkObj.Status = entity.PlagiarismDone
+	checkObj.Error = nil
+	checkObj.FinishedAt = &finishedAt
+	if err != nil {
+		slog.Error("check task for plagiarism", "task_id", checkObj.TaskID, "error", err)
+		errMsg := retry.ErrorText(err)
+		checkObj.Status = entity.PlagiarismFailed
+		checkObj.Error = &errMsg
+	}
+	if err := s.plagiarismRepoDB.FinishCheck(checkObj); err != nil {
+		slog.Warn("finish plagiarism check", "task_id", checkObj.TaskID, "error", err)
+		return
+	}
+	slog.Debug("check task for plagiarism: ok", "task_id", checkObj.TaskID,
+		"status", checkObj.Status)
+}
+
+// checkSolution claims the comparison, compares the solution with other solutions
+// of the task and deletes the comparison from the queue. The failed comparison is not
+// retried (the solution is compared again when it is sent for review or the task is checked).
+func (s *Analyzer) checkSolution(submitObj *entity.PlagiarismSubmit) {
+	claimed, err := s.plagiarismRepoDB.ClaimSubmit(submitObj, time.Now())
+	if err != nil {
+		slog.Warn("claim plagiarism submit", "solution_id", submitObj.SolutionID, "error", err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	err = s.ucAnalyzer.CheckSolution(submitObj.TaskID, submitObj.SolutionID)
+	// task or solution was deleted
+	if err != nil && !errors.Is(err, plagiarism.ErrNotFound) {
+		slog.Error("check solution for plagiarism", "solution_id", submitObj.SolutionID,
+			"error", err)
+	}
+	if err := s.plagiarismRepoDB.FinishSubmit(submitObj); err != nil {
+		slog.Warn("finish plagiarism submit", "solution_id", submitObj.SolutionID, "error", err)
+		return
+	}
+	slog.Debug("check solution for plagiarism: ok", "solution_id", submitObj.SolutionID)
+}
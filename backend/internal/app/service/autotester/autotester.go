@@ -0,0 +1,319 @@
+// Package autotester provides a background service to test solutions
+// of programming tasks in the sandbox.
+package autotester
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/autotest"
+	autotestrepo "skadi/backend/internal/app/autotest/repository"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/solution"
+	solrepo "skadi/backend/internal/app/solution/repository"
+	"skadi/backend/internal/pkg/sandbox"
+)
+
+const (
+	_dirPerms  = 0o777     // permissions for the run dir (the sandbox user writes build results to it)
+	_filePerms = 0o644     // permissions for the solution file copies
+	_maxErrLen = 16 * 1024 // max length of the saved compiler output
+)
+
+// test verdicts by sandbox verdicts
+var _verdicts = map[sandbox.Verdict]entity.AutotestStatus{
+	sandbox.VerdictOK:           entity.AutotestOK,
+	sandbox.VerdictWrongAnswer:  entity.AutotestWrongAnswer,
+	sandbox.VerdictTimeLimit:    entity.AutotestTimeLimit,
+	sandbox.VerdictMemoryLimit:  entity.AutotestMemoryLimit,
+	sandbox.VerdictRuntimeError: entity.AutotestRuntimeError,
+}
+
+// Autotester represents a background service with workers testing solutions in the sandbox.
+// Runs are taken from the DB queue, so every run is tested by one backend instance.
+// If autotests are disabled in config, the service does nothing.
+type Autotester struct {
+	// will be closed if the service was completely started and is ready-to-use now
+	ready          chan struct{}
+	cfg            *config.Config
+	sandbox        *sandbox.Sandbox
+	autotestRepoDB autotest.RepositoryDB
+	solRepoDB      solution.RepositoryDB
+	queue          chan entity.AutotestRun
+}
+
+// New returns a new instance of [Autotester].
+func New(cfg *config.Config, dbStorage *gorm.DB) (*Autotester, error) {
+	testCfg := cfg.Autotest
+	sb, err := sandbox.New(testCfg.Runner, testCfg.RunnerPath, testCfg.Mounts,
+		testCfg.StartGrace, testCfg.MaxOutput)
+	if err != nil {
+		return nil, fmt.Errorf("create sandbox: %w", err)
+	}
+	return &Autotester{
+		ready:          make(chan struct{}),
+		cfg:            cfg,
+		sandbox:        sb,
+		autotestRepoDB: autotestrepo.NewRepoDB(dbStorage),
+		solRepoDB:      solrepo.NewRepoDB(dbStorage),
+		queue:          make(chan entity.AutotestRun),
+	}, nil
+}
+
+// StartWithShutdown starts test workers, checks the run queue
+// and waits for context is done for gracefully shutdown.
+// This method is blocking.
+func (s *Autotester) StartWithShutdown(ctx context.Context) error {
+	if !s.cfg.Autotest.Enabled {
+		slog.Info("autotester is disabled")
+		close(s.ready)
+		<-ctx.Done()
+		return nil
+	}
+
+	slog.Info("start autotester...")
+	defer slog.Info("stop autotester: ok")
+
+	var wg sync.WaitGroup
+	for range s.cfg.Autotest.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.work(ctx)
+		}()
+	}
+
+	ticker := time.NewTicker(s.cfg.Autotest.PollInterval)
+	defer ticker.Stop()
+	// notify that service is ready-to-use
+	close(s.ready)
+	for {
+		s.enqueuePending(ctx)
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Ready signals that the service is ready-to-use.
+func (s *Autotester) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// enqueuePending restarts stale runs and passes pending runs to the workers.
+func (s *Autotester) enqueuePending(ctx context.Context) {
+	restarted, err := s.autotestRepoDB.RestartStale(time.Now().Add(-s.cfg.Autotest.StaleAfter))
+	if err != nil {
+		slog.Warn("restart stale autotest runs", "error", err)
+	}
+	if restarted > 0 {
+		slog.Warn("restart stale autotest runs", "count", restarted)
+	}
+
+	runs, err := s.autotestRepoDB.GetPending(s.cfg.Autotest.Workers)
+	if err != nil {
+		slog.Warn("get pending autotest runs", "error", err)
+		return
+	}
+	for _, runObj := range runs {
+		select {
+		case <-ctx.Done():
+			return
+		case s.queue <- runObj:
+		}
+	}
+}
+
+// work tests solutions from the queue until context is done.
+func (s *Autotester) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case runObj := <-s.queue:
+			s.test(ctx, &runObj)
+		}
+	}
+}
+
+// test claims the run, tests the solution and saves the results.
+// The run is claimed before testing, so other backend instances skip it.
+func (s *Autotester) test(ctx context.Context, runObj *entity.AutotestRun) {
+	claimed, err := s.autotestRepoDB.Claim(runObj, time.Now())
+	if err != nil {
+		slog.Warn("claim autotest run", "id", runObj.ID, "error", err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	if err := s.run(ctx, runObj); err != nil {
+		// run is restarted as stale after the service restart
+		if ctx.Err() != nil {
+			return
+		}
+		slog.Error("test solution", "run_id", runObj.ID, "solution_id", runObj.SolutionID,
+			"error", err)
+		errMsg := err.Error()
+		runObj.Status = entity.AutotestFailed
+		runObj.Error = &errMsg
+		runObj.Results = nil
+	}
+	finishedAt := time.Now()
+	runObj.FinishedAt = &finishedAt
+	if err := s.autotestRepoDB.Finish(runObj); err != nil {
+		slog.Warn("finish autotest run", "id", runObj.ID, "error", err)
+		return
+	}
+	slog.Debug("test solution: ok", "run_id", runObj.ID, "status", runObj.Status,
+		"passed", runObj.Passed, "total", runObj.Total)
+}
+
+// run copies solution files to the temporary dir, compiles the solution
+// and runs all suite tests. Status, counters, score and results are set to the run object.
+func (s *Autotester) run(ctx context.Context, runObj *entity.AutotestRun) error {
+	solObj, err := s.solRepoDB.GetByIDFull(runObj.SolutionID)
+	if err != nil {
+		return fmt.Errorf("get solution: %w", err)
+	}
+	suiteObj, err := s.autotestRepoDB.GetSuite(solObj.TaskID)
+	if err != nil {
+		return fmt.Errorf("get suite: %w", err)
+	}
+	langCfg, ok := s.cfg.Autotest.Langs[suiteObj.Lang]
+	if !ok {
+		return fmt.Errorf("language %q is not configured", suiteObj.Lang)
+	}
+	lang := &sandbox.Lang{Image: langCfg.Image, Build: langCfg.Build, Run: langCfg.Run}
+
+	dir, err := os.MkdirTemp(s.cfg.Autotest.WorkDir, "run-*")
+	if err != nil {
+		return fmt.Errorf("create run dir: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			slog.Warn("remove autotest run dir", "path", dir, "error", err)
+		}
+	}()
+	if err := os.Chmod(dir, _dirPerms); err != nil {
+		return fmt.Errorf("chmod run dir: %w", err)
+	}
+	files := solObj.Files.Downloadable(s.cfg.Media.Scan.Enabled)
+	if err := copyFiles(files, dir, langCfg.Entry); err != nil {
+		return fmt.Errorf("copy solution files: %w", err)
+	}
+
+	// compile solution
+	buildRes, err := s.sandbox.Build(ctx, lang, dir, s.cfg.Autotest.BuildTimeout)
+	if err != nil {
+		return fmt.Errorf("build: %w", err)
+	}
+	runObj.Total = len(suiteObj.Cases)
+	if buildRes != nil && (buildRes.TimedOut || buildRes.ExitCode != 0) {
+		output := strings.TrimSpace(buildRes.Stderr + "\n" + buildRes.Stdout)
+		if buildRes.TimedOut {
+			output = strings.TrimSpace("build timed out\n" + output)
+		}
+		if len(output) > _maxErrLen {
+			output = strings.ToValidUTF8(output[:_maxErrLen], "")
+		}
+		score := 0
+		runObj.Status = entity.AutotestCompileError
+		runObj.Error = &output
+		runObj.Score = &score
+		return nil
+	}
+
+	// run tests
+	runObj.Results = make([]entity.AutotestResult, 0, len(suiteObj.Cases))
+	for _, caseObj := range suiteObj.Cases {
+		res, err := s.sandbox.Run(ctx, lang, dir, caseObj.Stdin, sandbox.Limits{
+			Time:     time.Duration(caseObj.TimeLimitMS) * time.Millisecond,
+			MemoryMB: caseObj.MemoryLimitMB,
+		})
+		if err != nil {
+			return fmt.Errorf("run test %d: %w", caseObj.Position, err)
+		}
+		status := _verdicts[res.Verdict(caseObj.Expected)]
+		if status == entity.AutotestOK {
+			runObj.Passed++
+		}
+		runObj.Results = append(runObj.Results, entity.AutotestResult{
+			Position: caseObj.Position,
+			Hidden:   caseObj.Hidden,
+			Status:   status,
+			TimeMS:   int(res.Time.Milliseconds()),
+			Stdin:    &caseObj.Stdin,
+			Expected: &caseObj.Expected,
+			Stdout:   &res.Stdout,
+			Stderr:   &res.Stderr,
+		})
+	}
+	score := 0
+	if runObj.Total > 0 {
+		score = runObj.Passed * 100 / runObj.Total
+	}
+	runObj.Status = entity.AutotestDone
+	runObj.Score = &score
+	return nil
+}
+
+// copyFiles copies solution files to the dir by their base names.
+// If there is no entry file, the only file with the entry extension is copied as the entry file.
+func copyFiles(files entity.Files, dir, entry string) error {
+	names := make([]string, len(files))
+	hasEntry := false
+	var entryCandidates []int
+	for idx, fileObj := range files {
+		names[idx] = filepath.Base(fileObj.Name)
+		if names[idx] == entry {
+			hasEntry = true
+		}
+		if filepath.Ext(names[idx]) == filepath.Ext(entry) {
+			entryCandidates = append(entryCandidates, idx)
+		}
+	}
+	if !hasEntry && len(entryCandidates) == 1 {
+		names[entryCandidates[0]] = entry
+	}
+
+	for idx, fileObj := range files {
+		if err := copyFile(fileObj.Path, filepath.Join(dir, names[idx])); err != nil {
+			return fmt.Errorf("copy file %d: %w", fileObj.ID, err)
+		}
+	}
+	return nil
+}
+
+// copyFile copies the file from the source path to the destination path.
+func copyFile(srcPath, dstPath string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(dstPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, _filePerms)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	return dst.Close()
+}
@@ -0,0 +1,186 @@
+// Package dispatcher provides a background service to dispatch domain events
+// from the transactional outbox to the in-process subscribers.
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	goslices "slices"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/outbox"
+	outboxrepo "skadi/backend/internal/app/outbox/repository"
+	"skadi/backend/internal/pkg/retry"
+)
+
+// Ensure Dispatcher implements interface.
+var _ outbox.Bus = (*Dispatcher)(nil)
+
+// namedHandler represents a subscribed handler with its name.
+type namedHandler struct {
+	name    string
+	handler outbox.Handler
+}
+
+// Dispatcher represents a background service dispatching outbox events
+// to the subscribed handlers (at least once).
+// If any handler fails, the event is retried with exponential backoff
+// for the failed handlers only (successful handlings are saved).
+// It implements the [outbox.Bus] interface.
+type Dispatcher struct {
+	// will be closed if the service was completely started and is ready-to-use now
+	ready        chan struct{}
+	cfg          *config.Config
+	retry        retry.Policy
+	outboxRepoDB outbox.RepositoryDB
+
+	mu       sync.RWMutex
+	handlers map[entity.OutboxTopic][]namedHandler
+}
+
+// New returns a new instance of [Dispatcher].
+func New(cfg *config.Config, dbStorage *gorm.DB) (*Dispatcher, error) {
+	return &Dispatcher{
+		ready:        make(chan struct{}),
+		cfg:          cfg,
+		retry:        retry.Policy{MaxAttempts: cfg.Outbox.MaxAttempts, Delay: cfg.Outbox.RetryDelay},
+		outboxRepoDB: outboxrepo.NewRepoDB(dbStorage),
+		handlers:     make(map[entity.OutboxTopic][]namedHandler),
+	}, nil
+}
+
+// Subscribe registers the handler with the unique name for the given event topics.
+// The name is saved with handled events, so it must not be changed.
+func (d *Dispatcher) Subscribe(name string, handler outbox.Handler, topics ...entity.OutboxTopic) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, topic := range topics {
+		d.handlers[topic] = append(d.handlers[topic], namedHandler{name: name, handler: handler})
+	}
+}
+
+// StartWithShutdown starts checking the outbox
+// and waits for context is done for gracefully shutdown.
+// This method is blocking.
+func (d *Dispatcher) StartWithShutdown(ctx context.Context) error {
+	slog.Info("start outbox dispatcher...")
+	defer slog.Info("stop outbox dispatcher: ok")
+
+	ticker := time.NewTicker(d.cfg.Outbox.PollInterval)
+	defer ticker.Stop()
+	// notify that service is ready-to-use
+	close(d.ready)
+	for {
+		d.dispatchDue(ctx)
+		d.cleanup()
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Ready signals that the service is ready-to-use.
+func (d *Dispatcher) Ready() <-chan struct{} {
+	return d.ready
+}
+
+// dispatchDue dispatches outbox events which next attempt time has come.
+func (d *Dispatcher) dispatchDue(ctx context.Context) {
+	events, err := d.outboxRepoDB.GetDue(time.Now(), d.cfg.Outbox.MaxAttempts,
+		d.cfg.Outbox.BatchSize)
+	if err != nil {
+		slog.Warn("get outbox events", "error", err)
+		return
+	}
+	for idx := range events {
+		if ctx.Err() != nil {
+			return
+		}
+		d.dispatch(&events[idx])
+	}
+}
+
+// dispatch passes one event to all subscribed handlers and saves the result.
+// The event is claimed before dispatching, so other backend instances skip it.
+func (d *Dispatcher) dispatch(evtObj *entity.OutboxEvent) {
+	d.retry.Process(&retry.Item{
+		Name:     "outbox event",
+		Action:   "dispatch",
+		Attrs:    []any{"id", evtObj.ID, "topic", evtObj.Topic},
+		Attempts: evtObj.Attempts,
+		Claim: func() (bool, error) {
+			return d.outboxRepoDB.Claim(evtObj, time.Now().Add(d.cfg.Outbox.ClaimTimeout))
+		},
+		Run: func() error {
+			return d.handle(evtObj)
+		},
+		Done: func() error {
+			return d.outboxRepoDB.MarkDispatched(evtObj.ID, time.Now())
+		},
+		Failed: func(attempts int, nextAttemptAt time.Time, errMsg string) error {
+			return d.outboxRepoDB.MarkFailed(evtObj.ID, attempts, nextAttemptAt, errMsg)
+		},
+	})
+}
+
+// handle calls all handlers subscribed to the event topic
+// except the handlers which have already handled the event.
+// Panics of the handlers are recovered and returned as errors.
+func (d *Dispatcher) handle(evtObj *entity.OutboxEvent) error {
+	d.mu.RLock()
+	handlers := d.handlers[evtObj.Topic]
+	d.mu.RUnlock()
+
+	// the event could be handled partially by the failed attempt or the stopped instance
+	handled, err := d.outboxRepoDB.GetHandled(evtObj.ID)
+	if err != nil {
+		return fmt.Errorf("get handled: %w", err)
+	}
+
+	var errs []error
+	for _, h := range handlers {
+		if goslices.Contains(handled, h.name) {
+			continue
+		}
+		if err := safeHandle(h.handler, evtObj); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", h.name, err))
+			continue
+		}
+		if err := d.outboxRepoDB.MarkHandled(evtObj.ID, h.name, time.Now()); err != nil {
+			slog.Warn("mark outbox event handled", "id", evtObj.ID, "handler", h.name,
+				"error", err)
+		}
+	}
+	return errors.Join(errs...) // nil OR error
+}
+
+// cleanup deletes dispatched events older than the retention time.
+func (d *Dispatcher) cleanup() {
+	deleted, err := d.outboxRepoDB.DeleteDispatched(time.Now().Add(-d.cfg.Outbox.Retention))
+	if err != nil {
+		slog.Warn("delete dispatched outbox events", "error", err)
+		return
+	}
+	if deleted > 0 {
+		slog.Debug("delete dispatched outbox events: ok", "count", deleted)
+	}
+}
+
+// safeHandle calls the handler and recovers its panic.
+func safeHandle(handler outbox.Handler, evtObj *entity.OutboxEvent) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("handler panic: %v", rec)
+		}
+	}()
+	return handler(evtObj)
+}
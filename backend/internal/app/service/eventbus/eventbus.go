@@ -0,0 +1,179 @@
+// Package eventbus provides a background service to deliver real-time events to users.
+// Events are published to the redis pub/sub channel, so every backend instance
+// receives them and passes them to its own subscribers.
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/event"
+	"skadi/backend/internal/pkg/cache"
+)
+
+// Ensure EventBus implements interfaces.
+var (
+	_ event.Publisher  = (*EventBus)(nil)
+	_ event.Subscriber = (*EventBus)(nil)
+)
+
+// message represents an event with its recipients in the pub/sub channel.
+type message struct {
+	UserIDs []int           `json:"user_ids"`
+	Event   json.RawMessage `json:"event"`
+}
+
+// EventBus represents a background service to fan out events
+// from the pub/sub channel to the local subscribers.
+// It implements the [event.Publisher] and [event.Subscriber] interfaces.
+type EventBus struct {
+	// will be closed if the service was completely started and is ready-to-use now
+	ready  chan struct{}
+	cfg    *config.Config
+	pubSub cache.PubSub
+
+	mu          sync.Mutex
+	closed      bool
+	subscribers map[int]map[chan []byte]struct{} // subscribers chans by user ID
+}
+
+// New returns a new instance of [EventBus].
+func New(cfg *config.Config, pubSub cache.PubSub) (*EventBus, error) {
+	return &EventBus{
+		ready:       make(chan struct{}),
+		cfg:         cfg,
+		pubSub:      pubSub,
+		subscribers: make(map[int]map[chan []byte]struct{}),
+	}, nil
+}
+
+// Publish sends the event to all subscribed sessions of the given users
+// on all backend instances. It blocks until the event is published to the pub/sub
+// channel. Errors are only logged.
+func (b *EventBus) Publish(evt *entity.Event, userIDs ...int) {
+	if len(userIDs) == 0 {
+		return
+	}
+	evtData, err := json.Marshal(evt)
+	if err != nil {
+		slog.Warn("encode event", "type", evt.Type, "error", err)
+		return
+	}
+	msgData, err := json.Marshal(&message{UserIDs: userIDs, Event: evtData})
+	if err != nil {
+		slog.Warn("encode event message", "type", evt.Type, "error", err)
+		return
+	}
+	if err := b.pubSub.Publish(b.cfg.Event.Channel, msgData); err != nil {
+		slog.Warn("publish event", "type", evt.Type, "error", err)
+	}
+}
+
+// Subscribe returns a chan with JSON-encoded events for the given user
+// and a func to unsubscribe. The chan is closed after unsubscribing
+// or when the service is stopped.
+func (b *EventBus) Subscribe(userID int) (<-chan []byte, func()) {
+	evtChan := make(chan []byte, b.cfg.Event.BufferSize)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(evtChan)
+		return evtChan, func() {}
+	}
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = make(map[chan []byte]struct{})
+	}
+	b.subscribers[userID][evtChan] = struct{}{}
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() { b.unsubscribe(userID, evtChan) })
+	}
+	return evtChan, unsubscribe
+}
+
+// StartWithShutdown subscribes to the pub/sub channel and waits for
+// context is done for gracefully shutdown. All subscribers chans are closed on shutdown.
+// This method is blocking.
+func (b *EventBus) StartWithShutdown(ctx context.Context) error {
+	slog.Info("start event bus...")
+	defer slog.Info("stop event bus: ok")
+	defer b.closeAll()
+
+	msgChan, err := b.pubSub.Subscribe(ctx, b.cfg.Event.Channel)
+	if err != nil {
+		// app waits for all services are ready before handling the error
+		close(b.ready)
+		return fmt.Errorf("subscribe to events: %w", err)
+	}
+
+	// notify that service is ready-to-use
+	close(b.ready)
+	for msgData := range msgChan {
+		b.dispatch(msgData)
+	}
+	if ctx.Err() == nil {
+		return fmt.Errorf("events subscription was closed")
+	}
+	return nil
+}
+
+// Ready signals that the service is ready-to-use.
+func (b *EventBus) Ready() <-chan struct{} {
+	return b.ready
+}
+
+// dispatch passes the event from the pub/sub message to the local subscribers.
+// If subscriber buffer is full, the event is dropped for it.
+func (b *EventBus) dispatch(msgData []byte) {
+	msg := &message{}
+	if err := json.Unmarshal(msgData, msg); err != nil {
+		slog.Warn("decode event message", "error", err)
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, userID := range msg.UserIDs {
+		for evtChan := range b.subscribers[userID] {
+			select {
+			case evtChan <- msg.Event:
+			default:
+				slog.Debug("event buffer is full: skip event", "user_id", userID)
+			}
+		}
+	}
+}
+
+// unsubscribe removes the subscriber chan and closes it.
+func (b *EventBus) unsubscribe(userID int, evtChan chan []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subscribers[userID][evtChan]; !ok {
+		return // already closed on shutdown
+	}
+	delete(b.subscribers[userID], evtChan)
+	if len(b.subscribers[userID]) == 0 {
+		delete(b.subscribers, userID)
+	}
+	close(evtChan)
+}
+
+// closeAll closes all subscribers chans and prevents new subscriptions.
+func (b *EventBus) closeAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for userID, userChans := range b.subscribers {
+		for evtChan := range userChans {
+			close(evtChan)
+		}
+		delete(b.subscribers, userID)
+	}
+}
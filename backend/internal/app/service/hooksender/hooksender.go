@@ -0,0 +1,118 @@
+// Package hooksender provides a background service to deliver domain events
+// from the durable queue to the webhooks.
+package hooksender
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/webhook"
+	hookrepo "skadi/backend/internal/app/webhook/repository"
+	"skadi/backend/internal/pkg/retry"
+	pkgwebhook "skadi/backend/internal/pkg/webhook"
+)
+
+// HookSender represents a background service delivering events to the webhooks.
+// Failed deliveries are retried with exponential backoff.
+type HookSender struct {
+	// will be closed if the service was completely started and is ready-to-use now
+	ready      chan struct{}
+	cfg        *config.Config
+	sender     *pkgwebhook.Sender
+	retry      retry.Policy
+	hookRepoDB webhook.RepositoryDB
+}
+
+// New returns a new instance of [HookSender].
+func New(cfg *config.Config, dbStorage *gorm.DB) (*HookSender, error) {
+	return &HookSender{
+		ready:      make(chan struct{}),
+		cfg:        cfg,
+		sender:     pkgwebhook.NewSender(cfg.Webhook.Timeout),
+		retry:      retry.Policy{MaxAttempts: cfg.Webhook.MaxAttempts, Delay: cfg.Webhook.RetryDelay},
+		hookRepoDB: hookrepo.NewRepoDB(dbStorage),
+	}, nil
+}
+
+// StartWithShutdown starts checking the delivery queue
+// and waits for context is done for gracefully shutdown.
+// This method is blocking.
+func (s *HookSender) StartWithShutdown(ctx context.Context) error {
+	slog.Info("start webhook sender...")
+	defer slog.Info("stop webhook sender: ok")
+
+	ticker := time.NewTicker(s.cfg.Webhook.PollInterval)
+	defer ticker.Stop()
+	// notify that service is ready-to-use
+	close(s.ready)
+	for {
+		s.sendDue(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Ready signals that the service is ready-to-use.
+func (s *HookSender) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// sendDue sends enqueued deliveries which next attempt time has come.
+func (s *HookSender) sendDue(ctx context.Context) {
+	deliveries, err := s.hookRepoDB.GetDue(time.Now(), s.cfg.Webhook.MaxAttempts,
+		s.cfg.Webhook.BatchSize)
+	if err != nil {
+		slog.Warn("get enqueued webhook deliveries", "error", err)
+		return
+	}
+	for idx := range deliveries {
+		if ctx.Err() != nil {
+			return
+		}
+		s.send(ctx, &deliveries[idx])
+	}
+}
+
+// send sends one delivery and saves the result.
+// The delivery is claimed before sending, so other backend instances skip it.
+func (s *HookSender) send(ctx context.Context, deliveryObj *entity.WebhookDelivery) {
+	var statusCode int
+	s.retry.Process(&retry.Item{
+		Name:     "webhook delivery",
+		Action:   "send",
+		Attrs:    []any{"id", deliveryObj.ID},
+		Attempts: deliveryObj.Attempts,
+		Claim: func() (bool, error) {
+			return s.hookRepoDB.Claim(deliveryObj, time.Now().Add(s.cfg.Webhook.Timeout*2))
+		},
+		Run: func() error {
+			var err error
+			statusCode, err = s.sender.Send(ctx, &pkgwebhook.Request{
+				URL:        deliveryObj.Webhook.URL,
+				Secret:     deliveryObj.Webhook.Secret,
+				Event:      string(deliveryObj.Event),
+				DeliveryID: deliveryObj.ID,
+				Body:       []byte(deliveryObj.Payload),
+			})
+			return err
+		},
+		Done: func() error {
+			return s.hookRepoDB.MarkDelivered(deliveryObj.ID, statusCode, time.Now())
+		},
+		Failed: func(attempts int, nextAttemptAt time.Time, errMsg string) error {
+			var respStatus *int
+			if statusCode != 0 {
+				respStatus = &statusCode
+			}
+			return s.hookRepoDB.MarkFailed(deliveryObj.ID, attempts, respStatus, nextAttemptAt, errMsg)
+		},
+	})
+}
@@ -0,0 +1,117 @@
+// Package mailer provides a background service to send e-mails from the durable queue.
+package mailer
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/mail"
+	mailrepo "skadi/backend/internal/app/mail/repository"
+	pkgmail "skadi/backend/internal/pkg/mail"
+	"skadi/backend/internal/pkg/retry"
+)
+
+// Mailer represents a background service sending e-mails from the queue.
+// Failed e-mails are retried with exponential backoff.
+// If e-mails are disabled in config, the service does nothing.
+type Mailer struct {
+	// will be closed if the service was completely started and is ready-to-use now
+	ready      chan struct{}
+	cfg        *config.Config
+	sender     *pkgmail.Sender
+	retry      retry.Policy
+	mailRepoDB mail.RepositoryDB
+}
+
+// New returns a new instance of [Mailer].
+func New(cfg *config.Config, dbStorage *gorm.DB) (*Mailer, error) {
+	mailCfg := cfg.Mail
+	return &Mailer{
+		ready: make(chan struct{}),
+		cfg:   cfg,
+		sender: pkgmail.NewSender(mailCfg.Host, mailCfg.Port, mailCfg.Username, mailCfg.Password,
+			mailCfg.From, mailCfg.ImplicitTLS, mailCfg.Timeout),
+		retry:      retry.Policy{MaxAttempts: mailCfg.MaxAttempts, Delay: mailCfg.RetryDelay},
+		mailRepoDB: mailrepo.NewRepoDB(dbStorage),
+	}, nil
+}
+
+// StartWithShutdown starts checking the mail queue
+// and waits for context is done for gracefully shutdown.
+// This method is blocking.
+func (m *Mailer) StartWithShutdown(ctx context.Context) error {
+	if !m.cfg.Mail.Enabled {
+		slog.Info("mailer is disabled")
+		close(m.ready)
+		<-ctx.Done()
+		return nil
+	}
+
+	slog.Info("start mailer...")
+	defer slog.Info("stop mailer: ok")
+
+	ticker := time.NewTicker(m.cfg.Mail.PollInterval)
+	defer ticker.Stop()
+	// notify that service is ready-to-use
+	close(m.ready)
+	for {
+		m.sendDue(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Ready signals that the service is ready-to-use.
+func (m *Mailer) Ready() <-chan struct{} {
+	return m.ready
+}
+
+// sendDue sends enqueued e-mails which next attempt time has come.
+func (m *Mailer) sendDue(ctx context.Context) {
+	mails, err := m.mailRepoDB.GetDue(time.Now(), m.cfg.Mail.MaxAttempts, m.cfg.Mail.BatchSize)
+	if err != nil {
+		slog.Warn("get enqueued mails", "error", err)
+		return
+	}
+	for idx := range mails {
+		if ctx.Err() != nil {
+			return
+		}
+		m.send(ctx, &mails[idx])
+	}
+}
+
+// send sends one e-mail and saves the result.
+// The mail is claimed before sending, so other backend instances skip it.
+func (m *Mailer) send(ctx context.Context, mailObj *entity.Mail) {
+	m.retry.Process(&retry.Item{
+		Name:     "mail",
+		Action:   "send",
+		Attrs:    []any{"id", mailObj.ID},
+		Attempts: mailObj.Attempts,
+		Claim: func() (bool, error) {
+			return m.mailRepoDB.Claim(mailObj, time.Now().Add(m.cfg.Mail.Timeout*2))
+		},
+		Run: func() error {
+			return m.sender.Send(ctx, &pkgmail.Message{
+				To:      mailObj.Recipient,
+				Subject: mailObj.Subject,
+				HTML:    mailObj.Body,
+			})
+		},
+		Done: func() error {
+			return m.mailRepoDB.MarkSent(mailObj.ID, time.Now())
+		},
+		Failed: func(attempts int, nextAttemptAt time.Time, errMsg string) error {
+			return m.mailRepoDB.MarkFailed(mailObj.ID, attempts, nextAttemptAt, errMsg)
+		},
+	})
+}
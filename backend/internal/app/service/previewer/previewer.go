@@ -0,0 +1,113 @@
+// Package previewer provides a background service to make previews for uploaded files.
+package previewer
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"sync"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/pkg/preview"
+)
+
+// Previewer represents a background service with workers making file previews.
+type Previewer struct {
+	// will be closed if the service was completely started and is ready-to-use now
+	ready     chan struct{}
+	cfg       *config.Config
+	generator *preview.Generator
+	queue     chan *entity.File
+}
+
+// New returns a new instance of [Previewer].
+func New(cfg *config.Config) (*Previewer, error) {
+	return &Previewer{
+		ready:     make(chan struct{}),
+		cfg:       cfg,
+		generator: preview.NewGenerator(cfg.Media.Preview.MaxSize, cfg.Media.Preview.PDFRenderer),
+		queue:     make(chan *entity.File, cfg.Media.Preview.QueueSize),
+	}, nil
+}
+
+// Enqueue adds files to the preview queue. This method is non-blocking.
+// Files with unsupported MIME-types are skipped.
+// If the queue is full, the file is skipped too (it will have no preview).
+func (p *Previewer) Enqueue(files ...*entity.File) {
+	for _, fileObj := range files {
+		if !preview.Supported(fileObj.MimeType) {
+			continue
+		}
+		select {
+		case p.queue <- fileObj:
+		default:
+			slog.Warn("preview queue is full: skip file", "path", fileObj.Path)
+		}
+	}
+}
+
+// StartWithShutdown starts preview workers and waits for
+// context is done for gracefully shutdown them.
+// This method is blocking.
+func (p *Previewer) StartWithShutdown(ctx context.Context) error {
+	slog.Info("start previewer...")
+	defer slog.Info("stop previewer: ok")
+
+	var wg sync.WaitGroup
+	for range p.cfg.Media.Preview.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.work(ctx)
+		}()
+	}
+
+	// notify that service is ready-to-use
+	close(p.ready)
+	// wait for context and all workers
+	<-ctx.Done()
+	wg.Wait()
+	return nil
+}
+
+// Ready signals that the service is ready-to-use.
+func (p *Previewer) Ready() <-chan struct{} {
+	return p.ready
+}
+
+// work makes previews for files from the queue until context is done.
+func (p *Previewer) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case fileObj := <-p.queue:
+			p.make(ctx, fileObj)
+		}
+	}
+}
+
+// make makes a preview for one file.
+func (p *Previewer) make(ctx context.Context, fileObj *entity.File) {
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.Media.Preview.Timeout)
+	defer cancel()
+
+	err := p.generator.Make(ctx, fileObj.Path, fileObj.MimeType)
+	if errors.Is(err, preview.ErrUnsupported) {
+		slog.Debug("make preview: skip", "path", fileObj.Path, "error", err)
+		return
+	}
+	if err != nil {
+		slog.Warn("make preview", "path", fileObj.Path, "error", err)
+		return
+	}
+	// the original file could be removed while the preview was making
+	if _, err := os.Stat(fileObj.Path); errors.Is(err, fs.ErrNotExist) {
+		os.Remove(fileObj.PreviewPath())
+		return
+	}
+	slog.Debug("make preview: ok", "path", fileObj.Path)
+}
@@ -0,0 +1,238 @@
+// Package scanner provides a background service to scan uploaded files by antivirus (clamd).
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/file"
+	filerepo "skadi/backend/internal/app/file/repository"
+	"skadi/backend/internal/pkg/clamd"
+)
+
+const (
+	_updateAttempts = 5                      // max attempts to update scan status of the file in DB
+	_updateDelay    = 500 * time.Millisecond // delay before the first retry (it is doubled for every next one)
+)
+
+// Scanner represents a background service with workers scanning files by clamd.
+// If scanning is disabled in config, the service does nothing.
+type Scanner struct {
+	// will be closed if the service was completely started and is ready-to-use now
+	ready      chan struct{}
+	cfg        *config.Config
+	client     *clamd.Client
+	fileRepoDB file.RepositoryDB
+	queue      chan *entity.File
+}
+
+// New returns a new instance of [Scanner].
+func New(cfg *config.Config, dbStorage *gorm.DB) (*Scanner, error) {
+	scanCfg := cfg.Media.Scan
+	return &Scanner{
+		ready:      make(chan struct{}),
+		cfg:        cfg,
+		client:     clamd.NewClient(scanCfg.Network, scanCfg.Address, scanCfg.Timeout),
+		fileRepoDB: filerepo.NewRepoDB(dbStorage),
+		queue:      make(chan *entity.File, scanCfg.QueueSize),
+	}, nil
+}
+
+// Enqueue marks files as pending and adds them to the scan queue.
+// This method is non-blocking and must be called before files are inserted to DB.
+// If the queue is full, the file stays pending until it is enqueued again by the service.
+func (s *Scanner) Enqueue(files ...*entity.File) {
+	if !s.cfg.Media.Scan.Enabled {
+		return
+	}
+	for _, fileObj := range files {
+		fileObj.ScanStatus = entity.ScanPending
+		select {
+		case s.queue <- fileObj:
+		default:
+			slog.Warn("scan queue is full: skip file", "path", fileObj.Path)
+		}
+	}
+}
+
+// StartWithShutdown starts scan workers and waits for
+// context is done for gracefully shutdown them.
+// Files left pending or failed (after the previous run too) are enqueued again periodically.
+// This method is blocking.
+func (s *Scanner) StartWithShutdown(ctx context.Context) error {
+	if !s.cfg.Media.Scan.Enabled {
+		slog.Info("scanner is disabled")
+		close(s.ready)
+		<-ctx.Done()
+		return nil
+	}
+
+	slog.Info("start scanner...")
+	defer slog.Info("stop scanner: ok")
+
+	if err := s.client.Ping(ctx); err != nil {
+		// files will be marked as failed until clamd is available
+		slog.Warn("clamd is unavailable", "error", err)
+	}
+	s.enqueueUnfinished()
+
+	var wg sync.WaitGroup
+	for range s.cfg.Media.Scan.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.work(ctx)
+		}()
+	}
+
+	// notify that service is ready-to-use
+	close(s.ready)
+
+	ticker := time.NewTicker(s.cfg.Media.Scan.RetryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// wait for all workers
+			wg.Wait()
+			return nil
+		case <-ticker.C:
+			s.enqueueUnfinished()
+		}
+	}
+}
+
+// Ready signals that the service is ready-to-use.
+func (s *Scanner) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// enqueueUnfinished adds to the queue files which were not scanned
+// (uploaded while scanning was disabled, the queue was full or the service was stopped)
+// or scanning was failed.
+// Nothing is added until the queue is drained, so queued files are not duplicated.
+func (s *Scanner) enqueueUnfinished() {
+	if len(s.queue) > 0 {
+		return
+	}
+	files, err := s.fileRepoDB.GetManyByScanStatus(s.cfg.Media.Scan.QueueSize,
+		entity.ScanNotScanned, entity.ScanPending, entity.ScanFailed)
+	if err != nil {
+		slog.Warn("get unfinished files to scan", "error", err)
+		return
+	}
+	for _, fileObj := range files {
+		select {
+		case s.queue <- fileObj:
+		default:
+			return
+		}
+	}
+}
+
+// work scans files from the queue until context is done.
+func (s *Scanner) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case fileObj := <-s.queue:
+			s.scan(ctx, fileObj)
+		}
+	}
+}
+
+// scan scans one file, moves it to quarantine if it is infected
+// and saves scan status to DB.
+func (s *Scanner) scan(ctx context.Context, fileObj *entity.File) {
+	oldStatus, oldPath := fileObj.ScanStatus, fileObj.Path
+	status, err := s.check(ctx, oldPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		// file was removed (e.g. request with the file has failed)
+		slog.Debug("scan file: skip", "path", oldPath, "error", err)
+		return
+	}
+	if err != nil {
+		slog.Warn("scan file", "path", oldPath, "error", err)
+	}
+
+	newPath := oldPath
+	if status == entity.ScanInfected {
+		newPath = filepath.Join(s.cfg.Media.Scan.QuarantineDir, filepath.Base(oldPath))
+		if err := os.Rename(oldPath, newPath); err != nil {
+			slog.Error("move infected file to quarantine", "path", oldPath, "error", err)
+			newPath = oldPath
+		}
+		// preview of the infected file must not be available
+		if err := os.Remove(fileObj.PreviewPath()); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			slog.Warn("remove infected file preview", "path", oldPath, "error", err)
+		}
+	}
+	// status of the enqueued again file could be the same
+	if status == oldStatus && newPath == oldPath {
+		return
+	}
+
+	err = s.updateStatus(ctx, oldPath, status, newPath)
+	if errors.Is(err, file.ErrNotFound) && newPath != oldPath {
+		// file object was not inserted to DB, so quarantined file is not needed
+		os.Remove(newPath)
+	}
+	if err != nil {
+		slog.Warn("update file scan status", "path", oldPath, "status", status, "error", err)
+		return
+	}
+	slog.Info("scan file: ok", "path", newPath, "status", status)
+}
+
+// check scans the file by clamd and returns its scan status.
+// If scanning is failed, it returns [entity.ScanFailed] status with the error.
+func (s *Scanner) check(ctx context.Context, path string) (entity.ScanStatus, error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return entity.ScanFailed, err
+	}
+	defer src.Close()
+
+	res, err := s.client.Scan(ctx, src)
+	if err != nil {
+		return entity.ScanFailed, fmt.Errorf("clamd: %w", err)
+	}
+	if res.Infected {
+		slog.Warn("virus found", "path", path, "signature", res.Signature)
+		return entity.ScanInfected, nil
+	}
+	return entity.ScanClean, nil
+}
+
+// updateStatus saves scan status and new path of the file to DB.
+// The file is enqueued before it is inserted to DB,
+// so update is retried with delay while the file is not found.
+func (s *Scanner) updateStatus(ctx context.Context, path string,
+	status entity.ScanStatus, newPath string) error {
+
+	delay := _updateDelay
+	for attempt := 1; ; attempt++ {
+		err := s.fileRepoDB.UpdateScanStatus(path, status, newPath)
+		if !errors.Is(err, file.ErrNotFound) || attempt == _updateAttempts {
+			return err // err OR nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+			delay *= 2
+		}
+	}
+}
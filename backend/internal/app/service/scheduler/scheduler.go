@@ -0,0 +1,273 @@
+// Package scheduler provides a background service to run periodic jobs on cron schedules.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+
+	"skadi/backend/config"
+	commentrepo "skadi/backend/internal/app/comment/repository"
+	commentuc "skadi/backend/internal/app/comment/usecase"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/job"
+	jobrepo "skadi/backend/internal/app/job/repository"
+	mailrepo "skadi/backend/internal/app/mail/repository"
+	mailuc "skadi/backend/internal/app/mail/usecase"
+	taskrepo "skadi/backend/internal/app/task/repository"
+	taskuc "skadi/backend/internal/app/task/usecase"
+	uploadrepo "skadi/backend/internal/app/upload/repository"
+	uploaduc "skadi/backend/internal/app/upload/usecase"
+	"skadi/backend/internal/pkg/cache"
+	"skadi/backend/internal/pkg/cron"
+	"skadi/backend/internal/pkg/retry"
+)
+
+const _lockKey = "scheduler:%s:%d" // key of the job run lock (job name and scheduled unix time)
+
+// jobFunc runs the job at the given time.
+type jobFunc func(now time.Time) error
+
+// scheduledJob represents a job with its schedule.
+type scheduledJob struct {
+	name  string
+	sched *cron.Schedule
+	run   jobFunc
+	// next run time (zero if the schedule never matches)
+	next time.Time
+	// true if the job is running on this instance
+	running atomic.Bool
+}
+
+// Scheduler represents a background service running jobs on cron schedules (UTC).
+// Every scheduled run is locked in the cache storage, so it is run by one backend instance only.
+// All runs are saved to the job run history.
+// If the scheduler is disabled in config, the service does nothing.
+type Scheduler struct {
+	// will be closed if the service was completely started and is ready-to-use now
+	ready     chan struct{}
+	cfg       *config.Config
+	locker    cache.Locker
+	jobRepoDB job.RepositoryDB
+	// name of the backend instance for the run history
+	instance string
+	jobs     []*scheduledJob
+}
+
+// New returns a new instance of [Scheduler].
+// It returns error if config has unknown job or invalid cron expression.
+func New(cfg *config.Config, dbStorage *gorm.DB, locker cache.Locker) (*Scheduler, error) {
+	instance, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("get hostname: %w", err)
+	}
+	jobRepoDB := jobrepo.NewRepoDB(dbStorage)
+	mailUCMailer := mailuc.NewUCMailer(cfg, mailrepo.NewRepoDB(dbStorage))
+	uploadUCCleaner := uploaduc.NewUCClient(cfg, uploadrepo.NewRepoDB(dbStorage))
+	taskRepoDB := taskrepo.NewRepoDB(dbStorage)
+	taskUCRenderer := taskuc.NewUCRenderer(cfg, taskRepoDB)
+	commentUCRenderer := commentuc.NewUCRenderer(cfg, commentrepo.NewRepoDB(dbStorage))
+
+	// all known jobs
+	jobFuncs := map[string]jobFunc{
+		entity.JobMailDigest:    mailUCMailer.EnqueueDigests,
+		entity.JobParentSummary: mailUCMailer.EnqueueParentSummaries,
+		entity.JobUploadCleanup: func(time.Time) error {
+			_, err := uploadUCCleaner.CleanupExpired()
+			return err
+		},
+		entity.JobHistoryCleanup: func(now time.Time) error {
+			_, err := jobRepoDB.DeleteRuns(now.Add(-cfg.Scheduler.HistoryRetention))
+			return err
+		},
+		// students are notified by the outbox event handler
+		entity.JobTaskPublish: func(now time.Time) error {
+			_, err := taskRepoDB.PublishDue(now)
+			return err
+		},
+		// students are reminded by the outbox event handler
+		entity.JobExamReminder: func(now time.Time) error {
+			_, err := taskRepoDB.RemindExamsDue(now, now.Add(cfg.Scheduler.ExamRemindBefore))
+			return err
+		},
+		// students are reminded by the outbox event handler
+		entity.JobDeadlineReminder: func(now time.Time) error {
+			_, err := taskRepoDB.RemindDeadlinesDue(now,
+				now.Add(cfg.Scheduler.DeadlineRemindBefore))
+			return err
+		},
+		// new tasks and comments are rendered on save, so the job renders old ones only
+		entity.JobMarkdownRender: func(time.Time) error {
+			if _, err := taskUCRenderer.RenderPending(); err != nil {
+				return fmt.Errorf("tasks: %w", err)
+			}
+			_, err := commentUCRenderer.RenderPending()
+			return err
+		},
+	}
+
+	s := &Scheduler{
+		ready:     make(chan struct{}),
+		cfg:       cfg,
+		locker:    locker,
+		jobRepoDB: jobRepoDB,
+		instance:  instance,
+	}
+	for name, spec := range cfg.Scheduler.Jobs {
+		// skip disabled jobs
+		if spec == "" {
+			continue
+		}
+		run, ok := jobFuncs[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown job %q", name)
+		}
+		sched, err := cron.Parse(spec)
+		if err != nil {
+			return nil, fmt.Errorf("job %q: %w", name, err)
+		}
+		s.jobs = append(s.jobs, &scheduledJob{name: name, sched: sched, run: run})
+	}
+	return s, nil
+}
+
+// StartWithShutdown starts running jobs on schedule
+// and waits for context is done for gracefully shutdown.
+// Running jobs are awaited before return.
+// This method is blocking.
+func (s *Scheduler) StartWithShutdown(ctx context.Context) error {
+	if !s.cfg.Scheduler.Enabled {
+		slog.Info("scheduler is disabled")
+		close(s.ready)
+		<-ctx.Done()
+		return nil
+	}
+
+	slog.Info("start scheduler...", "jobs", len(s.jobs))
+	defer slog.Info("stop scheduler: ok")
+
+	now := time.Now().UTC()
+	for _, jobObj := range s.jobs {
+		jobObj.next = jobObj.sched.Next(now)
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	// notify that service is ready-to-use
+	close(s.ready)
+	for {
+		next := s.nearest()
+		if next.IsZero() {
+			// no jobs to run
+			<-ctx.Done()
+			return nil
+		}
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		now := time.Now().UTC()
+		for _, jobObj := range s.jobs {
+			if jobObj.next.IsZero() || jobObj.next.After(now) {
+				continue
+			}
+			scheduledAt := jobObj.next
+			jobObj.next = jobObj.sched.Next(now)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.run(jobObj, scheduledAt)
+			}()
+		}
+	}
+}
+
+// Ready signals that the service is ready-to-use.
+func (s *Scheduler) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// nearest returns the nearest next run time of all jobs (zero if there are no runs).
+func (s *Scheduler) nearest() time.Time {
+	var next time.Time
+	for _, jobObj := range s.jobs {
+		if jobObj.next.IsZero() {
+			continue
+		}
+		if next.IsZero() || jobObj.next.Before(next) {
+			next = jobObj.next
+		}
+	}
+	return next
+}
+
+// run runs the job scheduled at the given time and saves the run to the history.
+// The run is skipped if it is locked by another instance
+// or the previous run of the job is still in progress.
+func (s *Scheduler) run(jobObj *scheduledJob, scheduledAt time.Time) {
+	if !jobObj.running.CompareAndSwap(false, true) {
+		slog.Warn("job is still running: skip", "job", jobObj.name, "scheduled_at", scheduledAt)
+		return
+	}
+	defer jobObj.running.Store(false)
+
+	locked, err := s.locker.Lock(fmt.Sprintf(_lockKey, jobObj.name, scheduledAt.Unix()),
+		s.cfg.Scheduler.LockTTL)
+	if err != nil {
+		slog.Warn("lock job run", "job", jobObj.name, "error", err)
+		return
+	}
+	if !locked {
+		slog.Debug("job is run by another instance: skip", "job", jobObj.name)
+		return
+	}
+
+	runObj := &entity.JobRun{
+		Job:         jobObj.name,
+		Instance:    s.instance,
+		ScheduledAt: scheduledAt,
+		StartedAt:   time.Now(),
+	}
+	if err := s.jobRepoDB.CreateRun(runObj); err != nil {
+		slog.Warn("create job run", "job", jobObj.name, "error", err)
+	}
+
+	slog.Info("run job", "job", jobObj.name)
+	var runErr *string
+	if err := safeRun(jobObj.run, runObj.StartedAt); err != nil {
+		errMsg := retry.ErrorText(err)
+		runErr = &errMsg
+		slog.Error("run job", "job", jobObj.name, "error", errMsg)
+	} else {
+		slog.Info("run job: ok", "job", jobObj.name,
+			"duration", time.Since(runObj.StartedAt).String())
+	}
+
+	// run was not saved to the history
+	if runObj.ID == 0 {
+		return
+	}
+	if err := s.jobRepoDB.FinishRun(runObj.ID, time.Now(), runErr); err != nil {
+		slog.Warn("finish job run", "job", jobObj.name, "error", err)
+	}
+}
+
+// safeRun runs the job and recovers its panic.
+func safeRun(run jobFunc, now time.Time) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("job panic: %v", rec)
+		}
+	}()
+	return run(now)
+}
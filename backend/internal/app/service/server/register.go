@@ -7,16 +7,44 @@ import (
 	authhttpv1 "skadi/backend/internal/app/auth/controller/http/v1"
 	authrepo "skadi/backend/internal/app/auth/repository"
 	authuc "skadi/backend/internal/app/auth/usecase"
+	autotesthttpv1 "skadi/backend/internal/app/autotest/controller/http/v1"
+	autotestrepo "skadi/backend/internal/app/autotest/repository"
+	autotestuc "skadi/backend/internal/app/autotest/usecase"
 	classhttpv1 "skadi/backend/internal/app/class/controller/http/v1"
 	classrepo "skadi/backend/internal/app/class/repository"
 	classuc "skadi/backend/internal/app/class/usecase"
 	commenthttpv1 "skadi/backend/internal/app/comment/controller/http/v1"
 	commentrepo "skadi/backend/internal/app/comment/repository"
 	commentuc "skadi/backend/internal/app/comment/usecase"
+	coursehttpv1 "skadi/backend/internal/app/course/controller/http/v1"
+	courserepo "skadi/backend/internal/app/course/repository"
+	courseuc "skadi/backend/internal/app/course/usecase"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/event"
+	eventhttpv1 "skadi/backend/internal/app/event/controller/http/v1"
+	examhttpv1 "skadi/backend/internal/app/exam/controller/http/v1"
+	examrepo "skadi/backend/internal/app/exam/repository"
+	examuc "skadi/backend/internal/app/exam/usecase"
 	examplehttpv1 "skadi/backend/internal/app/example/controller/http/v1"
 	filehttpv1 "skadi/backend/internal/app/file/controller/http/v1"
 	filerepo "skadi/backend/internal/app/file/repository"
 	fileuc "skadi/backend/internal/app/file/usecase"
+	jobhttpv1 "skadi/backend/internal/app/job/controller/http/v1"
+	jobrepo "skadi/backend/internal/app/job/repository"
+	jobuc "skadi/backend/internal/app/job/usecase"
+	mailhttpv1 "skadi/backend/internal/app/mail/controller/http/v1"
+	mailrepo "skadi/backend/internal/app/mail/repository"
+	mailuc "skadi/backend/internal/app/mail/usecase"
+	notifhttpv1 "skadi/backend/internal/app/notification/controller/http/v1"
+	notifrepo "skadi/backend/internal/app/notification/repository"
+	notifuc "skadi/backend/internal/app/notification/usecase"
+	"skadi/backend/internal/app/outbox"
+	plagiarismhttpv1 "skadi/backend/internal/app/plagiarism/controller/http/v1"
+	plagiarismrepo "skadi/backend/internal/app/plagiarism/repository"
+	plagiarismuc "skadi/backend/internal/app/plagiarism/usecase"
+	quizhttpv1 "skadi/backend/internal/app/quiz/controller/http/v1"
+	quizrepo "skadi/backend/internal/app/quiz/repository"
+	quizuc "skadi/backend/internal/app/quiz/usecase"
 	"skadi/backend/internal/app/service/server/middleware"
 	solhttpv1 "skadi/backend/internal/app/solution/controller/http/v1"
 	solrepo "skadi/backend/internal/app/solution/repository"
@@ -25,16 +53,37 @@ import (
 	taskhttpv1 "skadi/backend/internal/app/task/controller/http/v1"
 	taskrepo "skadi/backend/internal/app/task/repository"
 	taskuc "skadi/backend/internal/app/task/usecase"
+	teamhttpv1 "skadi/backend/internal/app/team/controller/http/v1"
+	teamrepo "skadi/backend/internal/app/team/repository"
+	teamuc "skadi/backend/internal/app/team/usecase"
+	"skadi/backend/internal/app/telegram"
+	tghttpv1 "skadi/backend/internal/app/telegram/controller/http/v1"
+	tgrepo "skadi/backend/internal/app/telegram/repository"
+	tguc "skadi/backend/internal/app/telegram/usecase"
+	uploadhttpv1 "skadi/backend/internal/app/upload/controller/http/v1"
+	uploadrepo "skadi/backend/internal/app/upload/repository"
+	uploaduc "skadi/backend/internal/app/upload/usecase"
 	userhttpv1 "skadi/backend/internal/app/user/controller/http/v1"
 	userrepo "skadi/backend/internal/app/user/repository"
 	useruc "skadi/backend/internal/app/user/usecase"
+	hookhttpv1 "skadi/backend/internal/app/webhook/controller/http/v1"
+	hookrepo "skadi/backend/internal/app/webhook/repository"
+	hookuc "skadi/backend/internal/app/webhook/usecase"
 	"skadi/backend/internal/pkg/cache"
+	utilsfile "skadi/backend/internal/pkg/utils/file"
 	"skadi/backend/internal/pkg/validator"
 )
 
+// EventBus describes a publisher and subscriber of real-time events.
+type EventBus interface {
+	event.Publisher
+	event.Subscriber
+}
+
 // registerEndpointsV1 register all endpoints for 1st version of API.
 func (s *Server) registerEndpointsV1(cfg *config.Config, dbStorage *gorm.DB,
-	cacheStorage cache.Storage, valid validator.Validator) {
+	cacheStorage cache.Storage, fileQueue utilsfile.Queue, eventBus EventBus,
+	tgQueue telegram.Queue, outboxBus outbox.Bus, valid validator.Validator) {
 
 	// create repos
 	authRepoCache := authrepo.NewRepoCache(cfg, cacheStorage)
@@ -45,17 +94,82 @@ func (s *Server) registerEndpointsV1(cfg *config.Config, dbStorage *gorm.DB,
 	statusRepoDB := statusrepo.NewRepoDB(dbStorage)
 	fileRepoDB := filerepo.NewRepoDB(dbStorage)
 	commentRepoDB := commentrepo.NewRepoDB(dbStorage)
+	uploadRepoDB := uploadrepo.NewRepoDB(dbStorage)
+	notifRepoDB := notifrepo.NewRepoDB(dbStorage)
+	mailRepoDB := mailrepo.NewRepoDB(dbStorage)
+	tgRepoDB := tgrepo.NewRepoDB(dbStorage)
+	tgRepoCache := tgrepo.NewRepoCache(cfg, cacheStorage)
+	hookRepoDB := hookrepo.NewRepoDB(dbStorage)
+	jobRepoDB := jobrepo.NewRepoDB(dbStorage)
+	courseRepoDB := courserepo.NewRepoDB(dbStorage)
+	autotestRepoDB := autotestrepo.NewRepoDB(dbStorage)
+	quizRepoDB := quizrepo.NewRepoDB(dbStorage)
+	examRepoDB := examrepo.NewRepoDB(dbStorage)
+	plagiarismRepoDB := plagiarismrepo.NewRepoDB(dbStorage)
+	teamRepoDB := teamrepo.NewRepoDB(dbStorage)
 	// create usecases
+	hookUCEmitter := hookuc.NewUCEmitter(cfg, hookRepoDB)
+	// webhook events are subscribed separately, so every one is retried on its own
+	outboxBus.Subscribe("webhook.task_created", hookUCEmitter.HandleTaskCreated,
+		entity.TopicTaskCreated)
+	outboxBus.Subscribe("webhook.status_changed", hookUCEmitter.HandleStatusChanged,
+		entity.TopicSolutionUpdated)
+	outboxBus.Subscribe("webhook.graded", hookUCEmitter.HandleGraded,
+		entity.TopicSolutionUpdated)
+	mailUCMailer := mailuc.NewUCMailer(cfg, mailRepoDB)
+	notifUCNotifier := notifuc.NewUCNotifier(cfg, notifRepoDB, eventBus,
+		mailUCMailer, tgQueue)
 	authUCClient := authuc.NewUCClient(cfg, userRepoDB, authRepoCache)
 	authUCMiddleware := authuc.NewUCMiddleware(cfg, authRepoCache)
-	userUCAdminClient := useruc.NewUCAdminClient(cfg, userRepoDB, classRepoDB)
+	userUCAdminClient := useruc.NewUCAdminClient(cfg, userRepoDB, classRepoDB,
+		hookUCEmitter)
 	classUCAdminClient := classuc.NewUCAdminClient(cfg, classRepoDB, userRepoDB)
-	taskUCTeacher := taskuc.NewUCTeacher(cfg, taskRepoDB, userRepoDB)
+	taskUCTeacher := taskuc.NewUCTeacher(cfg, taskRepoDB, solRepoDB, userRepoDB,
+		quizRepoDB, autotestRepoDB, eventBus, notifUCNotifier)
+	outboxBus.Subscribe("task.notify_published", taskUCTeacher.HandlePublished,
+		entity.TopicTaskPublished)
+	outboxBus.Subscribe("task.remind_exam", taskUCTeacher.HandleExamClosing,
+		entity.TopicTaskExamClosing)
+	outboxBus.Subscribe("task.remind_deadline", taskUCTeacher.HandleDeadline,
+		entity.TopicTaskDeadline)
+	outboxBus.Subscribe("task.sync_class", taskUCTeacher.HandleMembersChanged,
+		entity.TopicClassMembersChanged)
 	solUCClient := soluc.NewUCClient(cfg, solRepoDB, taskRepoDB)
-	solUCStudent := soluc.NewUCStudent(cfg, solRepoDB, statusRepoDB)
-	solUCTeacher := soluc.NewUCTeacher(cfg, solRepoDB, statusRepoDB)
+	solUCStudent := soluc.NewUCStudent(cfg, solRepoDB, statusRepoDB,
+		eventBus, notifUCNotifier)
+	solUCTeacher := soluc.NewUCTeacher(cfg, solRepoDB, statusRepoDB,
+		eventBus, notifUCNotifier)
 	fileUCClient := fileuc.NewUCClient(cfg, fileRepoDB)
-	commentUCClient := commentuc.NewUCClient(cfg, commentRepoDB, solRepoDB)
+	commentUCClient := commentuc.NewUCClient(cfg, commentRepoDB, solRepoDB,
+		eventBus, notifUCNotifier, hookUCEmitter)
+	uploadUCClient := uploaduc.NewUCClient(cfg, uploadRepoDB)
+	notifUCClient := notifuc.NewUCClient(cfg, notifRepoDB)
+	mailUCClient := mailuc.NewUCClient(cfg, mailRepoDB)
+	tgUCClient := tguc.NewUCClient(cfg, tgRepoDB, tgRepoCache)
+	hookUCAdmin := hookuc.NewUCAdmin(cfg, hookRepoDB)
+	jobUCAdmin := jobuc.NewUCAdmin(cfg, jobRepoDB)
+	courseUCTeacher := courseuc.NewUCTeacher(cfg, courseRepoDB)
+	courseUCStudent := courseuc.NewUCStudent(cfg, courseRepoDB)
+	autotestUCTeacher := autotestuc.NewUCTeacher(cfg, autotestRepoDB, taskRepoDB, solRepoDB)
+	autotestUCClient := autotestuc.NewUCClient(cfg, autotestRepoDB, solRepoDB)
+	autotestUCQueue := autotestuc.NewUCQueue(cfg, autotestRepoDB)
+	outboxBus.Subscribe("autotest.enqueue", autotestUCQueue.HandleSolutionUpdated,
+		entity.TopicSolutionUpdated)
+	quizUCTeacher := quizuc.NewUCTeacher(cfg, quizRepoDB, taskRepoDB, solRepoDB,
+		eventBus, notifUCNotifier)
+	quizUCStudent := quizuc.NewUCStudent(cfg, quizRepoDB, solRepoDB, eventBus, notifUCNotifier)
+	quizUCClient := quizuc.NewUCClient(cfg, quizRepoDB, solRepoDB)
+	examUCTeacher := examuc.NewUCTeacher(cfg, examRepoDB, taskRepoDB, solRepoDB, eventBus)
+	examUCStudent := examuc.NewUCStudent(cfg, examRepoDB, solRepoDB, eventBus)
+	examUCClient := examuc.NewUCClient(cfg, examRepoDB, solRepoDB)
+	plagiarismUCTeacher := plagiarismuc.NewUCTeacher(cfg, plagiarismRepoDB, taskRepoDB,
+		solRepoDB)
+	plagiarismUCAnalyzer := plagiarismuc.NewUCAnalyzer(cfg, plagiarismRepoDB, taskRepoDB,
+		solRepoDB)
+	outboxBus.Subscribe("plagiarism.enqueue", plagiarismUCAnalyzer.HandleSolutionUpdated,
+		entity.TopicSolutionUpdated)
+	teamUCTeacher := teamuc.NewUCTeacher(cfg, teamRepoDB, taskRepoDB, solRepoDB,
+		eventBus, notifUCNotifier)
 	// create controllers
 	authController := authhttpv1.NewController(cfg, authUCClient, valid)
 	exampleController := examplehttpv1.NewController()
@@ -63,12 +177,34 @@ func (s *Server) registerEndpointsV1(cfg *config.Config, dbStorage *gorm.DB,
 	userController := userhttpv1.NewController(userUCAdminClient, valid)
 	classControllerAdmin := classhttpv1.NewControllerAdmin(classUCAdminClient, valid)
 	classController := classhttpv1.NewController(classUCAdminClient, valid)
-	taskControllerTeacher := taskhttpv1.NewControllerTeacher(cfg, taskUCTeacher, valid)
+	taskControllerTeacher := taskhttpv1.NewControllerTeacher(cfg, taskUCTeacher,
+		uploadUCClient, fileQueue, valid)
 	solController := solhttpv1.NewController(solUCClient, valid)
-	solControllerStudent := solhttpv1.NewControllerStudent(cfg, solUCStudent, valid)
+	solControllerStudent := solhttpv1.NewControllerStudent(cfg, solUCStudent,
+		uploadUCClient, fileQueue, valid)
 	solControllerTeacher := solhttpv1.NewControllerTeacher(solUCTeacher, valid)
-	fileController := filehttpv1.NewController(fileUCClient, valid)
-	commentController := commenthttpv1.NewController(commentUCClient, valid)
+	fileController := filehttpv1.NewController(cfg, fileUCClient, valid)
+	commentController := commenthttpv1.NewController(cfg, commentUCClient, fileQueue, valid)
+	uploadController := uploadhttpv1.NewController(uploadUCClient, valid)
+	eventController := eventhttpv1.NewController(cfg, eventBus)
+	notifController := notifhttpv1.NewController(notifUCClient, valid)
+	mailController := mailhttpv1.NewController(mailUCClient, valid)
+	tgController := tghttpv1.NewController(tgUCClient, valid)
+	hookControllerAdmin := hookhttpv1.NewControllerAdmin(hookUCAdmin, valid)
+	jobControllerAdmin := jobhttpv1.NewControllerAdmin(jobUCAdmin, valid)
+	courseControllerTeacher := coursehttpv1.NewControllerTeacher(courseUCTeacher, valid)
+	courseControllerStudent := coursehttpv1.NewControllerStudent(courseUCStudent)
+	autotestController := autotesthttpv1.NewController(autotestUCClient, valid)
+	autotestControllerTeacher := autotesthttpv1.NewControllerTeacher(autotestUCTeacher, valid)
+	quizController := quizhttpv1.NewController(quizUCClient, valid)
+	quizControllerStudent := quizhttpv1.NewControllerStudent(quizUCStudent, valid)
+	quizControllerTeacher := quizhttpv1.NewControllerTeacher(quizUCTeacher, valid)
+	examController := examhttpv1.NewController(examUCClient, valid)
+	examControllerStudent := examhttpv1.NewControllerStudent(examUCStudent, valid)
+	examControllerTeacher := examhttpv1.NewControllerTeacher(examUCTeacher, valid)
+	plagiarismControllerTeacher := plagiarismhttpv1.NewControllerTeacher(plagiarismUCTeacher,
+		valid)
+	teamControllerTeacher := teamhttpv1.NewControllerTeacher(teamUCTeacher, valid)
 
 	// middlewares
 	mwJWTRefresh := middleware.JWTRefresh(cfg, authUCMiddleware)
@@ -89,4 +225,22 @@ func (s *Server) registerEndpointsV1(cfg *config.Config, dbStorage *gorm.DB,
 		mwJWTAccess, middleware.Allow)
 	filehttpv1.RegisterEndpoints(apiV1, fileController, mwJWTAccess, middleware.Allow)
 	commenthttpv1.RegisterEndpoints(apiV1, commentController, mwJWTAccess, middleware.Allow)
+	uploadhttpv1.RegisterEndpoints(apiV1, uploadController, mwJWTAccess, middleware.Allow)
+	eventhttpv1.RegisterEndpoints(apiV1, eventController, mwJWTAccess, middleware.Allow)
+	notifhttpv1.RegisterEndpoints(apiV1, notifController, mwJWTAccess, middleware.Allow)
+	mailhttpv1.RegisterEndpoints(apiV1, mailController, mwJWTAccess, middleware.Allow)
+	tghttpv1.RegisterEndpoints(apiV1, tgController, mwJWTAccess, middleware.Allow)
+	hookhttpv1.RegisterEndpoints(apiV1, hookControllerAdmin, mwJWTAccess, middleware.Allow)
+	jobhttpv1.RegisterEndpoints(apiV1, jobControllerAdmin, mwJWTAccess, middleware.Allow)
+	coursehttpv1.RegisterEndpoints(apiV1, courseControllerTeacher, courseControllerStudent,
+		mwJWTAccess, middleware.Allow)
+	autotesthttpv1.RegisterEndpoints(apiV1, autotestController, autotestControllerTeacher,
+		mwJWTAccess, middleware.Allow)
+	quizhttpv1.RegisterEndpoints(apiV1, quizController, quizControllerStudent,
+		quizControllerTeacher, mwJWTAccess, middleware.Allow)
+	examhttpv1.RegisterEndpoints(apiV1, examController, examControllerStudent,
+		examControllerTeacher, mwJWTAccess, middleware.Allow)
+	plagiarismhttpv1.RegisterEndpoints(apiV1, plagiarismControllerTeacher,
+		mwJWTAccess, middleware.Allow)
+	teamhttpv1.RegisterEndpoints(apiV1, teamControllerTeacher, mwJWTAccess, middleware.Allow)
 }
@@ -11,9 +11,12 @@ import (
 	"gorm.io/gorm"
 
 	"skadi/backend/config"
+	"skadi/backend/internal/app/outbox"
 	"skadi/backend/internal/app/service/server/errhandler"
 	"skadi/backend/internal/app/service/server/middleware"
+	"skadi/backend/internal/app/telegram"
 	"skadi/backend/internal/pkg/cache"
+	utilsfile "skadi/backend/internal/pkg/utils/file"
 	"skadi/backend/internal/pkg/validator"
 )
 
@@ -54,7 +57,8 @@ type Server struct {
 //
 // New returns a new instance of [Server].
 func New(cfg *config.Config, dbStorage *gorm.DB, cacheStorage cache.Storage,
-	valid validator.Validator) (*Server, error) {
+	fileQueue utilsfile.Queue, eventBus EventBus, tgQueue telegram.Queue,
+	outboxBus outbox.Bus, valid validator.Validator) (*Server, error) {
 
 	// fiber init
 	server := &Server{
@@ -83,7 +87,8 @@ func New(cfg *config.Config, dbStorage *gorm.DB, cacheStorage cache.Storage,
 		server.fiberApp.Use(middleware.Swagger())
 	}
 	// register all endpoints
-	server.registerEndpointsV1(cfg, dbStorage, cacheStorage, valid)
+	server.registerEndpointsV1(cfg, dbStorage, cacheStorage, fileQueue, eventBus,
+		tgQueue, outboxBus, valid)
 
 	return server, nil
 }
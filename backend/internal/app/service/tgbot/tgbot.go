@@ -0,0 +1,171 @@
+// Package tgbot provides a background service of the Telegram bot:
+// it handles commands from the chats and sends notifications to the linked chats.
+package tgbot
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/telegram"
+	tgrepo "skadi/backend/internal/app/telegram/repository"
+	tguc "skadi/backend/internal/app/telegram/usecase"
+	"skadi/backend/internal/pkg/cache"
+	pkgtelegram "skadi/backend/internal/pkg/telegram"
+)
+
+const _retryDelay = 5 * time.Second // delay before the next poll after the failed one
+
+// Bot represents a background service of the Telegram bot.
+// Notifications are sent by every backend instance, but updates (commands)
+// are received by long polling only if it is enabled in config,
+// because Bot API rejects parallel polling from several instances.
+// If the bot is disabled in config, the service does nothing.
+type Bot struct {
+	// will be closed if the service was completely started and is ready-to-use now
+	ready   chan struct{}
+	cfg     *config.Config
+	client  *pkgtelegram.Client
+	tgUCBot telegram.UsecaseBot
+	queue   chan []entity.Notification
+}
+
+// New returns a new instance of [Bot].
+func New(cfg *config.Config, dbStorage *gorm.DB, cacheStorage cache.Storage) (*Bot, error) {
+	tgCfg := cfg.Telegram
+	if tgCfg.Enabled && tgCfg.Token == "" {
+		return nil, errors.New("telegram bot is enabled, but token is empty")
+	}
+	return &Bot{
+		ready:  make(chan struct{}),
+		cfg:    cfg,
+		client: pkgtelegram.NewClient(tgCfg.APIURL, tgCfg.Token, tgCfg.Timeout),
+		tgUCBot: tguc.NewUCBot(cfg, tgrepo.NewRepoDB(dbStorage),
+			tgrepo.NewRepoCache(cfg, cacheStorage)),
+		queue: make(chan []entity.Notification, tgCfg.QueueSize),
+	}, nil
+}
+
+// Enqueue adds notifications to the send queue. This method is non-blocking.
+// If the queue is full or the bot is disabled, notifications are skipped.
+func (b *Bot) Enqueue(notifList []entity.Notification) {
+	if !b.cfg.Telegram.Enabled || len(notifList) == 0 {
+		return
+	}
+	select {
+	case b.queue <- notifList:
+	default:
+		slog.Warn("telegram queue is full: skip notifications", "count", len(notifList))
+	}
+}
+
+// StartWithShutdown starts polling updates and sending notifications
+// and waits for context is done for gracefully shutdown.
+// This method is blocking.
+func (b *Bot) StartWithShutdown(ctx context.Context) error {
+	if !b.cfg.Telegram.Enabled {
+		slog.Info("telegram bot is disabled")
+		close(b.ready)
+		<-ctx.Done()
+		return nil
+	}
+
+	slog.Info("start telegram bot...")
+	defer slog.Info("stop telegram bot: ok")
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		b.work(ctx)
+	}()
+
+	// notify that service is ready-to-use
+	close(b.ready)
+	if b.cfg.Telegram.Poll {
+		b.poll(ctx)
+	} else {
+		<-ctx.Done()
+	}
+	wg.Wait()
+	return nil
+}
+
+// Ready signals that the service is ready-to-use.
+func (b *Bot) Ready() <-chan struct{} {
+	return b.ready
+}
+
+// poll receives updates and replies to commands until context is done.
+func (b *Bot) poll(ctx context.Context) {
+	var offset int64
+	for {
+		updates, err := b.client.GetUpdates(ctx, offset, b.cfg.Telegram.PollTimeout)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			slog.Warn("get telegram updates", "error", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(_retryDelay):
+			}
+			continue
+		}
+
+		for _, update := range updates {
+			offset = update.UpdateID + 1
+			msg := update.Message
+			// the bot works in private chats only
+			if msg == nil || !msg.Chat.Private() || msg.Text == "" {
+				continue
+			}
+			b.send(ctx, msg.Chat.ID, b.tgUCBot.HandleMessage(msg.Chat.ID, msg.Text))
+		}
+	}
+}
+
+// work sends notifications from the queue until context is done.
+func (b *Bot) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notifList := <-b.queue:
+			messages, err := b.tgUCBot.Messages(notifList)
+			if err != nil {
+				slog.Warn("compose telegram messages", "error", err)
+				continue
+			}
+			for _, msg := range messages {
+				b.send(ctx, msg.ChatID, msg.Text)
+			}
+		}
+	}
+}
+
+// send sends the message to the chat.
+// If the bot was blocked by the user, the chat is unlinked.
+func (b *Bot) send(ctx context.Context, chatID int64, text string) {
+	err := b.client.SendMessage(ctx, chatID, text)
+	var apiErr *pkgtelegram.APIError
+	if errors.As(err, &apiErr) && apiErr.Forbidden() {
+		slog.Info("telegram chat is not available: unlink", "chat_id", chatID, "error", err)
+		if err := b.tgUCBot.ForgetChat(chatID); err != nil {
+			slog.Warn("unlink telegram chat", "chat_id", chatID, "error", err)
+		}
+		return
+	}
+	if err != nil {
+		slog.Warn("send telegram message", "chat_id", chatID, "error", err)
+		return
+	}
+	slog.Debug("send telegram message: ok", "chat_id", chatID)
+}
@@ -31,6 +31,9 @@ func NewController(solUCClient solution.UsecaseClient,
 
 // @summary		Получение решения задания по id. [Преподаватель и ученик]
 // @description	Получение всех данных о решении задания (с файлами решения) с полной инфой о задании (с файлами задания) и преподе (ID и полное имя), а также со списком учеников, которые тоже выполняют это задание.
+// @description	Решение отмечается прочитанным текущим пользователем.
+// @description	Если решение тестировалось, возвращается итог последнего запуска автотестов (без результатов по каждому тесту).
+// @description	Ученику решение задания в режиме экзамена доступно только после начала попытки.
 // @router			/solution/{id} [get]
 // @id				solution-read
 // @tags			solution
@@ -42,6 +45,7 @@ func NewController(solUCClient solution.UsecaseClient,
 // @failure		401	"неверный токен (пустой, истекший или неверный формат)"
 // @failure		403	"доступ запрещён"
 // @failure		404	"решение задания не найдено"
+// @failure		409	"попытка экзамена не начата"
 func (c *SolController) Read(ctx *fiber.Ctx) error {
 	// parse user claims
 	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
@@ -66,6 +70,13 @@ func (c *SolController) Read(ctx *fiber.Ctx) error {
 			Message:    "доступ запрещён",
 		}
 	}
+	if errors.Is(err, solution.ErrExamNotBegun) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "попытка экзамена не начата",
+		}
+	}
 	if err != nil {
 		return fmt.Errorf("read: %w", err)
 	}
@@ -76,3 +87,45 @@ func (c *SolController) Read(ctx *fiber.Ctx) error {
 	}
 	return ctx.Status(fiber.StatusOK).JSON(res)
 }
+
+// @summary		Отметка решения задания прочитанным. [Преподаватель и ученик]
+// @description	Отметка решения задания и всех комментариев под ним прочитанными текущим пользователем (без получения данных решения).
+// @router			/solution/{id}/read [post]
+// @id				solution-mark-read
+// @tags			solution
+// @accept			json
+// @security		JWTAccess
+// @param			id	path	int	true	"ID решения задания"
+// @success		204	"решение отмечено прочитанным"
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+// @failure		404	"решение задания не найдено"
+func (c *SolController) MarkRead(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &solutionIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	err := c.solUCClient.MarkRead(inputPath.ID, userClaims)
+	if errors.Is(err, solution.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "решение задания не найдено",
+		}
+	}
+	if errors.Is(err, solution.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("mark read: %w", err)
+	}
+	return ctx.Status(fiber.StatusNoContent).JSON(nil)
+}
@@ -8,6 +8,8 @@ import (
 
 	"skadi/backend/config"
 	"skadi/backend/internal/app/solution"
+	"skadi/backend/internal/app/upload"
+	uploadhttpv1 "skadi/backend/internal/app/upload/controller/http/v1"
 	"skadi/backend/internal/pkg/httperror"
 	"skadi/backend/internal/pkg/serialize"
 	utilsfile "skadi/backend/internal/pkg/utils/file"
@@ -19,22 +21,28 @@ import (
 type SolControllerStudent struct {
 	valid           validator.Validator
 	solUCStudent    solution.UsecaseStudent
+	uploadUCClient  upload.UsecaseClient
 	solutionFileDir string
+	fileQueue       utilsfile.Queue
 }
 
 // NewControllerStudent returns a new instance of [SolControllerStudent].
 func NewControllerStudent(cfg *config.Config, solUCStudent solution.UsecaseStudent,
+	uploadUCClient upload.UsecaseClient, fileQueue utilsfile.Queue,
 	valid validator.Validator) *SolControllerStudent {
 
 	return &SolControllerStudent{
 		valid:           valid,
 		solUCStudent:    solUCStudent,
+		uploadUCClient:  uploadUCClient,
 		solutionFileDir: cfg.Media.SolutionFileDir,
+		fileQueue:       fileQueue,
 	}
 }
 
 // @summary		Обновление решения. [Только ученик]
 // @description	Частичное обновление решения (только переданные поля: статус, кроме "проверено", ответ, файл ответа) по его id.
+// @description	Решение задания в режиме экзамена можно изменить только во время начатой попытки.
 // @router			/solution/for-student/{id} [patch]
 // @id				solution-for-student-update
 // @tags			solution
@@ -45,12 +53,14 @@ func NewControllerStudent(cfg *config.Config, solUCStudent solution.UsecaseStude
 // @param			status_id		formData	int		false	"new status ID"
 // @param			answer			formData	string	false	"new answer"
 // @param			delete_files	formData	[]int	false	"IDs of files to delete from the solution"
-// @param			file			formData	[]file	false	"new solution files"
+// @param			file			formData	[]file		false	"new solution files"
+// @param			uploads			formData	[]string	false	"IDs of completed chunked uploads to attach as new solution files"
 // @success		200				{object}	entity.Solution
-// @failure		400				"неверный статус | отправка на проверку возможна только при наличии ответа"
+// @failure		400				"неверный статус | отправка на проверку возможна только при наличии ответа | загрузка не найдена | загрузка не завершена"
 // @failure		401				"неверный токен (пустой, истекший или неверный формат)"
 // @failure		403				"доступ запрещён"
 // @failure		404				"решение не найдено"
+// @failure		409				"статус теста меняется только ответами на вопросы | попытка не начата или время вышло"
 func (c *SolControllerStudent) Update(ctx *fiber.Ctx) error {
 	// parse user claims
 	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
@@ -63,13 +73,22 @@ func (c *SolControllerStudent) Update(ctx *fiber.Ctx) error {
 	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
 		return err
 	}
-	uploadedFiles, err := utilsfile.ParseAndSaveFiles(ctx, c.solutionFileDir)
+	uploadedFiles, err := utilsfile.ParseAndSaveFiles(ctx, c.solutionFileDir, c.fileQueue)
 	if err != nil {
 		return err
 	}
-	newData := inputBody.ToEntitySolutionUpdate(uploadedFiles)
+	// take completed chunked uploads
+	uploads, err := uploadhttpv1.TakeUploads(c.uploadUCClient, userClaims.ID,
+		inputBody.Uploads, c.solutionFileDir)
+	if err != nil {
+		uploadedFiles.Cleanup()
+		return err
+	}
+	linkedFiles := append(uploadedFiles, uploads.Files...)
+	newData := inputBody.ToEntitySolutionUpdate(linkedFiles)
 
 	solObj, err := c.solUCStudent.Update(userClaims.ID, inputPath.ID, newData)
+	uploads.Finish(err, c.fileQueue)
 	if err != nil {
 		uploadedFiles.Cleanup()
 	}
@@ -101,6 +120,20 @@ func (c *SolControllerStudent) Update(ctx *fiber.Ctx) error {
 			Message:    "решение не найдено",
 		}
 	}
+	if errors.Is(err, solution.ErrQuiz) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "статус теста меняется только ответами на вопросы",
+		}
+	}
+	if errors.Is(err, solution.ErrExamClosed) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "попытка не начата или время вышло",
+		}
+	}
 	if err != nil {
 		return err
 	}
@@ -109,6 +142,7 @@ func (c *SolControllerStudent) Update(ctx *fiber.Ctx) error {
 
 // @summary		Получение списка решений. [Только ученик]
 // @description	Получение списка решений конкретного ученика.
+// @description	Для каждого решения возвращается число непрочитанных комментариев и признак обновлений с последнего просмотра.
 // @router			/solution/for-student [get]
 // @id				solution-for-student-list
 // @tags			solution
@@ -131,7 +165,7 @@ func (c *SolControllerStudent) List(ctx *fiber.Ctx) error {
 
 	// get solutions
 	solListResp, err := c.solUCStudent.GetManyForStudent(userClaims.ID,
-		inputQuery.Search, inputQuery.StatusIDs, pageParams)
+		inputQuery.Search, inputQuery.StatusIDs, inputQuery.Unread, pageParams)
 	if err != nil {
 		return fmt.Errorf("list: %w", err)
 	}
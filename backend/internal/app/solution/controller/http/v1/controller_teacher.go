@@ -131,6 +131,7 @@ func (c *SolControllerTeacher) Delete(ctx *fiber.Ctx) error {
 
 // @summary		Получение списка решений. [Только преподаватель]
 // @description	Получение списка решений для заданий конкретного преподавателя.
+// @description	Для каждого решения возвращается число непрочитанных комментариев и признак обновлений с последнего просмотра.
 // @router			/solution/for-teacher [get]
 // @id				solution-for-teacher-list
 // @tags			solution
@@ -153,7 +154,7 @@ func (c *SolControllerTeacher) List(ctx *fiber.Ctx) error {
 
 	// get solutions
 	solListResp, err := c.solUCTeacher.GetManyForTeacher(userClaims.ID, inputQuery.Search,
-		inputQuery.StatusIDs, pageParams)
+		inputQuery.StatusIDs, inputQuery.Unread, pageParams)
 	if err != nil {
 		return fmt.Errorf("list: %w", err)
 	}
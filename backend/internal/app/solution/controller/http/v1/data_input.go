@@ -21,6 +21,8 @@ type updateSolutionBody struct {
 	Answer *string `form:"answer" json:"answer,omitempty" validate:"omitempty" example:"ООП - это объектно-ориентированное программирование"`
 	// IDs of files to delete from the task (student only)
 	DelFiles []int `form:"delete_files" json:"delete_files,omitempty" validate:"omitempty"`
+	// IDs of completed chunked uploads to attach as new solution files (student only)
+	Uploads []string `form:"uploads" json:"uploads,omitempty" validate:"omitempty,dive,uuid"`
 }
 
 func (u *updateSolutionBody) ToEntitySolutionUpdate(
@@ -47,6 +49,8 @@ type listSolutionQuery struct {
 	// case-insensitive substring to filter data by task title only for students
 	// or by task title or student fullname for teacher
 	Search string `query:"search,omitempty" json:"search" example:"HTML"`
+	// if true, only solutions updated or commented since the last view are returned
+	Unread bool `query:"unread,omitempty" json:"unread" example:"true"`
 	// pagination params
 	entity.PaginationQuery
 }
@@ -24,5 +24,6 @@ func RegisterEndpoints(router fiber.Router, controller *SolController,
 	authGroup.Patch("/for-teacher/:id", mwTeacherOnly, controllerTeacher.Update)
 	authGroup.Patch("/for-student/:id", mwStudentOnly, controllerStudent.Update)
 	authGroup.Get("/:id", mwTeacherStudent, controller.Read)
+	authGroup.Post("/:id/read", mwTeacherStudent, controller.MarkRead)
 	authGroup.Delete("/:id", mwTeacherOnly, controllerTeacher.Delete)
 }
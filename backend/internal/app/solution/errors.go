@@ -3,8 +3,11 @@ package solution
 import "errors"
 
 var (
-	ErrInvalidData     = errors.New("invalid data")     // code 400
-	ErrUnsupportedData = errors.New("unsupported data") // code 400
-	ErrForbidden       = errors.New("forbidden")        // code 403
-	ErrNotFound        = errors.New("record not found") // code 404
+	ErrInvalidData     = errors.New("invalid data")               // code 400
+	ErrUnsupportedData = errors.New("unsupported data")           // code 400
+	ErrForbidden       = errors.New("forbidden")                  // code 403
+	ErrNotFound        = errors.New("record not found")           // code 404
+	ErrQuiz            = errors.New("quiz solution")              // code 409
+	ErrExamClosed      = errors.New("exam attempt is not active") // code 409
+	ErrExamNotBegun    = errors.New("exam attempt is not begun")  // code 409
 )
@@ -1,10 +1,14 @@
 package solution
 
-import "skadi/backend/internal/app/entity"
+import (
+	"time"
+
+	"skadi/backend/internal/app/entity"
+)
 
 // RepositoryDB describes all DB methods for task and solution objects.
 type RepositoryDB interface {
-	// GetByID returns solution info (with task only) by the given ID.
+	// GetByID returns solution info (with task, exam attempt and members only) by the given ID.
 	GetByID(id int) (*entity.Solution, error)
 	// GetByIDFull returns a full solution info by the given ID.
 	GetByIDFull(id int) (*entity.Solution, error)
@@ -13,18 +17,27 @@ type RepositoryDB interface {
 	// Delete deletes solution and solution files.
 	Delete(solObj *entity.Solution) error
 
-	// GetManyForTeacher returns all solutions for the teacher tasks.
+	// GetManyForTeacher returns all solutions for the teacher tasks with unread activity.
 	// Search param appends condition to filter solutions
 	// by task title or student fullname (substring).
 	// StatusIDs param appends condition to filter solutions by statuses.
+	// UnreadOnly param appends condition to filter solutions with unread activity.
 	GetManyForTeacher(teacherID int, search string, statusIDs []int,
-		page *entity.Pagination) ([]entity.Solution, error)
-	// GetManyForStudent returns all student solutions.
+		unreadOnly bool, page *entity.Pagination) ([]entity.Solution, error)
+	// GetManyForStudent returns all student solutions (including team ones) with unread activity
+	// and exam attempts. Individual grades of the student replace the grades of team solutions.
 	// Search param appends condition to filter solutions by task title (substring).
 	// StatusIDs param appends condition to filter solutions by statuses.
+	// UnreadOnly param appends condition to filter solutions with unread activity.
 	GetManyForStudent(studID int, search string, statusIDs []int,
-		page *entity.Pagination) ([]entity.Solution, error)
+		unreadOnly bool, page *entity.Pagination) ([]entity.Solution, error)
+	// GetManyByTask returns all task solutions with students, statuses and files.
+	GetManyByTask(taskID int) ([]entity.Solution, error)
+
+	// MarkRead saves the datetime of the last solution view by the user.
+	MarkRead(solutionID, userID int, readAt time.Time) error
 
 	// UserPermit returns nil error if user has rights to the given solution.
+	// Any member of the team solution has rights to it.
 	UserPermit(solutionID int, userClaims *entity.UserClaims) error
 }
@@ -4,8 +4,10 @@ package repository
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"skadi/backend/internal/app/entity"
 	"skadi/backend/internal/app/solution"
@@ -20,15 +22,46 @@ const (
 	_preloadStudentProfile = "StudentUser.Profile"      // object field name
 	_preloadStatus         = "Status"                   // object field name
 	_preloadFiles          = "Files"                    // object field name
+	_preloadAutotest       = "Autotest"                 // object field name
+	_preloadAttempt        = "Attempt"                  // object field name
+
+	_preloadMembers       = "Members"                     // object field name
+	_preloadMemberStudent = "Members.StudentUser"         // object field name
+	_preloadMemberProfile = "Members.StudentUser.Profile" // object field name
 
 	_fieldID        = "id"          // table field name
 	_fieldFullname  = "fullname"    // table field name
 	_fieldTitle     = "title"       // table field name
 	_fieldDesc      = "description" // table field name
 	_fieldTeacherID = "teacher_id"  // table field name
+	_fieldTaskID    = "task_id"     // table field name
+	_fieldStudentID = "student_id"  // table field name
+	_fieldStatusID  = "status_id"   // table field name
+	_fieldGrade     = "grade"       // table field name
 	_fieldUpdatedAt = "updated_at"  // table field name
+	_fieldDraft     = "draft"       // table field name
+	_fieldSolID     = "solution_id" // table field name
 
 	_orderByIDDESC = "id DESC" // condition to order data by id DESC
+
+	// join the read marker of the user (user ID is a query param)
+	_joinSolutionRead = "LEFT JOIN solution_read ON solution_read.solution_id = solution.id " +
+		"AND solution_read.user_id = ?"
+	// condition for comments of other users created since the last view
+	// (user ID is a query param, solution_read must be joined)
+	_condUnreadComment = "comment.solution_id = solution.id AND comment.deleted_at IS NULL " +
+		"AND COALESCE(comment.user_id, 0) <> ? " +
+		"AND (solution_read.read_at IS NULL OR comment.created_at > solution_read.read_at)"
+	// condition for solutions updated since the last view (solution_read must be joined)
+	_condUpdated = "solution_read.read_at IS NULL OR solution.updated_at > solution_read.read_at"
+	// join the membership of the student (student ID is a query param)
+	_joinMember = "INNER JOIN solution_member ON solution_member.solution_id = solution.id " +
+		"AND solution_member.student_id = ?"
+	// condition for solutions shared by students with the fullname
+	// matching the pattern (pattern is a query param)
+	_condMemberName = "EXISTS (SELECT 1 FROM solution_member " +
+		"INNER JOIN profile ON profile.id = solution_member.student_id " +
+		"WHERE solution_member.solution_id = solution.id AND profile.fullname REGEXP ?)"
 )
 
 // Ensure RepoDB implements interface.
@@ -47,11 +80,13 @@ func NewRepoDB(dbStorage *gorm.DB) *RepoDB {
 	}
 }
 
-// GetByID returns solution info (with task only) by the given ID.
+// GetByID returns solution info (with task, exam attempt and members only) by the given ID.
 func (r *RepoDB) GetByID(id int) (*entity.Solution, error) {
 	var solObj entity.Solution
 	err := r.dbStorage.
 		Preload(_preloadTask).
+		Preload(_preloadAttempt).
+		Preload(_preloadMembers).
 		Where(id).First(&solObj).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		// solution object with such id not found
@@ -63,7 +98,7 @@ func (r *RepoDB) GetByID(id int) (*entity.Solution, error) {
 // GetByIDFull returns a full solution info by the given ID.
 func (r *RepoDB) GetByIDFull(id int) (*entity.Solution, error) {
 	var solObj entity.Solution
-	err := r.dbStorage.
+	err := r.withMembers(r.dbStorage).
 		Preload(_preloadTask).
 		Preload(_preloadTaskFiles).
 		Preload(_preloadTeacher).
@@ -76,6 +111,8 @@ func (r *RepoDB) GetByIDFull(id int) (*entity.Solution, error) {
 		}).
 		Preload(_preloadStatus).
 		Preload(_preloadFiles).
+		Preload(_preloadAutotest).
+		Preload(_preloadAttempt).
 		Where(id).First(&solObj).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		// solution object with such id not found
@@ -85,8 +122,9 @@ func (r *RepoDB) GetByIDFull(id int) (*entity.Solution, error) {
 		return nil, err
 	}
 
-	// set student and teacher profiles
+	// set student, members and teacher profiles
 	solObj.Student = solObj.StudentUser.Profile
+	solObj.SetMemberProfiles()
 	solObj.Task.Teacher = solObj.Task.TeacherUser.Profile
 	return &solObj, nil
 }
@@ -96,9 +134,28 @@ func (r *RepoDB) Update(solutionID int, newData *entity.SolutionUpdate) error {
 	return r.dbStorage.Transaction(func(tx *gorm.DB) error {
 		updates := newData.ToUpdatesMap()
 
+		// get solution state before update for the event
+		var oldSol entity.Solution
+		err := tx.Select(_fieldID, _fieldTaskID, _fieldStudentID, _fieldStatusID, _fieldGrade).
+			Where(solutionID).First(&oldSol).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("solution with id: %w", solution.ErrNotFound)
+		}
+		if err != nil {
+			return err
+		}
+
+		var studentIDs []int
+		err = tx.Model(&entity.SolutionMember{}).
+			Where(_fieldSolID+" = ?", solutionID).
+			Pluck(_fieldStudentID, &studentIDs).Error
+		if err != nil {
+			return fmt.Errorf("get solution members: %w", err)
+		}
+
 		var updatedSol *entity.Solution
 		// update solution
-		err := tx.Model(&entity.Solution{}).
+		err = tx.Model(&entity.Solution{}).
 			Where(_fieldID+" = ?", solutionID).
 			Updates(updates).
 			Scan(&updatedSol).Error
@@ -110,6 +167,31 @@ func (r *RepoDB) Update(solutionID int, newData *entity.SolutionUpdate) error {
 			return err
 		}
 
+		// write event to the outbox
+		evtData := &entity.SolutionUpdatedEvent{
+			SolutionID:  solutionID,
+			TaskID:      oldSol.TaskID,
+			StudentID:   oldSol.StudentID,
+			StatusID:    oldSol.StatusID,
+			OldStatusID: oldSol.StatusID,
+			Grade:       oldSol.Grade,
+			StudentIDs:  studentIDs,
+			Graded:      newData.Grade != nil,
+		}
+		if newData.StatusID != nil {
+			evtData.StatusID = *newData.StatusID
+		}
+		if newData.Grade != nil {
+			evtData.Grade = newData.Grade
+		}
+		evtObj, err := entity.NewOutboxEvent(entity.TopicSolutionUpdated, evtData)
+		if err != nil {
+			return fmt.Errorf("encode event: %w", err)
+		}
+		if err := tx.Create(evtObj).Error; err != nil {
+			return fmt.Errorf("write event: %w", err)
+		}
+
 		newData.UpdatedAt = updatedSol.UpdatedAt
 		return nil
 	})
@@ -132,15 +214,16 @@ func (r *RepoDB) Delete(solObj *entity.Solution) error {
 	})
 }
 
-// GetManyForTeacher returns all solutions for the teacher tasks.
+// GetManyForTeacher returns all solutions for the teacher tasks with unread activity.
 // Search param appends condition to filter solutions
 // by task title or student fullname (substring).
 // StatusIDs param appends condition to filter solutions by statuses.
+// UnreadOnly param appends condition to filter solutions with unread activity.
 func (r *RepoDB) GetManyForTeacher(teacherID int, search string, statusIDs []int,
-	page *entity.Pagination) ([]entity.Solution, error) {
+	unreadOnly bool, page *entity.Pagination) ([]entity.Solution, error) {
 
 	solList := make([]entity.Solution, 0)
-	query := r.dbStorage.Model(entity.Solution{}).
+	query := r.withMembers(r.dbStorage.Model(entity.Solution{})).
 		Omit("answer", "updated_at").
 		Preload(_preloadTask, func(db *gorm.DB) *gorm.DB {
 			return db.Select(_fieldID, _fieldTitle) // preload only ID and title
@@ -151,15 +234,17 @@ func (r *RepoDB) GetManyForTeacher(teacherID int, search string, statusIDs []int
 		}).
 		Preload(_preloadStatus).
 		Joins("INNER JOIN task ON task.id = solution.task_id").
-		Joins("INNER JOIN user ON solution.student_id = user.id").
-		Joins("INNER JOIN profile ON user.id=profile.id").
 		Where("task.teacher_id = ?", teacherID)
 	// add filters
 	if len(statusIDs) != 0 {
 		query = query.Where("status_id IN ?", statusIDs)
 	}
 	if search != "" {
-		query = query.Where("title REGEXP ? OR profile.fullname REGEXP ?", search, search)
+		// search by fullname of any team member
+		query = query.Where("title REGEXP ? OR "+_condMemberName, search, search)
+	}
+	if unreadOnly {
+		query = r.whereUnread(query, teacherID)
 	}
 	query = query.Order(_orderByIDDESC)
 	// apply pagination if it's not nil
@@ -171,23 +256,37 @@ func (r *RepoDB) GetManyForTeacher(teacherID int, search string, statusIDs []int
 	if err := query.Find(&solList).Error; err != nil {
 		return nil, err
 	}
+	if err := r.setActivity(teacherID, solList); err != nil {
+		return nil, fmt.Errorf("get activity: %w", err)
+	}
+	for idx := range solList {
+		solList[idx].SetMemberProfiles()
+	}
 	return solList, nil
 }
 
-// GetManyForStudent returns all student solutions.
+// GetManyForStudent returns all student solutions (including team ones) with unread activity
+// and exam attempts. Individual grades of the student replace the grades of team solutions.
 // StatusIDs param appends condition to filter solutions by statuses.
+// UnreadOnly param appends condition to filter solutions with unread activity.
 func (r *RepoDB) GetManyForStudent(studID int, search string, statusIDs []int,
-	page *entity.Pagination) ([]entity.Solution, error) {
+	unreadOnly bool, page *entity.Pagination) ([]entity.Solution, error) {
 
 	solList := make([]entity.Solution, 0)
 	query := r.dbStorage.Model(entity.Solution{}).
-		Omit("answer", "student_id").
+		// select all apart of answer and student_id, the individual grade replaces the solution one
+		Select("solution.id", "solution.task_id", "solution.status_id", "solution.updated_at",
+			"COALESCE(solution_member.grade, solution.grade) AS grade").
 		Preload(_preloadTask, func(db *gorm.DB) *gorm.DB {
-			return db.Select(_fieldID, _fieldTitle, _fieldDesc) // preload only ID, title and desc
+			// preload only ID, title, desc and exam settings
+			return db.Select(_fieldID, _fieldTitle, _fieldDesc,
+				"exam_opens_at", "exam_closes_at", "exam_duration")
 		}).
 		Preload(_preloadStatus).
+		Preload(_preloadAttempt).
 		Joins("INNER JOIN task ON task.id = solution.task_id").
-		Where("student_id = ?", studID)
+		Joins(_joinMember, studID).
+		Where("task.draft = ?", false) // drafts are not visible for students
 	// add filters
 	if len(statusIDs) != 0 {
 		query = query.Where("status_id IN ?", statusIDs)
@@ -195,6 +294,9 @@ func (r *RepoDB) GetManyForStudent(studID int, search string, statusIDs []int,
 	if search != "" {
 		query = query.Where("title REGEXP ?", search)
 	}
+	if unreadOnly {
+		query = r.whereUnread(query, studID)
+	}
 	query = query.Order(_orderByIDDESC)
 	// apply pagination if it's not nil
 	if page != nil {
@@ -205,18 +307,50 @@ func (r *RepoDB) GetManyForStudent(studID int, search string, statusIDs []int,
 	if err := query.Find(&solList).Error; err != nil {
 		return nil, err
 	}
+	if err := r.setActivity(studID, solList); err != nil {
+		return nil, fmt.Errorf("get activity: %w", err)
+	}
+	return solList, nil
+}
+
+// GetManyByTask returns all task solutions with students, statuses and files.
+func (r *RepoDB) GetManyByTask(taskID int) ([]entity.Solution, error) {
+	solList := make([]entity.Solution, 0)
+	err := r.withMembers(r.dbStorage.Model(entity.Solution{})).
+		Preload(_preloadStudent).
+		Preload(_preloadStudentProfile, func(db *gorm.DB) *gorm.DB {
+			return db.Select(_fieldID, _fieldFullname) // preload only ID and fullname
+		}).
+		Preload(_preloadStatus).
+		Preload(_preloadFiles).
+		Where("task_id = ?", taskID).
+		Order(_fieldID).
+		Find(&solList).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// set student and members profiles
+	for idx := range solList {
+		solList[idx].Student = solList[idx].StudentUser.Profile
+		solList[idx].SetMemberProfiles()
+	}
 	return solList, nil
 }
 
 // UserPermit returns nil error if user has rights to the given solution.
+// Any member of the team solution has rights to it.
 func (r *RepoDB) UserPermit(solutionID int, userClaims *entity.UserClaims) error {
-	// get student_id from solution and teacher_id from task
+	// get members from solution and teacher_id from task
 	var solObj entity.Solution
 	err := r.dbStorage.
 		Model(&entity.Solution{}).
-		Select("task_id, student_id").
+		Select("id, task_id, student_id").
 		Preload(_preloadTask, func(db *gorm.DB) *gorm.DB {
-			return db.Select(_fieldID, _fieldTeacherID) // preload only teacher ID
+			return db.Select(_fieldID, _fieldTeacherID, _fieldDraft) // preload only teacher ID and draft flag
+		}).
+		Preload(_preloadMembers, func(db *gorm.DB) *gorm.DB {
+			return db.Select(_fieldSolID, _fieldStudentID) // preload only student IDs
 		}).
 		Where(solutionID).First(&solObj).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -231,8 +365,11 @@ func (r *RepoDB) UserPermit(solutionID int, userClaims *entity.UserClaims) error
 	if userClaims.IsTeacher() && solObj.Task.TeacherID != userClaims.ID {
 		return fmt.Errorf("%w: user (teacher) is not a task owner", solution.ErrForbidden)
 	}
-	if userClaims.IsStudent() && solObj.StudentID != userClaims.ID {
-		return fmt.Errorf("%w: user (stud) is not a solution owner", solution.ErrForbidden)
+	if userClaims.IsStudent() && !solObj.IsMember(userClaims.ID) {
+		return fmt.Errorf("%w: user (stud) is not a solution member", solution.ErrForbidden)
+	}
+	if userClaims.IsStudent() && solObj.Task.Draft {
+		return fmt.Errorf("solution with id: %w: task is a draft", solution.ErrNotFound)
 	}
 	return nil
 }
@@ -267,3 +404,70 @@ func (r *RepoDB) updateSolutionFiles(tx *gorm.DB,
 	}
 	return nil
 }
+
+// MarkRead saves the datetime of the last solution view by the user.
+func (r *RepoDB) MarkRead(solutionID, userID int, readAt time.Time) error {
+	readObj := &entity.SolutionRead{
+		SolutionID: solutionID,
+		UserID:     userID,
+		ReadAt:     readAt,
+	}
+	return r.dbStorage.
+		Clauses(clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"read_at"})}).
+		Create(readObj).Error // nil OR error
+}
+
+// withMembers appends preloading of solution members with profiles (IDs and fullnames only).
+// Profiles are set to members by [entity.Solution.SetMemberProfiles].
+func (r *RepoDB) withMembers(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload(_preloadMembers).
+		Preload(_preloadMemberStudent).
+		Preload(_preloadMemberProfile, func(db *gorm.DB) *gorm.DB {
+			return db.Select(_fieldID, _fieldFullname) // preload only ID and fullname
+		})
+}
+
+// whereUnread appends condition to filter solutions
+// updated or commented since the last view by the user.
+func (r *RepoDB) whereUnread(query *gorm.DB, userID int) *gorm.DB {
+	return query.
+		Joins(_joinSolutionRead, userID).
+		Where(_condUpdated+" OR EXISTS (SELECT 1 FROM comment WHERE "+_condUnreadComment+")",
+			userID)
+}
+
+// setActivity sets unread comments number and updates flag for all given solutions.
+func (r *RepoDB) setActivity(userID int, solList []entity.Solution) error {
+	if len(solList) == 0 {
+		return nil
+	}
+	solIDs := make([]int, len(solList))
+	for idx := range solList {
+		solIDs[idx] = solList[idx].ID
+	}
+
+	var activities []entity.SolutionActivity
+	err := r.dbStorage.
+		Model(&entity.Solution{}).
+		Select("solution.id AS solution_id, "+
+			"(SELECT COUNT(*) FROM comment WHERE "+_condUnreadComment+") AS unread_comments, "+
+			"("+_condUpdated+") AS has_updates", userID).
+		Joins(_joinSolutionRead, userID).
+		Where("solution.id IN ?", solIDs).
+		Scan(&activities).Error
+	if err != nil {
+		return err
+	}
+
+	activityMap := make(map[int]entity.SolutionActivity, len(activities))
+	for _, activity := range activities {
+		activityMap[activity.SolutionID] = activity
+	}
+	for idx := range solList {
+		activity := activityMap[solList[idx].ID]
+		solList[idx].UnreadComments = activity.UnreadComments
+		solList[idx].HasUpdates = activity.HasUpdates || activity.UnreadComments > 0
+	}
+	return nil
+}
@@ -14,30 +14,36 @@ type UsecaseTeacher interface {
 		newData *entity.SolutionUpdate) (*entity.Solution, error)
 	// DeleteByID deletes solution object by given ID.
 	DeleteByID(userID, solutionID int) error
-	// GetManyForTeacher returns all solutions for the teacher tasks.
+	// GetManyForTeacher returns all solutions for the teacher tasks with unread activity.
 	// Search param appends condition to filter solutions
 	// by task title or student fullname (substring).
 	// StatusIDs param appends condition to filter solutions by statuses.
+	// UnreadOnly param appends condition to filter solutions with unread activity.
 	GetManyForTeacher(teacherID int, search string, statusIDs []int,
-		page *entity.Pagination) ([]entity.Solution, error)
+		unreadOnly bool, page *entity.Pagination) ([]entity.Solution, error)
 }
 
 // UsecaseStudent describes all class usecases for student.
 type UsecaseStudent interface {
 	// Update updates the given solution by given ID with the new data.
 	// It returns the updated solution object.
-	// Allows to update the status (apart of archived), answer and solution files.
+	// Allows to update the status (apart of archived and apart of quiz solutions), answer and solution files.
 	Update(studID, solutionID int, newData *entity.SolutionUpdate) (*entity.Solution, error)
-	// GetManyForStudent returns all student solutions.
+	// GetManyForStudent returns all student solutions with unread activity.
+	// Exam task content is hidden until the attempt is begun.
 	// Search param appends condition to filter solutions by task title (substring).
 	// StatusIDs param appends condition to filter solutions by statuses.
+	// UnreadOnly param appends condition to filter solutions with unread activity.
 	GetManyForStudent(studID int, search string, statusIDs []int,
-		page *entity.Pagination) ([]entity.Solution, error)
+		unreadOnly bool, page *entity.Pagination) ([]entity.Solution, error)
 }
 
 // UsecaseClient describes all class usecases for teacher and student.
 type UsecaseClient interface {
 	// GetByIDFull returns a full solution info and all students linked to the solution task.
+	// Solution is marked as read by the user.
 	GetByIDFull(solutionID int,
 		userClaims *entity.UserClaims) (*entity.Solution, []entity.Profile, error)
+	// MarkRead marks the solution as read by the user.
+	MarkRead(solutionID int, userClaims *entity.UserClaims) error
 }
@@ -4,6 +4,7 @@ package usecase
 
 import (
 	"fmt"
+	"time"
 
 	"skadi/backend/config"
 	"skadi/backend/internal/app/entity"
@@ -33,7 +34,8 @@ func NewUCClient(cfg *config.Config, solRepoDB solution.RepositoryDB,
 	}
 }
 
-// GetByID returns a full solution info and all students linked to the solution task.
+// GetByIDFull returns a full solution info and all students linked to the solution task.
+// Solution is marked as read by the user.
 func (u *UCClient) GetByIDFull(solutionID int,
 	userClaims *entity.UserClaims) (*entity.Solution, []entity.Profile, error) {
 
@@ -46,13 +48,45 @@ func (u *UCClient) GetByIDFull(solutionID int,
 	if userClaims.IsTeacher() && sol.Task.TeacherID != userClaims.ID {
 		return nil, nil, fmt.Errorf("%w: user (teacher) is not a task owner", solution.ErrForbidden)
 	}
-	if userClaims.IsStudent() && sol.StudentID != userClaims.ID {
-		return nil, nil, fmt.Errorf("%w: user (stud) is not a solution owner", solution.ErrForbidden)
+	if userClaims.IsStudent() && !sol.IsMember(userClaims.ID) {
+		return nil, nil, fmt.Errorf("%w: user (stud) is not a solution member", solution.ErrForbidden)
+	}
+	// drafts are not visible for students
+	if userClaims.IsStudent() && sol.Task.Draft {
+		return nil, nil, fmt.Errorf("solution with id: %w: task is a draft", solution.ErrNotFound)
+	}
+	// exam task content is not visible for students before the attempt is begun
+	if userClaims.IsStudent() && !sol.ExamBegun() {
+		return nil, nil, fmt.Errorf("%w: task content is hidden", solution.ErrExamNotBegun)
+	}
+
+	// sandbox errors are not shown to students
+	if userClaims.IsStudent() && sol.Autotest != nil {
+		sol.Autotest.HideSecrets()
+	}
+	// students see their own individual grades only
+	if userClaims.IsStudent() {
+		sol = sol.MemberView(userClaims.ID)
+	}
+	if sol.Attempt != nil {
+		sol.Attempt.SetDeadline(sol.Task)
 	}
 
 	studProfiles, err := u.taskRepoDB.GetTaskStudents(sol.TaskID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("get task students: %w", err)
 	}
+	if err := u.solRepoDB.MarkRead(solutionID, userClaims.ID, time.Now()); err != nil {
+		return nil, nil, fmt.Errorf("mark read: %w", err)
+	}
 	return sol, studProfiles, nil
 }
+
+// MarkRead marks the solution as read by the user.
+func (u *UCClient) MarkRead(solutionID int, userClaims *entity.UserClaims) error {
+	// check user rights for this solution
+	if err := u.solRepoDB.UserPermit(solutionID, userClaims); err != nil {
+		return fmt.Errorf("check solution permissions: %w", err)
+	}
+	return u.solRepoDB.MarkRead(solutionID, userClaims.ID, time.Now())
+}
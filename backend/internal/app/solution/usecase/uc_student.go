@@ -4,9 +4,12 @@ import (
 	"errors"
 	"fmt"
 	goslices "slices"
+	"time"
 
 	"skadi/backend/config"
 	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/event"
+	"skadi/backend/internal/app/notification"
 	"skadi/backend/internal/app/solution"
 	"skadi/backend/internal/app/status"
 )
@@ -20,22 +23,29 @@ type UCStudent struct {
 	cfg          *config.Config
 	solRepoDB    solution.RepositoryDB
 	statusRepoDB status.RepositoryDB
+	evtPub       event.Publisher
+	notifier     notification.Notifier
 }
 
 // NewUCStudent returns a new instance of [UCStudent].
 func NewUCStudent(cfg *config.Config, solRepoDB solution.RepositoryDB,
-	statusRepoDB status.RepositoryDB) *UCStudent {
+	statusRepoDB status.RepositoryDB, evtPub event.Publisher,
+	notifier notification.Notifier) *UCStudent {
 
 	return &UCStudent{
 		cfg:          cfg,
 		solRepoDB:    solRepoDB,
 		statusRepoDB: statusRepoDB,
+		evtPub:       evtPub,
+		notifier:     notifier,
 	}
 }
 
 // Update updates the given solution by given ID with the new data.
+// Any member of the team solution can update it.
 // It returns the updated solution object.
-// Allows to update the status (apart of archived), answer and solution files.
+// Allows to update the status (apart of archived and apart of quiz solutions), answer and solution files.
+// Solutions of the tasks in exam mode can be updated during the begun attempt only.
 func (u *UCStudent) Update(studID, solutionID int,
 	newData *entity.SolutionUpdate) (*entity.Solution, error) {
 
@@ -44,10 +54,18 @@ func (u *UCStudent) Update(studID, solutionID int,
 	if err != nil {
 		return nil, fmt.Errorf("get full solution: %w", err)
 	}
-	if studID != solObj.StudentID {
-		return nil, fmt.Errorf("%w: user (student) is not a solution owner",
+	if !solObj.IsMember(studID) {
+		return nil, fmt.Errorf("%w: user (student) is not a solution member",
 			solution.ErrForbidden)
 	}
+	// drafts are not visible for students
+	if solObj.Task.Draft {
+		return nil, fmt.Errorf("solution with id: %w: task is a draft", solution.ErrNotFound)
+	}
+	// solution of the exam is changed during the attempt only
+	if !solObj.ExamOpen(time.Now()) {
+		return nil, fmt.Errorf("%w: attempt is not begun or it is over", solution.ErrExamClosed)
+	}
 
 	newData.DelFiles = make(entity.Files, 0, len(newData.DelFilesIDs))
 	solFilesRemains := make(entity.Files, 0, len(solObj.Files))
@@ -62,6 +80,10 @@ func (u *UCStudent) Update(studID, solutionID int,
 	solObj.Files = solFilesRemains
 
 	newData.Grade = nil
+	// status of the quiz solution is changed with answers only
+	if newData.StatusID != nil && solObj.Task.Type == entity.TaskQuiz {
+		return nil, fmt.Errorf("%w: student cannot set status of the quiz", solution.ErrQuiz)
+	}
 	if newData.StatusID != nil {
 		if err := u.getStatusToUpdate(solObj, *newData.StatusID); err != nil {
 			return nil, err
@@ -80,22 +102,52 @@ func (u *UCStudent) Update(studID, solutionID int,
 		return nil, fmt.Errorf("update: %w", err)
 	}
 	solObj.UpdatedAt = newData.UpdatedAt
+	// own changes are not unread updates
+	if err := u.solRepoDB.MarkRead(solutionID, studID, time.Now()); err != nil {
+		return nil, fmt.Errorf("mark read: %w", err)
+	}
 
 	// append new files to solution files
 	solObj.Files = append(solObj.Files, newData.AddFiles...)
 	// remove deleted files from file system
 	newData.DelFiles.Cleanup()
 
-	return solObj, nil
+	// notify teacher and other team members about the new answer or status
+	u.evtPub.Publish(&entity.Event{Type: entity.EventSolutionUpdated, Data: solObj},
+		solObj.Task.TeacherID)
+	event.PublishToMembers(u.evtPub, entity.EventSolutionUpdated, solObj, studID)
+	// notify teacher that solution was sent to review
+	if newData.StatusID != nil && *newData.StatusID == _readyStatusID {
+		u.notifier.Notify(&entity.Notification{
+			Type:       entity.NotifySolutionSubmitted,
+			Message:    fmt.Sprintf("Решение задания «%s» отправлено на проверку", solObj.Task.Title),
+			TaskID:     &solObj.TaskID,
+			SolutionID: &solObj.ID,
+		}, solObj.Task.TeacherID)
+	}
+	return solObj.MemberView(studID), nil
 }
 
-// GetManyForStudent returns all student solutions.
+// GetManyForStudent returns all student solutions with unread activity.
+// Exam task content is hidden until the attempt is begun.
 // Search param appends condition to filter solutions by task title (substring).
 // StatusID param appends condition to filter solutions by status.
+// UnreadOnly param appends condition to filter solutions with unread activity.
 func (u *UCStudent) GetManyForStudent(studID int, search string, statusID []int,
-	page *entity.Pagination) ([]entity.Solution, error) {
+	unreadOnly bool, page *entity.Pagination) ([]entity.Solution, error) {
 
-	return u.solRepoDB.GetManyForStudent(studID, search, statusID, page)
+	solList, err := u.solRepoDB.GetManyForStudent(studID, search, statusID, unreadOnly, page)
+	if err != nil {
+		return nil, err
+	}
+	// exam task content is not visible before the attempt is begun
+	for idx := range solList {
+		solList[idx].HideExamContent()
+		if solList[idx].Attempt != nil {
+			solList[idx].Attempt.SetDeadline(solList[idx].Task)
+		}
+	}
+	return solList, nil
 }
 
 // getStatusToUpdate sets the new status object to updated solution.
@@ -3,9 +3,12 @@ package usecase
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"skadi/backend/config"
 	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/event"
+	"skadi/backend/internal/app/notification"
 	"skadi/backend/internal/app/solution"
 	"skadi/backend/internal/app/status"
 )
@@ -26,16 +29,21 @@ type UCTeacher struct {
 	cfg          *config.Config
 	solRepoDB    solution.RepositoryDB
 	statusRepoDB status.RepositoryDB
+	evtPub       event.Publisher
+	notifier     notification.Notifier
 }
 
 // NewUCTeacher returns a new instance of [UCTeacher].
 func NewUCTeacher(cfg *config.Config, solRepoDB solution.RepositoryDB,
-	statusRepoDB status.RepositoryDB) *UCTeacher {
+	statusRepoDB status.RepositoryDB, evtPub event.Publisher,
+	notifier notification.Notifier) *UCTeacher {
 
 	return &UCTeacher{
 		cfg:          cfg,
 		solRepoDB:    solRepoDB,
 		statusRepoDB: statusRepoDB,
+		evtPub:       evtPub,
+		notifier:     notifier,
 	}
 }
 
@@ -67,6 +75,21 @@ func (u *UCTeacher) Update(teacherID, solutionID int,
 		return nil, fmt.Errorf("update: %w", err)
 	}
 	solObj.UpdatedAt = newData.UpdatedAt
+	// own changes are not unread updates
+	if err := u.solRepoDB.MarkRead(solutionID, teacherID, time.Now()); err != nil {
+		return nil, fmt.Errorf("mark read: %w", err)
+	}
+	// notify students (all team members) about the new status or grade
+	event.PublishToMembers(u.evtPub, entity.EventSolutionUpdated, solObj, teacherID)
+	// notify students that solution was checked
+	if newData.Grade != nil || (newData.StatusID != nil && *newData.StatusID == _archivedStatusID) {
+		u.notifier.Notify(&entity.Notification{
+			Type:       entity.NotifySolutionChecked,
+			Message:    fmt.Sprintf("Решение задания «%s» проверено", solObj.Task.Title),
+			TaskID:     &solObj.TaskID,
+			SolutionID: &solObj.ID,
+		}, solObj.MemberIDs()...)
+	}
 	return solObj, nil
 }
 
@@ -94,14 +117,15 @@ func (u *UCTeacher) DeleteByID(userID, solutionID int) error {
 	return nil
 }
 
-// GetManyForTeacher returns all solutions for the teacher tasks.
+// GetManyForTeacher returns all solutions for the teacher tasks with unread activity.
 // Search param appends condition to filter solutions
 // by task title or student fullname (substring).
 // // StatusID param appends condition to filter solutions by status.
+// UnreadOnly param appends condition to filter solutions with unread activity.
 func (u *UCTeacher) GetManyForTeacher(teacherID int, search string, statusID []int,
-	page *entity.Pagination) ([]entity.Solution, error) {
+	unreadOnly bool, page *entity.Pagination) ([]entity.Solution, error) {
 
-	solList, err := u.solRepoDB.GetManyForTeacher(teacherID, search, statusID, page)
+	solList, err := u.solRepoDB.GetManyForTeacher(teacherID, search, statusID, unreadOnly, page)
 	if err != nil {
 		return nil, err
 	}
@@ -1,16 +1,22 @@
 package v1
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
+	"log/slog"
+	"time"
 
 	fiber "github.com/gofiber/fiber/v2"
 
 	"skadi/backend/config"
 	"skadi/backend/internal/app/entity"
 	"skadi/backend/internal/app/task"
+	"skadi/backend/internal/app/upload"
+	uploadhttpv1 "skadi/backend/internal/app/upload/controller/http/v1"
 	"skadi/backend/internal/pkg/httperror"
 	"skadi/backend/internal/pkg/serialize"
+	utilsarchive "skadi/backend/internal/pkg/utils/archive"
 	utilsfile "skadi/backend/internal/pkg/utils/file"
 	utilsjwt "skadi/backend/internal/pkg/utils/jwt"
 	"skadi/backend/internal/pkg/utils/slices"
@@ -19,24 +25,35 @@ import (
 
 // TaskControllerTeacher represents a controller for task routes accepted for teachers only.
 type TaskControllerTeacher struct {
-	valid         validator.Validator
-	taskUCTeacher task.UsecaseTeacher
-	taskFileDir   string
+	valid          validator.Validator
+	taskUCTeacher  task.UsecaseTeacher
+	uploadUCClient upload.UsecaseClient
+	taskFileDir    string
+	fileQueue      utilsfile.Queue
 }
 
 // NewControllerTeacher returns a new instance of [TaskControllerTeacher].
 func NewControllerTeacher(cfg *config.Config, taskUCTeacher task.UsecaseTeacher,
+	uploadUCClient upload.UsecaseClient, fileQueue utilsfile.Queue,
 	valid validator.Validator) *TaskControllerTeacher {
 
 	return &TaskControllerTeacher{
-		valid:         valid,
-		taskUCTeacher: taskUCTeacher,
-		taskFileDir:   cfg.Media.TaskFileDir,
+		valid:          valid,
+		taskUCTeacher:  taskUCTeacher,
+		uploadUCClient: uploadUCClient,
+		taskFileDir:    cfg.Media.TaskFileDir,
+		fileQueue:      fileQueue,
 	}
 }
 
 // @summary		Создание нового задания. [Только преподаватель]
 // @description	Создание нового задания (с прикреплением файлов) для переданных учеников и учеников из переданных групп.
+// @description	Черновик (draft=true или дата публикации publish_at в будущем) не виден ученикам до публикации, уведомления отправляются при публикации.
+// @description	Задание привязывается к переданным группам: ученикам, добавленным в группу позже, оно выдаётся автоматически.
+// @description	Перед сроком сдачи (deadline) ученикам, не отправившим решение, приходит напоминание.
+// @description	Описание поддерживает Markdown (CommonMark, формулы LaTeX в $...$ и $$...$$), в ответе оно возвращается также в виде безопасного HTML (description_html).
+// @description	Изображения могут ссылаться на файлы задания по имени (![](scheme.png)) или ID (![](file:12)).
+// @description	Для задания-теста (type=quiz) вопросы задаются отдельно, решение ученика - ответы на вопросы с автоматической проверкой.
 // @router			/task [post]
 // @id				task-create
 // @tags			task
@@ -47,9 +64,14 @@ func NewControllerTeacher(cfg *config.Config, taskUCTeacher task.UsecaseTeacher,
 // @param			description	formData	string	true	"task description"
 // @param			classes		formData	[]int	false	"classes for task solutions (list of class IDs)"
 // @param			students	formData	[]int	false	"students for task solutions (list of student IDs)"
-// @param			file		formData	[]file	false	"task files"
+// @param			file		formData	[]file		false	"task files"
+// @param			uploads		formData	[]string	false	"IDs of completed chunked uploads to attach as task files"
+// @param			draft		formData	bool		false	"true to create a draft"
+// @param			publish_at	formData	string		false	"datetime to publish the draft automatically (RFC 3339)"
+// @param			deadline	formData	string		false	"datetime students have to send solutions before (RFC 3339)"
+// @param			type		formData	string		false	"task type (assignment by default)"	Enums(assignment, quiz)
 // @success		201			{object}	createTaskOut
-// @failure		400			"неверный ученик | неверный преподаватель | преподаватель не найден"
+// @failure		400			"неверный ученик | группа не найдена | неверный преподаватель | преподаватель не найден | загрузка не найдена | загрузка не завершена | неверная дата публикации | неверный срок сдачи"
 // @failure		401			"неверный токен (пустой, истекший или неверный формат)"
 func (c *TaskControllerTeacher) Create(ctx *fiber.Ctx) error {
 	// parse user claims
@@ -59,21 +81,45 @@ func (c *TaskControllerTeacher) Create(ctx *fiber.Ctx) error {
 	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
 		return err
 	}
-	uploadedFiles, err := utilsfile.ParseAndSaveFiles(ctx, c.taskFileDir)
+	uploadedFiles, err := utilsfile.ParseAndSaveFiles(ctx, c.taskFileDir, c.fileQueue)
 	if err != nil {
 		return err
 	}
+	// take completed chunked uploads
+	uploads, err := uploadhttpv1.TakeUploads(c.uploadUCClient, userClaims.ID,
+		inputBody.Uploads, c.taskFileDir)
+	if err != nil {
+		uploadedFiles.Cleanup()
+		return err
+	}
+	linkedFiles := append(uploadedFiles, uploads.Files...)
 
 	// data reshaping
 	taskObj := &entity.Task{
 		Title:     inputBody.Title,
 		Desc:      inputBody.Desc,
 		TeacherID: userClaims.ID,
-		Files:     uploadedFiles,
+		Type:      entity.TaskAssignment,
+		Draft:     inputBody.Draft,
+		Files:     linkedFiles,
+	}
+	if inputBody.Type != "" {
+		taskObj.Type = inputBody.Type
+	}
+	if inputBody.PublishAt != nil {
+		// datetime format is already validated
+		publishAt, _ := time.Parse(time.RFC3339, *inputBody.PublishAt)
+		taskObj.PublishAt = &publishAt
+	}
+	if inputBody.Deadline != nil {
+		// datetime format is already validated
+		deadline, _ := time.Parse(time.RFC3339, *inputBody.Deadline)
+		taskObj.Deadline = &deadline
 	}
 	// create a new task with solutions
 	solutions, err := c.taskUCTeacher.CreateWithSolutions(taskObj,
 		inputBody.StudentIDs, inputBody.ClassIDs)
+	uploads.Finish(err, c.fileQueue)
 	if err != nil {
 		uploadedFiles.Cleanup()
 	}
@@ -98,6 +144,27 @@ func (c *TaskControllerTeacher) Create(ctx *fiber.Ctx) error {
 			Message:    "неверный ученик",
 		}
 	}
+	if errors.Is(err, task.ErrNotFoundClass) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "группа не найдена",
+		}
+	}
+	if errors.Is(err, task.ErrInvalidPublishAt) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "неверная дата публикации",
+		}
+	}
+	if errors.Is(err, task.ErrInvalidDeadline) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "неверный срок сдачи",
+		}
+	}
 	if err != nil {
 		return err
 	}
@@ -157,8 +224,66 @@ func (c *TaskControllerTeacher) Read(ctx *fiber.Ctx) error {
 	return ctx.Status(fiber.StatusOK).JSON(res)
 }
 
+// @summary		Скачивание всех решений задания архивом. [Только преподаватель]
+// @description	Скачивание ZIP-архива со всеми решениями задания: для каждого ученика отдельная папка с текстом ответа и файлами решения.
+// @description	В корне архива лежит manifest.csv со статусом, оценкой и датой последнего обновления каждого решения.
+// @description	Файлы, не проверенные антивирусом или заражённые, в архив не попадают.
+// @router			/task/{id}/solutions.zip [get]
+// @id				task-download-solutions
+// @tags			task
+// @accept			json
+// @produce		application/zip
+// @security		JWTAccess
+// @param			id	path	int	true	"ID задания"
+// @success		200	"ZIP-архив с решениями"
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+// @failure		404	"задание не найдено"
+func (c *TaskControllerTeacher) DownloadSolutions(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &taskIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	taskObj, solutions, err := c.taskUCTeacher.GetSolutions(userClaims.ID, inputPath.ID)
+	if errors.Is(err, task.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "задание не найдено",
+		}
+	}
+	if errors.Is(err, task.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("download solutions: %w", err)
+	}
+
+	// set filename
+	filename := fmt.Sprintf("task_%d_solutions.zip", taskObj.ID)
+	ctx.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	// set MIME-type
+	ctx.Set("Content-Type", "application/zip")
+	// stream archive (headers are already sent, so errors can be only logged)
+	ctx.Status(fiber.StatusOK).Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := utilsarchive.WriteSolutionsZIP(w, solutions); err != nil {
+			slog.Error("write solutions archive", "task", taskObj.ID, "error", err)
+		}
+	})
+	return nil
+}
+
 // @summary		Обновление задания. [Только преподаватель]
-// @description	Частичное обновление задания (только переданные поля: название, описание, привязанные ученики, прикреплённые файлы) по его id.
+// @description	Частичное обновление задания (только переданные поля: название, описание, срок сдачи, привязанные ученики, прикреплённые файлы) по его id.
+// @description	Дату публикации можно изменить только у черновика.
 // @router			/task/{id} [patch]
 // @id				task-update
 // @tags			task
@@ -170,12 +295,16 @@ func (c *TaskControllerTeacher) Read(ctx *fiber.Ctx) error {
 // @param			description		formData	string	false	"task description"
 // @param			students		formData	[]int	false	"IDs of students (updated list) for the task"
 // @param			delete_files	formData	[]int	false	"IDs of files to delete from the task"
-// @param			file			formData	[]file	false	"new task files"
+// @param			file			formData	[]file		false	"new task files"
+// @param			uploads			formData	[]string	false	"IDs of completed chunked uploads to attach as new task files"
+// @param			publish_at		formData	string		false	"new datetime to publish the draft automatically (RFC 3339)"
+// @param			deadline		formData	string		false	"new datetime students have to send solutions before (RFC 3339)"
 // @success		200				{object}	entity.TaskWithStudents
-// @failure		400				"неверный ученик"
+// @failure		400				"неверный ученик | загрузка не найдена | загрузка не завершена | неверная дата публикации | неверный срок сдачи"
 // @failure		401				"неверный токен (пустой, истекший или неверный формат)"
 // @failure		403				"доступ запрещён"
 // @failure		404				"задание не найдено"
+// @failure		409				"задание уже опубликовано"
 func (c *TaskControllerTeacher) Update(ctx *fiber.Ctx) error {
 	// parse user claims
 	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
@@ -188,20 +317,39 @@ func (c *TaskControllerTeacher) Update(ctx *fiber.Ctx) error {
 	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
 		return err
 	}
-	uploadedFiles, err := utilsfile.ParseAndSaveFiles(ctx, c.taskFileDir)
+	uploadedFiles, err := utilsfile.ParseAndSaveFiles(ctx, c.taskFileDir, c.fileQueue)
+	if err != nil {
+		return err
+	}
+	// take completed chunked uploads
+	uploads, err := uploadhttpv1.TakeUploads(c.uploadUCClient, userClaims.ID,
+		inputBody.Uploads, c.taskFileDir)
 	if err != nil {
+		uploadedFiles.Cleanup()
 		return err
 	}
+	linkedFiles := append(uploadedFiles, uploads.Files...)
 
 	// data reshaping
 	newData := &entity.TaskUpdate{
 		Title:           inputBody.Title,
 		Desc:            inputBody.Desc,
 		NewFullStudents: slices.DelDupls(inputBody.Students), // delete duplicates from list
-		AddFiles:        uploadedFiles,
+		AddFiles:        linkedFiles,
 		DelFilesIDs:     slices.DelDupls(inputBody.DelFiles), // delete duplicates from list
 	}
+	if inputBody.PublishAt != nil {
+		// datetime format is already validated
+		publishAt, _ := time.Parse(time.RFC3339, *inputBody.PublishAt)
+		newData.PublishAt = &publishAt
+	}
+	if inputBody.Deadline != nil {
+		// datetime format is already validated
+		deadline, _ := time.Parse(time.RFC3339, *inputBody.Deadline)
+		newData.Deadline = &deadline
+	}
 	taskObj, students, err := c.taskUCTeacher.Update(userClaims.ID, inputPath.ID, newData)
+	uploads.Finish(err, c.fileQueue)
 	if err != nil {
 		uploadedFiles.Cleanup()
 	}
@@ -226,6 +374,27 @@ func (c *TaskControllerTeacher) Update(ctx *fiber.Ctx) error {
 			Message:    "неверный ученик",
 		}
 	}
+	if errors.Is(err, task.ErrInvalidPublishAt) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "неверная дата публикации",
+		}
+	}
+	if errors.Is(err, task.ErrInvalidDeadline) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "неверный срок сдачи",
+		}
+	}
+	if errors.Is(err, task.ErrPublished) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "задание уже опубликовано",
+		}
+	}
 	if err != nil {
 		return err
 	}
@@ -271,8 +440,60 @@ func (c *TaskControllerTeacher) Delete(ctx *fiber.Ctx) error {
 	return ctx.Status(fiber.StatusNoContent).JSON(nil)
 }
 
+// @summary		Публикация черновика задания. [Только преподаватель]
+// @description	Немедленная публикация черновика задания по его id: задание становится видно ученикам, им отправляются уведомления.
+// @router			/task/{id}/publish [post]
+// @id				task-publish
+// @tags			task
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id	path		int	true	"ID задания"
+// @success		200	{object}	entity.Task
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+// @failure		404	"задание не найдено"
+// @failure		409	"задание уже опубликовано"
+func (c *TaskControllerTeacher) Publish(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &taskIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	taskObj, err := c.taskUCTeacher.Publish(userClaims.ID, inputPath.ID)
+	if errors.Is(err, task.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "задание не найдено",
+		}
+	}
+	if errors.Is(err, task.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, task.ErrPublished) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "задание уже опубликовано",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("publish: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(taskObj)
+}
+
 // @summary		Получение списка заданий. [Только преподаватель]
 // @description	Получение списка заданий (с файлами задания и учениками, которые выполняют это задание) конкретного преподавателя.
+// @description	С параметром library=true возвращаются только задания библиотеки (без выданных решений).
 // @router			/task [get]
 // @id				task-list
 // @tags			task
@@ -294,7 +515,8 @@ func (c *TaskControllerTeacher) List(ctx *fiber.Ctx) error {
 	pageParams := inputQuery.PaginationQuery.ToPagination()
 
 	// get tasks
-	taskListResp, err := c.taskUCTeacher.GetMany(userClaims.ID, inputQuery.Search, pageParams)
+	taskListResp, err := c.taskUCTeacher.GetMany(userClaims.ID, inputQuery.Search,
+		inputQuery.Library, pageParams)
 	if err != nil {
 		return fmt.Errorf("list: %w", err)
 	}
@@ -304,3 +526,267 @@ func (c *TaskControllerTeacher) List(ctx *fiber.Ctx) error {
 	}
 	return ctx.Status(fiber.StatusOK).JSON(output)
 }
+
+// @summary		Получение списка заданий, которыми поделились другие преподаватели. [Только преподаватель]
+// @description	Получение списка заданий (с файлами и преподавателем-автором), которыми с текущим преподавателем поделились другие преподаватели.
+// @router			/task/shared [get]
+// @id				task-list-shared
+// @tags			task
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			listSharedTaskQuery	query		listSharedTaskQuery	false	"listSharedTaskQuery"
+// @success		200					{object}	listSharedTaskOut
+// @failure		401					"неверный токен (пустой, истекший или неверный формат)"
+func (c *TaskControllerTeacher) ListShared(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputQuery := &listSharedTaskQuery{}
+	if err := serialize.Deserialize(inputQuery, ctx.QueryParser, c.valid.Validate); err != nil {
+		return err
+	}
+	// get pagination object OR nil
+	pageParams := inputQuery.PaginationQuery.ToPagination()
+
+	// get shared tasks
+	taskList, err := c.taskUCTeacher.GetShared(userClaims.ID, inputQuery.Search, pageParams)
+	if err != nil {
+		return fmt.Errorf("list shared: %w", err)
+	}
+	output := &listSharedTaskOut{
+		Data:       taskList,
+		Pagination: pageParams,
+	}
+	return ctx.Status(fiber.StatusOK).JSON(output)
+}
+
+// @summary		Копирование задания в библиотеку. [Только преподаватель]
+// @description	Создание копии своего задания или задания, которым поделился другой преподаватель. Копируются название, описание, тип, файлы (файлы не дублируются), вопросы теста и автотесты.
+// @description	Копия попадает в библиотеку преподавателя без учеников.
+// @router			/task/{id}/clone [post]
+// @id				task-clone
+// @tags			task
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id				path		int				true	"ID задания"
+// @param			cloneTaskBody	body		cloneTaskBody	false	"cloneTaskBody"
+// @success		201				{object}	entity.Task
+// @failure		401				"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403				"доступ запрещён"
+// @failure		404				"задание не найдено"
+func (c *TaskControllerTeacher) Clone(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &taskIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputBody := &cloneTaskBody{}
+	// body is optional
+	if len(ctx.Body()) != 0 {
+		if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+			return err
+		}
+	}
+
+	taskObj, err := c.taskUCTeacher.Clone(userClaims.ID, inputPath.ID, inputBody.Title)
+	if errors.Is(err, task.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "задание не найдено",
+		}
+	}
+	if errors.Is(err, task.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("clone: %w", err)
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(taskObj)
+}
+
+// @summary		Выдача задания ученикам. [Только преподаватель]
+// @description	Выдача своего задания переданным ученикам и ученикам из переданных групп (создание решений).
+// @description	Ученики, которым задание уже выдано, пропускаются.
+// @description	Задание привязывается к переданным группам: ученикам, добавленным в группу позже, оно выдаётся автоматически.
+// @router			/task/{id}/assign [post]
+// @id				task-assign
+// @tags			task
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id				path		int				true	"ID задания"
+// @param			assignTaskBody	body		assignTaskBody	true	"assignTaskBody"
+// @success		200				{object}	entity.TaskWithStudents
+// @failure		400				"неверный ученик | группа не найдена"
+// @failure		401				"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403				"доступ запрещён"
+// @failure		404				"задание не найдено"
+func (c *TaskControllerTeacher) Assign(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &taskIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputBody := &assignTaskBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	taskObj, students, err := c.taskUCTeacher.Assign(userClaims.ID, inputPath.ID,
+		inputBody.StudentIDs, inputBody.ClassIDs)
+	if errors.Is(err, task.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "задание не найдено",
+		}
+	}
+	if errors.Is(err, task.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, task.ErrInvalidStudent) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "неверный ученик",
+		}
+	}
+	if errors.Is(err, task.ErrNotFoundClass) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "группа не найдена",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("assign: %w", err)
+	}
+
+	res := &entity.TaskWithStudents{
+		Task:     taskObj,
+		Students: students,
+	}
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+// @summary		Получение списка преподавателей, с которыми поделились заданием. [Только преподаватель]
+// @description	Получение списка преподавателей (ID и полное имя), которым доступно своё задание.
+// @router			/task/{id}/share [get]
+// @id				task-read-share
+// @tags			task
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id	path		int	true	"ID задания"
+// @success		200	{object}	shareTaskOut
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+// @failure		404	"задание не найдено"
+func (c *TaskControllerTeacher) ReadShare(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &taskIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	teachers, err := c.taskUCTeacher.GetShares(userClaims.ID, inputPath.ID)
+	if errors.Is(err, task.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "задание не найдено",
+		}
+	}
+	if errors.Is(err, task.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("read share: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(&shareTaskOut{Teachers: teachers})
+}
+
+// @summary		Изменение списка преподавателей, с которыми поделились заданием. [Только преподаватель]
+// @description	Замена списка преподавателей, которым доступно своё задание (для просмотра и копирования в свою библиотеку).
+// @description	Пустой список закрывает доступ всем преподавателям.
+// @router			/task/{id}/share [put]
+// @id				task-update-share
+// @tags			task
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id				path		int				true	"ID задания"
+// @param			shareTaskBody	body		shareTaskBody	true	"shareTaskBody"
+// @success		200				{object}	shareTaskOut
+// @failure		400				"неверный преподаватель | преподаватель не найден"
+// @failure		401				"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403				"доступ запрещён"
+// @failure		404				"задание не найдено"
+func (c *TaskControllerTeacher) UpdateShare(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &taskIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputBody := &shareTaskBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	teachers, err := c.taskUCTeacher.Share(userClaims.ID, inputPath.ID, inputBody.TeacherIDs)
+	if errors.Is(err, task.ErrNotFoundUser) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "преподаватель не найден",
+		}
+	}
+	if errors.Is(err, task.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "задание не найдено",
+		}
+	}
+	if errors.Is(err, task.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, task.ErrInvalidTeacher) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "неверный преподаватель",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("update share: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(&shareTaskOut{Teachers: teachers})
+}
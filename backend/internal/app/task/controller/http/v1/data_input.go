@@ -14,6 +14,16 @@ type taskBody struct {
 	ClassIDs []int `form:"classes" json:"classes,omitempty" validate:"omitempty" example:"3,6,9"`
 	// students for task solutions
 	StudentIDs []int `form:"students" json:"students,omitempty" validate:"omitempty" example:"22,32,14"`
+	// IDs of completed chunked uploads to attach as task files
+	Uploads []string `form:"uploads" json:"uploads,omitempty" validate:"omitempty,dive,uuid"`
+	// true to create a draft (students do not see it until publication)
+	Draft bool `form:"draft" json:"draft,omitempty" validate:"omitempty" example:"true"`
+	// datetime to publish the draft automatically (RFC 3339)
+	PublishAt *string `form:"publish_at" json:"publish_at,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2026-09-01T09:00:00+03:00"`
+	// datetime students have to send solutions before (RFC 3339)
+	Deadline *string `form:"deadline" json:"deadline,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2026-09-15T23:59:00+03:00"`
+	// task type (assignment by default)
+	Type entity.TaskType `form:"type" json:"type,omitempty" validate:"omitempty,oneof=assignment quiz" example:"quiz" enums:"assignment,quiz"`
 }
 
 // @description taskIDPath represents a data with task ID in path params.
@@ -32,12 +42,48 @@ type updateTaskBody struct {
 	Students []int `form:"students" json:"students,omitempty" validate:"omitempty"`
 	// IDs of files to delete from the task
 	DelFiles []int `form:"delete_files" json:"delete_files,omitempty" validate:"omitempty"`
+	// IDs of completed chunked uploads to attach as new task files
+	Uploads []string `form:"uploads" json:"uploads,omitempty" validate:"omitempty,dive,uuid"`
+	// new datetime to publish the draft automatically (RFC 3339)
+	PublishAt *string `form:"publish_at" json:"publish_at,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2026-09-01T09:00:00+03:00"`
+	// new datetime students have to send solutions before (RFC 3339)
+	Deadline *string `form:"deadline" json:"deadline,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2026-09-15T23:59:00+03:00"`
 }
 
 // @description listTaskQuery represents a data with optional query-params to get tasks list.
 type listTaskQuery struct {
 	// substring to filter data by substring (case-insensitive)
 	Search string `query:"search,omitempty" json:"search" example:"F26"`
+	// true to get library tasks only (tasks without solutions)
+	Library bool `query:"library,omitempty" json:"library" example:"true"`
 	// pagination params
 	entity.PaginationQuery
 }
+
+// @description listSharedTaskQuery represents a data with optional query-params to get shared tasks list.
+type listSharedTaskQuery struct {
+	// substring to filter data by substring (case-insensitive)
+	Search string `query:"search,omitempty" json:"search" example:"F26"`
+	// pagination params
+	entity.PaginationQuery
+}
+
+// @description cloneTaskBody represents a data with optional body to clone task.
+type cloneTaskBody struct {
+	// title of the clone (title of the source task by default)
+	Title *string `json:"title,omitempty" validate:"omitempty,max=100" example:"ООП в Python (копия)" maxLength:"100"`
+}
+
+// @description assignTaskBody represents a data to assign task to students.
+type assignTaskBody struct {
+	// classes for task solutions
+	ClassIDs []int `json:"classes,omitempty" validate:"required_without=StudentIDs" example:"3,6,9"`
+	// students for task solutions
+	StudentIDs []int `json:"students,omitempty" validate:"required_without=ClassIDs" example:"22,32,14"`
+}
+
+// @description shareTaskBody represents a data with teachers to share task with.
+type shareTaskBody struct {
+	// IDs of teachers (updated list) the task is shared with
+	TeacherIDs []int `json:"teachers" validate:"omitempty" example:"4,7"`
+}
@@ -19,3 +19,17 @@ type listTaskOut struct {
 	// pagination params
 	Pagination *entity.Pagination `json:"pagination,omitempty" validate:"omitempty"`
 }
+
+// @description listSharedTaskOut represents a shared task list data.
+type listSharedTaskOut struct {
+	// tasks list
+	Data []entity.Task `json:"data" validate:"required"`
+	// pagination params
+	Pagination *entity.Pagination `json:"pagination,omitempty" validate:"omitempty"`
+}
+
+// @description shareTaskOut represents a list of teachers the task is shared with.
+type shareTaskOut struct {
+	// teachers list
+	Teachers []entity.Profile `json:"teachers" validate:"required"`
+}
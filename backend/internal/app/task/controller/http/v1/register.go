@@ -18,7 +18,14 @@ func RegisterEndpoints(router fiber.Router, controllerTeacher *TaskControllerTea
 	authGroup := router.Group("/task", mwJWTAccess)
 	authGroup.Post("/", mwTeacherOnly, controllerTeacher.Create)
 	authGroup.Get("/", mwTeacherOnly, controllerTeacher.List)
+	authGroup.Get("/shared", mwTeacherOnly, controllerTeacher.ListShared)
 	authGroup.Get("/:id", mwTeacherOnly, controllerTeacher.Read)
+	authGroup.Get("/:id/solutions.zip", mwTeacherOnly, controllerTeacher.DownloadSolutions)
 	authGroup.Patch("/:id", mwTeacherOnly, controllerTeacher.Update)
 	authGroup.Delete("/:id", mwTeacherOnly, controllerTeacher.Delete)
+	authGroup.Post("/:id/publish", mwTeacherOnly, controllerTeacher.Publish)
+	authGroup.Post("/:id/clone", mwTeacherOnly, controllerTeacher.Clone)
+	authGroup.Post("/:id/assign", mwTeacherOnly, controllerTeacher.Assign)
+	authGroup.Get("/:id/share", mwTeacherOnly, controllerTeacher.ReadShare)
+	authGroup.Put("/:id/share", mwTeacherOnly, controllerTeacher.UpdateShare)
 }
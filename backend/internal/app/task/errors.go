@@ -3,10 +3,14 @@ package task
 import "errors"
 
 var (
-	ErrInvalidData    = errors.New("invalid data")     // code 400
-	ErrInvalidTeacher = errors.New("invalid teacher")  // code 400
-	ErrInvalidStudent = errors.New("invalid student")  // code 400
-	ErrForbidden      = errors.New("forbidden")        // code 403
-	ErrNotFoundUser   = errors.New("record not found") // code 404
-	ErrNotFound       = errors.New("record not found") // code 404
+	ErrInvalidData      = errors.New("invalid data")                 // code 400
+	ErrInvalidTeacher   = errors.New("invalid teacher")              // code 400
+	ErrInvalidStudent   = errors.New("invalid student")              // code 400
+	ErrInvalidPublishAt = errors.New("invalid publication datetime") // code 400
+	ErrInvalidDeadline  = errors.New("invalid deadline")             // code 400
+	ErrForbidden        = errors.New("forbidden")                    // code 403
+	ErrNotFoundUser     = errors.New("record not found")             // code 404
+	ErrNotFound         = errors.New("record not found")             // code 404
+	ErrNotFoundClass    = errors.New("record not found")             // code 400
+	ErrPublished        = errors.New("task is already published")    // code 409
 )
@@ -1,21 +1,66 @@
 package task
 
-import "skadi/backend/internal/app/entity"
+import (
+	"time"
+
+	"skadi/backend/internal/app/entity"
+)
 
 // RepositoryDB describes all DB methods for task and solution objects.
 type RepositoryDB interface {
 	// CreateForStudents create a new task and empty solutions for every student.
-	CreateForStudents(taskObj *entity.Task, students []entity.Profile) ([]entity.Solution, error)
+	// The task is linked to the given classes to issue it for students joined them later.
+	CreateForStudents(taskObj *entity.Task, students []entity.Profile,
+		classIDs []int) ([]entity.Solution, error)
 	// GetByID returns task info by the given ID.
 	GetByID(id int) (*entity.Task, error)
 	// Update updates the given task by given ID with the new data.
 	Update(taskID int, newData *entity.TaskUpdate) error
-	// Delete deletes task and task files.
-	Delete(taskObj *entity.Task) error
+	// SetDescHTML sets the task description rendered to HTML.
+	SetDescHTML(taskID int, descHTML string) error
+	// GetUnrendered returns tasks (with files) which description is not rendered to HTML yet.
+	GetUnrendered(limit int) ([]entity.Task, error)
+	// Delete deletes task and task files not linked to other tasks.
+	// It returns deleted files to remove them from the file system.
+	Delete(taskObj *entity.Task) (entity.Files, error)
 
 	// GetMany returns all teacher tasks.
 	// Search param appends condition to filter tasks by title (substring).
-	GetMany(teacherID int, search string, page *entity.Pagination) ([]entity.Task, error)
+	// LibraryOnly param appends condition to filter tasks without solutions (library items).
+	GetMany(teacherID int, search string, libraryOnly bool,
+		page *entity.Pagination) ([]entity.Task, error)
 	// GetTaskStudents returns all students linked to the given task.
 	GetTaskStudents(taskID int) ([]entity.Profile, error)
+
+	// GetClassTasks returns tasks (without files) issued to the given class.
+	GetClassTasks(classID int) ([]entity.Task, error)
+	// WithdrawUnstarted deletes unstarted solutions of the given students
+	// for the given tasks (except tasks issued to the actual class of the student).
+	// It returns the number of deleted solutions.
+	WithdrawUnstarted(taskIDs, studentIDs []int) (int, error)
+
+	// Publish publishes the draft task (students can see it).
+	// It returns false if the task is not a draft (e.g. it was already published).
+	Publish(taskID int) (bool, error)
+	// PublishDue publishes all drafts with the publication datetime before the given time.
+	// It returns the number of published tasks.
+	PublishDue(now time.Time) (int, error)
+	// RemindExamsDue marks published exams closing before the given time as reminded
+	// and writes events to remind students who have not sent solutions yet.
+	// It returns the number of reminded exams.
+	RemindExamsDue(now, closesBefore time.Time) (int, error)
+	// RemindDeadlinesDue marks published tasks with the deadline before the given time
+	// as reminded and writes events to remind students who have not sent solutions yet.
+	// It returns the number of reminded tasks.
+	RemindDeadlinesDue(now, dueBefore time.Time) (int, error)
+
+	// GetShared returns tasks shared with the teacher by other teachers (with owner profiles).
+	// Search param appends condition to filter tasks by title (substring).
+	GetShared(teacherID int, search string, page *entity.Pagination) ([]entity.Task, error)
+	// IsShared returns true if the task is shared with the teacher.
+	IsShared(taskID, teacherID int) (bool, error)
+	// GetShares returns profiles of teachers the task is shared with.
+	GetShares(taskID int) ([]entity.Profile, error)
+	// SetShares replaces the list of teachers the task is shared with.
+	SetShares(taskID int, teacherIDs []int) error
 }
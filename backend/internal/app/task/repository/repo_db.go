@@ -4,8 +4,11 @@ package repository
 import (
 	"errors"
 	"fmt"
+	goslices "slices"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"skadi/backend/internal/app/entity"
 	"skadi/backend/internal/app/task"
@@ -20,15 +23,25 @@ const (
 	_preloadStatus         = "Status"                   // object field name
 	_preloadFiles          = "Files"                    // object field name
 
-	_fieldID        = "id"          // table field name
-	_fieldFullname  = "fullname"    // table field name
-	_fieldTitle     = "title"       // table field name
-	_fieldDesc      = "description" // table field name
-	_fieldUpdatedAt = "updated_at"  // table field name
+	_preloadTeacherUser        = "TeacherUser"         // object field name
+	_preloadTeacherUserProfile = "TeacherUser.Profile" // object field name
+
+	_fieldID        = "id"               // table field name
+	_fieldFullname  = "fullname"         // table field name
+	_fieldTitle     = "title"            // table field name
+	_fieldDesc      = "description"      // table field name
+	_fieldUpdatedAt = "updated_at"       // table field name
+	_fieldTeacherID = "teacher_id"       // table field name
+	_fieldDraft     = "draft"            // table field name
+	_fieldDescHTML  = "description_html" // table field name
+
+	_fieldExamReminded     = "exam_reminded"     // table field name
+	_fieldDeadlineReminded = "deadline_reminded" // table field name
 
 	_orderByIDDESC = "id DESC" // condition to order data by id DESC
 
 	_defaultStatusID  = 1 // ID of default solution status "backlog"
+	_readyStatusID    = 3 // ID of solution status "ready"
 	_archivedStatusID = 4 // ID of archived solution status "checked"
 )
 
@@ -49,8 +62,9 @@ func NewRepoDB(dbStorage *gorm.DB) *RepoDB {
 }
 
 // CreateForStudents create a new task and empty solutions for every student.
-func (r *RepoDB) CreateForStudents(taskObj *entity.Task,
-	students []entity.Profile) ([]entity.Solution, error) {
+// The task is linked to the given classes to issue it for students joined them later.
+func (r *RepoDB) CreateForStudents(taskObj *entity.Task, students []entity.Profile,
+	classIDs []int) ([]entity.Solution, error) {
 
 	var (
 		solutions = make([]entity.Solution, len(students))
@@ -75,12 +89,9 @@ func (r *RepoDB) CreateForStudents(taskObj *entity.Task,
 			return fmt.Errorf("get default solution status: %w", err)
 		}
 
-		// finish transaction if no one student was given
-		if len(students) == 0 {
-			return nil
-		}
-
+		studentIDs := make([]int, len(students))
 		for idx := range students {
+			studentIDs[idx] = *students[idx].ID
 			solutions[idx] = entity.Solution{
 				TaskID:    taskObj.ID,
 				StudentID: *students[idx].ID,
@@ -89,8 +100,32 @@ func (r *RepoDB) CreateForStudents(taskObj *entity.Task,
 				Status:    statusObj,
 			}
 		}
-		if err = tx.Omit(_preloadStudent, _preloadStatus).Create(solutions).Error; err != nil {
-			return fmt.Errorf("solutions for students: %w", err)
+		if len(solutions) != 0 {
+			err = tx.Omit(_preloadStudent, _preloadStatus).Create(solutions).Error
+			if err != nil {
+				return fmt.Errorf("solutions for students: %w", err)
+			}
+			if err := r.createMembers(tx, solutions); err != nil {
+				return err
+			}
+		}
+		// link task to the classes
+		if err := r.linkClasses(tx, taskObj.ID, classIDs); err != nil {
+			return err
+		}
+
+		// write event to the outbox
+		evtObj, err := entity.NewOutboxEvent(entity.TopicTaskCreated, &entity.TaskCreatedEvent{
+			TaskID:     taskObj.ID,
+			TeacherID:  taskObj.TeacherID,
+			Title:      taskObj.Title,
+			StudentIDs: studentIDs,
+		})
+		if err != nil {
+			return fmt.Errorf("encode event: %w", err)
+		}
+		if err := tx.Create(evtObj).Error; err != nil {
+			return fmt.Errorf("write event: %w", err)
 		}
 		return nil
 	})
@@ -130,30 +165,54 @@ func (r *RepoDB) Update(taskID int, newData *entity.TaskUpdate) error {
 		if err := r.updateTaskSolutions(tx, taskID, newData); err != nil {
 			return err
 		}
-		return err
+		// link task to the classes
+		return r.linkClasses(tx, taskID, newData.AddClasses)
 	})
 }
 
-// Delete deletes task and task files.
-func (r *RepoDB) Delete(taskObj *entity.Task) error {
-	return r.dbStorage.Transaction(func(tx *gorm.DB) error {
+// SetDescHTML sets the task description rendered to HTML.
+func (r *RepoDB) SetDescHTML(taskID int, descHTML string) error {
+	return r.dbStorage.Model(&entity.Task{}).
+		Where(_fieldID+" = ?", taskID).
+		UpdateColumn(_fieldDescHTML, descHTML).Error // nil OR error
+}
+
+// GetUnrendered returns tasks (with files) which description is not rendered to HTML yet.
+func (r *RepoDB) GetUnrendered(limit int) ([]entity.Task, error) {
+	var taskList []entity.Task
+	err := r.dbStorage.Model(&entity.Task{}).
+		Preload(_preloadFiles).
+		Where(_fieldDescHTML + " IS NULL").
+		Order(_fieldID).
+		Limit(limit).
+		Find(&taskList).Error
+	return taskList, err // err OR nil
+}
+
+// Delete deletes task and task files not linked to other tasks.
+// It returns deleted files to remove them from the file system.
+func (r *RepoDB) Delete(taskObj *entity.Task) (entity.Files, error) {
+	var delFiles entity.Files
+	err := r.dbStorage.Transaction(func(tx *gorm.DB) error {
 		// delete task and task_file records
 		if err := tx.Delete(&entity.Task{}, taskObj.ID).Error; err != nil {
 			return err
 		}
 		// delete files
-		for _, file := range taskObj.Files {
-			if err := tx.Delete(&entity.File{}, file.ID).Error; err != nil {
-				return fmt.Errorf("delete file %d: %w", file.ID, err)
-			}
-		}
-		return nil
+		var err error
+		delFiles, err = r.deleteOrphanFiles(tx, taskObj.Files)
+		return err
 	})
+	if err != nil {
+		return nil, err
+	}
+	return delFiles, nil
 }
 
 // GetMany returns all teacher tasks.
 // Search param appends condition to filter tasks by title (substring).
-func (r *RepoDB) GetMany(teacherID int, search string,
+// LibraryOnly param appends condition to filter tasks without solutions (library items).
+func (r *RepoDB) GetMany(teacherID int, search string, libraryOnly bool,
 	page *entity.Pagination) ([]entity.Task, error) {
 
 	var taskList []entity.Task
@@ -163,6 +222,9 @@ func (r *RepoDB) GetMany(teacherID int, search string,
 	if search != "" {
 		query = query.Where("title REGEXP ?", search)
 	}
+	if libraryOnly {
+		query = query.Where("NOT EXISTS (SELECT 1 FROM solution WHERE solution.task_id = task.id)")
+	}
 	query = query.Order(_orderByIDDESC)
 	// apply pagination if it's not nil
 	if page != nil {
@@ -176,26 +238,320 @@ func (r *RepoDB) GetMany(teacherID int, search string,
 	return taskList, nil
 }
 
-// GetTaskStudents returns all students linked to the given task.
+// Publish publishes the draft task (students can see it).
+// It returns false if the task is not a draft (e.g. it was already published).
+// Event to notify the task students is written to the outbox.
+func (r *RepoDB) Publish(taskID int) (bool, error) {
+	var published bool
+	err := r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		var taskObj entity.Task
+		err := tx.Select(_fieldID, _fieldTeacherID).
+			Where(taskID).First(&taskObj).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("task with id: %w", task.ErrNotFound)
+		}
+		if err != nil {
+			return err
+		}
+		// the draft could be published concurrently (by teacher or by job)
+		res := tx.Model(&entity.Task{}).
+			Where("id = ? AND draft", taskID).
+			Update(_fieldDraft, false)
+		if res.Error != nil {
+			return fmt.Errorf("update draft: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+
+		var studentIDs []int
+		err = tx.Model(&entity.SolutionMember{}).
+			Where("task_id = ?", taskID).
+			Pluck("student_id", &studentIDs).Error
+		if err != nil {
+			return fmt.Errorf("get task students: %w", err)
+		}
+		// write event to notify students
+		evtObj, err := entity.NewOutboxEvent(entity.TopicTaskPublished, &entity.TaskPublishedEvent{
+			TaskID:     taskID,
+			TeacherID:  taskObj.TeacherID,
+			StudentIDs: studentIDs,
+		})
+		if err != nil {
+			return fmt.Errorf("encode outbox event: %w", err)
+		}
+		if err := tx.Create(evtObj).Error; err != nil {
+			return fmt.Errorf("write outbox event: %w", err)
+		}
+		published = true
+		return nil
+	})
+	return published, err // err OR nil
+}
+
+// PublishDue publishes all drafts with the publication datetime before the given time.
+// It returns the number of published tasks.
+func (r *RepoDB) PublishDue(now time.Time) (int, error) {
+	var taskIDs []int
+	err := r.dbStorage.Model(&entity.Task{}).
+		Where("draft AND publish_at <= ?", now).
+		Order(_fieldID).
+		Pluck(_fieldID, &taskIDs).Error
+	if err != nil {
+		return 0, fmt.Errorf("get due drafts: %w", err)
+	}
+
+	var count int
+	for _, taskID := range taskIDs {
+		published, err := r.Publish(taskID)
+		if err != nil {
+			return count, fmt.Errorf("publish task %d: %w", taskID, err)
+		}
+		if published {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// RemindExamsDue marks published exams closing before the given time as reminded
+// and writes events to remind students who have not sent solutions yet.
+// It returns the number of reminded exams.
+func (r *RepoDB) RemindExamsDue(now, closesBefore time.Time) (int, error) {
+	var taskIDs []int
+	err := r.dbStorage.Model(&entity.Task{}).
+		Where("NOT draft AND NOT exam_reminded AND exam_duration IS NOT NULL").
+		Where("exam_closes_at > ? AND exam_closes_at <= ?", now, closesBefore).
+		Order(_fieldID).
+		Pluck(_fieldID, &taskIDs).Error
+	if err != nil {
+		return 0, fmt.Errorf("get closing exams: %w", err)
+	}
+	return r.remindTasks(taskIDs, _fieldExamReminded, func(taskID int, studentIDs []int) any {
+		return &entity.TaskExamClosingEvent{TaskID: taskID, StudentIDs: studentIDs}
+	}, entity.TopicTaskExamClosing)
+}
+
+// RemindDeadlinesDue marks published tasks with the deadline before the given time
+// as reminded and writes events to remind students who have not sent solutions yet.
+// It returns the number of reminded tasks.
+func (r *RepoDB) RemindDeadlinesDue(now, dueBefore time.Time) (int, error) {
+	var taskIDs []int
+	err := r.dbStorage.Model(&entity.Task{}).
+		Where("NOT draft AND NOT deadline_reminded").
+		Where("deadline > ? AND deadline <= ?", now, dueBefore).
+		Order(_fieldID).
+		Pluck(_fieldID, &taskIDs).Error
+	if err != nil {
+		return 0, fmt.Errorf("get tasks with deadlines: %w", err)
+	}
+	return r.remindTasks(taskIDs, _fieldDeadlineReminded, func(taskID int, studentIDs []int) any {
+		return &entity.TaskDeadlineEvent{TaskID: taskID, StudentIDs: studentIDs}
+	}, entity.TopicTaskDeadline)
+}
+
+// remindTasks sets the given reminded flag of the tasks and writes events (created
+// by the given function) to remind students who have not sent solutions yet.
+// It returns the number of reminded tasks.
+func (r *RepoDB) remindTasks(taskIDs []int, remindedField string,
+	newEvent func(taskID int, studentIDs []int) any, topic entity.OutboxTopic) (int, error) {
+
+	var count int
+	for _, taskID := range taskIDs {
+		var reminded bool
+		err := r.dbStorage.Transaction(func(tx *gorm.DB) error {
+			// the task could be reminded concurrently (by job on another instance)
+			res := tx.Model(&entity.Task{}).
+				Where(_fieldID+" = ? AND NOT "+remindedField, taskID).
+				Update(remindedField, true)
+			if res.Error != nil {
+				return fmt.Errorf("update reminded: %w", res.Error)
+			}
+			if res.RowsAffected == 0 {
+				return nil
+			}
+			reminded = true
+
+			var studentIDs []int
+			err := tx.Model(&entity.SolutionMember{}).
+				Joins("INNER JOIN solution ON solution.id = solution_member.solution_id").
+				Where("solution_member.task_id = ? AND solution.status_id < ?",
+					taskID, _readyStatusID).
+				Pluck("solution_member.student_id", &studentIDs).Error
+			if err != nil {
+				return fmt.Errorf("get task students: %w", err)
+			}
+			if len(studentIDs) == 0 {
+				return nil
+			}
+			// write event to notify students
+			evtObj, err := entity.NewOutboxEvent(topic, newEvent(taskID, studentIDs))
+			if err != nil {
+				return fmt.Errorf("encode outbox event: %w", err)
+			}
+			if err := tx.Create(evtObj).Error; err != nil {
+				return fmt.Errorf("write outbox event: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return count, fmt.Errorf("remind task %d: %w", taskID, err)
+		}
+		if reminded {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// GetShared returns tasks shared with the teacher by other teachers (with owner profiles).
+// Search param appends condition to filter tasks by title (substring).
+func (r *RepoDB) GetShared(teacherID int, search string,
+	page *entity.Pagination) ([]entity.Task, error) {
+
+	var taskList []entity.Task
+	query := r.dbStorage.Model(&entity.Task{}).
+		Preload(_preloadFiles).
+		Preload(_preloadTeacherUser).
+		Preload(_preloadTeacherUserProfile, func(db *gorm.DB) *gorm.DB {
+			return db.Select(_fieldID, _fieldFullname) // preload only ID and fullname
+		}).
+		Joins("INNER JOIN task_share ON task_share.task_id = task.id").
+		Where("task_share.teacher_id = ?", teacherID)
+	if search != "" {
+		query = query.Where("title REGEXP ?", search)
+	}
+	query = query.Order("task_share.created_at DESC")
+	// apply pagination if it's not nil
+	if page != nil {
+		page.CountTotal(query)
+		query = page.Query(query)
+	}
+	// exec query
+	if err := query.Find(&taskList).Error; err != nil {
+		return nil, err
+	}
+	// set teacher profiles
+	for idx := range taskList {
+		if taskList[idx].TeacherUser != nil {
+			taskList[idx].Teacher = taskList[idx].TeacherUser.Profile
+		}
+	}
+	return taskList, nil
+}
+
+// IsShared returns true if the task is shared with the teacher.
+func (r *RepoDB) IsShared(taskID, teacherID int) (bool, error) {
+	var count int64
+	err := r.dbStorage.Model(&entity.TaskShare{}).
+		Where("task_id = ? AND teacher_id = ?", taskID, teacherID).
+		Count(&count).Error
+	return count > 0, err // err OR nil
+}
+
+// GetShares returns profiles of teachers the task is shared with.
+func (r *RepoDB) GetShares(taskID int) ([]entity.Profile, error) {
+	profiles := make([]entity.Profile, 0)
+	err := r.dbStorage.Model(entity.Profile{}).
+		Select("profile.id", "profile.fullname").
+		Joins("INNER JOIN task_share ON task_share.teacher_id = profile.id").
+		Where("task_share.task_id = ?", taskID).
+		Order("profile.fullname").
+		Find(&profiles).Error
+	return profiles, err // err OR nil
+}
+
+// SetShares replaces the list of teachers the task is shared with.
+func (r *RepoDB) SetShares(taskID int, teacherIDs []int) error {
+	return r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		// delete teachers not in the new list
+		query := tx.Where("task_id = ?", taskID)
+		if len(teacherIDs) > 0 {
+			query = query.Where("teacher_id NOT IN ?", teacherIDs)
+		}
+		if err := query.Delete(&entity.TaskShare{}).Error; err != nil {
+			return fmt.Errorf("delete shares: %w", err)
+		}
+		if len(teacherIDs) == 0 {
+			return nil
+		}
+		// add new teachers (existing shares are kept)
+		shares := make([]entity.TaskShare, len(teacherIDs))
+		for idx, teacherID := range teacherIDs {
+			shares[idx] = entity.TaskShare{TaskID: taskID, TeacherID: teacherID}
+		}
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(shares).Error
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return fmt.Errorf("share: teacher: %w", task.ErrNotFoundUser)
+		}
+		if err != nil {
+			return fmt.Errorf("create shares: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetClassTasks returns tasks (without files) issued to the given class.
+func (r *RepoDB) GetClassTasks(classID int) ([]entity.Task, error) {
+	var taskList []entity.Task
+	err := r.dbStorage.Model(&entity.Task{}).
+		Joins("INNER JOIN task_class ON task_class.task_id = task.id").
+		Where("task_class.class_id = ?", classID).
+		Order("task.id").
+		Find(&taskList).Error
+	return taskList, err // err OR nil
+}
+
+// WithdrawUnstarted deletes unstarted solutions of the given students
+// for the given tasks. Solution is started if it has the answer,
+// files, grade, comments or not default status. Solutions for the tasks issued to
+// the actual class of the student are kept too. Team solutions are never withdrawn.
+// It returns the number of deleted solutions.
+func (r *RepoDB) WithdrawUnstarted(taskIDs, studentIDs []int) (int, error) {
+	if len(taskIDs) == 0 || len(studentIDs) == 0 {
+		return 0, nil
+	}
+	res := r.dbStorage.
+		Where("student_id IN ?", studentIDs).
+		Where("task_id IN ?", taskIDs).
+		Where("status_id = ? AND grade IS NULL AND (answer IS NULL OR answer = '')",
+			_defaultStatusID).
+		Where("NOT EXISTS (SELECT 1 FROM solution_file WHERE solution_file.solution_id = solution.id)").
+		Where("NOT EXISTS (SELECT 1 FROM comment WHERE comment.solution_id = solution.id)").
+		Where(`NOT EXISTS (SELECT 1 FROM solution_member
+			WHERE solution_member.solution_id = solution.id
+			AND solution_member.student_id <> solution.student_id)`).
+		Where(`NOT EXISTS (SELECT 1 FROM user
+			INNER JOIN task_class ON task_class.class_id = user.class_id
+			WHERE user.id = solution.student_id AND task_class.task_id = solution.task_id)`).
+		Delete(&entity.Solution{})
+	return int(res.RowsAffected), res.Error
+}
+
+// GetTaskStudents returns all students linked to the given task (including team members).
 func (r *RepoDB) GetTaskStudents(taskID int) ([]entity.Profile, error) {
 	profiles := make([]entity.Profile, 0)
 	err := r.dbStorage.Model(entity.Profile{}).
 		Select("profile.id", "profile.fullname").
 		Joins("INNER JOIN user ON user.id = profile.id").
-		Joins("INNER JOIN solution ON solution.student_id = profile.id").
-		Joins("INNER JOIN task ON task.id = solution.task_id").
-		Where("task.id = ?", taskID).Find(&profiles).Error
+		Joins("INNER JOIN solution_member ON solution_member.student_id = profile.id").
+		Where("solution_member.task_id = ?", taskID).Find(&profiles).Error
 	return profiles, err // err OR nil
 }
 
 // updateTaskFiles deletes old task files and creates new ones.
+// Old files linked to other tasks are only unlinked from the given task.
 func (r *RepoDB) updateTaskFiles(tx *gorm.DB, taskID int, newData *entity.TaskUpdate) error {
 	// delete old files
-	if len(newData.DelFilesIDs) > 0 {
-		err := tx.Where("id IN ?", newData.DelFilesIDs).
-			Delete(&entity.File{}).Error
+	if len(newData.DelFiles) > 0 {
+		err := tx.Model(&entity.Task{ID: taskID}).
+			Association("Files").Delete(newData.DelFiles)
+		if err != nil {
+			return fmt.Errorf("unlink files: %w", err)
+		}
+		newData.DelFiles, err = r.deleteOrphanFiles(tx, newData.DelFiles)
 		if err != nil {
-			return fmt.Errorf("delete files: %w", err)
+			return err
 		}
 	}
 
@@ -217,15 +573,85 @@ func (r *RepoDB) updateTaskFiles(tx *gorm.DB, taskID int, newData *entity.TaskUp
 	return nil
 }
 
+// deleteOrphanFiles deletes given files which are not linked to any task.
+// Files are shared between the task and its clones, so they are deleted with the last task.
+// It returns deleted files.
+func (r *RepoDB) deleteOrphanFiles(tx *gorm.DB, files entity.Files) (entity.Files, error) {
+	if len(files) == 0 {
+		return entity.Files{}, nil
+	}
+	fileIDs := make([]int, len(files))
+	for idx := range files {
+		fileIDs[idx] = files[idx].ID
+	}
+
+	var orphanIDs []int
+	err := tx.Model(&entity.File{}).
+		Where("id IN ?", fileIDs).
+		Where("NOT EXISTS (SELECT 1 FROM task_file WHERE task_file.file_id = file.id)").
+		Pluck(_fieldID, &orphanIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("get orphan files: %w", err)
+	}
+	if len(orphanIDs) == 0 {
+		return entity.Files{}, nil
+	}
+	if err := tx.Where("id IN ?", orphanIDs).Delete(&entity.File{}).Error; err != nil {
+		return nil, fmt.Errorf("delete files: %w", err)
+	}
+
+	delFiles := make(entity.Files, 0, len(orphanIDs))
+	for _, fileObj := range files {
+		if goslices.Contains(orphanIDs, fileObj.ID) {
+			delFiles = append(delFiles, fileObj)
+		}
+	}
+	return delFiles, nil
+}
+
+// linkClasses links the task to the given classes (existing links are kept).
+func (r *RepoDB) linkClasses(tx *gorm.DB, taskID int, classIDs []int) error {
+	if len(classIDs) == 0 {
+		return nil
+	}
+	links := make([]entity.TaskClass, len(classIDs))
+	for idx, classID := range classIDs {
+		links[idx] = entity.TaskClass{TaskID: taskID, ClassID: classID}
+	}
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(links).Error
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return fmt.Errorf("link classes: %w", task.ErrNotFoundClass)
+	}
+	if err != nil {
+		return fmt.Errorf("link classes: %w", err)
+	}
+	return nil
+}
+
 // updateTaskSolutions deletes old task solutions and creates new ones.
+// Deleted students leave their team solutions, the solutions without members are deleted.
 func (r *RepoDB) updateTaskSolutions(tx *gorm.DB, taskID int, newData *entity.TaskUpdate) error {
 	// delete old solutions
 	if len(newData.DelStudents) > 0 {
 		err := tx.Where("student_id IN ? AND task_id = ?", newData.DelStudents, taskID).
+			Delete(&entity.SolutionMember{}).Error
+		if err != nil {
+			return fmt.Errorf("delete members for students: %w", err)
+		}
+		err = tx.Where("task_id = ?", taskID).
+			Where("NOT EXISTS (SELECT 1 FROM solution_member WHERE solution_member.solution_id = solution.id)").
 			Delete(&entity.Solution{}).Error
 		if err != nil {
 			return fmt.Errorf("delete solutions for students: %w", err)
 		}
+		// pass team solutions of deleted students to the remaining members
+		err = tx.Model(&entity.Solution{}).
+			Where("student_id IN ? AND task_id = ?", newData.DelStudents, taskID).
+			Update("student_id", gorm.Expr("(SELECT MIN(solution_member.student_id) "+
+				"FROM solution_member WHERE solution_member.solution_id = solution.id)")).Error
+		if err != nil {
+			return fmt.Errorf("change owners of team solutions: %w", err)
+		}
 	}
 
 	// skip creating new solutions if add list is empty
@@ -244,5 +670,21 @@ func (r *RepoDB) updateTaskSolutions(tx *gorm.DB, taskID int, newData *entity.Ta
 	if err := tx.Omit(_preloadStudent, _preloadStatus).Create(solutions).Error; err != nil {
 		return fmt.Errorf("create solutions for students: %w", err)
 	}
+	return r.createMembers(tx, solutions)
+}
+
+// createMembers makes students the only members of their new solutions.
+func (r *RepoDB) createMembers(tx *gorm.DB, solutions []entity.Solution) error {
+	members := make([]entity.SolutionMember, len(solutions))
+	for idx := range solutions {
+		members[idx] = entity.SolutionMember{
+			SolutionID: solutions[idx].ID,
+			TaskID:     solutions[idx].TaskID,
+			StudentID:  solutions[idx].StudentID,
+		}
+	}
+	if err := tx.Omit(_preloadStudent).Create(members).Error; err != nil {
+		return fmt.Errorf("create solution members: %w", err)
+	}
 	return nil
 }
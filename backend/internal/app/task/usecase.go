@@ -1,6 +1,7 @@
 // Package task contains all repos, usecases and controllers for task.
 // Sub-package repo contains RepoDB implementation.
-// Sub-package usecase contains UsecaseTeacher implementation.
+// Sub-package usecase contains UsecaseTeacher, UsecasePublisher, UsecaseClassSync
+// and UsecaseRenderer implementations.
 package task
 
 import "skadi/backend/internal/app/entity"
@@ -9,6 +10,7 @@ import "skadi/backend/internal/app/entity"
 type UsecaseTeacher interface {
 	// CreateWithSolutions creates a new task and solutions
 	// for all given students and for all students linked to the given classes.
+	// The task is linked to the classes, so students joined them later get it too.
 	CreateWithSolutions(taskObj *entity.Task, studentIDs []int,
 		classIDs []int) ([]entity.Solution, error)
 	// GetByID returns a task object by the given id and
@@ -16,15 +18,73 @@ type UsecaseTeacher interface {
 	GetByID(teacherID, taskID int) (*entity.Task, []entity.Profile, error)
 	// Update updates the given task by given ID with the new data.
 	// It returns the updated task object and updated students linked to the task.
-	// Allows to update the title, desc, linked students and task files.
+	// Allows to update the title, desc, deadline, linked students and task files.
 	Update(teacherID, taskID int,
 		newData *entity.TaskUpdate) (*entity.Task, []entity.Profile, error)
 	// DeleteByID deletes task object by given ID.
 	DeleteByID(userID, taskID int) error
+	// Publish publishes the draft task by given ID immediately.
+	// Students are notified about the task by the outbox event handler.
+	Publish(teacherID, taskID int) (*entity.Task, error)
 
 	// GetMany returns all teacher tasks.
 	// Search param appends condition to filter tasks by title (substring).
+	// LibraryOnly param appends condition to filter tasks without solutions (library items).
 	// For each elem returns task object and list of students linked to it.
-	GetMany(teacherID int, search string,
+	GetMany(teacherID int, search string, libraryOnly bool,
 		page *entity.Pagination) ([]entity.TaskWithStudents, error)
+	// GetSolutions returns a task object by the given id and
+	// all its solutions with students, statuses and files.
+	GetSolutions(teacherID, taskID int) (*entity.Task, []entity.Solution, error)
+
+	// Assign issues the task solutions for the given students and for all students
+	// linked to the given classes. Students who already have the task solution are skipped.
+	// The task is linked to the classes, so students joined them later get it too.
+	// It returns the task object and all students linked to the task.
+	Assign(teacherID, taskID int, studentIDs []int,
+		classIDs []int) (*entity.Task, []entity.Profile, error)
+	// Clone creates a copy of the own or shared task in the teacher library
+	// (without students). Saved files are linked to the clone without duplicating,
+	// exam settings, quiz questions and autotest suite are copied.
+	// If title is not nil, it replaces the title of the clone.
+	Clone(teacherID, taskID int, title *string) (*entity.Task, error)
+	// GetShared returns tasks shared with the teacher by other teachers.
+	// Search param appends condition to filter tasks by title (substring).
+	GetShared(teacherID int, search string, page *entity.Pagination) ([]entity.Task, error)
+	// GetShares returns teachers the own task is shared with.
+	GetShares(teacherID, taskID int) ([]entity.Profile, error)
+	// Share replaces the list of teachers the own task is shared with.
+	// It returns the updated list of teachers.
+	Share(teacherID, taskID int, teacherIDs []int) ([]entity.Profile, error)
+}
+
+// UsecasePublisher describes usecases to notify students about published draft tasks,
+// closing exams and coming deadlines. It is used by the outbox event handler.
+type UsecasePublisher interface {
+	// HandlePublished notifies students of the published task.
+	// It handles the task.published outbox event.
+	HandlePublished(evtObj *entity.OutboxEvent) error
+	// HandleExamClosing reminds students that the task exam closes soon.
+	// It handles the task.exam_closing outbox event.
+	HandleExamClosing(evtObj *entity.OutboxEvent) error
+	// HandleDeadline reminds students that the task deadline is coming soon.
+	// It handles the task.deadline outbox event.
+	HandleDeadline(evtObj *entity.OutboxEvent) error
+}
+
+// UsecaseClassSync describes usecases to sync the class task solutions with the class members.
+// It is used by the outbox event handler.
+type UsecaseClassSync interface {
+	// HandleMembersChanged issues the class tasks for students joined the class and
+	// withdraws unstarted solutions of the class tasks from students left the class.
+	// It handles the class.members_changed outbox event.
+	HandleMembersChanged(evtObj *entity.OutboxEvent) error
+}
+
+// UsecaseRenderer describes usecases to render task descriptions from Markdown to HTML.
+// It is used by the scheduled background job.
+type UsecaseRenderer interface {
+	// RenderPending renders descriptions of tasks which are not rendered yet
+	// (e.g. tasks created before Markdown support). It returns the number of rendered tasks.
+	RenderPending() (int, error)
 }
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"fmt"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/task"
+	"skadi/backend/internal/pkg/markdown"
+)
+
+const _renderBatchSize = 100 // number of tasks rendered at once
+
+// Ensure UCRenderer implements interface.
+var _ task.UsecaseRenderer = (*UCRenderer)(nil)
+
+// UCRenderer represents a task usecase to render descriptions in background.
+// It implements the [task.UsecaseRenderer] interface.
+type UCRenderer struct {
+	cfg        *config.Config
+	taskRepoDB task.RepositoryDB
+	mdRenderer *markdown.Renderer
+}
+
+// NewUCRenderer returns a new instance of [UCRenderer].
+func NewUCRenderer(cfg *config.Config, taskRepoDB task.RepositoryDB) *UCRenderer {
+	return &UCRenderer{
+		cfg:        cfg,
+		taskRepoDB: taskRepoDB,
+		mdRenderer: markdown.NewRenderer(),
+	}
+}
+
+// RenderPending renders descriptions of tasks which are not rendered yet
+// (e.g. tasks created before Markdown support). It returns the number of rendered tasks.
+func (u *UCRenderer) RenderPending() (int, error) {
+	var count int
+	for {
+		taskList, err := u.taskRepoDB.GetUnrendered(_renderBatchSize)
+		if err != nil {
+			return count, fmt.Errorf("get tasks: %w", err)
+		}
+		for idx := range taskList {
+			if err := renderDesc(u.mdRenderer, u.taskRepoDB, &taskList[idx]); err != nil {
+				return count, fmt.Errorf("task %d: %w", taskList[idx].ID, err)
+			}
+			count++
+		}
+		if len(taskList) < _renderBatchSize {
+			return count, nil
+		}
+	}
+}
+
+// renderDesc renders the task description to HTML and saves it.
+// Images can refer to the task files by names or IDs.
+func renderDesc(mdRenderer *markdown.Renderer, taskRepoDB task.RepositoryDB,
+	taskObj *entity.Task) error {
+
+	descHTML, err := mdRenderer.Render(taskObj.Desc, taskObj.Files.ResolveImage)
+	if err != nil {
+		return fmt.Errorf("render description: %w", err)
+	}
+	if err := taskRepoDB.SetDescHTML(taskObj.ID, descHTML); err != nil {
+		return fmt.Errorf("save description: %w", err)
+	}
+	taskObj.DescHTML = &descHTML
+	return nil
+}
@@ -1,46 +1,86 @@
-// Package usecase contains task.UsecaseTeacher implementation.
+// Package usecase contains task.UsecaseTeacher and task.UsecaseRenderer implementations.
 package usecase
 
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	goslices "slices"
 	"sync"
+	"time"
 
 	"skadi/backend/config"
+	"skadi/backend/internal/app/autotest"
 	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/event"
+	"skadi/backend/internal/app/notification"
+	"skadi/backend/internal/app/quiz"
+	"skadi/backend/internal/app/solution"
 	"skadi/backend/internal/app/task"
 	"skadi/backend/internal/app/user"
+	"skadi/backend/internal/pkg/markdown"
 	"skadi/backend/internal/pkg/utils/slices"
 )
 
 // Ensure UCAdmin implements interfaces.
-var _ task.UsecaseTeacher = (*UCTeacher)(nil)
+var (
+	_ task.UsecaseTeacher   = (*UCTeacher)(nil)
+	_ task.UsecasePublisher = (*UCTeacher)(nil)
+	_ task.UsecaseClassSync = (*UCTeacher)(nil)
+)
 
 // UCTeacher represents a task usecase for teacher.
-// It implements the [task.UsecaseTeacher] interface.
+// It implements the [task.UsecaseTeacher], [task.UsecasePublisher]
+// and [task.UsecaseClassSync] interfaces.
 type UCTeacher struct {
 	cfg        *config.Config
 	taskRepoDB task.RepositoryDB
+	solRepoDB  solution.RepositoryDB
 	userRepoDB user.RepositoryDB
+	quizRepoDB quiz.RepositoryDB
+	testRepoDB autotest.RepositoryDB
+	evtPub     event.Publisher
+	notifier   notification.Notifier
+	mdRenderer *markdown.Renderer
 }
 
 // NewUCTeacher returns a new instance of [UCTeacher].
 func NewUCTeacher(cfg *config.Config, taskRepoDB task.RepositoryDB,
-	userRepoDB user.RepositoryDB) *UCTeacher {
+	solRepoDB solution.RepositoryDB, userRepoDB user.RepositoryDB,
+	quizRepoDB quiz.RepositoryDB, testRepoDB autotest.RepositoryDB,
+	evtPub event.Publisher, notifier notification.Notifier) *UCTeacher {
 
 	return &UCTeacher{
 		cfg:        cfg,
 		taskRepoDB: taskRepoDB,
+		solRepoDB:  solRepoDB,
 		userRepoDB: userRepoDB,
+		quizRepoDB: quizRepoDB,
+		testRepoDB: testRepoDB,
+		evtPub:     evtPub,
+		notifier:   notifier,
+		mdRenderer: markdown.NewRenderer(),
 	}
 }
 
 // CreateWithSolutions creates a new task and solutions
 // for all given students and for all students linked to the given classes.
+// The task is linked to the classes, so students joined them later get it too.
+// If the task is a draft, students are notified after its publication.
 func (u *UCTeacher) CreateWithSolutions(taskObj *entity.Task,
 	studentIDs []int, classIDs []int) ([]entity.Solution, error) {
 
+	// task with the publication datetime is a draft until this datetime
+	if taskObj.PublishAt != nil {
+		if !taskObj.PublishAt.After(time.Now()) {
+			return nil, fmt.Errorf("%w: must be in the future", task.ErrInvalidPublishAt)
+		}
+		taskObj.Draft = true
+	}
+	if taskObj.Deadline != nil && !taskObj.Deadline.After(time.Now()) {
+		return nil, fmt.Errorf("%w: must be in the future", task.ErrInvalidDeadline)
+	}
+
 	teacher, err := u.userRepoDB.GetByIDWithProfileShort(taskObj.TeacherID)
 	if err != nil {
 		return nil, fmt.Errorf("get teacher: %w", err)
@@ -48,44 +88,36 @@ func (u *UCTeacher) CreateWithSolutions(taskObj *entity.Task,
 	if !teacher.IsTeacher() {
 		return nil, fmt.Errorf("%w: user %d: not teacher", task.ErrInvalidTeacher, teacher.ID)
 	}
-
-	// get all users by student IDs
-	studentUsers, err := u.userRepoDB.GetManyWithProfilesShort(studentIDs)
+	// collect students by IDs and classes
+	studentProfiles, err := u.collectStudents(studentIDs, classIDs)
 	if err != nil {
-		return nil, fmt.Errorf("get students by ids: %w", err)
-	}
-	studentProfiles := make([]entity.Profile, 0, len(studentUsers))
-	// check user roles
-	for idx := range studentUsers {
-		if !studentUsers[idx].IsStudent() {
-			return nil, fmt.Errorf("%w: user %d: not student",
-				task.ErrInvalidStudent, studentUsers[idx].ID)
-		}
-		studentProfiles = append(studentProfiles, *studentUsers[idx].Profile)
-	}
-
-	var classStudents []entity.Profile
-	// get all students by class IDs
-	for _, classID := range classIDs {
-		classStudents, err = u.userRepoDB.GetProfilesShortByClass(classID)
-		if err != nil {
-			return nil, fmt.Errorf("get students: class %d: %w", classID, err)
-		}
-		studentProfiles = append(studentProfiles, classStudents...)
+		return nil, err
 	}
-	// delete duplicated students
-	studentProfiles = slices.DelDuplsFunc(studentProfiles, func(p entity.Profile) int {
-		return *p.ID
-	})
 
 	// set teacher profile
 	taskObj.TeacherUser = teacher
 	taskObj.Teacher = teacher.Profile
 	// create task and solutions for all collected students
-	solutions, err := u.taskRepoDB.CreateForStudents(taskObj, studentProfiles)
+	solutions, err := u.taskRepoDB.CreateForStudents(taskObj, studentProfiles,
+		slices.DelDupls(classIDs))
 	if err != nil {
 		return nil, fmt.Errorf("create task for students: %w", err)
 	}
+	// render description after creating files (images are resolved by file IDs)
+	if err := renderDesc(u.mdRenderer, u.taskRepoDB, taskObj); err != nil {
+		return nil, err
+	}
+	if taskObj.Draft {
+		return solutions, nil
+	}
+	// notify students about the new task
+	studentIDs = make([]int, len(solutions))
+	for idx := range solutions {
+		studentIDs[idx] = solutions[idx].StudentID
+	}
+	u.evtPub.Publish(&entity.Event{Type: entity.EventTaskAssigned, Data: taskObj.AssignedView()},
+		studentIDs...)
+	u.notifyAssigned(taskObj, studentIDs)
 	return solutions, nil
 }
 
@@ -118,7 +150,7 @@ func (u *UCTeacher) GetByID(teacherID, taskID int) (*entity.Task, []entity.Profi
 
 // Update updates the given task by given ID with the new data.
 // It returns the updated task object and updated students linked to the task.
-// Allows to update the title, desc, linked students and task files.
+// Allows to update the title, desc, deadline, linked students and task files.
 func (u *UCTeacher) Update(teacherID, taskID int,
 	newData *entity.TaskUpdate) (*entity.Task, []entity.Profile, error) {
 
@@ -130,6 +162,22 @@ func (u *UCTeacher) Update(teacherID, taskID int,
 	if teacherID != taskObj.TeacherID {
 		return nil, nil, fmt.Errorf("%w: user is not a task owner", task.ErrForbidden)
 	}
+	// publication datetime can be changed for drafts only
+	if newData.PublishAt != nil {
+		if !taskObj.Draft {
+			return nil, nil, fmt.Errorf("change publication datetime: %w", task.ErrPublished)
+		}
+		if !newData.PublishAt.After(time.Now()) {
+			return nil, nil, fmt.Errorf("%w: must be in the future", task.ErrInvalidPublishAt)
+		}
+		taskObj.PublishAt = newData.PublishAt
+	}
+	if newData.Deadline != nil {
+		if !newData.Deadline.After(time.Now()) {
+			return nil, nil, fmt.Errorf("%w: must be in the future", task.ErrInvalidDeadline)
+		}
+		taskObj.Deadline = newData.Deadline
+	}
 
 	newData.DelFiles = make(entity.Files, 0, len(newData.DelFilesIDs))
 	taskFilesRemains := make(entity.Files, 0, len(taskObj.Files))
@@ -169,9 +217,22 @@ func (u *UCTeacher) Update(teacherID, taskID int,
 	}
 	// append new files to task files
 	taskObj.Files = append(taskObj.Files, newData.AddFiles...)
-	// remove deleted files from file system
+	// remove deleted files from file system (files of the clones are kept)
 	newData.DelFiles.Cleanup()
+	// render description again (images may refer to added or deleted files)
+	if newData.Desc != nil || len(newData.AddFiles) > 0 || len(newData.DelFilesIDs) > 0 {
+		if err := renderDesc(u.mdRenderer, u.taskRepoDB, taskObj); err != nil {
+			return nil, nil, err
+		}
+	}
 
+	if taskObj.Draft {
+		return taskObj, students, nil
+	}
+	// notify added students about the new task
+	u.evtPub.Publish(&entity.Event{Type: entity.EventTaskAssigned, Data: taskObj.AssignedView()},
+		newData.AddStudents...)
+	u.notifyAssigned(taskObj, newData.AddStudents)
 	return taskObj, students, nil
 }
 
@@ -191,21 +252,216 @@ func (u *UCTeacher) DeleteByID(userID, taskID int) error {
 		return fmt.Errorf("%w: user (teacher) is not a task owner", task.ErrForbidden)
 	}
 	// delete task
-	if err := u.taskRepoDB.Delete(taskObj); err != nil {
+	delFiles, err := u.taskRepoDB.Delete(taskObj)
+	if err != nil {
 		return err
 	}
-	// delete files from the file system
-	taskObj.Files.Cleanup()
+	// delete files from the file system (files of the clones are kept)
+	delFiles.Cleanup()
+	return nil
+}
+
+// Publish publishes the draft task by given ID immediately.
+// Students are notified about the task by the outbox event handler.
+func (u *UCTeacher) Publish(teacherID, taskID int) (*entity.Task, error) {
+	// get task with files
+	taskObj, err := u.taskRepoDB.GetByID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("get task: %w", err)
+	}
+	if teacherID != taskObj.TeacherID {
+		return nil, fmt.Errorf("%w: user is not a task owner", task.ErrForbidden)
+	}
+	if !taskObj.Draft {
+		return nil, fmt.Errorf("publish: %w", task.ErrPublished)
+	}
+
+	published, err := u.taskRepoDB.Publish(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("publish: %w", err)
+	}
+	// the draft was published by the job in the meantime
+	if !published {
+		return nil, fmt.Errorf("publish: %w", task.ErrPublished)
+	}
+	taskObj.Draft = false
+	return taskObj, nil
+}
+
+// HandlePublished notifies students of the published task.
+// It handles the task.published outbox event.
+func (u *UCTeacher) HandlePublished(evtObj *entity.OutboxEvent) error {
+	var data entity.TaskPublishedEvent
+	if err := evtObj.Decode(&data); err != nil {
+		return fmt.Errorf("decode event: %w", err)
+	}
+	if len(data.StudentIDs) == 0 {
+		return nil
+	}
+
+	taskObj, err := u.taskRepoDB.GetByID(data.TaskID)
+	// task was deleted after publication
+	if errors.Is(err, task.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get task: %w", err)
+	}
+	// notify students about the new task
+	u.evtPub.Publish(&entity.Event{Type: entity.EventTaskAssigned, Data: taskObj.AssignedView()},
+		data.StudentIDs...)
+	u.notifyAssigned(taskObj, data.StudentIDs)
+	return nil
+}
+
+// HandleExamClosing reminds students that the task exam closes soon.
+// It handles the task.exam_closing outbox event.
+func (u *UCTeacher) HandleExamClosing(evtObj *entity.OutboxEvent) error {
+	var data entity.TaskExamClosingEvent
+	if err := evtObj.Decode(&data); err != nil {
+		return fmt.Errorf("decode event: %w", err)
+	}
+
+	taskObj, err := u.taskRepoDB.GetByID(data.TaskID)
+	// task was deleted before the reminder
+	if errors.Is(err, task.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get task: %w", err)
+	}
+	// exam was turned off or moved after the reminder
+	if taskObj.ExamDuration == nil || taskObj.ExamClosesAt == nil || !taskObj.ExamReminded {
+		return nil
+	}
+	u.notifier.Notify(&entity.Notification{
+		Type: entity.NotifyDeadlineApproaching,
+		Message: fmt.Sprintf("Экзамен по заданию «%s» закроется %s",
+			taskObj.Title, taskObj.ExamClosesAt.UTC().Format("02.01.2006 15:04 UTC")),
+		TaskID: &taskObj.ID,
+	}, data.StudentIDs...)
+	return nil
+}
+
+// HandleDeadline reminds students that the task deadline is coming soon.
+// It handles the task.deadline outbox event.
+func (u *UCTeacher) HandleDeadline(evtObj *entity.OutboxEvent) error {
+	var data entity.TaskDeadlineEvent
+	if err := evtObj.Decode(&data); err != nil {
+		return fmt.Errorf("decode event: %w", err)
+	}
+
+	taskObj, err := u.taskRepoDB.GetByID(data.TaskID)
+	// task was deleted before the reminder
+	if errors.Is(err, task.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get task: %w", err)
+	}
+	// deadline was removed or moved after the reminder
+	if taskObj.Deadline == nil || !taskObj.DeadlineReminded {
+		return nil
+	}
+	u.notifier.Notify(&entity.Notification{
+		Type: entity.NotifyDeadlineApproaching,
+		Message: fmt.Sprintf("Срок сдачи задания «%s» истекает %s",
+			taskObj.Title, taskObj.Deadline.UTC().Format("02.01.2006 15:04 UTC")),
+		TaskID: &taskObj.ID,
+	}, data.StudentIDs...)
+	return nil
+}
+
+// HandleMembersChanged issues the class tasks for students joined the class and
+// withdraws unstarted solutions of the class tasks from students left the class.
+// Both actions can be disabled in config. It handles the class.members_changed outbox event.
+func (u *UCTeacher) HandleMembersChanged(evtObj *entity.OutboxEvent) error {
+	var data entity.ClassMembersChangedEvent
+	if err := evtObj.Decode(&data); err != nil {
+		return fmt.Errorf("decode event: %w", err)
+	}
+
+	syncCfg := u.cfg.ClassSync
+	if syncCfg.WithdrawOnLeave && len(data.Left) > 0 {
+		// tasks of the deleted class are passed in the event
+		taskIDs := data.TaskIDs
+		if taskIDs == nil {
+			taskList, err := u.taskRepoDB.GetClassTasks(data.ClassID)
+			if err != nil {
+				return fmt.Errorf("get class tasks: %w", err)
+			}
+			for _, taskObj := range taskList {
+				taskIDs = append(taskIDs, taskObj.ID)
+			}
+		}
+		count, err := u.taskRepoDB.WithdrawUnstarted(taskIDs, data.Left)
+		if err != nil {
+			return fmt.Errorf("withdraw solutions: %w", err)
+		}
+		slog.Debug("withdraw class task solutions", "class_id", data.ClassID, "count", count)
+	}
+	if !syncCfg.AssignOnJoin || len(data.Joined) == 0 {
+		return nil
+	}
+
+	// skip students left the class before the event was handled
+	classStuds, err := u.userRepoDB.GetProfilesShortByClass(data.ClassID)
+	if err != nil {
+		return fmt.Errorf("get class students: %w", err)
+	}
+	joined := make([]int, 0, len(data.Joined))
+	for _, studID := range data.Joined {
+		if goslices.ContainsFunc(classStuds, func(p entity.Profile) bool { return *p.ID == studID }) {
+			joined = append(joined, studID)
+		}
+	}
+	if len(joined) == 0 {
+		return nil
+	}
+
+	taskList, err := u.taskRepoDB.GetClassTasks(data.ClassID)
+	if err != nil {
+		return fmt.Errorf("get class tasks: %w", err)
+	}
+	for idx := range taskList {
+		taskObj := &taskList[idx]
+		students, err := u.taskRepoDB.GetTaskStudents(taskObj.ID)
+		if err != nil {
+			return fmt.Errorf("task %d: get task students: %w", taskObj.ID, err)
+		}
+		// skip students already linked to the task
+		newData := &entity.TaskUpdate{}
+		for _, studID := range joined {
+			if !goslices.ContainsFunc(students, func(p entity.Profile) bool { return *p.ID == studID }) {
+				newData.AddStudents = append(newData.AddStudents, studID)
+			}
+		}
+		if len(newData.AddStudents) == 0 {
+			continue
+		}
+
+		if err := u.taskRepoDB.Update(taskObj.ID, newData); err != nil {
+			return fmt.Errorf("task %d: create solutions: %w", taskObj.ID, err)
+		}
+		// students of the draft are notified after its publication
+		if taskObj.Draft {
+			continue
+		}
+		u.evtPub.Publish(&entity.Event{Type: entity.EventTaskAssigned, Data: taskObj.AssignedView()},
+			newData.AddStudents...)
+		u.notifyAssigned(taskObj, newData.AddStudents)
+	}
 	return nil
 }
 
 // GetMany returns all teacher tasks.
 // Search param appends condition to filter tasks by title (substring).
-func (u *UCTeacher) GetMany(teacherID int, search string,
+// LibraryOnly param appends condition to filter tasks without solutions (library items).
+func (u *UCTeacher) GetMany(teacherID int, search string, libraryOnly bool,
 	page *entity.Pagination) ([]entity.TaskWithStudents, error) {
 
 	// get tasks
-	taskList, err := u.taskRepoDB.GetMany(teacherID, search, page)
+	taskList, err := u.taskRepoDB.GetMany(teacherID, search, libraryOnly, page)
 	if err != nil {
 		return nil, fmt.Errorf("get tasks: %w", err)
 	}
@@ -246,6 +502,265 @@ func (u *UCTeacher) GetMany(teacherID int, search string,
 	return res, nil
 }
 
+// GetSolutions returns a task object by the given id and
+// all its solutions with students, statuses and files.
+func (u *UCTeacher) GetSolutions(teacherID, taskID int) (*entity.Task, []entity.Solution, error) {
+	// get task
+	taskObj, err := u.taskRepoDB.GetByID(taskID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get task: %w", err)
+	}
+	if teacherID != taskObj.TeacherID {
+		return nil, nil, fmt.Errorf("%w: user is not a task owner", task.ErrForbidden)
+	}
+
+	// get solutions
+	solutions, err := u.solRepoDB.GetManyByTask(taskID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get task solutions: %w", err)
+	}
+	// skip files which cannot be downloaded (not scanned or infected)
+	for idx := range solutions {
+		solutions[idx].Files = solutions[idx].Files.Downloadable(u.cfg.Media.Scan.Enabled)
+	}
+	return taskObj, solutions, nil
+}
+
+// Assign issues the task solutions for the given students and for all students
+// linked to the given classes. Students who already have the task solution are skipped.
+// The task is linked to the classes, so students joined them later get it too.
+// It returns the task object and all students linked to the task.
+func (u *UCTeacher) Assign(teacherID, taskID int, studentIDs []int,
+	classIDs []int) (*entity.Task, []entity.Profile, error) {
+
+	// get task with files
+	taskObj, err := u.taskRepoDB.GetByID(taskID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get task: %w", err)
+	}
+	if teacherID != taskObj.TeacherID {
+		return nil, nil, fmt.Errorf("%w: user is not a task owner", task.ErrForbidden)
+	}
+
+	// collect students by IDs and classes
+	newStuds, err := u.collectStudents(studentIDs, classIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	students, err := u.taskRepoDB.GetTaskStudents(taskID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get task students: %w", err)
+	}
+	// skip students already linked to the task
+	newData := &entity.TaskUpdate{AddClasses: slices.DelDupls(classIDs)}
+	for _, stud := range newStuds {
+		if !goslices.ContainsFunc(students, func(p entity.Profile) bool { return *p.ID == *stud.ID }) {
+			newData.AddStudents = append(newData.AddStudents, *stud.ID)
+			students = append(students, stud)
+		}
+	}
+	if len(newData.AddStudents) == 0 && len(newData.AddClasses) == 0 {
+		return taskObj, students, nil
+	}
+
+	if err := u.taskRepoDB.Update(taskID, newData); err != nil {
+		return nil, nil, fmt.Errorf("create solutions: %w", err)
+	}
+	// students of the draft are notified after its publication
+	if taskObj.Draft || len(newData.AddStudents) == 0 {
+		return taskObj, students, nil
+	}
+	// notify added students about the new task
+	u.evtPub.Publish(&entity.Event{Type: entity.EventTaskAssigned, Data: taskObj.AssignedView()},
+		newData.AddStudents...)
+	u.notifyAssigned(taskObj, newData.AddStudents)
+	return taskObj, students, nil
+}
+
+// Clone creates a copy of the own or shared task in the teacher library
+// (without students). Title, description, type, exam settings, files, quiz questions
+// and autotest suite are copied, but the clone is linked to the same saved files (they are not duplicated).
+// If title is not nil, it replaces the title of the clone.
+func (u *UCTeacher) Clone(teacherID, taskID int, title *string) (*entity.Task, error) {
+	// get task with files
+	srcTask, err := u.taskRepoDB.GetByID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("get task: %w", err)
+	}
+	if err := u.readPermit(teacherID, srcTask); err != nil {
+		return nil, err
+	}
+
+	teacher, err := u.userRepoDB.GetByIDWithProfileShort(teacherID)
+	if err != nil {
+		return nil, fmt.Errorf("get teacher: %w", err)
+	}
+	taskObj := &entity.Task{
+		Title:        srcTask.Title,
+		Desc:         srcTask.Desc,
+		DescHTML:     srcTask.DescHTML,
+		TeacherID:    teacherID,
+		Type:         srcTask.Type,
+		ExamOpensAt:  srcTask.ExamOpensAt,
+		ExamClosesAt: srcTask.ExamClosesAt,
+		ExamDuration: srcTask.ExamDuration,
+		Files:        srcTask.Files,
+		TeacherUser:  teacher,
+		Teacher:      teacher.Profile,
+	}
+	if title != nil {
+		taskObj.Title = *title
+	}
+	// questions are created with the clone
+	if srcTask.Type == entity.TaskQuiz {
+		taskObj.Questions, err = u.quizRepoDB.GetQuestions(taskID)
+		if err != nil {
+			return nil, fmt.Errorf("get quiz questions: %w", err)
+		}
+		for idx := range taskObj.Questions {
+			taskObj.Questions[idx].ID = 0
+		}
+	}
+	// suite with cases is created with the clone too
+	taskObj.Suite, err = u.testRepoDB.GetSuite(taskID)
+	if err != nil && !errors.Is(err, autotest.ErrNotFoundSuite) {
+		return nil, fmt.Errorf("get autotest suite: %w", err)
+	}
+	if taskObj.Suite != nil {
+		taskObj.Suite.TaskID = 0
+		for idx := range taskObj.Suite.Cases {
+			taskObj.Suite.Cases[idx].ID = 0
+			taskObj.Suite.Cases[idx].TaskID = 0
+		}
+	}
+	if _, err := u.taskRepoDB.CreateForStudents(taskObj, nil, nil); err != nil {
+		return nil, fmt.Errorf("create clone: %w", err)
+	}
+	return taskObj, nil
+}
+
+// GetShared returns tasks shared with the teacher by other teachers.
+// Search param appends condition to filter tasks by title (substring).
+func (u *UCTeacher) GetShared(teacherID int, search string,
+	page *entity.Pagination) ([]entity.Task, error) {
+
+	taskList, err := u.taskRepoDB.GetShared(teacherID, search, page)
+	if err != nil {
+		return nil, fmt.Errorf("get shared tasks: %w", err)
+	}
+	// skip files which cannot be downloaded (not scanned or infected)
+	for idx := range taskList {
+		taskList[idx].Files = taskList[idx].Files.Downloadable(u.cfg.Media.Scan.Enabled)
+	}
+	return taskList, nil
+}
+
+// GetShares returns teachers the own task is shared with.
+func (u *UCTeacher) GetShares(teacherID, taskID int) ([]entity.Profile, error) {
+	taskObj, err := u.taskRepoDB.GetByID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("get task: %w", err)
+	}
+	if teacherID != taskObj.TeacherID {
+		return nil, fmt.Errorf("%w: user is not a task owner", task.ErrForbidden)
+	}
+	return u.taskRepoDB.GetShares(taskID)
+}
+
+// Share replaces the list of teachers the own task is shared with.
+// It returns the updated list of teachers.
+func (u *UCTeacher) Share(teacherID, taskID int, teacherIDs []int) ([]entity.Profile, error) {
+	taskObj, err := u.taskRepoDB.GetByID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("get task: %w", err)
+	}
+	if teacherID != taskObj.TeacherID {
+		return nil, fmt.Errorf("%w: user is not a task owner", task.ErrForbidden)
+	}
+
+	// check teachers
+	teacherIDs = slices.DelDupls(teacherIDs)
+	if goslices.Contains(teacherIDs, teacherID) {
+		return nil, fmt.Errorf("%w: task cannot be shared with its owner", task.ErrInvalidTeacher)
+	}
+	teachers, err := u.userRepoDB.GetManyWithProfilesShort(teacherIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get teachers by ids: %w", err)
+	}
+	if len(teachers) != len(teacherIDs) {
+		return nil, fmt.Errorf("share: teacher: %w", task.ErrNotFoundUser)
+	}
+	for idx := range teachers {
+		if !teachers[idx].IsTeacher() {
+			return nil, fmt.Errorf("%w: user %d: not teacher",
+				task.ErrInvalidTeacher, teachers[idx].ID)
+		}
+	}
+
+	if err := u.taskRepoDB.SetShares(taskID, teacherIDs); err != nil {
+		return nil, fmt.Errorf("set shares: %w", err)
+	}
+	return u.taskRepoDB.GetShares(taskID)
+}
+
+// readPermit returns nil error if the teacher is a task owner
+// or the task is shared with the teacher.
+func (u *UCTeacher) readPermit(teacherID int, taskObj *entity.Task) error {
+	if teacherID == taskObj.TeacherID {
+		return nil
+	}
+	shared, err := u.taskRepoDB.IsShared(taskObj.ID, teacherID)
+	if err != nil {
+		return fmt.Errorf("check share: %w", err)
+	}
+	if !shared {
+		return fmt.Errorf("%w: task is not shared with the user", task.ErrForbidden)
+	}
+	return nil
+}
+
+// collectStudents returns profiles of the given students and all students
+// linked to the given classes without duplicates.
+func (u *UCTeacher) collectStudents(studentIDs []int, classIDs []int) ([]entity.Profile, error) {
+	// get all users by student IDs
+	studentUsers, err := u.userRepoDB.GetManyWithProfilesShort(studentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get students by ids: %w", err)
+	}
+	studentProfiles := make([]entity.Profile, 0, len(studentUsers))
+	// check user roles
+	for idx := range studentUsers {
+		if !studentUsers[idx].IsStudent() {
+			return nil, fmt.Errorf("%w: user %d: not student",
+				task.ErrInvalidStudent, studentUsers[idx].ID)
+		}
+		studentProfiles = append(studentProfiles, *studentUsers[idx].Profile)
+	}
+
+	var classStudents []entity.Profile
+	// get all students by class IDs
+	for _, classID := range classIDs {
+		classStudents, err = u.userRepoDB.GetProfilesShortByClass(classID)
+		if err != nil {
+			return nil, fmt.Errorf("get students: class %d: %w", classID, err)
+		}
+		studentProfiles = append(studentProfiles, classStudents...)
+	}
+	// delete duplicated students
+	return slices.DelDuplsFunc(studentProfiles, func(p entity.Profile) int {
+		return *p.ID
+	}), nil
+}
+
+// notifyAssigned notifies students about the new task assigned to them.
+func (u *UCTeacher) notifyAssigned(taskObj *entity.Task, studentIDs []int) {
+	u.notifier.Notify(&entity.Notification{
+		Type:    entity.NotifyTaskAssigned,
+		Message: fmt.Sprintf("Новое задание «%s»", taskObj.Title),
+		TaskID:  &taskObj.ID,
+	}, studentIDs...)
+}
+
 // sepNewStudents separates students from new students list into add/delete (linked to task) lists.
 // It returns both result lists and new student profiles list.
 func (u *UCTeacher) sepNewStudents(taskID int, newStudIDs []int) (add []int, del []int,
@@ -0,0 +1,276 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/team"
+	"skadi/backend/internal/pkg/httperror"
+	"skadi/backend/internal/pkg/serialize"
+	utilsjwt "skadi/backend/internal/pkg/utils/jwt"
+	"skadi/backend/internal/pkg/validator"
+)
+
+// TeamControllerTeacher represents a controller for team routes accepted for teachers only.
+type TeamControllerTeacher struct {
+	valid         validator.Validator
+	teamUCTeacher team.UsecaseTeacher
+}
+
+// NewControllerTeacher returns a new instance of [TeamControllerTeacher].
+func NewControllerTeacher(teamUCTeacher team.UsecaseTeacher,
+	valid validator.Validator) *TeamControllerTeacher {
+
+	return &TeamControllerTeacher{
+		valid:         valid,
+		teamUCTeacher: teamUCTeacher,
+	}
+}
+
+// @summary		Получение команд задания. [Только преподаватель]
+// @description	Получение командных решений своего задания (решение, статус, оценка и участники с индивидуальными оценками).
+// @router			/task/{id}/team [get]
+// @id				team-list
+// @tags			team
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id	path	int	true	"ID задания"
+// @success		200	{array}	entity.Solution
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+// @failure		404	"задание не найдено"
+func (c *TeamControllerTeacher) List(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &taskIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	solList, err := c.teamUCTeacher.GetTeams(userClaims.ID, inputPath.ID)
+	if errors.Is(err, team.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "задание не найдено",
+		}
+	}
+	if errors.Is(err, team.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("list teams: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(solList)
+}
+
+// @summary		Создание команды. [Только преподаватель]
+// @description	Объединение решений учеников своего задания в одно командное решение. Решение первого ученика становится командным, решения остальных удаляются, поэтому они не должны быть начаты (статус "не начато" без ответа, файлов, оценки, комментариев, ответов теста и попытки экзамена).
+// @description	Командное решение может изменять любой участник, преподаватель оценивает его один раз (при необходимости с индивидуальными оценками участников).
+// @router			/task/{id}/team [post]
+// @id				team-create
+// @tags			team
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id			path		int			true	"ID задания"
+// @param			teamBody	body		teamBody	true	"teamBody"
+// @success		201			{object}	entity.Solution
+// @failure		400			"в команде должно быть не меньше двух учеников"
+// @failure		401			"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403			"доступ запрещён"
+// @failure		404			"задание не найдено или ученику не выдано задание"
+// @failure		409			"ученик уже в команде или решение ученика уже начато"
+func (c *TeamControllerTeacher) Create(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &taskIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputBody := &teamBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	solObj, err := c.teamUCTeacher.Create(userClaims.ID, inputPath.ID, inputBody.StudentIDs)
+	if errors.Is(err, team.ErrInvalidData) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "в команде должно быть не меньше двух учеников",
+		}
+	}
+	if errors.Is(err, team.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "задание не найдено",
+		}
+	}
+	if errors.Is(err, team.ErrNotFoundMember) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "ученику не выдано задание",
+		}
+	}
+	if errors.Is(err, team.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, team.ErrInTeam) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "ученик уже в команде",
+		}
+	}
+	if errors.Is(err, team.ErrStarted) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "решение ученика уже начато",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("create team: %w", err)
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(solObj)
+}
+
+// @summary		Исключение ученика из команды. [Только преподаватель]
+// @description	Исключение ученика из командного решения своего задания. Ученик получает новое индивидуальное решение, командное решение остаётся у остальных участников.
+// @router			/solution/{id}/team/{studentID} [delete]
+// @id				team-remove-member
+// @tags			team
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id			path	int	true	"ID решения"
+// @param			studentID	path	int	true	"ID ученика"
+// @success		204			"No Content"
+// @failure		401			"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403			"доступ запрещён"
+// @failure		404			"решение не найдено или ученик не участник решения"
+// @failure		409			"решение не командное"
+func (c *TeamControllerTeacher) RemoveMember(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &memberPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	err := c.teamUCTeacher.RemoveMember(userClaims.ID, inputPath.ID, inputPath.StudentID)
+	if errors.Is(err, team.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "решение не найдено",
+		}
+	}
+	if errors.Is(err, team.ErrNotFoundMember) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "ученик не участник решения",
+		}
+	}
+	if errors.Is(err, team.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, team.ErrNotTeam) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "решение не командное",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("remove member: %w", err)
+	}
+	return ctx.Status(fiber.StatusNoContent).JSON(nil)
+}
+
+// @summary		Индивидуальная оценка участника команды. [Только преподаватель]
+// @description	Установка оценки участника командного решения своего задания вместо оценки решения (null - сброс к оценке решения). Ученик видит только свою оценку.
+// @router			/solution/{id}/team/{studentID}/grade [put]
+// @id				team-set-grade
+// @tags			team
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id			path		int			true	"ID решения"
+// @param			studentID	path		int			true	"ID ученика"
+// @param			gradeBody	body		gradeBody	true	"gradeBody"
+// @success		200			{object}	entity.Solution
+// @failure		401			"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403			"доступ запрещён"
+// @failure		404			"решение не найдено или ученик не участник решения"
+// @failure		409			"решение не командное"
+func (c *TeamControllerTeacher) SetGrade(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &memberPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputBody := &gradeBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	solObj, err := c.teamUCTeacher.SetGrade(userClaims.ID, inputPath.ID, inputPath.StudentID,
+		inputBody.Grade)
+	if errors.Is(err, team.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "решение не найдено",
+		}
+	}
+	if errors.Is(err, team.ErrNotFoundMember) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "ученик не участник решения",
+		}
+	}
+	if errors.Is(err, team.ErrForbidden) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusForbidden,
+			Message:    "доступ запрещён",
+		}
+	}
+	if errors.Is(err, team.ErrNotTeam) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "решение не командное",
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("set member grade: %w", err)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(solObj)
+}
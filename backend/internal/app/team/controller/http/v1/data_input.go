@@ -0,0 +1,27 @@
+package v1
+
+// @description taskIDPath represents a data with task ID in path params.
+type taskIDPath struct {
+	// task id
+	ID int `params:"id" validate:"required" example:"2"`
+}
+
+// @description memberPath represents a data with solution ID and student ID in path params.
+type memberPath struct {
+	// solution id
+	ID int `params:"id" validate:"required" example:"14"`
+	// student id
+	StudentID int `params:"studentID" validate:"required" example:"22"`
+}
+
+// @description teamBody represents a data with students of the new team.
+type teamBody struct {
+	// IDs of students (solution of the first student becomes the team solution)
+	StudentIDs []int `json:"students" validate:"required,min=2,max=30,unique" example:"22,32,14" minItems:"2" maxItems:"30"`
+}
+
+// @description gradeBody represents a data with individual grade of the team member.
+type gradeBody struct {
+	// individual grade (null to reset it to the team solution grade)
+	Grade *string `json:"grade" validate:"omitempty,max=5" example:"5+" maxLength:"5"`
+}
@@ -0,0 +1,25 @@
+// Package http/v1 is a first version of team HTTP-controller.
+// It provides registers for team HTTP-routes and controllers with handlers for them.
+package v1
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/service/server/middleware"
+)
+
+// RegisterEndpoints registers all team endpoints.
+func RegisterEndpoints(router fiber.Router, controllerTeacher *TeamControllerTeacher,
+	mwJWTAccess fiber.Handler, mwAllow middleware.AllowFunc) {
+
+	mwTeacherOnly := mwAllow(entity.Teacher)
+
+	taskGroup := router.Group("/task/:id/team", mwJWTAccess, mwTeacherOnly)
+	taskGroup.Get("/", controllerTeacher.List)
+	taskGroup.Post("/", controllerTeacher.Create)
+
+	solGroup := router.Group("/solution/:id/team", mwJWTAccess, mwTeacherOnly)
+	solGroup.Delete("/:studentID", controllerTeacher.RemoveMember)
+	solGroup.Put("/:studentID/grade", controllerTeacher.SetGrade)
+}
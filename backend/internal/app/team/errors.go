@@ -0,0 +1,13 @@
+package team
+
+import "errors"
+
+var (
+	ErrInvalidData    = errors.New("invalid data")                    // code 400
+	ErrForbidden      = errors.New("forbidden")                       // code 403
+	ErrNotFound       = errors.New("record not found")                // code 404
+	ErrNotFoundMember = errors.New("student is not a member")         // code 404
+	ErrInTeam         = errors.New("student is already in a team")    // code 409
+	ErrStarted        = errors.New("solution is already started")     // code 409
+	ErrNotTeam        = errors.New("solution is not a team solution") // code 409
+)
@@ -0,0 +1,21 @@
+package team
+
+import "skadi/backend/internal/app/entity"
+
+// RepositoryDB describes all DB methods for team solutions.
+type RepositoryDB interface {
+	// GetTeams returns team solutions (with statuses and members) of the task.
+	GetTeams(taskID int) ([]entity.Solution, error)
+	// GetStudentSolutions returns the task solutions (with members) of the given students.
+	GetStudentSolutions(taskID int, studentIDs []int) ([]entity.Solution, error)
+	// Merge moves members of the given solutions to the team solution
+	// and deletes the given solutions. Started solutions cannot be merged.
+	Merge(teamSolID int, solIDs []int) error
+	// RemoveMember removes the student from the team solution (the owner is passed
+	// to the remaining member) and creates a new individual solution for the student.
+	// Individual grade of the last remaining member is reset. It returns the new solution.
+	RemoveMember(member *entity.SolutionMember) (*entity.Solution, error)
+	// SetGrade sets the individual grade of the team member (nil grade resets it).
+	// It writes the solution.updated event for the member.
+	SetGrade(member *entity.SolutionMember, grade *string) error
+}
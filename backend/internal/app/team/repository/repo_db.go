@@ -0,0 +1,237 @@
+// Package repository contains team.RepositoryDB implementation.
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/team"
+)
+
+const (
+	_preloadStatus        = "Status"                      // object field name
+	_preloadMembers       = "Members"                     // object field name
+	_preloadMemberStudent = "Members.StudentUser"         // object field name
+	_preloadMemberProfile = "Members.StudentUser.Profile" // object field name
+	_preloadStudent       = "StudentUser"                 // object field name
+
+	_fieldID         = "id"          // table field name
+	_fieldFullname   = "fullname"    // table field name
+	_fieldSolutionID = "solution_id" // table field name
+	_fieldStudentID  = "student_id"  // table field name
+	_fieldGrade      = "grade"       // table field name
+
+	_defaultStatusID = 1 // ID of default solution status "backlog"
+
+	// condition for solutions shared by several students
+	_condTeam = "(SELECT COUNT(*) FROM solution_member " +
+		"WHERE solution_member.solution_id = solution.id) > 1"
+	// condition for started solutions: solution has the answer, files, grade, comments,
+	// quiz answers, begun exam attempt or not default status (default status ID is a query param)
+	_condStarted = "status_id <> ? OR grade IS NOT NULL OR (answer IS NOT NULL AND answer <> '') " +
+		"OR EXISTS (SELECT 1 FROM solution_file WHERE solution_file.solution_id = solution.id) " +
+		"OR EXISTS (SELECT 1 FROM comment WHERE comment.solution_id = solution.id) " +
+		"OR EXISTS (SELECT 1 FROM quiz_answer WHERE quiz_answer.solution_id = solution.id) " +
+		"OR EXISTS (SELECT 1 FROM exam_attempt WHERE exam_attempt.solution_id = solution.id " +
+		"AND exam_attempt.started_at IS NOT NULL)"
+)
+
+// Ensure RepoDB implements interface.
+var _ team.RepositoryDB = (*RepoDB)(nil)
+
+// RepoDB is a team DB repo.
+// It implements the [team.RepositoryDB] interface.
+type RepoDB struct {
+	dbStorage *gorm.DB
+}
+
+// NewRepoDB returns a new instance of [RepoDB].
+func NewRepoDB(dbStorage *gorm.DB) *RepoDB {
+	return &RepoDB{
+		dbStorage: dbStorage,
+	}
+}
+
+// GetTeams returns team solutions (with statuses and members) of the task.
+func (r *RepoDB) GetTeams(taskID int) ([]entity.Solution, error) {
+	solList := make([]entity.Solution, 0)
+	err := r.withMembers(r.dbStorage.Model(&entity.Solution{})).
+		Omit("answer").
+		Preload(_preloadStatus).
+		Where("task_id = ?", taskID).
+		Where(_condTeam).
+		Order(_fieldID).
+		Find(&solList).Error
+	if err != nil {
+		return nil, err
+	}
+	for idx := range solList {
+		solList[idx].SetMemberProfiles()
+	}
+	return solList, nil
+}
+
+// GetStudentSolutions returns the task solutions (with members) of the given students.
+func (r *RepoDB) GetStudentSolutions(taskID int, studentIDs []int) ([]entity.Solution, error) {
+	solList := make([]entity.Solution, 0)
+	err := r.dbStorage.Model(&entity.Solution{}).
+		Omit("answer").
+		Preload(_preloadMembers).
+		Where("task_id = ?", taskID).
+		Where("id IN (SELECT solution_id FROM solution_member WHERE student_id IN ?)", studentIDs).
+		Order(_fieldID).
+		Find(&solList).Error
+	if err != nil {
+		return nil, err
+	}
+	return solList, nil
+}
+
+// Merge moves members of the given solutions to the team solution
+// and deletes the given solutions. Started solutions cannot be merged.
+func (r *RepoDB) Merge(teamSolID int, solIDs []int) error {
+	return r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		var startedIDs []int
+		err := tx.Model(&entity.Solution{}).
+			Where("id IN ?", solIDs).
+			Where(_condStarted, _defaultStatusID).
+			Pluck(_fieldID, &startedIDs).Error
+		if err != nil {
+			return fmt.Errorf("get started solutions: %w", err)
+		}
+		if len(startedIDs) > 0 {
+			return fmt.Errorf("solutions %v: %w", startedIDs, team.ErrStarted)
+		}
+
+		err = tx.Model(&entity.SolutionMember{}).
+			Where(_fieldSolutionID+" IN ?", solIDs).
+			Update(_fieldSolutionID, teamSolID).Error
+		if err != nil {
+			return fmt.Errorf("move members: %w", err)
+		}
+		if err := tx.Where("id IN ?", solIDs).Delete(&entity.Solution{}).Error; err != nil {
+			return fmt.Errorf("delete solutions: %w", err)
+		}
+		return nil
+	})
+}
+
+// RemoveMember removes the student from the team solution (the owner is passed
+// to the remaining member) and creates a new individual solution for the student.
+// Individual grade of the last remaining member is reset. It returns the new solution.
+func (r *RepoDB) RemoveMember(member *entity.SolutionMember) (*entity.Solution, error) {
+	solObj := &entity.Solution{
+		TaskID:    member.TaskID,
+		StudentID: member.StudentID,
+		StatusID:  _defaultStatusID,
+	}
+	err := r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		res := tx.Where(_fieldSolutionID+" = ? AND "+_fieldStudentID+" = ?",
+			member.SolutionID, member.StudentID).
+			Delete(&entity.SolutionMember{})
+		if res.Error != nil {
+			return fmt.Errorf("delete member: %w", res.Error)
+		}
+		// member was removed concurrently
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("solution %d: %w", member.SolutionID, team.ErrNotFoundMember)
+		}
+		// pass the team solution to the remaining member
+		err := tx.Model(&entity.Solution{}).
+			Where(_fieldID+" = ? AND "+_fieldStudentID+" = ?", member.SolutionID, member.StudentID).
+			Update(_fieldStudentID, gorm.Expr("(SELECT MIN(solution_member.student_id) "+
+				"FROM solution_member WHERE solution_member.solution_id = solution.id)")).Error
+		if err != nil {
+			return fmt.Errorf("change owner: %w", err)
+		}
+		// individual grades are reset if the only member remains (the solution grade is used)
+		var count int64
+		err = tx.Model(&entity.SolutionMember{}).
+			Where(_fieldSolutionID+" = ?", member.SolutionID).
+			Count(&count).Error
+		if err != nil {
+			return fmt.Errorf("count members: %w", err)
+		}
+		if count == 1 {
+			err = tx.Model(&entity.SolutionMember{}).
+				Where(_fieldSolutionID+" = ?", member.SolutionID).
+				Update(_fieldGrade, nil).Error
+			if err != nil {
+				return fmt.Errorf("reset member grade: %w", err)
+			}
+		}
+
+		if err := tx.Omit(_preloadStudent, _preloadStatus).Create(solObj).Error; err != nil {
+			return fmt.Errorf("create solution: %w", err)
+		}
+		err = tx.Omit(_preloadStudent).Create(&entity.SolutionMember{
+			SolutionID: solObj.ID,
+			TaskID:     solObj.TaskID,
+			StudentID:  solObj.StudentID,
+		}).Error
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("student %d: %w", member.StudentID, team.ErrInTeam)
+		}
+		if err != nil {
+			return fmt.Errorf("create solution member: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return solObj, nil
+}
+
+// SetGrade sets the individual grade of the team member (nil grade resets it).
+// It writes the solution.updated event for the member.
+func (r *RepoDB) SetGrade(member *entity.SolutionMember, grade *string) error {
+	return r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.SolutionMember{}).
+			Where(_fieldSolutionID+" = ? AND "+_fieldStudentID+" = ?",
+				member.SolutionID, member.StudentID).
+			Update(_fieldGrade, grade).Error
+		if err != nil {
+			return err
+		}
+
+		var solObj entity.Solution
+		err = tx.Select(_fieldID, "task_id", _fieldStudentID, "status_id").
+			First(&solObj, member.SolutionID).Error
+		if err != nil {
+			return fmt.Errorf("get solution: %w", err)
+		}
+		// write event to the outbox
+		evtObj, err := entity.NewOutboxEvent(entity.TopicSolutionUpdated, &entity.SolutionUpdatedEvent{
+			SolutionID:  solObj.ID,
+			TaskID:      solObj.TaskID,
+			StudentID:   solObj.StudentID,
+			StatusID:    solObj.StatusID,
+			OldStatusID: solObj.StatusID,
+			Grade:       grade,
+			StudentIDs:  []int{member.StudentID},
+			Graded:      grade != nil,
+		})
+		if err != nil {
+			return fmt.Errorf("encode event: %w", err)
+		}
+		if err := tx.Create(evtObj).Error; err != nil {
+			return fmt.Errorf("write event: %w", err)
+		}
+		return nil
+	})
+}
+
+// withMembers appends preloading of solution members with profiles (IDs and fullnames only).
+// Profiles are set to members by [entity.Solution.SetMemberProfiles].
+func (r *RepoDB) withMembers(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload(_preloadMembers).
+		Preload(_preloadMemberStudent).
+		Preload(_preloadMemberProfile, func(db *gorm.DB) *gorm.DB {
+			return db.Select(_fieldID, _fieldFullname) // preload only ID and fullname
+		})
+}
@@ -0,0 +1,22 @@
+// Package team contains all repos, usecases and controllers for team assignments
+// (one shared solution of the task belongs to several students).
+// Sub-package repo contains RepoDB implementation.
+// Sub-package usecase contains UsecaseTeacher implementation.
+package team
+
+import "skadi/backend/internal/app/entity"
+
+// UsecaseTeacher describes all team usecases for teacher.
+type UsecaseTeacher interface {
+	// GetTeams returns team solutions (with members) of the own task.
+	GetTeams(teacherID, taskID int) ([]entity.Solution, error)
+	// Create joins solutions of the given students of the own task into the one team solution.
+	// It returns the team solution with members.
+	Create(teacherID, taskID int, studentIDs []int) (*entity.Solution, error)
+	// RemoveMember removes the student from the team solution of the own task.
+	// The student gets a new individual solution.
+	RemoveMember(teacherID, solutionID, studID int) error
+	// SetGrade sets the individual grade of the team member instead of the solution grade
+	// (nil grade resets it). It returns the team solution with members.
+	SetGrade(teacherID, solutionID, studID int, grade *string) (*entity.Solution, error)
+}
@@ -0,0 +1,210 @@
+// Package usecase contains team.UsecaseTeacher implementation.
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/event"
+	"skadi/backend/internal/app/notification"
+	"skadi/backend/internal/app/solution"
+	"skadi/backend/internal/app/task"
+	"skadi/backend/internal/app/team"
+)
+
+// Ensure UCTeacher implements interfaces.
+var _ team.UsecaseTeacher = (*UCTeacher)(nil)
+
+// UCTeacher represents a team usecase for teacher.
+// It implements the [team.UsecaseTeacher] interface.
+type UCTeacher struct {
+	cfg        *config.Config
+	teamRepoDB team.RepositoryDB
+	taskRepoDB task.RepositoryDB
+	solRepoDB  solution.RepositoryDB
+	evtPub     event.Publisher
+	notifier   notification.Notifier
+}
+
+// NewUCTeacher returns a new instance of [UCTeacher].
+func NewUCTeacher(cfg *config.Config, teamRepoDB team.RepositoryDB,
+	taskRepoDB task.RepositoryDB, solRepoDB solution.RepositoryDB,
+	evtPub event.Publisher, notifier notification.Notifier) *UCTeacher {
+
+	return &UCTeacher{
+		cfg:        cfg,
+		teamRepoDB: teamRepoDB,
+		taskRepoDB: taskRepoDB,
+		solRepoDB:  solRepoDB,
+		evtPub:     evtPub,
+		notifier:   notifier,
+	}
+}
+
+// GetTeams returns team solutions (with members) of the own task.
+func (u *UCTeacher) GetTeams(teacherID, taskID int) ([]entity.Solution, error) {
+	if _, err := u.getOwnTask(teacherID, taskID); err != nil {
+		return nil, err
+	}
+	return u.teamRepoDB.GetTeams(taskID)
+}
+
+// Create joins solutions of the given students of the own task into the one team solution.
+// The solution of the first student becomes the team solution, solutions of other students
+// must not be started (they are deleted). Students of other teams cannot be joined.
+// It returns the team solution with members.
+func (u *UCTeacher) Create(teacherID, taskID int, studentIDs []int) (*entity.Solution, error) {
+	// skip repeated students (the first student order matters)
+	uniqueIDs := make([]int, 0, len(studentIDs))
+	for _, studID := range studentIDs {
+		if !slices.Contains(uniqueIDs, studID) {
+			uniqueIDs = append(uniqueIDs, studID)
+		}
+	}
+	studentIDs = uniqueIDs
+	if len(studentIDs) < 2 {
+		return nil, fmt.Errorf("%w: team must have at least two students", team.ErrInvalidData)
+	}
+	if _, err := u.getOwnTask(teacherID, taskID); err != nil {
+		return nil, err
+	}
+
+	solutions, err := u.teamRepoDB.GetStudentSolutions(taskID, studentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get student solutions: %w", err)
+	}
+	solByStudent := make(map[int]*entity.Solution, len(studentIDs))
+	for idx := range solutions {
+		for _, studID := range solutions[idx].MemberIDs() {
+			solByStudent[studID] = &solutions[idx]
+		}
+	}
+	otherSolIDs := make([]int, 0, len(studentIDs)-1)
+	for idx, studID := range studentIDs {
+		solObj, ok := solByStudent[studID]
+		if !ok {
+			return nil, fmt.Errorf("student %d: %w: task is not assigned",
+				studID, team.ErrNotFoundMember)
+		}
+		if solObj.IsTeam() {
+			return nil, fmt.Errorf("student %d: %w", studID, team.ErrInTeam)
+		}
+		if idx > 0 {
+			otherSolIDs = append(otherSolIDs, solObj.ID)
+		}
+	}
+	teamSolID := solByStudent[studentIDs[0]].ID
+	if err := u.teamRepoDB.Merge(teamSolID, otherSolIDs); err != nil {
+		return nil, fmt.Errorf("merge solutions: %w", err)
+	}
+
+	solObj, err := u.solRepoDB.GetByIDFull(teamSolID)
+	if err != nil {
+		return nil, fmt.Errorf("get team solution: %w", err)
+	}
+	// notify students about the team solution
+	event.PublishToMembers(u.evtPub, entity.EventSolutionUpdated, solObj, teacherID)
+	return solObj, nil
+}
+
+// RemoveMember removes the student from the team solution of the own task.
+// The student gets a new individual solution, the team solution is kept for other members
+// (the first remaining member becomes its owner if the owner was removed).
+func (u *UCTeacher) RemoveMember(teacherID, solutionID, studID int) error {
+	solObj, member, err := u.getOwnMember(teacherID, solutionID, studID)
+	if err != nil {
+		return err
+	}
+	if _, err := u.teamRepoDB.RemoveMember(member); err != nil {
+		return fmt.Errorf("remove member: %w", err)
+	}
+
+	// notify the student about the new individual solution
+	if !solObj.Task.Draft {
+		u.evtPub.Publish(&entity.Event{
+			Type: entity.EventTaskAssigned,
+			Data: solObj.Task.AssignedView(),
+		}, studID)
+	}
+	return nil
+}
+
+// SetGrade sets the individual grade of the team member instead of the solution grade
+// (nil grade resets it). It returns the team solution with members.
+func (u *UCTeacher) SetGrade(teacherID, solutionID, studID int,
+	grade *string) (*entity.Solution, error) {
+
+	_, member, err := u.getOwnMember(teacherID, solutionID, studID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.teamRepoDB.SetGrade(member, grade); err != nil {
+		return nil, fmt.Errorf("set grade: %w", err)
+	}
+
+	solObj, err := u.solRepoDB.GetByIDFull(solutionID)
+	if err != nil {
+		return nil, fmt.Errorf("get team solution: %w", err)
+	}
+	// notify the student about the individual grade
+	u.evtPub.Publish(&entity.Event{
+		Type: entity.EventSolutionUpdated,
+		Data: solObj.MemberView(studID),
+	}, studID)
+	if grade != nil {
+		u.notifier.Notify(&entity.Notification{
+			Type: entity.NotifySolutionChecked,
+			Message: fmt.Sprintf("Решение задания «%s» проверено, ваша оценка: %s",
+				solObj.Task.Title, *grade),
+			TaskID:     &solObj.TaskID,
+			SolutionID: &solObj.ID,
+		}, studID)
+	}
+	return solObj, nil
+}
+
+// getOwnTask returns the task if it exists and belongs to the teacher.
+func (u *UCTeacher) getOwnTask(teacherID, taskID int) (*entity.Task, error) {
+	taskObj, err := u.taskRepoDB.GetByID(taskID)
+	if errors.Is(err, task.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %w", team.ErrNotFound, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get task: %w", err)
+	}
+	if taskObj.TeacherID != teacherID {
+		return nil, fmt.Errorf("%w: user (teacher) is not a task owner", team.ErrForbidden)
+	}
+	return taskObj, nil
+}
+
+// getOwnMember returns the team solution (with task and members) of the own task
+// and its member.
+func (u *UCTeacher) getOwnMember(teacherID, solutionID,
+	studID int) (*entity.Solution, *entity.SolutionMember, error) {
+
+	solObj, err := u.solRepoDB.GetByID(solutionID)
+	if errors.Is(err, solution.ErrNotFound) {
+		return nil, nil, fmt.Errorf("%w: %w", team.ErrNotFound, err)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("get solution: %w", err)
+	}
+	if teacherID != solObj.Task.TeacherID {
+		return nil, nil, fmt.Errorf("%w: user (teacher) is not a task owner", team.ErrForbidden)
+	}
+	idx := slices.IndexFunc(solObj.Members, func(m entity.SolutionMember) bool {
+		return m.StudentID == studID
+	})
+	if idx == -1 {
+		return nil, nil, fmt.Errorf("solution %d: student %d: %w",
+			solutionID, studID, team.ErrNotFoundMember)
+	}
+	if !solObj.IsTeam() {
+		return nil, nil, fmt.Errorf("solution %d: %w", solutionID, team.ErrNotTeam)
+	}
+	return solObj, &solObj.Members[idx], nil
+}
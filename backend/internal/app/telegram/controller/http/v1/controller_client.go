@@ -0,0 +1,122 @@
+package v1
+
+import (
+	"errors"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/telegram"
+	"skadi/backend/internal/pkg/httperror"
+	"skadi/backend/internal/pkg/serialize"
+	utilsjwt "skadi/backend/internal/pkg/utils/jwt"
+	"skadi/backend/internal/pkg/validator"
+)
+
+// TelegramController represents a controller for the telegram routes.
+type TelegramController struct {
+	valid      validator.Validator
+	tgUCClient telegram.UsecaseClient
+}
+
+// NewController returns a new instance of [TelegramController].
+func NewController(tgUCClient telegram.UsecaseClient, valid validator.Validator) *TelegramController {
+	return &TelegramController{
+		valid:      valid,
+		tgUCClient: tgUCClient,
+	}
+}
+
+// @summary		Получение кода для привязки Telegram. [Преподаватель и ученик]
+// @description	Получение одноразового кода для привязки Telegram-чата к аккаунту текущего пользователя.
+// @description	Код нужно отправить боту командой "/start КОД" (или открыть ссылку link, если она есть).
+// @description	Ученик может получить код для чата родителя (for_parent): туда приходят новые задания и оценки.
+// @router			/user/me/telegram [post]
+// @id				telegram-new-code
+// @tags			telegram
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			newCodeBody	body		newCodeBody	true	"newCodeBody"
+// @success		201			{object}	entity.TelegramCode
+// @failure		400			"чат родителя доступен только ученикам"
+// @failure		401			"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403			"доступ запрещён"
+func (c *TelegramController) NewCode(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputBody := &newCodeBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	codeObj, err := c.tgUCClient.NewCode(userClaims, inputBody.ForParent)
+	if errors.Is(err, telegram.ErrInvalidData) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "чат родителя доступен только ученикам",
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(codeObj)
+}
+
+// @summary		Получение привязанных Telegram-чатов. [Преподаватель и ученик]
+// @description	Получение списка Telegram-чатов, привязанных к аккаунту текущего пользователя.
+// @router			/user/me/telegram [get]
+// @id				telegram-list-chats
+// @tags			telegram
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @success		200	{array}	entity.TelegramChat
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+func (c *TelegramController) ListChats(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	chats, err := c.tgUCClient.GetChats(userClaims.ID)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(chats)
+}
+
+// @summary		Отвязка Telegram-чата. [Преподаватель и ученик]
+// @description	Отвязка Telegram-чата от аккаунта текущего пользователя. Уведомления в чат больше не приходят.
+// @router			/user/me/telegram/{id} [delete]
+// @id				telegram-delete-chat
+// @tags			telegram
+// @accept			json
+// @security		JWTAccess
+// @param			id	path	int	true	"ID привязки чата"
+// @success		204	"чат отвязан"
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+// @failure		404	"чат не найден"
+func (c *TelegramController) DeleteChat(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &chatIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	err := c.tgUCClient.DeleteChat(userClaims.ID, inputPath.ID)
+	if errors.Is(err, telegram.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "чат не найден",
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusNoContent).JSON(nil)
+}
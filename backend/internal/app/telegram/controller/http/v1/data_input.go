@@ -0,0 +1,13 @@
+package v1
+
+// @description newCodeBody represents a data to get the link code.
+type newCodeBody struct {
+	// if true, the code links the chat of the student's parent (for students only)
+	ForParent bool `json:"for_parent" example:"false"`
+}
+
+// @description chatIDPath represents a data with telegram chat link ID in path params.
+type chatIDPath struct {
+	// chat link id
+	ID int `params:"id" validate:"required" example:"3"`
+}
@@ -0,0 +1,21 @@
+// Package http/v1 is a first version of telegram HTTP-controller.
+// It provides registers for telegram HTTP-routes and controller with handlers for them.
+package v1
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/service/server/middleware"
+)
+
+// RegisterEndpoints registers all telegram endpoints.
+// They are placed under the current user routes.
+func RegisterEndpoints(router fiber.Router, controller *TelegramController,
+	mwJWTAccess fiber.Handler, mwAllow middleware.AllowFunc) {
+
+	authGroup := router.Group("/user/me/telegram", mwJWTAccess, mwAllow(entity.Teacher, entity.Student))
+	authGroup.Post("/", controller.NewCode)
+	authGroup.Get("/", controller.ListChats)
+	authGroup.Delete("/:id", controller.DeleteChat)
+}
@@ -0,0 +1,8 @@
+package telegram
+
+import "errors"
+
+var (
+	ErrNotFound    = errors.New("record not found") // code 404
+	ErrInvalidData = errors.New("invalid data")     // code 400
+)
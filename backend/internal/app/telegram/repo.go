@@ -0,0 +1,39 @@
+package telegram
+
+import (
+	"time"
+
+	"skadi/backend/internal/app/entity"
+)
+
+// RepositoryDB describes all DB methods for telegram.
+type RepositoryDB interface {
+	// CreateChat links the chat with the user. Existing link is not changed.
+	CreateChat(chatObj *entity.TelegramChat) error
+	// GetChats returns all chats linked with the user.
+	GetChats(userID int) ([]entity.TelegramChat, error)
+	// GetChatsByChatID returns all links of the chat with users and their profiles.
+	GetChatsByChatID(chatID int64) ([]entity.TelegramChat, error)
+	// GetChatsByUserIDs returns all chats linked with the given users
+	// with users and their profiles.
+	GetChatsByUserIDs(userIDs []int) ([]entity.TelegramChat, error)
+	// DeleteChat deletes the user chat link by ID.
+	DeleteChat(userID, id int) error
+	// DeleteByChatID deletes all links of the chat.
+	DeleteByChatID(chatID int64) error
+
+	// GetOpenSolutions returns student solutions (drafts are skipped) which are not checked yet
+	// with tasks and statuses.
+	GetOpenSolutions(studentID int) ([]entity.Solution, error)
+	// GetSolutionsToCheck returns solutions of the teacher tasks waiting for the review
+	// with tasks and student profiles.
+	GetSolutionsToCheck(teacherID int) ([]entity.Solution, error)
+}
+
+// RepositoryCache describes all cache methods for telegram.
+type RepositoryCache interface {
+	// SetCode saves the one-time link code for the user expiring after exp duration.
+	SetCode(code string, userID int, isParent bool, exp time.Duration) error
+	// PopCode returns the user ID and the parent flag for the link code and deletes the code atomically.
+	PopCode(code string) (int, bool, error)
+}
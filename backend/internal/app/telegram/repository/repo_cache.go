@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/telegram"
+	"skadi/backend/internal/pkg/cache"
+)
+
+const (
+	_codePrefix = "telegram:code:" // key prefix for link code values
+	_parentFlag = ":parent"        // value suffix for the parent link codes
+)
+
+// Ensure RepoCache implements interface.
+var _ telegram.RepositoryCache = (*RepoCache)(nil)
+
+// RepoCache is a telegram cache repo.
+// It implements the [telegram.RepositoryCache] interface.
+type RepoCache struct {
+	cfg          *config.Config
+	cacheStorage cache.Storage
+}
+
+// NewRepoCache returns a new instance of [RepoCache].
+func NewRepoCache(cfg *config.Config, cacheStorage cache.Storage) *RepoCache {
+	return &RepoCache{
+		cfg:          cfg,
+		cacheStorage: cacheStorage,
+	}
+}
+
+// SetCode saves the one-time link code for the user expiring after exp duration.
+// Value is the user ID with the optional parent flag (like "12:parent").
+func (r *RepoCache) SetCode(code string, userID int, isParent bool, exp time.Duration) error {
+	val := strconv.Itoa(userID)
+	if isParent {
+		val += _parentFlag
+	}
+	if err := r.cacheStorage.Set(_codePrefix+code, []byte(val), exp); err != nil {
+		return fmt.Errorf("set to cache: %w", err)
+	}
+	return nil
+}
+
+// PopCode returns the user ID and the parent flag for the link code and deletes the code
+// atomically, so the code can be used once only. It returns telegram.ErrNotFound if the code does not exist or is expired.
+func (r *RepoCache) PopCode(code string) (int, bool, error) {
+	val, err := r.cacheStorage.Pop(_codePrefix + code)
+	if err != nil {
+		return 0, false, fmt.Errorf("pop from cache: %w", err)
+	}
+	if len(val) == 0 {
+		return 0, false, fmt.Errorf("link code: %w", telegram.ErrNotFound)
+	}
+
+	rawUserID, isParent := strings.CutSuffix(string(val), _parentFlag)
+	userID, err := strconv.Atoi(rawUserID)
+	if err != nil {
+		return 0, false, fmt.Errorf("parse link code value: %w", err)
+	}
+	return userID, isParent, nil
+}
@@ -0,0 +1,162 @@
+// Package repository contains telegram.RepositoryDB and telegram.RepositoryCache implementations.
+package repository
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/telegram"
+)
+
+const (
+	_preloadUserProfile    = "User.Profile"        // object field name
+	_preloadTask           = "Task"                // object field name
+	_preloadStatus         = "Status"              // object field name
+	_preloadStudentProfile = "StudentUser.Profile" // object field name
+
+	_fieldID     = "id"      // table field name
+	_fieldUserID = "user_id" // table field name
+	_fieldChatID = "chat_id" // table field name
+
+	_checkedStatusID = 4 // ID of solution status "checked"
+	_readyStatusID   = 3 // ID of solution status "ready"
+
+	// join the solution task (to filter by the task teacher)
+	_joinTask = "INNER JOIN task ON task.id = solution.task_id"
+	// join the membership of the student (student ID is a query param)
+	_joinMember = "INNER JOIN solution_member ON solution_member.solution_id = solution.id " +
+		"AND solution_member.student_id = ?"
+)
+
+// Ensure RepoDB implements interface.
+var _ telegram.RepositoryDB = (*RepoDB)(nil)
+
+// RepoDB is a telegram DB repo.
+// It implements the [telegram.RepositoryDB] interface.
+type RepoDB struct {
+	dbStorage *gorm.DB
+}
+
+// NewRepoDB returns a new instance of [RepoDB].
+func NewRepoDB(dbStorage *gorm.DB) *RepoDB {
+	return &RepoDB{
+		dbStorage: dbStorage,
+	}
+}
+
+// CreateChat links the chat with the user. Existing link is not changed.
+func (r *RepoDB) CreateChat(chatObj *entity.TelegramChat) error {
+	return r.dbStorage.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Omit(clause.Associations).
+		Create(chatObj).Error // nil OR error
+}
+
+// GetChats returns all chats linked with the user.
+func (r *RepoDB) GetChats(userID int) ([]entity.TelegramChat, error) {
+	chats := []entity.TelegramChat{}
+	err := r.dbStorage.
+		Where(_fieldUserID+" = ?", userID).
+		Order(_fieldID).
+		Find(&chats).Error
+	if err != nil {
+		return nil, err
+	}
+	return chats, nil
+}
+
+// GetChatsByChatID returns all links of the chat with users and their profiles.
+func (r *RepoDB) GetChatsByChatID(chatID int64) ([]entity.TelegramChat, error) {
+	chats := []entity.TelegramChat{}
+	err := r.dbStorage.
+		Preload(_preloadUserProfile).
+		Where(_fieldChatID+" = ?", chatID).
+		Order(_fieldID).
+		Find(&chats).Error
+	if err != nil {
+		return nil, err
+	}
+	return chats, nil
+}
+
+// GetChatsByUserIDs returns all chats linked with the given users
+// with users and their profiles.
+func (r *RepoDB) GetChatsByUserIDs(userIDs []int) ([]entity.TelegramChat, error) {
+	chats := []entity.TelegramChat{}
+	if len(userIDs) == 0 {
+		return chats, nil
+	}
+	err := r.dbStorage.
+		Preload(_preloadUserProfile).
+		Where(_fieldUserID+" IN ?", userIDs).
+		Order(_fieldID).
+		Find(&chats).Error
+	if err != nil {
+		return nil, err
+	}
+	return chats, nil
+}
+
+// DeleteChat deletes the user chat link by ID.
+func (r *RepoDB) DeleteChat(userID, id int) error {
+	result := r.dbStorage.
+		Where(_fieldUserID+" = ?", userID).
+		Delete(&entity.TelegramChat{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("telegram chat with id: %w", telegram.ErrNotFound)
+	}
+	return nil
+}
+
+// DeleteByChatID deletes all links of the chat.
+func (r *RepoDB) DeleteByChatID(chatID int64) error {
+	return r.dbStorage.
+		Where(_fieldChatID+" = ?", chatID).
+		Delete(&entity.TelegramChat{}).Error // nil OR error
+}
+
+// GetOpenSolutions returns student solutions (drafts are skipped) which are not checked yet
+// with tasks and statuses. Individual grades of the student replace the grades of team solutions.
+func (r *RepoDB) GetOpenSolutions(studentID int) ([]entity.Solution, error) {
+	solutions := []entity.Solution{}
+	err := r.dbStorage.
+		// the individual grade of the student replaces the solution one
+		Select("solution.id", "solution.task_id", "solution.student_id", "solution.status_id",
+			"solution.answer", "solution.updated_at",
+			"COALESCE(solution_member.grade, solution.grade) AS grade").
+		Preload(_preloadTask).
+		Preload(_preloadStatus).
+		Joins(_joinMember, studentID).
+		Where("solution.status_id <> ?", _checkedStatusID).
+		Where("solution.task_id NOT IN (SELECT id FROM task WHERE draft)"). // skip drafts
+		Order("solution.id").
+		Find(&solutions).Error
+	if err != nil {
+		return nil, err
+	}
+	return solutions, nil
+}
+
+// GetSolutionsToCheck returns solutions of the teacher tasks waiting for the review
+// with tasks and student profiles.
+func (r *RepoDB) GetSolutionsToCheck(teacherID int) ([]entity.Solution, error) {
+	solutions := []entity.Solution{}
+	err := r.dbStorage.
+		Preload(_preloadTask).
+		Preload(_preloadStudentProfile).
+		Joins(_joinTask).
+		Where("task.teacher_id = ?", teacherID).
+		Where("solution.status_id = ?", _readyStatusID).
+		Order("solution.updated_at").
+		Find(&solutions).Error
+	if err != nil {
+		return nil, err
+	}
+	return solutions, nil
+}
@@ -0,0 +1,35 @@
+// Package telegram contains all repos, usecases and controllers for telegram bot.
+// Sub-package repo contains RepoDB and RepoCache implementations.
+// Sub-package usecase contains UsecaseClient and UsecaseBot implementations.
+package telegram
+
+import "skadi/backend/internal/app/entity"
+
+// UsecaseClient describes all telegram usecases for teacher and student.
+type UsecaseClient interface {
+	// NewCode returns a new one-time code to link the chat with the user account.
+	// If forParent is true, the code links the chat of the student's parent.
+	NewCode(userClaims *entity.UserClaims, forParent bool) (*entity.TelegramCode, error)
+	// GetChats returns all chats linked with the user.
+	GetChats(userID int) ([]entity.TelegramChat, error)
+	// DeleteChat unlinks the user chat by link ID.
+	DeleteChat(userID, id int) error
+}
+
+// UsecaseBot describes usecases for the bot service.
+type UsecaseBot interface {
+	// HandleMessage handles the text message (command) from the chat
+	// and returns a reply text.
+	HandleMessage(chatID int64, text string) string
+	// Messages returns messages with the given notifications for all linked chats.
+	Messages(notifList []entity.Notification) ([]entity.TelegramMessage, error)
+	// ForgetChat unlinks the chat from all accounts (e.g. if the bot was blocked).
+	ForgetChat(chatID int64) error
+}
+
+// Queue describes a queue of notifications waiting for sending to the linked chats.
+// It is used by usecases of other domains.
+type Queue interface {
+	// Enqueue adds notifications to the queue. This method is non-blocking.
+	Enqueue(notifList []entity.Notification)
+}
@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	goslices "slices"
+	"strings"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/telegram"
+)
+
+const (
+	_cmdStart  = "/start"       // command to start the bot (with the link code from the link)
+	_cmdLink   = "/link"        // command to link the chat with the code
+	_cmdTasks  = "/tasks"       // command to get open tasks
+	_cmdUnlink = "/unlink"      // command to unlink the chat from all accounts
+	_textTasks = "мои задания"  // text alternative for the tasks command
+	_maxList   = 30             // max number of tasks in the list
+	_bullet    = "• "           // list item prefix
+	_noName    = "пользователь" // name for the user without profile
+	_noTitle   = "без названия" // title for the task without title
+	_noStatus  = "без статуса"  // name for the solution without status
+
+	_replyHelp = "Бот платформы Skadi присылает уведомления о новых заданиях, оценках и комментариях.\n\n" +
+		"Команды:\n" +
+		"/tasks — мои открытые задания\n" +
+		"/unlink — отвязать чат от аккаунтов Skadi\n" +
+		"/help — помощь\n\n" +
+		"Чтобы привязать аккаунт, получите код в профиле Skadi и отправьте: /start КОД"
+	_replyLinked       = "Аккаунт Skadi привязан. Уведомления будут приходить в этот чат."
+	_replyParentLinked = "Аккаунт ученика привязан. Сюда будут приходить новые задания и оценки."
+	_replyBadCode      = "Код не найден или устарел. Получите новый код в профиле Skadi."
+	_replyNotLinked    = "Чат не привязан к аккаунту Skadi. " +
+		"Получите код в профиле Skadi и отправьте: /start КОД"
+	_replyUnlinked = "Чат отвязан от аккаунтов Skadi. Уведомления больше не будут приходить."
+	_replyError    = "Что-то пошло не так. Попробуйте позже."
+)
+
+// notification types sent to the parent chats
+var _parentTypes = []entity.NotificationType{
+	entity.NotifyTaskAssigned,
+	entity.NotifySolutionChecked,
+}
+
+// Ensure UCBot implements interfaces.
+var _ telegram.UsecaseBot = (*UCBot)(nil)
+
+// UCBot represents a telegram usecase for the bot service.
+// It implements the [telegram.UsecaseBot] interface.
+type UCBot struct {
+	cfg         *config.Config
+	tgRepoDB    telegram.RepositoryDB
+	tgRepoCache telegram.RepositoryCache
+}
+
+// NewUCBot returns a new instance of [UCBot].
+func NewUCBot(cfg *config.Config, tgRepoDB telegram.RepositoryDB,
+	tgRepoCache telegram.RepositoryCache) *UCBot {
+
+	return &UCBot{
+		cfg:         cfg,
+		tgRepoDB:    tgRepoDB,
+		tgRepoCache: tgRepoCache,
+	}
+}
+
+// HandleMessage handles the text message (command) from the chat
+// and returns a reply text. Unknown commands get the help reply.
+func (u *UCBot) HandleMessage(chatID int64, text string) string {
+	args := strings.Fields(text)
+	if len(args) == 0 {
+		return _replyHelp
+	}
+	// commands in groups can be sent with the bot name (like /tasks@skadi_bot)
+	cmd, _, _ := strings.Cut(strings.ToLower(args[0]), "@")
+
+	var reply string
+	var err error
+	switch {
+	case (cmd == _cmdStart || cmd == _cmdLink) && len(args) > 1:
+		reply, err = u.link(chatID, args[1])
+	case cmd == _cmdTasks || strings.EqualFold(strings.Join(args, " "), _textTasks):
+		reply, err = u.tasks(chatID)
+	case cmd == _cmdUnlink:
+		reply, err = _replyUnlinked, u.ForgetChat(chatID)
+	default:
+		reply = _replyHelp
+	}
+	if err != nil {
+		slog.Warn("handle telegram command", "chat_id", chatID, "command", cmd, "error", err)
+		return _replyError
+	}
+	return reply
+}
+
+// Messages returns messages with the given notifications for all linked chats.
+// Parent chats get only new tasks and checked solutions with the student name.
+func (u *UCBot) Messages(notifList []entity.Notification) ([]entity.TelegramMessage, error) {
+	userIDs := make([]int, 0, len(notifList))
+	for idx := range notifList {
+		if !goslices.Contains(userIDs, notifList[idx].UserID) {
+			userIDs = append(userIDs, notifList[idx].UserID)
+		}
+	}
+	chats, err := u.tgRepoDB.GetChatsByUserIDs(userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get chats: %w", err)
+	}
+
+	messages := make([]entity.TelegramMessage, 0, len(chats))
+	for _, chatObj := range chats {
+		for idx := range notifList {
+			notifObj := &notifList[idx]
+			if notifObj.UserID != chatObj.UserID {
+				continue
+			}
+			text := notifObj.Message
+			if chatObj.IsParent {
+				if !goslices.Contains(_parentTypes, notifObj.Type) {
+					continue
+				}
+				text = userName(chatObj.User) + ": " + text
+			}
+			messages = append(messages, entity.TelegramMessage{ChatID: chatObj.ChatID, Text: text})
+		}
+	}
+	return messages, nil
+}
+
+// ForgetChat unlinks the chat from all accounts (e.g. if the bot was blocked).
+func (u *UCBot) ForgetChat(chatID int64) error {
+	return u.tgRepoDB.DeleteByChatID(chatID)
+}
+
+// link links the chat with the account of the link code owner.
+func (u *UCBot) link(chatID int64, code string) (string, error) {
+	userID, isParent, err := u.tgRepoCache.PopCode(strings.ToUpper(code))
+	if errors.Is(err, telegram.ErrNotFound) {
+		return _replyBadCode, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get code: %w", err)
+	}
+
+	err = u.tgRepoDB.CreateChat(&entity.TelegramChat{
+		UserID:   userID,
+		ChatID:   chatID,
+		IsParent: isParent,
+	})
+	if err != nil {
+		return "", fmt.Errorf("create chat: %w", err)
+	}
+	if isParent {
+		return _replyParentLinked, nil
+	}
+	return _replyLinked, nil
+}
+
+// tasks returns a list of open tasks for every account linked with the chat.
+// Students (and parents) get not checked solutions, teachers get solutions to check.
+func (u *UCBot) tasks(chatID int64) (string, error) {
+	chats, err := u.tgRepoDB.GetChatsByChatID(chatID)
+	if err != nil {
+		return "", fmt.Errorf("get chats: %w", err)
+	}
+	if len(chats) == 0 {
+		return _replyNotLinked, nil
+	}
+
+	sections := make([]string, 0, len(chats))
+	for _, chatObj := range chats {
+		if chatObj.User == nil {
+			continue
+		}
+		var section string
+		if chatObj.User.IsTeacher() {
+			section, err = u.solutionsToCheck(chatObj.User)
+		} else {
+			section, err = u.openSolutions(chatObj.User)
+		}
+		if err != nil {
+			return "", err
+		}
+		sections = append(sections, section)
+	}
+	return strings.Join(sections, "\n\n"), nil
+}
+
+// openSolutions returns a list of not checked student solutions.
+func (u *UCBot) openSolutions(userObj *entity.User) (string, error) {
+	solutions, err := u.tgRepoDB.GetOpenSolutions(userObj.ID)
+	if err != nil {
+		return "", fmt.Errorf("get open solutions: %w", err)
+	}
+	lines := make([]string, len(solutions))
+	for idx := range solutions {
+		status := _noStatus
+		if solutions[idx].Status != nil {
+			status = solutions[idx].Status.Name
+		}
+		lines[idx] = fmt.Sprintf("«%s» — %s", taskTitle(&solutions[idx]), status)
+	}
+	return formatList("Открытые задания ("+userName(userObj)+")", lines), nil
+}
+
+// solutionsToCheck returns a list of solutions waiting for the teacher review.
+func (u *UCBot) solutionsToCheck(userObj *entity.User) (string, error) {
+	solutions, err := u.tgRepoDB.GetSolutionsToCheck(userObj.ID)
+	if err != nil {
+		return "", fmt.Errorf("get solutions to check: %w", err)
+	}
+	lines := make([]string, len(solutions))
+	for idx := range solutions {
+		lines[idx] = fmt.Sprintf("«%s» — %s",
+			taskTitle(&solutions[idx]), userName(solutions[idx].StudentUser))
+	}
+	return formatList("Решения на проверке ("+userName(userObj)+")", lines), nil
+}
+
+// formatList returns a list with the header. Long lists are cut.
+func formatList(header string, lines []string) string {
+	if len(lines) == 0 {
+		return header + ":\nнет"
+	}
+	var b strings.Builder
+	b.WriteString(header + ":")
+	for idx, line := range lines {
+		if idx == _maxList {
+			fmt.Fprintf(&b, "\n… и ещё %d", len(lines)-_maxList)
+			break
+		}
+		b.WriteString("\n" + _bullet + line)
+	}
+	return b.String()
+}
+
+// userName returns the user full name or placeholder if the profile is not loaded.
+func userName(userObj *entity.User) string {
+	if userObj == nil || userObj.Profile == nil || userObj.Profile.Fullname == "" {
+		return _noName
+	}
+	return userObj.Profile.Fullname
+}
+
+// taskTitle returns the solution task title or placeholder if the task is not loaded.
+func taskTitle(sol *entity.Solution) string {
+	if sol.Task == nil || sol.Task.Title == "" {
+		return _noTitle
+	}
+	return sol.Task.Title
+}
@@ -0,0 +1,88 @@
+// Package usecase contains telegram.UsecaseClient and telegram.UsecaseBot implementations.
+package usecase
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/telegram"
+)
+
+const (
+	_codeLen      = 8                                  // length of the link code
+	_codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // code symbols (32 symbols without similar ones)
+	_botLink      = "https://t.me/%s?start=%s"         // link to start the bot with the code
+)
+
+// Ensure UCClient implements interfaces.
+var _ telegram.UsecaseClient = (*UCClient)(nil)
+
+// UCClient represents a telegram usecase for teacher and student.
+// It implements the [telegram.UsecaseClient] interface.
+type UCClient struct {
+	cfg         *config.Config
+	tgRepoDB    telegram.RepositoryDB
+	tgRepoCache telegram.RepositoryCache
+}
+
+// NewUCClient returns a new instance of [UCClient].
+func NewUCClient(cfg *config.Config, tgRepoDB telegram.RepositoryDB,
+	tgRepoCache telegram.RepositoryCache) *UCClient {
+
+	return &UCClient{
+		cfg:         cfg,
+		tgRepoDB:    tgRepoDB,
+		tgRepoCache: tgRepoCache,
+	}
+}
+
+// NewCode returns a new one-time code to link the chat with the user account.
+// If forParent is true, the code links the chat of the student's parent
+// (parent chats are available for students only).
+func (u *UCClient) NewCode(userClaims *entity.UserClaims,
+	forParent bool) (*entity.TelegramCode, error) {
+
+	if forParent && !userClaims.IsStudent() {
+		return nil, fmt.Errorf("%w: parent chat is available for students only",
+			telegram.ErrInvalidData)
+	}
+
+	code := newCode()
+	ttl := u.cfg.Telegram.CodeTTL
+	if err := u.tgRepoCache.SetCode(code, userClaims.ID, forParent, ttl); err != nil {
+		return nil, fmt.Errorf("save code: %w", err)
+	}
+	codeObj := &entity.TelegramCode{
+		Code:      code,
+		IsParent:  forParent,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if u.cfg.Telegram.BotName != "" {
+		codeObj.Link = fmt.Sprintf(_botLink, u.cfg.Telegram.BotName, code)
+	}
+	return codeObj, nil
+}
+
+// GetChats returns all chats linked with the user.
+func (u *UCClient) GetChats(userID int) ([]entity.TelegramChat, error) {
+	return u.tgRepoDB.GetChats(userID)
+}
+
+// DeleteChat unlinks the user chat by link ID.
+func (u *UCClient) DeleteChat(userID, id int) error {
+	return u.tgRepoDB.DeleteChat(userID, id)
+}
+
+// newCode returns a new random link code.
+func newCode() string {
+	code := make([]byte, _codeLen)
+	rand.Read(code)
+	for idx := range code {
+		// alphabet has 32 symbols, so every symbol has the same probability
+		code[idx] = _codeAlphabet[int(code[idx])%len(_codeAlphabet)]
+	}
+	return string(code)
+}
@@ -0,0 +1,196 @@
+package v1
+
+import (
+	"errors"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/upload"
+	"skadi/backend/internal/pkg/httperror"
+	"skadi/backend/internal/pkg/serialize"
+	utilsjwt "skadi/backend/internal/pkg/utils/jwt"
+	"skadi/backend/internal/pkg/validator"
+)
+
+// UploadController represents a controller for upload routes.
+type UploadController struct {
+	valid          validator.Validator
+	uploadUCClient upload.UsecaseClient
+}
+
+// NewController returns a new instance of [UploadController].
+func NewController(uploadUCClient upload.UsecaseClient,
+	valid validator.Validator) *UploadController {
+
+	return &UploadController{
+		valid:          valid,
+		uploadUCClient: uploadUCClient,
+	}
+}
+
+// @summary		Создание загрузки файла по частям. [Преподаватель и ученик]
+// @description	Создание пустой загрузки файла. Далее файл передаётся по частям, а ID завершённой загрузки прикрепляется к заданию или решению вместо файла.
+// @router			/upload [post]
+// @id				upload-create
+// @tags			upload
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			uploadBody	body		uploadBody	true	"uploadBody"
+// @success		201			{object}	entity.Upload
+// @failure		400			"файл слишком большой"
+// @failure		401			"неверный токен (пустой, истекший или неверный формат)"
+func (c *UploadController) Create(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputBody := &uploadBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	// data reshaping
+	uploadObj := &entity.Upload{
+		Name:     inputBody.Name,
+		MimeType: inputBody.MimeType,
+		Size:     inputBody.Size,
+	}
+	err := c.uploadUCClient.Create(userClaims.ID, uploadObj)
+	if errors.Is(err, upload.ErrTooLarge) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "файл слишком большой",
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(uploadObj)
+}
+
+// @summary		Получение загрузки по id. [Преподаватель и ученик]
+// @description	Получение состояния загрузки (количества полученных байт) для возобновления прерванной загрузки.
+// @router			/upload/{id} [get]
+// @id				upload-read
+// @tags			upload
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id	path		string	true	"ID загрузки"
+// @success		200	{object}	entity.Upload
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		404	"загрузка не найдена"
+func (c *UploadController) Read(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &uploadIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	uploadObj, err := c.uploadUCClient.GetByID(userClaims.ID, inputPath.ID)
+	if errors.Is(err, upload.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "загрузка не найдена",
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(uploadObj)
+}
+
+// @summary		Загрузка части файла. [Преподаватель и ученик]
+// @description	Запись части файла (тело запроса) по смещению из заголовка Upload-Offset. Смещение должно совпадать с количеством уже полученных байт.
+// @router			/upload/{id} [patch]
+// @id				upload-append-chunk
+// @tags			upload
+// @accept			octet-stream
+// @produce		json
+// @security		JWTAccess
+// @param			id				path		string	true	"ID загрузки"
+// @param			Upload-Offset	header		int		true	"смещение части файла"
+// @param			chunk			body		string	true	"часть файла"
+// @success		200				{object}	entity.Upload
+// @failure		400				"неверная часть файла"
+// @failure		401				"неверный токен (пустой, истекший или неверный формат)"
+// @failure		404				"загрузка не найдена"
+// @failure		409				"неверное смещение | загрузка части файла уже выполняется"
+func (c *UploadController) AppendChunk(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &uploadIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputHeader := &chunkHeader{}
+	if err := serialize.Deserialize(inputHeader, ctx.ReqHeaderParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	uploadObj, err := c.uploadUCClient.AppendChunk(userClaims.ID, inputPath.ID,
+		*inputHeader.Offset, ctx.Body())
+	if errors.Is(err, upload.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "загрузка не найдена",
+		}
+	}
+	if errors.Is(err, upload.ErrInvalidChunk) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "неверная часть файла",
+		}
+	}
+	if errors.Is(err, upload.ErrOffset) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "неверное смещение",
+		}
+	}
+	if errors.Is(err, upload.ErrLocked) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusConflict,
+			Message:    "загрузка части файла уже выполняется",
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(uploadObj)
+}
+
+// @summary		Удаление загрузки по id. [Преподаватель и ученик]
+// @description	Отмена загрузки и удаление уже полученной части файла.
+// @router			/upload/{id} [delete]
+// @id				upload-delete
+// @tags			upload
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id	path	string	true	"ID загрузки"
+// @success		204	"No Content"
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+func (c *UploadController) Delete(ctx *fiber.Ctx) error {
+	// parse user claims
+	userClaims := utilsjwt.ParseUserClaimsFromRequest(ctx)
+
+	inputPath := &uploadIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	if err := c.uploadUCClient.Delete(userClaims.ID, inputPath.ID); err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusNoContent).JSON(nil)
+}
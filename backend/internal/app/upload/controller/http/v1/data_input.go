@@ -0,0 +1,23 @@
+package v1
+
+// @description uploadBody represents a data with metadata of the file to upload.
+type uploadBody struct {
+	// filename
+	Name string `json:"name" validate:"required,max=255" example:"project.mp4" maxLength:"255"`
+	// file MIME-type
+	MimeType string `json:"mime_type" validate:"required,max=255" example:"video/mp4" maxLength:"255"`
+	// total file size in bytes
+	Size int64 `json:"size" validate:"required,min=1" example:"104857600"`
+}
+
+// @description uploadIDPath represents a data with upload ID in path params.
+type uploadIDPath struct {
+	// upload id
+	ID string `params:"id" validate:"required,uuid" example:"9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d"`
+}
+
+// @description chunkHeader represents a data with chunk params in headers.
+type chunkHeader struct {
+	// offset of the chunk (number of already received bytes)
+	Offset *int64 `reqHeader:"Upload-Offset" validate:"required,min=0" example:"0"`
+}
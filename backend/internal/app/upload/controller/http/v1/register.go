@@ -0,0 +1,23 @@
+// Package http/v1 is a first version of upload HTTP-controller.
+// It provides registers for upload HTTP-routes and controller with handlers for them.
+package v1
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/service/server/middleware"
+)
+
+// RegisterEndpoints registers all upload endpoints.
+func RegisterEndpoints(router fiber.Router, controller *UploadController,
+	mwJWTAccess fiber.Handler, mwAllow middleware.AllowFunc) {
+
+	mwTeacherStudent := mwAllow(entity.Teacher, entity.Student)
+
+	authGroup := router.Group("/upload", mwJWTAccess, mwTeacherStudent)
+	authGroup.Post("/", controller.Create)
+	authGroup.Get("/:id", controller.Read)
+	authGroup.Patch("/:id", controller.AppendChunk)
+	authGroup.Delete("/:id", controller.Delete)
+}
@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/upload"
+	"skadi/backend/internal/pkg/httperror"
+	utilsfile "skadi/backend/internal/pkg/utils/file"
+)
+
+// Uploads represents completed chunked uploads taken to be linked to a task or solution.
+type Uploads struct {
+	uploadUCClient upload.UsecaseClient
+	uploadIDs      []string
+	// taken files
+	Files entity.Files
+}
+
+// TakeUploads takes completed chunked uploads of the user to the given file dir.
+// Upload errors are converted to HTTP errors.
+func TakeUploads(uploadUCClient upload.UsecaseClient, userID int, uploadIDs []string,
+	fileDir string) (*Uploads, error) {
+
+	takenFiles, err := uploadUCClient.Take(userID, uploadIDs, fileDir)
+	if errors.Is(err, upload.ErrNotFound) {
+		return nil, &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "загрузка не найдена",
+		}
+	}
+	if errors.Is(err, upload.ErrNotCompleted) {
+		return nil, &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusBadRequest,
+			Message:    "загрузка не завершена",
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Uploads{
+		uploadUCClient: uploadUCClient,
+		uploadIDs:      uploadIDs,
+		Files:          takenFiles,
+	}, nil
+}
+
+// Finish deletes the uploads and adds their files to the queue for background processing
+// if the files were linked (linkErr is nil). Otherwise the files are returned to
+// the staging storage, so the completed uploads are not lost.
+func (u *Uploads) Finish(linkErr error, fileQueue utilsfile.Queue) {
+	if len(u.Files) == 0 {
+		return
+	}
+	if linkErr != nil {
+		u.uploadUCClient.Release(u.uploadIDs, u.Files)
+		return
+	}
+	// files are already linked, so the expired uploads are deleted by the cleanup job
+	if err := u.uploadUCClient.Commit(u.uploadIDs); err != nil {
+		slog.Warn("commit taken uploads", "ids", u.uploadIDs, "error", err)
+	}
+	if fileQueue != nil {
+		fileQueue.Enqueue(u.Files...)
+	}
+}
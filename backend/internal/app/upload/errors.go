@@ -0,0 +1,12 @@
+package upload
+
+import "errors"
+
+var (
+	ErrTooLarge     = errors.New("file is too large")       // code 400
+	ErrInvalidChunk = errors.New("invalid chunk")           // code 400
+	ErrNotCompleted = errors.New("upload is not completed") // code 400
+	ErrNotFound     = errors.New("record not found")        // code 404
+	ErrOffset       = errors.New("offset mismatch")         // code 409
+	ErrLocked       = errors.New("upload is locked")        // code 409
+)
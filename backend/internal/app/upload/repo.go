@@ -0,0 +1,23 @@
+package upload
+
+import (
+	"time"
+
+	"skadi/backend/internal/app/entity"
+)
+
+// RepositoryDB describes all DB methods for upload object.
+type RepositoryDB interface {
+	// Create creates a new upload and fills given struct.
+	Create(uploadObj *entity.Upload) error
+	// GetByID returns upload by the given ID.
+	GetByID(id string) (*entity.Upload, error)
+	// UpdateUploaded sets the new number of received bytes for the given upload
+	// if it is still equal to the old one. It returns false if the upload was changed
+	// by another request.
+	UpdateUploaded(id string, oldUploaded, newUploaded int64) (bool, error)
+	// Delete deletes uploads by the given IDs.
+	Delete(ids ...string) error
+	// GetExpired returns all uploads (not taken yet) expired before the given time.
+	GetExpired(before time.Time) ([]entity.Upload, error)
+}
@@ -0,0 +1,76 @@
+// Package repository contains upload.RepositoryDB implementation.
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/upload"
+)
+
+// Ensure RepoDB implements interface.
+var _ upload.RepositoryDB = (*RepoDB)(nil)
+
+// RepoDB is an upload DB repo.
+// It implements the [upload.RepositoryDB] interface.
+type RepoDB struct {
+	dbStorage *gorm.DB
+}
+
+// NewRepoDB returns a new instance of [RepoDB].
+func NewRepoDB(dbStorage *gorm.DB) *RepoDB {
+	return &RepoDB{
+		dbStorage: dbStorage,
+	}
+}
+
+// Create creates a new upload and fills given struct.
+func (r *RepoDB) Create(uploadObj *entity.Upload) error {
+	return r.dbStorage.Create(uploadObj).Error // nil OR error
+}
+
+// GetByID returns upload by the given ID.
+func (r *RepoDB) GetByID(id string) (*entity.Upload, error) {
+	var uploadObj entity.Upload
+	err := r.dbStorage.
+		Where("id = ?", id).First(&uploadObj).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// upload object with such id not found
+		return nil, fmt.Errorf("upload with id: %w", upload.ErrNotFound)
+	}
+	return &uploadObj, err // err OR nil
+}
+
+// UpdateUploaded sets the new number of received bytes for the given upload
+// if it is still equal to the old one. It returns false if the upload was changed
+// by another request (on this or another backend instance).
+func (r *RepoDB) UpdateUploaded(id string, oldUploaded, newUploaded int64) (bool, error) {
+	res := r.dbStorage.Model(&entity.Upload{}).
+		Where("id = ? AND uploaded = ?", id, oldUploaded).
+		Update("uploaded", newUploaded)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+// Delete deletes uploads by the given IDs.
+func (r *RepoDB) Delete(ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.dbStorage.Where("id IN ?", ids).Delete(&entity.Upload{}).Error
+}
+
+// GetExpired returns all uploads (not taken yet) expired before the given time.
+func (r *RepoDB) GetExpired(before time.Time) ([]entity.Upload, error) {
+	uploads := make([]entity.Upload, 0)
+	err := r.dbStorage.
+		Where("expires_at < ?", before).
+		Find(&uploads).Error
+	return uploads, err // err OR nil
+}
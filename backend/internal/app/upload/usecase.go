@@ -0,0 +1,36 @@
+// Package upload contains all repos, usecases and controllers for resumable file uploads.
+// Sub-package repo contains RepoDB implementation.
+// Sub-package usecase contains UsecaseClient and UsecaseCleaner implementations.
+package upload
+
+import "skadi/backend/internal/app/entity"
+
+// UsecaseClient describes all upload usecases for teacher and student.
+type UsecaseClient interface {
+	// Create creates a new empty upload in the staging storage.
+	Create(userID int, uploadObj *entity.Upload) error
+	// GetByID returns user upload by the given ID.
+	GetByID(userID int, uploadID string) (*entity.Upload, error)
+	// AppendChunk writes the chunk to the upload file at the given offset.
+	// Offset must be equal to the number of already received bytes.
+	AppendChunk(userID int, uploadID string, offset int64, chunk []byte) (*entity.Upload, error)
+	// Delete deletes the user upload with its staging file.
+	Delete(userID int, uploadID string) error
+	// Take moves completed user uploads to the given dir and
+	// returns them as files ready to be linked to a task or solution.
+	// Taken uploads must be committed after the files are linked or released otherwise.
+	Take(userID int, uploadIDs []string, fileDir string) (entity.Files, error)
+	// Commit deletes the taken uploads (their files are linked to a task or solution).
+	Commit(uploadIDs []string) error
+	// Release moves the taken files back to the staging storage,
+	// so the uploads are kept and can be taken again.
+	Release(uploadIDs []string, takenFiles entity.Files)
+}
+
+// UsecaseCleaner describes usecases to clean up the staging storage.
+// It is used by the scheduled background job.
+type UsecaseCleaner interface {
+	// CleanupExpired deletes expired uploads with their staging files.
+	// It returns the number of deleted uploads.
+	CleanupExpired() (int, error)
+}
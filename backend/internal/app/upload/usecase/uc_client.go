@@ -0,0 +1,261 @@
+// Package usecase contains upload.UsecaseClient implementation.
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/upload"
+)
+
+const _filePerms = 0o644 // permissions for the staging files
+
+// Ensure UCClient implements interfaces.
+var (
+	_ upload.UsecaseClient  = (*UCClient)(nil)
+	_ upload.UsecaseCleaner = (*UCClient)(nil)
+)
+
+// UCClient represents an upload usecase for teacher and student.
+// It implements the [upload.UsecaseClient] and [upload.UsecaseCleaner] interfaces.
+type UCClient struct {
+	cfg          *config.Config
+	uploadRepoDB upload.RepositoryDB
+}
+
+// NewUCClient returns a new instance of [UCClient].
+func NewUCClient(cfg *config.Config, uploadRepoDB upload.RepositoryDB) *UCClient {
+	return &UCClient{
+		cfg:          cfg,
+		uploadRepoDB: uploadRepoDB,
+	}
+}
+
+// Create creates a new empty upload in the staging storage.
+func (u *UCClient) Create(userID int, uploadObj *entity.Upload) error {
+	if uploadObj.Size > u.cfg.Media.Upload.MaxSize {
+		return fmt.Errorf("%w: %d bytes (max %d)",
+			upload.ErrTooLarge, uploadObj.Size, u.cfg.Media.Upload.MaxSize)
+	}
+	// remove expired uploads to free the staging storage
+	if _, err := u.CleanupExpired(); err != nil {
+		slog.Warn("cleanup expired uploads", "error", err)
+	}
+
+	uploadObj.ID = uuid.NewString()
+	uploadObj.UserID = userID
+	uploadObj.Uploaded = 0
+	uploadObj.Path = filepath.Join(u.cfg.Media.Upload.Dir, uploadObj.ID)
+	uploadObj.ExpiresAt = time.Now().Add(u.cfg.Media.Upload.TTL)
+
+	// create empty staging file
+	stagingFile, err := os.OpenFile(uploadObj.Path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, _filePerms)
+	if err != nil {
+		return fmt.Errorf("create staging file: %w", err)
+	}
+	stagingFile.Close()
+
+	if err := u.uploadRepoDB.Create(uploadObj); err != nil {
+		removeStaging(uploadObj.Path)
+		return fmt.Errorf("create upload: %w", err)
+	}
+	return nil
+}
+
+// GetByID returns user upload by the given ID.
+func (u *UCClient) GetByID(userID int, uploadID string) (*entity.Upload, error) {
+	uploadObj, err := u.uploadRepoDB.GetByID(uploadID)
+	if err != nil {
+		return nil, err
+	}
+	// other user uploads are hidden
+	if uploadObj.UserID != userID {
+		return nil, fmt.Errorf("%w: user %d is not an upload owner", upload.ErrNotFound, userID)
+	}
+	return uploadObj, nil
+}
+
+// AppendChunk writes the chunk to the upload file at the given offset.
+// Offset must be equal to the number of already received bytes.
+// The chunk bytes are reserved in DB before writing, so only one chunk of the upload
+// is written at the same time (on all backend instances).
+func (u *UCClient) AppendChunk(userID int, uploadID string, offset int64,
+	chunk []byte) (*entity.Upload, error) {
+
+	uploadObj, err := u.GetByID(userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if offset != uploadObj.Uploaded {
+		return nil, fmt.Errorf("%w: got %d, expected %d", upload.ErrOffset, offset, uploadObj.Uploaded)
+	}
+	newUploaded := offset + int64(len(chunk))
+	if len(chunk) == 0 || newUploaded > uploadObj.Size {
+		return nil, fmt.Errorf("%w: chunk with %d bytes at offset %d exceeds file size %d",
+			upload.ErrInvalidChunk, len(chunk), offset, uploadObj.Size)
+	}
+
+	reserved, err := u.uploadRepoDB.UpdateUploaded(uploadID, offset, newUploaded)
+	if err != nil {
+		return nil, fmt.Errorf("update upload: %w", err)
+	}
+	if !reserved {
+		return nil, fmt.Errorf("%w: upload %s", upload.ErrLocked, uploadID)
+	}
+	if err := writeChunk(uploadObj.Path, offset, chunk); err != nil {
+		// return the reserved bytes, so the chunk can be sent again
+		if _, undoErr := u.uploadRepoDB.UpdateUploaded(uploadID, newUploaded,
+			offset); undoErr != nil {
+			slog.Warn("undo upload update", "id", uploadID, "error", undoErr)
+		}
+		return nil, fmt.Errorf("write chunk: %w", err)
+	}
+	uploadObj.Uploaded = newUploaded
+	return uploadObj, nil
+}
+
+// Delete deletes the user upload with its staging file.
+func (u *UCClient) Delete(userID int, uploadID string) error {
+	uploadObj, err := u.GetByID(userID, uploadID)
+	// return nil error if upload was not found
+	if errors.Is(err, upload.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := u.uploadRepoDB.Delete(uploadID); err != nil {
+		return fmt.Errorf("delete upload: %w", err)
+	}
+	removeStaging(uploadObj.Path)
+	return nil
+}
+
+// Take moves completed user uploads to the given dir and
+// returns them as files ready to be linked to a task or solution.
+// If scanning is enabled, files are marked as pending, so they are inserted to DB
+// as waiting for scanning and cannot be downloaded before it.
+// Taken uploads must be committed after the files are linked or released otherwise.
+func (u *UCClient) Take(userID int, uploadIDs []string, fileDir string) (entity.Files, error) {
+	if len(uploadIDs) == 0 {
+		return nil, nil
+	}
+
+	// check all uploads before moving any of them
+	uploads := make([]*entity.Upload, len(uploadIDs))
+	for idx, uploadID := range uploadIDs {
+		uploadObj, err := u.GetByID(userID, uploadID)
+		if err != nil {
+			return nil, err
+		}
+		if !uploadObj.Completed() {
+			return nil, fmt.Errorf("%w: upload %s: received %d of %d bytes",
+				upload.ErrNotCompleted, uploadID, uploadObj.Uploaded, uploadObj.Size)
+		}
+		// reserved chunk could be not written (e.g. the instance crashed while writing)
+		stagingInfo, err := os.Stat(uploadObj.Path)
+		if err != nil {
+			return nil, fmt.Errorf("stat staging file: %w", err)
+		}
+		if stagingInfo.Size() != uploadObj.Size {
+			return nil, fmt.Errorf("%w: upload %s: staging file has %d of %d bytes",
+				upload.ErrNotCompleted, uploadID, stagingInfo.Size(), uploadObj.Size)
+		}
+		uploads[idx] = uploadObj
+	}
+
+	// move staging files to the file dir
+	takenFiles := make(entity.Files, 0, len(uploads))
+	for _, uploadObj := range uploads {
+		newFile := entity.NewFile(uploadObj.Name, uploadObj.MimeType, uploadObj.Size,
+			entity.FileWithPathPrefix(fileDir))
+		if u.cfg.Media.Scan.Enabled {
+			newFile.ScanStatus = entity.ScanPending
+		}
+		if err := os.Rename(uploadObj.Path, newFile.Path); err != nil {
+			u.Release(uploadIDs, takenFiles)
+			return nil, fmt.Errorf("move upload %s: %w", uploadObj.ID, err)
+		}
+		takenFiles = append(takenFiles, newFile)
+	}
+	return takenFiles, nil
+}
+
+// Commit deletes the taken uploads (their files are linked to a task or solution).
+func (u *UCClient) Commit(uploadIDs []string) error {
+	if len(uploadIDs) == 0 {
+		return nil
+	}
+	return u.uploadRepoDB.Delete(uploadIDs...)
+}
+
+// Release moves the taken files back to the staging storage,
+// so the uploads are kept and can be taken again.
+func (u *UCClient) Release(uploadIDs []string, takenFiles entity.Files) {
+	for idx, fileObj := range takenFiles {
+		stagingPath := filepath.Join(u.cfg.Media.Upload.Dir, uploadIDs[idx])
+		if err := os.Rename(fileObj.Path, stagingPath); err != nil {
+			slog.Warn("release taken upload", "id", uploadIDs[idx], "error", err)
+		}
+	}
+}
+
+// CleanupExpired deletes expired uploads with their staging files.
+// It returns the number of deleted uploads.
+func (u *UCClient) CleanupExpired() (int, error) {
+	expired, err := u.uploadRepoDB.GetExpired(time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("get expired uploads: %w", err)
+	}
+	if len(expired) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]string, len(expired))
+	for idx := range expired {
+		ids[idx] = expired[idx].ID
+	}
+	if err := u.uploadRepoDB.Delete(ids...); err != nil {
+		return 0, fmt.Errorf("delete expired uploads: %w", err)
+	}
+	for idx := range expired {
+		removeStaging(expired[idx].Path)
+	}
+	slog.Info("delete expired uploads", "count", len(expired))
+	return len(expired), nil
+}
+
+// writeChunk writes the chunk to the staging file at the given offset.
+// Bytes after the chunk (left from the interrupted writing) are truncated.
+func writeChunk(path string, offset int64, chunk []byte) error {
+	stagingFile, err := os.OpenFile(path, os.O_WRONLY, _filePerms)
+	if err != nil {
+		return err
+	}
+	defer stagingFile.Close()
+
+	if _, err := stagingFile.WriteAt(chunk, offset); err != nil {
+		return err
+	}
+	if err := stagingFile.Truncate(offset + int64(len(chunk))); err != nil {
+		return err
+	}
+	// chunk must be on disk before the offset is saved to DB
+	return stagingFile.Sync()
+}
+
+// removeStaging removes the staging file from the file system.
+func removeStaging(path string) {
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		slog.Warn("remove staging file", "path", path, "error", err)
+	}
+}
@@ -26,7 +26,8 @@ type RepositoryDB interface {
 
 	// Delete deletes user object and user profile (by data ID).
 	// Also user profile contacts (contact and parent contact) will be deleted
-	// if they are not used in other profiles.
+	// if they are not used in other profiles. Team solutions of the user are transferred
+	// to the remaining team member.
 	Delete(data *entity.User) error
 
 	// GetByRoles returns user (with class if set and profile) list with given roles.
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"skadi/backend/internal/app/entity"
 	"skadi/backend/internal/app/user"
@@ -23,6 +24,7 @@ const (
 	_fieldID       = "id"       // table field name
 	_fieldName     = "name"     // table field name
 	_fieldFullname = "fullname" // table field name
+	_fieldClassID  = "class_id" // table field name
 )
 
 // Ensure RepoDB implements interface.
@@ -95,13 +97,22 @@ func (r *RepoDB) CreateUserFull(userObj *entity.User) error {
 		userObj.Profile.ID = nil
 
 		// get class info (ID and name only)
-		if userObj.ClassID != nil {
-			err = tx.Select(_fieldID, _fieldName).
-				Where(*userObj.ClassID).
-				First(&userObj.Class).Error
-			if err != nil {
-				return fmt.Errorf("class: %w", err)
-			}
+		if userObj.ClassID == nil {
+			return nil
+		}
+		err = tx.Select(_fieldID, _fieldName).
+			Where(*userObj.ClassID).
+			First(&userObj.Class).Error
+		if err != nil {
+			return fmt.Errorf("class: %w", err)
+		}
+		// issue the class tasks for the new student
+		evtObj, err := entity.NewClassMembersEvent(*userObj.ClassID, []int{userObj.ID}, nil)
+		if err != nil {
+			return fmt.Errorf("encode event: %w", err)
+		}
+		if err := tx.Create(evtObj).Error; err != nil {
+			return fmt.Errorf("write event: %w", err)
 		}
 		return nil
 	})
@@ -155,26 +166,70 @@ func (r *RepoDB) GetByIDWithProfileShort(id int) (*entity.User, error) {
 }
 
 // UpdateUser updates old user data to new one (by data ID).
+// If the class of the student is changed, the class tasks are issued or withdrawn
+// by the class.members_changed event.
 func (r *RepoDB) UpdateUser(data *entity.User) error {
 	// collect updates to map
 	updates := make(map[string]any, 0)
-	updates["class_id"] = data.ClassID
+	updates[_fieldClassID] = data.ClassID
 	if len(data.Password) > 0 {
 		updates["password"] = data.Password
 	}
 
-	if len(updates) == 0 {
+	return r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		// get old class ID (lock the user record until the end of the transaction)
+		var oldUserObj entity.User
+		err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Select(_fieldID, _fieldClassID).
+			Where(data.ID).First(&oldUserObj).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// user object with such id not found
+			return fmt.Errorf("user with id: %w: %s", user.ErrNotFound, err.Error())
+		}
+		if err != nil {
+			return err
+		}
+
+		// update user
+		err = tx.Model(&entity.User{}).
+			Where("id = ?", data.ID).
+			Updates(updates).Error
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			// class with given ID is not found
+			return fmt.Errorf("user: %w: class is not found", user.ErrInvalidData)
+		}
+		if err != nil {
+			return err
+		}
+
+		// skip events if the class is not changed
+		oldClassID, newClassID := oldUserObj.ClassID, data.ClassID
+		if oldClassID != nil && newClassID != nil && *oldClassID == *newClassID {
+			return nil
+		}
+		var events []*entity.OutboxEvent
+		if oldClassID != nil {
+			evtObj, err := entity.NewClassMembersEvent(*oldClassID, nil, []int{data.ID})
+			if err != nil {
+				return fmt.Errorf("encode event: %w", err)
+			}
+			events = append(events, evtObj)
+		}
+		if newClassID != nil {
+			evtObj, err := entity.NewClassMembersEvent(*newClassID, []int{data.ID}, nil)
+			if err != nil {
+				return fmt.Errorf("encode event: %w", err)
+			}
+			events = append(events, evtObj)
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		if err := tx.Create(&events).Error; err != nil {
+			return fmt.Errorf("write events: %w", err)
+		}
 		return nil
-	}
-	// update user
-	err := r.dbStorage.Model(&entity.User{}).
-		Where("id = ?", data.ID).
-		Updates(updates).Error
-	if errors.Is(err, gorm.ErrForeignKeyViolated) {
-		// class with given ID is not found
-		return fmt.Errorf("user: %w: class is not found", user.ErrInvalidData)
-	}
-	return err
+	})
 }
 
 // UpdateProfile updates old user profile to new one (by profile ID).
@@ -205,9 +260,22 @@ func (r *RepoDB) UpdateProfile(oldData, newData *entity.Profile) error {
 
 // Delete deletes user object and user profile (by data ID).
 // Also user profile contacts (contact and parent contact) will be deleted
-// if they are not used in other profiles.
+// if they are not used in other profiles. Team solutions of the user are transferred
+// to the remaining team member.
 func (r *RepoDB) Delete(data *entity.User) error {
 	return r.dbStorage.Transaction(func(tx *gorm.DB) error {
+		// transfer owned team solutions to the remaining members (not to delete them in cascade)
+		err := tx.Model(&entity.Solution{}).
+			Where("student_id = ?", data.ID).
+			Where(`EXISTS (SELECT 1 FROM solution_member
+				WHERE solution_member.solution_id = solution.id
+				AND solution_member.student_id <> ?)`, data.ID).
+			Update("student_id", gorm.Expr(`(SELECT MIN(solution_member.student_id)
+				FROM solution_member WHERE solution_member.solution_id = solution.id
+				AND solution_member.student_id <> ?)`, data.ID)).Error
+		if err != nil {
+			return fmt.Errorf("transfer team solutions: %w", err)
+		}
 		// delete user (cascade with profile)
 		if err := tx.Delete(&entity.User{}, data.ID).Error; err != nil {
 			return err
@@ -10,6 +10,7 @@ import (
 	"skadi/backend/internal/app/class"
 	"skadi/backend/internal/app/entity"
 	"skadi/backend/internal/app/user"
+	"skadi/backend/internal/app/webhook"
 	"skadi/backend/internal/pkg/password"
 )
 
@@ -25,16 +26,18 @@ type UCAdminClient struct {
 	cfg         *config.Config
 	userRepoDB  user.RepositoryDB
 	classRepoDB class.RepositoryDB
+	hookEmitter webhook.Emitter
 }
 
 // NewUCAdminClient returns a new instance of [UCAdminClient].
 func NewUCAdminClient(cfg *config.Config, userRepoDB user.RepositoryDB,
-	classRepoDB class.RepositoryDB) *UCAdminClient {
+	classRepoDB class.RepositoryDB, hookEmitter webhook.Emitter) *UCAdminClient {
 
 	return &UCAdminClient{
 		cfg:         cfg,
 		userRepoDB:  userRepoDB,
 		classRepoDB: classRepoDB,
+		hookEmitter: hookEmitter,
 	}
 }
 
@@ -62,6 +65,13 @@ func (u *UCAdminClient) CreateWithProfile(userObj *entity.User) error {
 	if err != nil {
 		return fmt.Errorf("create user: %w", err)
 	}
+	u.hookEmitter.Emit(entity.HookUserCreated, &entity.WebhookUserData{
+		UserID:   userObj.ID,
+		Username: userObj.Username,
+		Role:     userObj.Role,
+		Fullname: userObj.Profile.Fullname,
+		ClassID:  userObj.ClassID,
+	})
 	return nil
 }
 
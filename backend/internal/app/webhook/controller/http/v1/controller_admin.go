@@ -0,0 +1,213 @@
+package v1
+
+import (
+	"errors"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/webhook"
+	"skadi/backend/internal/pkg/httperror"
+	"skadi/backend/internal/pkg/serialize"
+	"skadi/backend/internal/pkg/validator"
+)
+
+// WebhookControllerAdmin represents a controller for webhook routes accepted for admin only.
+type WebhookControllerAdmin struct {
+	valid       validator.Validator
+	hookUCAdmin webhook.UsecaseAdmin
+}
+
+// NewControllerAdmin returns a new instance of [WebhookControllerAdmin].
+func NewControllerAdmin(hookUCAdmin webhook.UsecaseAdmin,
+	valid validator.Validator) *WebhookControllerAdmin {
+
+	return &WebhookControllerAdmin{
+		valid:       valid,
+		hookUCAdmin: hookUCAdmin,
+	}
+}
+
+// @summary		Создание вебхука. [Только админ]
+// @description	Создание подписки внешнего URL на события платформы.
+// @description	События отправляются POST-запросом с JSON-телом {event, created_at, data}.
+// @description	Запрос подписан заголовком X-Skadi-Signature: "sha256=" + hex(HMAC-SHA256(secret, X-Skadi-Timestamp + "." + тело)).
+// @description	Неудачные доставки (не 2xx) повторяются с экспоненциальной задержкой.
+// @router			/webhook [post]
+// @id				webhook-create
+// @tags			webhook
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			webhookBody	body		webhookBody	true	"webhookBody"
+// @success		201			{object}	entity.Webhook
+// @failure		401			"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403			"доступ запрещён"
+func (c *WebhookControllerAdmin) Create(ctx *fiber.Ctx) error {
+	inputBody := &webhookBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	hookObj := inputBody.ToEntity()
+	if err := c.hookUCAdmin.Create(hookObj); err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(hookObj)
+}
+
+// @summary		Получение списка вебхуков. [Только админ]
+// @description	Получение списка всех вебхуков (секреты не возвращаются).
+// @router			/webhook [get]
+// @id				webhook-list
+// @tags			webhook
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @success		200	{array}	entity.Webhook
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+func (c *WebhookControllerAdmin) List(ctx *fiber.Ctx) error {
+	hooks, err := c.hookUCAdmin.List()
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(hooks)
+}
+
+// @summary		Получение вебхука по id. [Только админ]
+// @description	Получение вебхука по его id (секрет не возвращается).
+// @router			/webhook/{id} [get]
+// @id				webhook-read
+// @tags			webhook
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id	path		int	true	"ID вебхука"
+// @success		200	{object}	entity.Webhook
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+// @failure		404	"вебхук не найден"
+func (c *WebhookControllerAdmin) Read(ctx *fiber.Ctx) error {
+	inputPath := &webhookIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	hookObj, err := c.hookUCAdmin.GetByID(inputPath.ID)
+	if errors.Is(err, webhook.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "вебхук не найден",
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(hookObj)
+}
+
+// @summary		Обновление вебхука по id. [Только админ]
+// @description	Частичное обновление вебхука (только переданные поля) по его id.
+// @router			/webhook/{id} [patch]
+// @id				webhook-update
+// @tags			webhook
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id			path		int			true	"ID вебхука"
+// @param			updateBody	body		updateBody	true	"updateBody"
+// @success		200			{object}	entity.Webhook
+// @failure		401			"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403			"доступ запрещён"
+// @failure		404			"вебхук не найден"
+func (c *WebhookControllerAdmin) Update(ctx *fiber.Ctx) error {
+	inputPath := &webhookIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputBody := &updateBody{}
+	if err := serialize.Deserialize(inputBody, ctx.BodyParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	hookObj, err := c.hookUCAdmin.Update(inputPath.ID, inputBody.ToEntity())
+	if errors.Is(err, webhook.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "вебхук не найден",
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusOK).JSON(hookObj)
+}
+
+// @summary		Удаление вебхука по id. [Только админ]
+// @description	Удаление вебхука вместе с журналом доставок по его id.
+// @router			/webhook/{id} [delete]
+// @id				webhook-delete
+// @tags			webhook
+// @accept			json
+// @security		JWTAccess
+// @param			id	path	int	true	"ID вебхука"
+// @success		204	"No Content"
+// @failure		401	"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403	"доступ запрещён"
+func (c *WebhookControllerAdmin) Delete(ctx *fiber.Ctx) error {
+	inputPath := &webhookIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+
+	if err := c.hookUCAdmin.DeleteByID(inputPath.ID); err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusNoContent).JSON(nil)
+}
+
+// @summary		Журнал доставок вебхука. [Только админ]
+// @description	Получение журнала доставок вебхука (сначала новые): тело запроса, число попыток,
+// @description	код ответа и ошибка последней попытки, время следующей попытки и доставки.
+// @router			/webhook/{id}/delivery [get]
+// @id				webhook-list-deliveries
+// @tags			webhook
+// @accept			json
+// @produce		json
+// @security		JWTAccess
+// @param			id					path		int					true	"ID вебхука"
+// @param			listDeliveryQuery	query		listDeliveryQuery	false	"listDeliveryQuery"
+// @success		200					{object}	listDeliveryOut
+// @failure		401					"неверный токен (пустой, истекший или неверный формат)"
+// @failure		403					"доступ запрещён"
+// @failure		404					"вебхук не найден"
+func (c *WebhookControllerAdmin) ListDeliveries(ctx *fiber.Ctx) error {
+	inputPath := &webhookIDPath{}
+	if err := serialize.Deserialize(inputPath, ctx.ParamsParser, c.valid.Validate); err != nil {
+		return err
+	}
+	inputQuery := &listDeliveryQuery{}
+	if err := serialize.Deserialize(inputQuery, ctx.QueryParser, c.valid.Validate); err != nil {
+		return err
+	}
+	pageParams := inputQuery.ToPagination()
+
+	deliveries, err := c.hookUCAdmin.ListDeliveries(inputPath.ID, pageParams)
+	if errors.Is(err, webhook.ErrNotFound) {
+		return &httperror.HTTPError{
+			CauseErr:   err,
+			StatusCode: fiber.StatusNotFound,
+			Message:    "вебхук не найден",
+		}
+	}
+	if err != nil {
+		return err
+	}
+	output := &listDeliveryOut{
+		Data:       deliveries,
+		Pagination: pageParams,
+	}
+	return ctx.Status(fiber.StatusOK).JSON(output)
+}
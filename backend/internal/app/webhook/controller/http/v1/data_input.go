@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/pkg/utils/slices"
+)
+
+// @description webhookBody represents a data to create webhook.
+type webhookBody struct {
+	// URL to send events to
+	URL string `json:"url" validate:"required,http_url,max=2048" example:"https://crm.school.ru/skadi/hook"`
+	// secret to sign deliveries with HMAC-SHA256
+	Secret string `json:"secret" validate:"required,min=16,max=255" example:"very-long-webhook-secret"`
+	// event types: task.created, solution.status_changed, solution.graded, comment.created, user.created
+	Events []string `json:"events" validate:"required,min=1,dive,oneof=task.created solution.status_changed solution.graded comment.created user.created" example:"solution.graded"`
+	// false to pause deliveries (true by default)
+	Enabled *bool `json:"enabled" validate:"omitempty" example:"true"`
+}
+
+// ToEntity returns a webhook object.
+func (b *webhookBody) ToEntity() *entity.Webhook {
+	hookObj := &entity.Webhook{
+		URL:     b.URL,
+		Secret:  b.Secret,
+		Events:  toEvents(b.Events),
+		Enabled: true,
+	}
+	if b.Enabled != nil {
+		hookObj.Enabled = *b.Enabled
+	}
+	return hookObj
+}
+
+// @description updateBody represents a data to update webhook (only given fields are changed).
+type updateBody struct {
+	// URL to send events to
+	URL *string `json:"url" validate:"omitempty,http_url,max=2048" example:"https://crm.school.ru/skadi/hook"`
+	// secret to sign deliveries with HMAC-SHA256
+	Secret *string `json:"secret" validate:"omitempty,min=16,max=255" example:"very-long-webhook-secret"`
+	// event types: task.created, solution.status_changed, solution.graded, comment.created, user.created
+	Events []string `json:"events" validate:"omitempty,min=1,dive,oneof=task.created solution.status_changed solution.graded comment.created user.created" example:"solution.graded"`
+	// false to pause deliveries
+	Enabled *bool `json:"enabled" validate:"omitempty" example:"false"`
+}
+
+// ToEntity returns a webhook update object.
+func (b *updateBody) ToEntity() *entity.WebhookUpdate {
+	newData := &entity.WebhookUpdate{
+		URL:     b.URL,
+		Secret:  b.Secret,
+		Enabled: b.Enabled,
+	}
+	if b.Events != nil {
+		newData.Events = toEvents(b.Events)
+	}
+	return newData
+}
+
+// @description webhookIDPath represents a data with webhook ID in path params.
+type webhookIDPath struct {
+	// webhook id
+	ID int `params:"id" validate:"required" example:"2"`
+}
+
+// @description listDeliveryQuery represents a data with optional query-params to get delivery log.
+type listDeliveryQuery struct {
+	// pagination params
+	entity.PaginationQuery
+}
+
+// toEvents converts event names to event types without duplicates.
+func toEvents(names []string) []entity.WebhookEvent {
+	names = slices.DelDupls(names)
+	events := make([]entity.WebhookEvent, len(names))
+	for idx, name := range names {
+		events[idx] = entity.WebhookEvent(name)
+	}
+	return events
+}
@@ -0,0 +1,11 @@
+package v1
+
+import "skadi/backend/internal/app/entity"
+
+// @description listDeliveryOut represents a webhook delivery log and pagination params.
+type listDeliveryOut struct {
+	// deliveries list (newest first)
+	Data []entity.WebhookDelivery `json:"data" validate:"required"`
+	// pagination params
+	Pagination *entity.Pagination `json:"pagination,omitempty" validate:"omitempty"`
+}
@@ -0,0 +1,23 @@
+// Package http/v1 is a first version of webhook HTTP-controller.
+// It provides registers for webhook HTTP-routes and controller with handlers for them.
+package v1
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/service/server/middleware"
+)
+
+// RegisterEndpoints registers all webhook endpoints.
+func RegisterEndpoints(router fiber.Router, controllerAdmin *WebhookControllerAdmin,
+	mwJWTAccess fiber.Handler, mwAllow middleware.AllowFunc) {
+
+	authGroup := router.Group("/webhook", mwJWTAccess, mwAllow(entity.Admin))
+	authGroup.Post("/", controllerAdmin.Create)
+	authGroup.Get("/", controllerAdmin.List)
+	authGroup.Get("/:id", controllerAdmin.Read)
+	authGroup.Patch("/:id", controllerAdmin.Update)
+	authGroup.Delete("/:id", controllerAdmin.Delete)
+	authGroup.Get("/:id/delivery", controllerAdmin.ListDeliveries)
+}
@@ -0,0 +1,7 @@
+package webhook
+
+import "errors"
+
+var (
+	ErrNotFound = errors.New("record not found") // code 404
+)
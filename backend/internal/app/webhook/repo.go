@@ -0,0 +1,38 @@
+package webhook
+
+import (
+	"time"
+
+	"skadi/backend/internal/app/entity"
+)
+
+// RepositoryDB describes all DB methods for webhooks.
+type RepositoryDB interface {
+	// Create creates a new webhook.
+	Create(hookObj *entity.Webhook) error
+	// GetAll returns all webhooks.
+	GetAll() ([]entity.Webhook, error)
+	// GetByID returns webhook by the given ID.
+	GetByID(id int) (*entity.Webhook, error)
+	// Update saves URL, secret, events and enabled state of the webhook.
+	Update(hookObj *entity.Webhook) error
+	// Delete deletes the webhook by the given ID.
+	Delete(id int) error
+	// GetSubscribed returns enabled webhooks subscribed to the event.
+	GetSubscribed(evt entity.WebhookEvent) ([]entity.Webhook, error)
+
+	// Enqueue puts the given deliveries to the send queue.
+	Enqueue(deliveries []entity.WebhookDelivery) error
+	// GetDeliveries returns the webhook deliveries (newest first).
+	GetDeliveries(webhookID int, page *entity.Pagination) ([]entity.WebhookDelivery, error)
+	// GetDue returns undelivered deliveries of enabled webhooks
+	// which next attempt time has come (with webhooks).
+	GetDue(now time.Time, maxAttempts, limit int) ([]entity.WebhookDelivery, error)
+	// Claim postpones the next attempt of the delivery until the given time.
+	// It returns false if the delivery was already claimed by another worker.
+	Claim(deliveryObj *entity.WebhookDelivery, until time.Time) (bool, error)
+	// MarkDelivered sets the response status code and delivery datetime.
+	MarkDelivered(id, statusCode int, deliveredAt time.Time) error
+	// MarkFailed saves the failed attempt of the delivery and the next attempt time.
+	MarkFailed(id, attempts int, statusCode *int, nextAttemptAt time.Time, lastErr string) error
+}
@@ -0,0 +1,190 @@
+// Package repository contains webhook.RepositoryDB implementation.
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/webhook"
+)
+
+const (
+	_joinWebhook = "Webhook" // object field name
+
+	_fieldID            = "id"              // table field name
+	_fieldWebhookID     = "webhook_id"      // table field name
+	_fieldURL           = "url"             // table field name
+	_fieldSecret        = "secret"          // table field name
+	_fieldEvents        = "events"          // table field name
+	_fieldEnabled       = "enabled"         // table field name
+	_fieldAttempts      = "attempts"        // table field name
+	_fieldStatusCode    = "status_code"     // table field name
+	_fieldLastError     = "last_error"      // table field name
+	_fieldNextAttemptAt = "next_attempt_at" // table field name
+	_fieldDeliveredAt   = "delivered_at"    // table field name
+
+	// condition for the webhook subscribed to the event (event is a query param)
+	_condSubscribed = "JSON_CONTAINS(" + _fieldEvents + ", JSON_QUOTE(?))"
+)
+
+// Ensure RepoDB implements interface.
+var _ webhook.RepositoryDB = (*RepoDB)(nil)
+
+// RepoDB is a webhook DB repo.
+// It implements the [webhook.RepositoryDB] interface.
+type RepoDB struct {
+	dbStorage *gorm.DB
+}
+
+// NewRepoDB returns a new instance of [RepoDB].
+func NewRepoDB(dbStorage *gorm.DB) *RepoDB {
+	return &RepoDB{
+		dbStorage: dbStorage,
+	}
+}
+
+// Create creates a new webhook.
+func (r *RepoDB) Create(hookObj *entity.Webhook) error {
+	return r.dbStorage.Create(hookObj).Error // nil OR error
+}
+
+// GetAll returns all webhooks.
+func (r *RepoDB) GetAll() ([]entity.Webhook, error) {
+	hooks := []entity.Webhook{}
+	if err := r.dbStorage.Order(_fieldID).Find(&hooks).Error; err != nil {
+		return nil, err
+	}
+	return hooks, nil
+}
+
+// GetByID returns webhook by the given ID.
+func (r *RepoDB) GetByID(id int) (*entity.Webhook, error) {
+	var hookObj entity.Webhook
+	err := r.dbStorage.Where(id).First(&hookObj).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// webhook object with such id not found
+		return nil, fmt.Errorf("webhook with id: %w", webhook.ErrNotFound)
+	}
+	return &hookObj, err // err OR nil
+}
+
+// Update saves URL, secret, events and enabled state of the webhook.
+func (r *RepoDB) Update(hookObj *entity.Webhook) error {
+	return r.dbStorage.
+		Model(hookObj).
+		Select(_fieldURL, _fieldSecret, _fieldEvents, _fieldEnabled).
+		Updates(hookObj).Error // nil OR error
+}
+
+// Delete deletes the webhook by the given ID.
+// Its deliveries are deleted by the DB (cascade).
+func (r *RepoDB) Delete(id int) error {
+	return r.dbStorage.Delete(&entity.Webhook{}, id).Error // nil OR error
+}
+
+// GetSubscribed returns enabled webhooks subscribed to the event.
+func (r *RepoDB) GetSubscribed(evt entity.WebhookEvent) ([]entity.Webhook, error) {
+	hooks := []entity.Webhook{}
+	err := r.dbStorage.
+		Where(_fieldEnabled+" = ?", true).
+		Where(_condSubscribed, evt).
+		Order(_fieldID).
+		Find(&hooks).Error
+	if err != nil {
+		return nil, err
+	}
+	return hooks, nil
+}
+
+// Enqueue puts the given deliveries to the send queue.
+func (r *RepoDB) Enqueue(deliveries []entity.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.dbStorage.Omit(clause.Associations).Create(&deliveries).Error // nil OR error
+}
+
+// GetDeliveries returns the webhook deliveries (newest first).
+func (r *RepoDB) GetDeliveries(webhookID int,
+	page *entity.Pagination) ([]entity.WebhookDelivery, error) {
+
+	deliveries := []entity.WebhookDelivery{}
+	// create query
+	query := r.dbStorage.
+		Model(&entity.WebhookDelivery{}).
+		Where(_fieldWebhookID+" = ?", webhookID).
+		Order("id DESC")
+	// apply pagination if it's not nil
+	if page != nil {
+		page.CountTotal(query)
+		query = page.Query(query)
+	}
+	// exec query
+	if err := query.Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// GetDue returns undelivered deliveries of enabled webhooks
+// which next attempt time has come (with webhooks).
+func (r *RepoDB) GetDue(now time.Time, maxAttempts,
+	limit int) ([]entity.WebhookDelivery, error) {
+
+	deliveries := []entity.WebhookDelivery{}
+	err := r.dbStorage.
+		Joins(_joinWebhook).
+		Where(_joinWebhook+"."+_fieldEnabled+" = ?", true).
+		Where("webhook_delivery."+_fieldDeliveredAt+" IS NULL").
+		Where("webhook_delivery."+_fieldNextAttemptAt+" <= ?", now).
+		Where("webhook_delivery."+_fieldAttempts+" < ?", maxAttempts).
+		Order("webhook_delivery." + _fieldNextAttemptAt).
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// Claim postpones the next attempt of the delivery until the given time.
+// It returns false if the delivery was already claimed by another worker.
+func (r *RepoDB) Claim(deliveryObj *entity.WebhookDelivery, until time.Time) (bool, error) {
+	res := r.dbStorage.
+		Model(&entity.WebhookDelivery{}).
+		Where(_fieldID+" = ? AND "+_fieldDeliveredAt+" IS NULL", deliveryObj.ID).
+		Where(_fieldNextAttemptAt+" = ?", deliveryObj.NextAttemptAt).
+		Update(_fieldNextAttemptAt, until)
+	return res.RowsAffected == 1, res.Error
+}
+
+// MarkDelivered sets the response status code and delivery datetime.
+func (r *RepoDB) MarkDelivered(id, statusCode int, deliveredAt time.Time) error {
+	return r.dbStorage.
+		Model(&entity.WebhookDelivery{}).
+		Where(_fieldID+" = ?", id).
+		Updates(map[string]any{
+			_fieldStatusCode:  statusCode,
+			_fieldDeliveredAt: deliveredAt,
+		}).Error // nil OR error
+}
+
+// MarkFailed saves the failed attempt of the delivery and the next attempt time.
+func (r *RepoDB) MarkFailed(id, attempts int, statusCode *int, nextAttemptAt time.Time,
+	lastErr string) error {
+
+	return r.dbStorage.
+		Model(&entity.WebhookDelivery{}).
+		Where(_fieldID+" = ?", id).
+		Updates(map[string]any{
+			_fieldAttempts:      attempts,
+			_fieldStatusCode:    statusCode,
+			_fieldNextAttemptAt: nextAttemptAt,
+			_fieldLastError:     lastErr,
+		}).Error // nil OR error
+}
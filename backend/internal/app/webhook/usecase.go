@@ -0,0 +1,39 @@
+// Package webhook contains all repos, usecases and controllers for webhooks.
+// Sub-package repo contains RepoDB implementation.
+// Sub-package usecase contains UsecaseAdmin and Emitter implementations.
+package webhook
+
+import "skadi/backend/internal/app/entity"
+
+// UsecaseAdmin describes all webhook usecases for admin.
+type UsecaseAdmin interface {
+	// Create creates a new webhook.
+	Create(hookObj *entity.Webhook) error
+	// List returns all webhooks.
+	List() ([]entity.Webhook, error)
+	// GetByID returns webhook by the given ID.
+	GetByID(id int) (*entity.Webhook, error)
+	// Update updates the webhook by the given ID with the new data.
+	Update(id int, newData *entity.WebhookUpdate) (*entity.Webhook, error)
+	// DeleteByID deletes the webhook with its delivery log by the given ID.
+	DeleteByID(id int) error
+	// ListDeliveries returns the webhook delivery log (newest first).
+	ListDeliveries(webhookID int, page *entity.Pagination) ([]entity.WebhookDelivery, error)
+}
+
+// Emitter describes a sender of domain events to the webhooks.
+// It is used by usecases of other domains.
+type Emitter interface {
+	// Emit enqueues deliveries of the event to all enabled webhooks subscribed to it.
+	// This method never fails: errors are only logged.
+	Emit(evt entity.WebhookEvent, data any)
+	// HandleTaskCreated enqueues deliveries of the task.created webhook event.
+	// It handles the task.created outbox event.
+	HandleTaskCreated(evtObj *entity.OutboxEvent) error
+	// HandleStatusChanged enqueues deliveries of the solution.status_changed webhook event.
+	// It handles the solution.updated outbox event.
+	HandleStatusChanged(evtObj *entity.OutboxEvent) error
+	// HandleGraded enqueues deliveries of the solution.graded webhook event.
+	// It handles the solution.updated outbox event.
+	HandleGraded(evtObj *entity.OutboxEvent) error
+}
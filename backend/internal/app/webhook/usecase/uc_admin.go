@@ -0,0 +1,86 @@
+// Package usecase contains webhook.UsecaseAdmin and webhook.Emitter implementations.
+package usecase
+
+import (
+	"fmt"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/webhook"
+)
+
+// Ensure UCAdmin implements interfaces.
+var _ webhook.UsecaseAdmin = (*UCAdmin)(nil)
+
+// UCAdmin represents a webhook usecase for admin.
+// It implements the [webhook.UsecaseAdmin] interface.
+type UCAdmin struct {
+	cfg        *config.Config
+	hookRepoDB webhook.RepositoryDB
+}
+
+// NewUCAdmin returns a new instance of [UCAdmin].
+func NewUCAdmin(cfg *config.Config, hookRepoDB webhook.RepositoryDB) *UCAdmin {
+	return &UCAdmin{
+		cfg:        cfg,
+		hookRepoDB: hookRepoDB,
+	}
+}
+
+// Create creates a new webhook.
+func (u *UCAdmin) Create(hookObj *entity.Webhook) error {
+	if err := u.hookRepoDB.Create(hookObj); err != nil {
+		return fmt.Errorf("create webhook: %w", err)
+	}
+	return nil
+}
+
+// List returns all webhooks.
+func (u *UCAdmin) List() ([]entity.Webhook, error) {
+	return u.hookRepoDB.GetAll()
+}
+
+// GetByID returns webhook by the given ID.
+func (u *UCAdmin) GetByID(id int) (*entity.Webhook, error) {
+	return u.hookRepoDB.GetByID(id)
+}
+
+// Update updates the webhook by the given ID with the new data.
+// Only given fields are changed.
+func (u *UCAdmin) Update(id int, newData *entity.WebhookUpdate) (*entity.Webhook, error) {
+	hookObj, err := u.hookRepoDB.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook: %w", err)
+	}
+	if newData.URL != nil {
+		hookObj.URL = *newData.URL
+	}
+	if newData.Secret != nil {
+		hookObj.Secret = *newData.Secret
+	}
+	if newData.Events != nil {
+		hookObj.Events = newData.Events
+	}
+	if newData.Enabled != nil {
+		hookObj.Enabled = *newData.Enabled
+	}
+	if err := u.hookRepoDB.Update(hookObj); err != nil {
+		return nil, fmt.Errorf("update webhook: %w", err)
+	}
+	return hookObj, nil
+}
+
+// DeleteByID deletes the webhook with its delivery log by the given ID.
+func (u *UCAdmin) DeleteByID(id int) error {
+	return u.hookRepoDB.Delete(id)
+}
+
+// ListDeliveries returns the webhook delivery log (newest first).
+func (u *UCAdmin) ListDeliveries(webhookID int,
+	page *entity.Pagination) ([]entity.WebhookDelivery, error) {
+
+	if _, err := u.hookRepoDB.GetByID(webhookID); err != nil {
+		return nil, fmt.Errorf("get webhook: %w", err)
+	}
+	return u.hookRepoDB.GetDeliveries(webhookID, page)
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"skadi/backend/config"
+	"skadi/backend/internal/app/entity"
+	"skadi/backend/internal/app/webhook"
+)
+
+// Ensure UCEmitter implements interfaces.
+var _ webhook.Emitter = (*UCEmitter)(nil)
+
+// UCEmitter represents a webhook usecase for other usecases to emit domain events.
+// Deliveries are put to the durable queue and sent by the webhook sender service.
+// It implements the [webhook.Emitter] interface.
+type UCEmitter struct {
+	cfg        *config.Config
+	hookRepoDB webhook.RepositoryDB
+}
+
+// NewUCEmitter returns a new instance of [UCEmitter].
+func NewUCEmitter(cfg *config.Config, hookRepoDB webhook.RepositoryDB) *UCEmitter {
+	return &UCEmitter{
+		cfg:        cfg,
+		hookRepoDB: hookRepoDB,
+	}
+}
+
+// Emit enqueues deliveries of the event to all enabled webhooks subscribed to it.
+// Errors are only logged.
+func (u *UCEmitter) Emit(evt entity.WebhookEvent, data any) {
+	if err := u.emit(evt, data); err != nil {
+		slog.Warn("emit webhook event", "event", evt, "error", err)
+	}
+}
+
+// HandleTaskCreated enqueues deliveries of the task.created webhook event for the new task.
+// It handles the task.created outbox event.
+func (u *UCEmitter) HandleTaskCreated(evtObj *entity.OutboxEvent) error {
+	var evtData entity.TaskCreatedEvent
+	if err := evtObj.Decode(&evtData); err != nil {
+		return fmt.Errorf("decode event: %w", err)
+	}
+	return u.emit(entity.HookTaskCreated, &entity.WebhookTaskData{
+		TaskID:     evtData.TaskID,
+		TeacherID:  evtData.TeacherID,
+		Title:      evtData.Title,
+		StudentIDs: evtData.StudentIDs,
+	})
+}
+
+// HandleStatusChanged enqueues deliveries of the solution.status_changed webhook event
+// if the solution status was changed. It handles the solution.updated outbox event.
+func (u *UCEmitter) HandleStatusChanged(evtObj *entity.OutboxEvent) error {
+	var evtData entity.SolutionUpdatedEvent
+	if err := evtObj.Decode(&evtData); err != nil {
+		return fmt.Errorf("decode event: %w", err)
+	}
+	if evtData.StatusID == evtData.OldStatusID {
+		return nil
+	}
+	return u.emit(entity.HookSolutionStatusChanged, solutionData(&evtData))
+}
+
+// HandleGraded enqueues deliveries of the solution.graded webhook event
+// if the solution was graded. It handles the solution.updated outbox event.
+func (u *UCEmitter) HandleGraded(evtObj *entity.OutboxEvent) error {
+	var evtData entity.SolutionUpdatedEvent
+	if err := evtObj.Decode(&evtData); err != nil {
+		return fmt.Errorf("decode event: %w", err)
+	}
+	if !evtData.Graded {
+		return nil
+	}
+	return u.emit(entity.HookSolutionGraded, solutionData(&evtData))
+}
+
+// solutionData returns the webhook data of the updated solution.
+func solutionData(evtData *entity.SolutionUpdatedEvent) *entity.WebhookSolutionData {
+	return &entity.WebhookSolutionData{
+		SolutionID:  evtData.SolutionID,
+		TaskID:      evtData.TaskID,
+		StudentID:   evtData.StudentID,
+		StatusID:    evtData.StatusID,
+		OldStatusID: evtData.OldStatusID,
+		Grade:       evtData.Grade,
+		StudentIDs:  evtData.StudentIDs,
+	}
+}
+
+// emit enqueues deliveries of the event to all enabled webhooks subscribed to it.
+func (u *UCEmitter) emit(evt entity.WebhookEvent, data any) error {
+	hooks, err := u.hookRepoDB.GetSubscribed(evt)
+	if err != nil {
+		return fmt.Errorf("get subscribed webhooks: %w", err)
+	}
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(&entity.WebhookPayload{
+		Event:     evt,
+		CreatedAt: now,
+		Data:      data,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+	deliveries := make([]entity.WebhookDelivery, len(hooks))
+	for idx := range hooks {
+		deliveries[idx] = entity.WebhookDelivery{
+			WebhookID:     hooks[idx].ID,
+			Event:         evt,
+			Payload:       string(payload),
+			NextAttemptAt: now,
+		}
+	}
+	if err := u.hookRepoDB.Enqueue(deliveries); err != nil {
+		return fmt.Errorf("enqueue deliveries: %w", err)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@
 package cache
 
 import (
+	"context"
 	"time"
 
 	_ "github.com/gofiber/fiber/v2" // imported for comment hint
@@ -13,6 +14,9 @@ import (
 type Storage interface {
 	// Get gets the value for the given key.
 	Get(key string) ([]byte, error)
+	// Pop gets the value for the given key and deletes the key atomically.
+	// Only one of the concurrent callers gets the value.
+	Pop(key string) ([]byte, error)
 	// Set stores the given value for the given key along
 	// with an expiration value, 0 means no expiration.
 	Set(key string, val []byte, exp time.Duration) error
@@ -23,3 +27,19 @@ type Storage interface {
 	// Close closes the redis client.
 	Close() error
 }
+
+// PubSub represents a publish/subscribe messaging channel.
+type PubSub interface {
+	// Publish sends the message to all subscribers of the channel.
+	Publish(channel string, msg []byte) error
+	// Subscribe subscribes to the channel and returns a chan with received messages.
+	// The returned chan is closed when the context is done.
+	Subscribe(ctx context.Context, channel string) (<-chan []byte, error)
+}
+
+// Locker represents a storage of the distributed locks.
+type Locker interface {
+	// Lock sets the key with an expiration value if it does not exist yet.
+	// It returns false if the key is already locked by another owner.
+	Lock(key string, exp time.Duration) (bool, error)
+}
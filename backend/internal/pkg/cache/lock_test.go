@@ -0,0 +1,27 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLock(t *testing.T) {
+	t.Log("Lock key twice and get false for the second time")
+
+	locker, ok := _storage.(Locker)
+	require.True(t, ok, "storage is not a locker")
+
+	key := _key + ":lock"
+	require.NoError(t, _storage.Delete(key), "delete key error")
+
+	locked, err := locker.Lock(key, _exp)
+	require.NoError(t, err, "lock error")
+	require.True(t, locked)
+
+	locked, err = locker.Lock(key, _exp)
+	require.NoError(t, err, "lock error")
+	require.False(t, locked)
+
+	require.NoError(t, _storage.Delete(key), "delete key error")
+}
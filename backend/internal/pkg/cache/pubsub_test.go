@@ -0,0 +1,34 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPubSub(t *testing.T) {
+	t.Log("Subscribe to channel and receive published message")
+
+	pubSub, ok := _storage.(PubSub)
+	require.True(t, ok, "storage is not a pub/sub")
+
+	ctx, cancel := context.WithTimeout(context.Background(), _exp)
+	defer cancel()
+	msgChan, err := pubSub.Subscribe(ctx, _key)
+	require.NoError(t, err, "subscribe error")
+
+	require.NoError(t, pubSub.Publish(_key, _value), "publish error")
+	select {
+	case msg := <-msgChan:
+		require.Equal(t, _value, msg)
+	case <-time.After(_exp):
+		t.Fatal("message was not received")
+	}
+
+	// chan is closed after context is done
+	cancel()
+	for range msgChan {
+	}
+}
@@ -12,11 +12,15 @@ const (
 	_queryTimeout = 2 * time.Second // ctx timeout for cache queries
 )
 
-// Ensure Redis implements interface.
-var _ Storage = (*Redis)(nil)
+// Ensure Redis implements interfaces.
+var (
+	_ Storage = (*Redis)(nil)
+	_ PubSub  = (*Redis)(nil)
+	_ Locker  = (*Redis)(nil)
+)
 
-// Redis is a cache key-value storage based on Redis.
-// Redis implements the [Storage] interface.
+// Redis is a cache key-value storage and pub/sub channel based on Redis.
+// Redis implements the [Storage] and [PubSub] interfaces.
 type Redis struct {
 	client *redis.Client
 }
@@ -54,6 +58,20 @@ func (s *Redis) Get(key string) ([]byte, error) {
 	return value, nil
 }
 
+// Pop gets the value for the given key and deletes the key atomically.
+// Only one of the concurrent callers gets the value.
+func (s *Redis) Pop(key string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), _queryTimeout)
+	defer cancel()
+	value, err := s.client.GetDel(ctx, key).Bytes()
+
+	// if NOT "Not found" error
+	if err != nil && !redis.HasErrorPrefix(err, "redis: nil") {
+		return nil, fmt.Errorf("pop value: %w", err)
+	}
+	return value, nil
+}
+
 // Set stores the given value for the given key along
 // with an expiration value, 0 means no expiration.
 func (s *Redis) Set(key string, val []byte, exp time.Duration) error {
@@ -85,6 +103,62 @@ func (s *Redis) Reset() error {
 	return nil
 }
 
+// Lock sets the key with an expiration value if it does not exist yet.
+// It returns false if the key is already locked by another owner.
+func (s *Redis) Lock(key string, exp time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), _queryTimeout)
+	defer cancel()
+	locked, err := s.client.SetNX(ctx, key, 1, exp).Result()
+	if err != nil {
+		return false, fmt.Errorf("lock key: %w", err)
+	}
+	return locked, nil
+}
+
+// Publish sends the message to all subscribers of the channel.
+func (s *Redis) Publish(channel string, msg []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), _queryTimeout)
+	defer cancel()
+	if err := s.client.Publish(ctx, channel, msg).Err(); err != nil {
+		return fmt.Errorf("publish message: %w", err)
+	}
+	return nil
+}
+
+// Subscribe subscribes to the channel and returns a chan with received messages.
+// The returned chan is closed when the context is done.
+func (s *Redis) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	sub := s.client.Subscribe(ctx, channel)
+	// wait for subscription is confirmed
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		return nil, fmt.Errorf("subscribe: %w", err)
+	}
+
+	msgChan := make(chan []byte)
+	go func() {
+		defer close(msgChan)
+		defer sub.Close()
+		redisChan := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-redisChan:
+				if !ok {
+					return
+				}
+				select {
+				case msgChan <- []byte(msg.Payload):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return msgChan, nil
+}
+
 // Close closes the redis client.
 func (s *Redis) Close() error {
 	if err := s.client.Close(); err != nil {
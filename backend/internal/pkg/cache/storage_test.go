@@ -48,6 +48,19 @@ func TestGet(t *testing.T) {
 	t.Logf("Gotten value: %q", value)
 }
 
+func TestPop(t *testing.T) {
+	TestSet(t)
+
+	t.Log("Pop value twice and get nothing for the second time")
+	value, err := _storage.Pop(_key)
+	require.NoError(t, err, "pop value error")
+	require.Equal(t, _value, value)
+
+	value, err = _storage.Pop(_key)
+	require.NoError(t, err, "pop value error")
+	require.Empty(t, value)
+}
+
 func TestDelete(t *testing.T) {
 	t.Log("Delete value")
 
@@ -0,0 +1,159 @@
+// Package clamd provides a client for the ClamAV daemon (clamd).
+// It supports TCP and Unix socket connections and uses the INSTREAM command
+// to scan data, so clamd does not need access to the scanned files.
+package clamd
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	_chunkSize = 64 * 1024 // size of the data chunk sending to clamd
+
+	_cmdPing     = "zPING\x00"     // ping command (null-terminated)
+	_cmdInstream = "zINSTREAM\x00" // stream scan command (null-terminated)
+
+	_replyPong   = "PONG"     // reply for ping command
+	_replyPrefix = "stream: " // prefix of the reply for INSTREAM command
+	_replyOK     = "OK"       // reply body for clean data
+	_replyFound  = " FOUND"   // suffix of the reply body for infected data
+	_replyDelim  = '\x00'     // replies for z-commands are null-terminated
+)
+
+// ErrScan means that clamd returned an error instead of a scan result.
+var ErrScan = errors.New("clamd scan error")
+
+// Result represents a result of the data scanning.
+type Result struct {
+	// true if a virus was found
+	Infected bool
+	// name of the found virus signature
+	Signature string
+}
+
+// Client represents a clamd client.
+type Client struct {
+	// "tcp" or "unix"
+	network string
+	// host:port for tcp or socket path for unix
+	address string
+	// timeout for one command (connecting, sending data and reading a reply)
+	timeout time.Duration
+}
+
+// NewClient returns a new instance of [Client].
+func NewClient(network, address string, timeout time.Duration) *Client {
+	return &Client{
+		network: network,
+		address: address,
+		timeout: timeout,
+	}
+}
+
+// Ping checks clamd is available.
+func (c *Client) Ping(ctx context.Context) error {
+	reply, err := c.exec(ctx, _cmdPing, nil)
+	if err != nil {
+		return err
+	}
+	if reply != _replyPong {
+		return fmt.Errorf("%w: unexpected reply: %q", ErrScan, reply)
+	}
+	return nil
+}
+
+// Scan sends data to clamd and returns a scan result.
+func (c *Client) Scan(ctx context.Context, data io.Reader) (*Result, error) {
+	reply, err := c.exec(ctx, _cmdInstream, data)
+	if err != nil {
+		return nil, err
+	}
+	return parseScanReply(reply)
+}
+
+// exec sends the command (and stream data if it is not nil) and reads a reply.
+func (c *Client) exec(ctx context.Context, cmd string, data io.Reader) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return "", fmt.Errorf("connect to clamd: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return "", fmt.Errorf("set deadline: %w", err)
+		}
+	}
+
+	if _, err := io.WriteString(conn, cmd); err != nil {
+		return "", fmt.Errorf("send command: %w", err)
+	}
+	if data != nil {
+		if err := writeStream(conn, data); err != nil {
+			return "", fmt.Errorf("send data: %w", err)
+		}
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(_replyDelim)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("read reply: %w", err)
+	}
+	return strings.TrimSpace(strings.TrimSuffix(reply, string(_replyDelim))), nil
+}
+
+// writeStream writes data as a sequence of chunks.
+// Every chunk is prefixed with its length (4 bytes, network byte order).
+func writeStream(w io.Writer, data io.Reader) error {
+	buf := make([]byte, _chunkSize)
+	sizeBuf := make([]byte, 4)
+	for {
+		n, err := data.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(sizeBuf, uint32(n))
+			if _, err := w.Write(sizeBuf); err != nil {
+				return err
+			}
+			if _, err := w.Write(buf[:n]); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	// zero-length chunk terminates the stream
+	binary.BigEndian.PutUint32(sizeBuf, 0)
+	_, err := w.Write(sizeBuf)
+	return err
+}
+
+// parseScanReply parses clamd reply for INSTREAM command.
+// Reply examples: "stream: OK", "stream: Eicar-Signature FOUND",
+// "INSTREAM size limit exceeded. ERROR".
+func parseScanReply(reply string) (*Result, error) {
+	body, ok := strings.CutPrefix(reply, _replyPrefix)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrScan, reply)
+	}
+	if body == _replyOK {
+		return &Result{}, nil
+	}
+	if signature, found := strings.CutSuffix(body, _replyFound); found {
+		return &Result{Infected: true, Signature: signature}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrScan, reply)
+}
@@ -0,0 +1,88 @@
+package clamd
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// startStub starts a fake clamd which reads INSTREAM data
+// and replies FOUND if the data contains the "virus" word.
+func startStub(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveStub(conn)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func serveStub(conn net.Conn) {
+	defer conn.Close()
+
+	cmd := make([]byte, len(_cmdInstream))
+	if _, err := io.ReadFull(conn, cmd); err != nil {
+		return
+	}
+	var data bytes.Buffer
+	sizeBuf := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(conn, sizeBuf); err != nil {
+			return
+		}
+		size := binary.BigEndian.Uint32(sizeBuf)
+		if size == 0 {
+			break
+		}
+		if _, err := io.CopyN(&data, conn, int64(size)); err != nil {
+			return
+		}
+	}
+
+	reply := "stream: OK\x00"
+	if strings.Contains(data.String(), "virus") {
+		reply = "stream: Test-Signature FOUND\x00"
+	}
+	io.WriteString(conn, reply)
+}
+
+func TestClient_Scan(t *testing.T) {
+	t.Log("Scan clean and infected data with fake clamd")
+
+	client := NewClient("tcp", startStub(t), time.Second)
+
+	res, err := client.Scan(context.Background(), strings.NewReader("hello world"))
+	require.NoError(t, err)
+	require.False(t, res.Infected)
+
+	// data bigger than one chunk
+	data := strings.Repeat("a", _chunkSize) + "virus"
+	res, err = client.Scan(context.Background(), strings.NewReader(data))
+	require.NoError(t, err)
+	require.True(t, res.Infected)
+	require.Equal(t, "Test-Signature", res.Signature)
+}
+
+func TestParseScanReply(t *testing.T) {
+	t.Log("Parse clamd error reply and get error")
+
+	_, err := parseScanReply("INSTREAM size limit exceeded. ERROR")
+	require.ErrorIs(t, err, ErrScan)
+}
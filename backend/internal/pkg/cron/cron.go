@@ -0,0 +1,180 @@
+// Package cron provides a parser of the standard cron expressions
+// (minute, hour, day of month, month, day of week) and calculates next run times.
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const _maxSearch = 5 * 366 * 24 * time.Hour // max time to search the next run
+
+// ErrInvalidExpr means that the cron expression cannot be parsed.
+var ErrInvalidExpr = errors.New("invalid cron expression")
+
+// predefined schedules
+var _descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// bounds of the expression field values
+type bounds struct {
+	min, max int
+}
+
+var (
+	_minutes  = bounds{0, 59}
+	_hours    = bounds{0, 23}
+	_days     = bounds{1, 31}
+	_months   = bounds{1, 12}
+	_weekdays = bounds{0, 7} // 0 and 7 are Sunday
+)
+
+// Schedule represents a parsed cron expression.
+// Every field is a bit set of the matching values.
+type Schedule struct {
+	minute, hour, day, month, weekday uint64
+	// true if the field is "*" (day and weekday are matched by OR if both are restricted)
+	anyDay, anyWeekday bool
+}
+
+// Parse parses the cron expression with 5 fields separated by spaces.
+// Every field supports "*", values, ranges ("1-5"), steps ("*/15", "0-30/10")
+// and lists of them ("1,15"). Descriptors like "@daily" and "@hourly" are also supported.
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if spec, ok := _descriptors[expr]; ok {
+		expr = spec
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: expected 5 fields, got %d", ErrInvalidExpr, len(fields))
+	}
+
+	var (
+		sched Schedule
+		err   error
+	)
+	if sched.minute, err = parseField(fields[0], _minutes); err != nil {
+		return nil, fmt.Errorf("minute: %w", err)
+	}
+	if sched.hour, err = parseField(fields[1], _hours); err != nil {
+		return nil, fmt.Errorf("hour: %w", err)
+	}
+	if sched.day, err = parseField(fields[2], _days); err != nil {
+		return nil, fmt.Errorf("day of month: %w", err)
+	}
+	if sched.month, err = parseField(fields[3], _months); err != nil {
+		return nil, fmt.Errorf("month: %w", err)
+	}
+	if sched.weekday, err = parseField(fields[4], _weekdays); err != nil {
+		return nil, fmt.Errorf("day of week: %w", err)
+	}
+	// 7 is Sunday too
+	if sched.weekday&(1<<7) != 0 {
+		sched.weekday |= 1
+	}
+	sched.anyDay = fields[2] == "*"
+	sched.anyWeekday = fields[4] == "*"
+	return &sched, nil
+}
+
+// Next returns the next run time after the given one (with minute precision).
+// It returns zero time if the schedule never matches (e.g. February 30).
+func (s *Schedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(_maxSearch)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches returns true if the day of month and the day of week match the schedule.
+// If both fields are restricted, the day matches any of them (like in cron).
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dayOK := s.day&(1<<uint(t.Day())) != 0
+	weekdayOK := s.weekday&(1<<uint(t.Weekday())) != 0
+	if s.anyDay || s.anyWeekday {
+		return dayOK && weekdayOK
+	}
+	return dayOK || weekdayOK
+}
+
+// parseField parses one field of the expression to the bit set of values.
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for part := range strings.SplitSeq(field, ",") {
+		rangePart, rawStep, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(rawStep)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("%w: bad step %q", ErrInvalidExpr, rawStep)
+			}
+		}
+
+		start, end := b.min, b.max
+		if rangePart != "*" {
+			rawStart, rawEnd, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = parseValue(rawStart, b); err != nil {
+				return 0, err
+			}
+			end = start
+			if isRange {
+				if end, err = parseValue(rawEnd, b); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				// "5/15" means from 5 to max with step 15
+				end = b.max
+			}
+			if start > end {
+				return 0, fmt.Errorf("%w: bad range %q", ErrInvalidExpr, rangePart)
+			}
+		}
+		for val := start; val <= end; val += step {
+			bits |= 1 << uint(val)
+		}
+	}
+	return bits, nil
+}
+
+// parseValue parses one number of the field and checks its bounds.
+func parseValue(raw string, b bounds) (int, error) {
+	val, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%w: bad value %q", ErrInvalidExpr, raw)
+	}
+	if val < b.min || val > b.max {
+		return 0, fmt.Errorf("%w: value %d out of range %d-%d", ErrInvalidExpr, val, b.min, b.max)
+	}
+	return val, nil
+}
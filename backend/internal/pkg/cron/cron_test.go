@@ -0,0 +1,48 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var _testNow = time.Date(2025, time.March, 14, 10, 7, 30, 0, time.UTC) // Friday
+
+func TestSchedule_Next(t *testing.T) {
+	t.Log("Parse expressions and get next run times")
+
+	cases := map[string]time.Time{
+		"*/15 * * * *":  time.Date(2025, time.March, 14, 10, 15, 0, 0, time.UTC),
+		"0 18 * * *":    time.Date(2025, time.March, 14, 18, 0, 0, 0, time.UTC),
+		"30 9 * * 1-5":  time.Date(2025, time.March, 17, 9, 30, 0, 0, time.UTC),
+		"0 0 * * 7":     time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC),
+		"0 0 1,15 * *":  time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC),
+		"0 0 1 * 1":     time.Date(2025, time.March, 17, 0, 0, 0, 0, time.UTC),
+		"@monthly":      time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC),
+		"5/20 10 * * *": time.Date(2025, time.March, 14, 10, 25, 0, 0, time.UTC),
+	}
+	for expr, want := range cases {
+		sched, err := Parse(expr)
+		require.NoError(t, err, expr)
+		require.Equal(t, want, sched.Next(_testNow), expr)
+	}
+}
+
+func TestSchedule_NextNever(t *testing.T) {
+	t.Log("Get zero time for schedule which never matches")
+
+	sched, err := Parse("0 0 30 2 *")
+	require.NoError(t, err)
+	require.True(t, sched.Next(_testNow).IsZero())
+}
+
+func TestParseInvalid(t *testing.T) {
+	t.Log("Parse invalid expressions and get error")
+
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "5-1 * * * *",
+		"*/0 * * * *", "a * * * *", "@sometimes"} {
+		_, err := Parse(expr)
+		require.ErrorIs(t, err, ErrInvalidExpr, expr)
+	}
+}
@@ -0,0 +1,192 @@
+// Package mail provides sending of HTML e-mails via SMTP
+// and rendering of their subjects and bodies from templates.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+const _lineLen = 76 // max length of the base64-encoded body line
+
+// Message represents an e-mail message.
+type Message struct {
+	// recipient address
+	To string
+	// message subject
+	Subject string
+	// message HTML-body
+	HTML string
+}
+
+// Sender represents a SMTP-client to send e-mails.
+type Sender struct {
+	// SMTP-server host:port
+	address string
+	// SMTP-server host (for TLS and auth)
+	host string
+	// username and password for PLAIN auth (auth is disabled if username is empty)
+	username string
+	password string
+	// sender address (with optional name)
+	from string
+	// if true, connection uses TLS from the start (port 465), otherwise STARTTLS is used if supported
+	implicitTLS bool
+	// timeout to send one message
+	timeout time.Duration
+}
+
+// NewSender returns a new instance of [Sender].
+func NewSender(host, port, username, password, from string, implicitTLS bool,
+	timeout time.Duration) *Sender {
+
+	return &Sender{
+		address:     net.JoinHostPort(host, port),
+		host:        host,
+		username:    username,
+		password:    password,
+		from:        from,
+		implicitTLS: implicitTLS,
+		timeout:     timeout,
+	}
+}
+
+// Send sends the message to SMTP-server.
+func (s *Sender) Send(ctx context.Context, msg *Message) error {
+	fromAddr, err := mail.ParseAddress(s.from)
+	if err != nil {
+		return fmt.Errorf("parse from address: %w", err)
+	}
+	toAddr, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("parse to address: %w", err)
+	}
+	data, err := s.build(fromAddr, toAddr, msg)
+	if err != nil {
+		return fmt.Errorf("build message: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	client, err := s.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer client.Close()
+
+	if err := s.auth(client); err != nil {
+		return err
+	}
+	if err := client.Mail(fromAddr.Address); err != nil {
+		return fmt.Errorf("mail from: %w", err)
+	}
+	if err := client.Rcpt(toAddr.Address); err != nil {
+		return fmt.Errorf("rcpt to: %w", err)
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("data: %w", err)
+	}
+	if _, err := writer.Write(data); err != nil {
+		return fmt.Errorf("write data: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("close data: %w", err)
+	}
+	return client.Quit()
+}
+
+// dial connects to SMTP-server and returns a SMTP-client.
+// Connection deadline is taken from the context.
+func (s *Sender) dial(ctx context.Context) (*smtp.Client, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("set deadline: %w", err)
+		}
+	}
+	if s.implicitTLS {
+		conn = tls.Client(conn, &tls.Config{ServerName: s.host})
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// auth upgrades connection to TLS (if supported) and authenticates the client.
+func (s *Sender) auth(client *smtp.Client) error {
+	if ok, _ := client.Extension("STARTTLS"); ok && !s.implicitTLS {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return fmt.Errorf("starttls: %w", err)
+		}
+	}
+	if s.username == "" {
+		return nil
+	}
+	if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+		return fmt.Errorf("auth: %w", err)
+	}
+	return nil
+}
+
+// build returns a MIME-message with headers and base64-encoded HTML-body.
+func (s *Sender) build(from, to *mail.Address, msg *Message) ([]byte, error) {
+	messageID, err := newMessageID(from.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	headers := [][2]string{
+		{"From", from.String()},
+		{"To", to.String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", msg.Subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", messageID},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/html; charset=utf-8"},
+		{"Content-Transfer-Encoding", "base64"},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", header[0], header[1])
+	}
+	buf.WriteString("\r\n")
+
+	body := base64.StdEncoding.EncodeToString([]byte(msg.HTML))
+	for len(body) > _lineLen {
+		buf.WriteString(body[:_lineLen] + "\r\n")
+		body = body[_lineLen:]
+	}
+	buf.WriteString(body + "\r\n")
+	return buf.Bytes(), nil
+}
+
+// newMessageID returns a unique Message-ID header value for the sender domain.
+func newMessageID(fromAddress string) (string, error) {
+	randBytes := make([]byte, 16)
+	if _, err := rand.Read(randBytes); err != nil {
+		return "", err
+	}
+	domain := fromAddress[strings.LastIndexByte(fromAddress, '@')+1:]
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(randBytes), domain), nil
+}
@@ -0,0 +1,118 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// startStub starts a fake SMTP-server which accepts one message
+// and sends its data to the returned chan.
+func startStub(t *testing.T) (string, <-chan string) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	dataChan := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		serveStub(conn, dataChan)
+	}()
+	return listener.Addr().String(), dataChan
+}
+
+func serveStub(conn net.Conn, dataChan chan<- string) {
+	reader := bufio.NewReader(conn)
+	io.WriteString(conn, "220 localhost ESMTP\r\n")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"):
+			io.WriteString(conn, "250 localhost\r\n")
+		case strings.HasPrefix(cmd, "DATA"):
+			io.WriteString(conn, "354 go ahead\r\n")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil || dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			dataChan <- data.String()
+			io.WriteString(conn, "250 OK\r\n")
+		case strings.HasPrefix(cmd, "QUIT"):
+			io.WriteString(conn, "221 bye\r\n")
+			return
+		default: // MAIL, RCPT
+			io.WriteString(conn, "250 OK\r\n")
+		}
+	}
+}
+
+func TestSender_Send(t *testing.T) {
+	t.Log("Send message to SMTP-server and check its headers and body")
+
+	address, dataChan := startStub(t)
+	host, port, err := net.SplitHostPort(address)
+	require.NoError(t, err)
+
+	sender := NewSender(host, port, "", "", "Skadi <noreply@example.com>", false, time.Second)
+	err = sender.Send(context.Background(), &Message{
+		To:      "student@example.com",
+		Subject: "Новое задание",
+		HTML:    "<p>Задание «Циклы»</p>",
+	})
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(strings.NewReader(<-dataChan))
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	require.Equal(t, "Новое задание", subject)
+	require.Equal(t, "<student@example.com>", msg.Header.Get("To"))
+
+	body, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, msg.Body))
+	require.NoError(t, err)
+	require.Equal(t, "<p>Задание «Циклы»</p>", string(body))
+}
+
+func TestTemplates_Render(t *testing.T) {
+	t.Log("Render template with shared layout")
+
+	fsys := fstest.MapFS{
+		"layout.html": {Data: []byte(`{{define "footer"}}<p>Skadi</p>{{end}}`)},
+		"task.html": {Data: []byte(`{{define "subject"}}Задание "{{.}}"{{end}}` +
+			`{{define "body"}}<p>{{.}}</p>{{template "footer"}}{{end}}`)},
+	}
+	templates, err := ParseTemplates(fsys, "task.html", "layout.html")
+	require.NoError(t, err)
+
+	msg, err := templates.Render("task", "student@example.com", "<Циклы>")
+	require.NoError(t, err)
+	require.Equal(t, `Задание "<Циклы>"`, msg.Subject)
+	require.Equal(t, "<p>&lt;Циклы&gt;</p><p>Skadi</p>", msg.HTML)
+
+	_, err = templates.Render("unknown", "student@example.com", nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"html/template"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+const (
+	_tmplSubject = "subject" // name of the subject template block
+	_tmplBody    = "body"    // name of the body template block
+)
+
+// Templates represents a set of e-mail templates.
+// Every template file defines "subject" and "body" blocks
+// and can use blocks from the shared layout files.
+type Templates struct {
+	templates map[string]*template.Template
+}
+
+// ParseTemplates parses e-mail templates matching the pattern in the file system.
+// Layout files matching the layoutPattern are shared by all templates.
+// Templates are available by their file names without extension.
+func ParseTemplates(fsys fs.FS, pattern, layoutPattern string) (*Templates, error) {
+	layout, err := template.ParseFS(fsys, layoutPattern)
+	if err != nil {
+		return nil, fmt.Errorf("parse layout: %w", err)
+	}
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("find templates: %w", err)
+	}
+
+	templates := make(map[string]*template.Template, len(files))
+	for _, file := range files {
+		tmpl, err := template.Must(layout.Clone()).ParseFS(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("parse template %q: %w", file, err)
+		}
+		name := path.Base(file)
+		templates[strings.TrimSuffix(name, path.Ext(name))] = tmpl
+	}
+	return &Templates{templates: templates}, nil
+}
+
+// Render renders the template with the given name and returns a message for the recipient.
+func (t *Templates) Render(name, to string, data any) (*Message, error) {
+	tmpl, ok := t.templates[name]
+	if !ok {
+		return nil, fmt.Errorf("template %q not found", name)
+	}
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, _tmplSubject, data); err != nil {
+		return nil, fmt.Errorf("render subject: %w", err)
+	}
+	if err := tmpl.ExecuteTemplate(&body, _tmplBody, data); err != nil {
+		return nil, fmt.Errorf("render body: %w", err)
+	}
+	// subject is a plain text, so it must not be HTML-escaped
+	return &Message{
+		To:      to,
+		Subject: html.UnescapeString(strings.TrimSpace(subject.String())),
+		HTML:    body.String(),
+	}, nil
+}
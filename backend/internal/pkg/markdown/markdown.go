@@ -0,0 +1,81 @@
+// Package markdown provides rendering of the Markdown text (CommonMark with tables,
+// strikethrough, autolinks and LaTeX math) to the sanitized HTML.
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+)
+
+var (
+	// classes of the highlighted code blocks (```go)
+	_codeClass = regexp.MustCompile(`^language-[\w#+.-]+$`)
+	// classes of the math formulas
+	_mathClass = regexp.MustCompile(`^math math-(inline|display)$`)
+)
+
+// ImageResolver returns a new destination of the image (e.g. URL of the attached file).
+// It returns false if the destination should be kept as is.
+type ImageResolver func(dest string) (string, bool)
+
+// Renderer renders Markdown to the sanitized HTML.
+// Raw HTML in the source is omitted, math formulas are rendered as
+// elements with "math" class and escaped TeX source (they are typeset by the client).
+// It is safe for concurrent use.
+type Renderer struct {
+	md     goldmark.Markdown
+	policy *bluemonday.Policy
+}
+
+// NewRenderer returns a new instance of [Renderer].
+func NewRenderer() *Renderer {
+	policy := bluemonday.UGCPolicy()
+	policy.AllowAttrs("class").Matching(_codeClass).OnElements("code")
+	policy.AllowAttrs("class").Matching(_mathClass).OnElements("span", "div")
+
+	return &Renderer{
+		md: goldmark.New(goldmark.WithExtensions(
+			extension.Table,
+			extension.Strikethrough,
+			extension.Linkify,
+			Math,
+		)),
+		policy: policy,
+	}
+}
+
+// Render renders the Markdown source to the sanitized HTML.
+// If resolve func is not nil, it is used to replace image destinations.
+func (r *Renderer) Render(src string, resolve ImageResolver) (string, error) {
+	source := []byte(src)
+	doc := r.md.Parser().Parse(text.NewReader(source))
+	if resolve != nil {
+		resolveImages(doc, resolve)
+	}
+
+	var buf bytes.Buffer
+	if err := r.md.Renderer().Render(&buf, source, doc); err != nil {
+		return "", err
+	}
+	return r.policy.Sanitize(buf.String()), nil
+}
+
+// resolveImages replaces destinations of all images in the document.
+func resolveImages(doc ast.Node, resolve ImageResolver) {
+	ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		img, ok := node.(*ast.Image)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+		if dest, ok := resolve(string(img.Destination)); ok {
+			img.Destination = []byte(dest)
+		}
+		return ast.WalkContinue, nil
+	})
+}
@@ -0,0 +1,51 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var _testRenderer = NewRenderer()
+
+func TestRenderer_Render(t *testing.T) {
+	t.Log("Render code block, math formulas and resolve image")
+
+	src := "# Task\n\n" +
+		"Solve $x^2 < 4$ for $5 and $6.\n\n" +
+		"```go\nfmt.Println(\"<b>\")\n```\n\n" +
+		"$$\n\\frac{a}{b}\n$$\n\n" +
+		"![scheme](scheme.png)"
+	html, err := _testRenderer.Render(src, func(dest string) (string, bool) {
+		if dest == "scheme.png" {
+			return "/api/v1/file/7", true
+		}
+		return "", false
+	})
+	require.NoError(t, err)
+	t.Log(html)
+
+	require.Contains(t, html, "<h1>Task</h1>")
+	require.Contains(t, html, `<span class="math math-inline">x^2 &lt; 4</span>`)
+	require.Contains(t, html, "for $5 and $6.")
+	require.Contains(t, html, `<code class="language-go">fmt.Println(&#34;&lt;b&gt;&#34;)`)
+	require.Contains(t, html, `<div class="math math-display">\frac{a}{b}`)
+	require.Contains(t, html, `<img src="/api/v1/file/7" alt="scheme"`)
+}
+
+func TestRenderer_RenderUnsafe(t *testing.T) {
+	t.Log("Remove raw HTML and dangerous links")
+
+	src := "<script>alert(1)</script>\n\n" +
+		"[link](javascript:alert(1)) <img src=x onerror=alert(1)>\n\n" +
+		"$<b>$"
+	html, err := _testRenderer.Render(src, nil)
+	require.NoError(t, err)
+	t.Log(html)
+
+	require.NotContains(t, html, "<script")
+	require.NotContains(t, html, "javascript:")
+	require.NotContains(t, html, "onerror")
+	require.False(t, strings.Contains(html, "<b>"))
+}
@@ -0,0 +1,212 @@
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+var _mathDelim = []byte("$$") // delimiter of the display formula
+
+var (
+	// KindMathInline is a node kind of the inline formula ($...$ or $$...$$ inside the text).
+	KindMathInline = ast.NewNodeKind("MathInline")
+	// KindMathBlock is a node kind of the display formula (lines between $$ lines).
+	KindMathBlock = ast.NewNodeKind("MathBlock")
+)
+
+// Math is an extension to parse LaTeX math formulas.
+var Math goldmark.Extender = &mathExtension{}
+
+// MathInline represents an inline formula.
+type MathInline struct {
+	ast.BaseInline
+	// true for the display formula ($$...$$)
+	Display bool
+	// TeX source of the formula
+	Value text.Segment
+}
+
+// Kind implements ast.Node.Kind.
+func (n *MathInline) Kind() ast.NodeKind {
+	return KindMathInline
+}
+
+// Dump implements ast.Node.Dump.
+func (n *MathInline) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// MathBlock represents a display formula written on separate lines.
+type MathBlock struct {
+	ast.BaseBlock
+}
+
+// Kind implements ast.Node.Kind.
+func (n *MathBlock) Kind() ast.NodeKind {
+	return KindMathBlock
+}
+
+// IsRaw implements ast.Node.IsRaw (formula lines are not parsed as Markdown).
+func (n *MathBlock) IsRaw() bool {
+	return true
+}
+
+// Dump implements ast.Node.Dump.
+func (n *MathBlock) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// mathExtension registers math parsers and renderer.
+type mathExtension struct{}
+
+// Extend implements goldmark.Extender.
+func (e *mathExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(
+		parser.WithInlineParsers(util.Prioritized(&mathInlineParser{}, 500)),
+		parser.WithBlockParsers(util.Prioritized(&mathBlockParser{}, 700)),
+	)
+	m.Renderer().AddOptions(
+		renderer.WithNodeRenderers(util.Prioritized(&mathRenderer{}, 500)),
+	)
+}
+
+// mathInlineParser parses inline formulas.
+// Like in Pandoc, the opening $ must not be followed by a space and
+// the closing $ must not be preceded by a space or followed by a digit,
+// so prices like "$5 and $6" are not parsed as formulas.
+type mathInlineParser struct{}
+
+// Trigger implements parser.InlineParser.
+func (p *mathInlineParser) Trigger() []byte {
+	return []byte{'$'}
+}
+
+// Parse implements parser.InlineParser.
+func (p *mathInlineParser) Parse(_ ast.Node, block text.Reader, _ parser.Context) ast.Node {
+	line, segment := block.PeekLine()
+	delim := _mathDelim[:1]
+	if bytes.HasPrefix(line, _mathDelim) {
+		delim = _mathDelim
+	}
+
+	body := line[len(delim):]
+	end := bytes.Index(body, delim)
+	// formula is empty or not closed
+	if end <= 0 {
+		return nil
+	}
+	if len(delim) == 1 {
+		after := len(delim) + end + 1
+		if util.IsSpace(body[0]) || util.IsSpace(body[end-1]) ||
+			after < len(line) && line[after] >= '0' && line[after] <= '9' {
+			return nil
+		}
+	}
+
+	start := segment.Start + len(delim)
+	block.Advance(len(delim)*2 + end)
+	return &MathInline{
+		Display: len(delim) == 2,
+		Value:   text.NewSegment(start, start+end),
+	}
+}
+
+// mathBlockParser parses display formulas written between $$ lines.
+type mathBlockParser struct{}
+
+// Trigger implements parser.BlockParser.
+func (b *mathBlockParser) Trigger() []byte {
+	return []byte{'$'}
+}
+
+// Open implements parser.BlockParser.
+func (b *mathBlockParser) Open(_ ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, _ := reader.PeekLine()
+	pos := pc.BlockOffset()
+	if pos < 0 || !isMathDelimLine(line[pos:]) {
+		return nil, parser.NoChildren
+	}
+	return &MathBlock{}, parser.NoChildren
+}
+
+// Continue implements parser.BlockParser.
+func (b *mathBlockParser) Continue(node ast.Node, reader text.Reader, _ parser.Context) parser.State {
+	line, segment := reader.PeekLine()
+	if pos := util.FirstNonSpacePosition(line); pos >= 0 && isMathDelimLine(line[pos:]) {
+		reader.Advance(segment.Len())
+		return parser.Close
+	}
+	seg := text.NewSegment(segment.Start, segment.Stop)
+	seg.ForceNewline = true // EOF as newline
+	node.Lines().Append(seg)
+	reader.Advance(segment.Len() - 1)
+	return parser.Continue | parser.NoChildren
+}
+
+// Close implements parser.BlockParser.
+func (b *mathBlockParser) Close(ast.Node, text.Reader, parser.Context) {}
+
+// CanInterruptParagraph implements parser.BlockParser.
+func (b *mathBlockParser) CanInterruptParagraph() bool {
+	return true
+}
+
+// CanAcceptIndentedLine implements parser.BlockParser.
+func (b *mathBlockParser) CanAcceptIndentedLine() bool {
+	return false
+}
+
+// isMathDelimLine returns true if the line contains $$ only.
+func isMathDelimLine(line []byte) bool {
+	return bytes.HasPrefix(line, _mathDelim) && util.IsBlank(line[len(_mathDelim):])
+}
+
+// mathRenderer renders formulas as elements with "math" class and escaped TeX source.
+type mathRenderer struct{}
+
+// RegisterFuncs implements renderer.NodeRenderer.
+func (r *mathRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindMathInline, r.renderInline)
+	reg.Register(KindMathBlock, r.renderBlock)
+}
+
+// renderInline renders the inline formula.
+func (r *mathRenderer) renderInline(w util.BufWriter, source []byte,
+	node ast.Node, entering bool) (ast.WalkStatus, error) {
+
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*MathInline)
+	if n.Display {
+		_, _ = w.WriteString(`<span class="math math-display">`)
+	} else {
+		_, _ = w.WriteString(`<span class="math math-inline">`)
+	}
+	_, _ = w.Write(util.EscapeHTML(n.Value.Value(source)))
+	_, _ = w.WriteString("</span>")
+	return ast.WalkSkipChildren, nil
+}
+
+// renderBlock renders the display formula.
+func (r *mathRenderer) renderBlock(w util.BufWriter, source []byte,
+	node ast.Node, entering bool) (ast.WalkStatus, error) {
+
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	_, _ = w.WriteString(`<div class="math math-display">`)
+	lines := node.Lines()
+	for idx := 0; idx < lines.Len(); idx++ {
+		seg := lines.At(idx)
+		_, _ = w.Write(util.EscapeHTML(seg.Value(source)))
+	}
+	_, _ = w.WriteString("</div>\n")
+	return ast.WalkSkipChildren, nil
+}
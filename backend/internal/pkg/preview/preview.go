@@ -0,0 +1,162 @@
+// Package preview provides generation of small JPEG previews
+// (thumbnails) for images and first pages of PDF documents.
+package preview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // register GIF decoder
+	"image/jpeg"
+	_ "image/png" // register PNG decoder
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // register WEBP decoder
+)
+
+const (
+	_ext         = ".preview.jpg"   // suffix for preview file path
+	_tmpExt      = ".tmp"           // suffix for preview file while it is generating
+	_jpegQuality = 80               // quality of the preview JPEG
+	_maxPixels   = 80 * 1000 * 1000 // max pixels of the source image (protection from image bombs)
+	_filePerms   = 0o644            // permissions for the preview file
+)
+
+var (
+	// ErrUnsupported means that the preview cannot be made for the given MIME-type.
+	ErrUnsupported = errors.New("unsupported MIME-type")
+	// ErrTooLarge means that the source image is too large to make a preview.
+	ErrTooLarge = errors.New("image is too large")
+)
+
+// supported image MIME-types
+var _imageTypes = map[string]struct{}{
+	"image/jpeg": {},
+	"image/png":  {},
+	"image/gif":  {},
+	"image/webp": {},
+}
+
+const _pdfType = "application/pdf"
+
+// Path returns a path to the preview for the file with the given path.
+// Preview is stored next to the original file.
+func Path(srcPath string) string {
+	return srcPath + _ext
+}
+
+// Supported returns true if the preview can be made for the given MIME-type.
+func Supported(mimeType string) bool {
+	_, ok := _imageTypes[mimeType]
+	return ok || mimeType == _pdfType
+}
+
+// Generator makes previews for the files.
+type Generator struct {
+	// max width and height of the preview in pixels
+	maxSize int
+	// path to the pdftoppm binary (poppler-utils). If empty, PDF previews are disabled.
+	pdfRenderer string
+}
+
+// NewGenerator returns a new instance of [Generator].
+func NewGenerator(maxSize int, pdfRenderer string) *Generator {
+	return &Generator{
+		maxSize:     maxSize,
+		pdfRenderer: pdfRenderer,
+	}
+}
+
+// Make makes a preview for the file with the given path and MIME-type.
+// Preview is saved to the path returned by the [Path] func.
+func (g *Generator) Make(ctx context.Context, srcPath, mimeType string) error {
+	dstPath := Path(srcPath)
+	if _, ok := _imageTypes[mimeType]; ok {
+		return g.makeFromImage(srcPath, dstPath)
+	}
+	if mimeType == _pdfType && g.pdfRenderer != "" {
+		return g.makeFromPDF(ctx, srcPath, dstPath)
+	}
+	return fmt.Errorf("%w: %s", ErrUnsupported, mimeType)
+}
+
+// makeFromImage makes a preview for the image.
+func (g *Generator) makeFromImage(srcPath, dstPath string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return fmt.Errorf("open source: %w", err)
+	}
+	defer src.Close()
+
+	// check image size before decoding
+	imgCfg, _, err := image.DecodeConfig(src)
+	if err != nil {
+		return fmt.Errorf("decode image config: %w", err)
+	}
+	if imgCfg.Width*imgCfg.Height > _maxPixels {
+		return fmt.Errorf("%w: %dx%d", ErrTooLarge, imgCfg.Width, imgCfg.Height)
+	}
+	if _, err := src.Seek(0, 0); err != nil {
+		return fmt.Errorf("seek source: %w", err)
+	}
+	img, _, err := image.Decode(src)
+	if err != nil {
+		return fmt.Errorf("decode image: %w", err)
+	}
+
+	// scale image with white background (for transparent images)
+	bounds := g.fit(img.Bounds().Dx(), img.Bounds().Dy())
+	thumb := image.NewRGBA(bounds)
+	draw.Draw(thumb, bounds, image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(thumb, bounds, img, img.Bounds(), draw.Over, nil)
+
+	// save preview through the temp file to avoid serving of half-written previews
+	tmpPath := dstPath + _tmpExt
+	dst, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, _filePerms)
+	if err != nil {
+		return fmt.Errorf("create preview: %w", err)
+	}
+	if err := jpeg.Encode(dst, thumb, &jpeg.Options{Quality: _jpegQuality}); err != nil {
+		dst.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("encode preview: %w", err)
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("close preview: %w", err)
+	}
+	return os.Rename(tmpPath, dstPath)
+}
+
+// makeFromPDF makes a preview for the first page of the PDF document.
+func (g *Generator) makeFromPDF(ctx context.Context, srcPath, dstPath string) error {
+	// pdftoppm adds ".jpg" extension to the output file itself
+	outPrefix := dstPath + _tmpExt
+	cmd := exec.CommandContext(ctx, g.pdfRenderer,
+		"-f", "1", "-l", "1", "-singlefile",
+		"-jpeg", "-jpegopt", "quality="+strconv.Itoa(_jpegQuality),
+		"-scale-to", strconv.Itoa(g.maxSize),
+		srcPath, outPrefix)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		os.Remove(outPrefix + ".jpg")
+		return fmt.Errorf("render pdf: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return os.Rename(outPrefix+".jpg", dstPath)
+}
+
+// fit returns bounds of the preview keeping the aspect ratio of the source.
+func (g *Generator) fit(width, height int) image.Rectangle {
+	if width <= g.maxSize && height <= g.maxSize {
+		return image.Rect(0, 0, width, height)
+	}
+	if width >= height {
+		return image.Rect(0, 0, g.maxSize, max(1, height*g.maxSize/width))
+	}
+	return image.Rect(0, 0, max(1, width*g.maxSize/height), g.maxSize)
+}
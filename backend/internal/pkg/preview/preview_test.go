@@ -0,0 +1,51 @@
+package preview
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_MakeFromImage(t *testing.T) {
+	t.Log("Make preview for PNG image")
+
+	// create source image
+	srcPath := filepath.Join(t.TempDir(), "image")
+	src, err := os.Create(srcPath)
+	require.NoError(t, err)
+	img := image.NewRGBA(image.Rect(0, 0, 1000, 500))
+	img.Set(10, 10, color.Black)
+	require.NoError(t, png.Encode(src, img))
+	require.NoError(t, src.Close())
+
+	gen := NewGenerator(100, "")
+	err = gen.Make(context.Background(), srcPath, "image/png")
+	require.NoError(t, err)
+
+	// check preview size
+	dst, err := os.Open(Path(srcPath))
+	require.NoError(t, err)
+	defer dst.Close()
+	imgCfg, err := jpeg.DecodeConfig(dst)
+	require.NoError(t, err)
+	require.Equal(t, 100, imgCfg.Width)
+	require.Equal(t, 50, imgCfg.Height)
+}
+
+func TestGenerator_MakeUnsupported(t *testing.T) {
+	t.Log("Make preview for PDF without renderer and get error")
+
+	gen := NewGenerator(100, "")
+	err := gen.Make(context.Background(), "file.pdf", "application/pdf")
+	require.ErrorIs(t, err, ErrUnsupported)
+
+	err = gen.Make(context.Background(), "file.txt", "text/plain")
+	require.ErrorIs(t, err, ErrUnsupported)
+}
@@ -0,0 +1,95 @@
+// Package retry provides a helper to process items of the durable queues
+// and to retry failed attempts with exponential backoff.
+package retry
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+const _maxErrLen = 1024 // max length of the saved error
+
+// Policy represents a policy to retry failed attempts with exponential backoff.
+type Policy struct {
+	// max attempts to process one item
+	MaxAttempts int
+	// delay before the first retry (it is doubled for every next retry)
+	Delay time.Duration
+}
+
+// Item represents an item of the durable queue with its processing steps.
+type Item struct {
+	// item name for log messages (like "mail")
+	Name string
+	// action name for log messages (like "send")
+	Action string
+	// extra attributes for log messages (like "id", 12)
+	Attrs []any
+	// number of the previous failed attempts
+	Attempts int
+	// Claim hides the item from other workers (e.g. other backend instances).
+	// It returns false if the item was already claimed by another worker.
+	Claim func() (bool, error)
+	// Run processes the item.
+	Run func() error
+	// Done saves the successful result.
+	Done func() error
+	// Failed saves the failed attempt with the next attempt time and the error.
+	Failed func(attempts int, nextAttemptAt time.Time, errMsg string) error
+}
+
+// Process claims the item, processes it and saves the result.
+// Failed item is retried after the delay doubled for every previous attempt
+// until max attempts are reached. Errors are only logged.
+func (p Policy) Process(item *Item) {
+	claimed, err := item.Claim()
+	if err != nil {
+		slog.Warn("claim "+item.Name, item.logAttrs("error", err)...)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	err = item.Run()
+	if err == nil {
+		if err := item.Done(); err != nil {
+			slog.Warn("mark "+item.Name+" done", item.logAttrs("error", err)...)
+		}
+		slog.Debug(item.Action+" "+item.Name+": ok", item.Attrs...)
+		return
+	}
+
+	attempts := item.Attempts + 1
+	errMsg := ErrorText(err)
+	if err := item.Failed(attempts, p.NextAttemptAt(attempts, time.Now()), errMsg); err != nil {
+		slog.Warn("mark "+item.Name+" failed", item.logAttrs("error", err)...)
+	}
+	attrs := item.logAttrs("attempts", attempts, "error", errMsg)
+	if attempts >= p.MaxAttempts {
+		slog.Error(item.Action+" "+item.Name+": give up", attrs...)
+		return
+	}
+	slog.Warn(item.Action+" "+item.Name, attrs...)
+}
+
+// logAttrs returns the item attributes for log messages with the given extra ones.
+func (item *Item) logAttrs(extra ...any) []any {
+	return append(slices.Clone(item.Attrs), extra...)
+}
+
+// NextAttemptAt returns the time of the next attempt after the given number of failed attempts.
+func (p Policy) NextAttemptAt(attempts int, now time.Time) time.Time {
+	return now.Add(p.Delay << (attempts - 1))
+}
+
+// ErrorText returns the error text truncated to be saved.
+func ErrorText(err error) string {
+	errMsg := err.Error()
+	if len(errMsg) > _maxErrLen {
+		errMsg = strings.ToValidUTF8(errMsg[:_maxErrLen], "")
+	}
+	return errMsg
+}
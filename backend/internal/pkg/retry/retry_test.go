@@ -0,0 +1,71 @@
+package retry
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var _testNow = time.Date(2025, time.March, 14, 10, 0, 0, 0, time.UTC)
+
+func TestPolicy_NextAttemptAt(t *testing.T) {
+	t.Log("Double the delay for every failed attempt")
+
+	policy := Policy{MaxAttempts: 5, Delay: time.Minute}
+	require.Equal(t, _testNow.Add(time.Minute), policy.NextAttemptAt(1, _testNow))
+	require.Equal(t, _testNow.Add(2*time.Minute), policy.NextAttemptAt(2, _testNow))
+	require.Equal(t, _testNow.Add(8*time.Minute), policy.NextAttemptAt(4, _testNow))
+}
+
+func TestPolicy_Process(t *testing.T) {
+	t.Log("Save the failed attempt and then the successful one")
+
+	policy := Policy{MaxAttempts: 5, Delay: time.Minute}
+	var failedAttempts int
+	var done bool
+	runErr := errors.New(strings.Repeat("я", _maxErrLen))
+	item := &Item{
+		Name:     "item",
+		Action:   "process",
+		Attempts: 1,
+		Claim:    func() (bool, error) { return true, nil },
+		Run:      func() error { return runErr },
+		Done: func() error {
+			done = true
+			return nil
+		},
+		Failed: func(attempts int, nextAttemptAt time.Time, errMsg string) error {
+			failedAttempts = attempts
+			require.True(t, nextAttemptAt.After(time.Now()))
+			require.LessOrEqual(t, len(errMsg), _maxErrLen)
+			return nil
+		},
+	}
+
+	policy.Process(item)
+	require.Equal(t, 2, failedAttempts)
+	require.False(t, done)
+
+	runErr = nil
+	item.Run = func() error { return runErr }
+	policy.Process(item)
+	require.True(t, done)
+}
+
+func TestPolicy_ProcessNotClaimed(t *testing.T) {
+	t.Log("Skip the item claimed by another worker")
+
+	policy := Policy{MaxAttempts: 5, Delay: time.Minute}
+	policy.Process(&Item{
+		Name:   "item",
+		Action: "process",
+		Claim:  func() (bool, error) { return false, nil },
+		Run: func() error {
+			t.Fatal("not claimed item is processed")
+			return nil
+		},
+	})
+}
@@ -0,0 +1,308 @@
+// Package sandbox provides running of untrusted programs in an isolated environment
+// (docker container or nsjail) with time and memory limits, without network
+// and with the read-only file system. Nsjail sandbox has an empty root
+// with the given host paths mounted read-only, so other host files are not visible.
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RunnerDocker = "docker" // run programs in docker containers
+	RunnerNsjail = "nsjail" // run programs in nsjail on the host
+
+	_workDir       = "/code"         // dir with the program files inside the sandbox
+	_user          = "65534"         // uid and gid of the program (nobody)
+	_pidsLimit     = "64"            // max number of processes in the container
+	_buildMemoryMB = 1024            // memory limit to compile the program
+	_exitKilled    = 137             // exit code of the process killed by SIGKILL (e.g. out of memory)
+	_exitDocker    = 125             // exit code of docker if the container was not started
+	_killTimeout   = 5 * time.Second // timeout to kill the timed out container
+)
+
+// ErrUnsupportedRunner means that the given runner is not supported.
+var ErrUnsupportedRunner = errors.New("unsupported sandbox runner")
+
+// Verdict represents a result of the program test.
+type Verdict string
+
+var (
+	VerdictOK           Verdict = "ok"            // output matches the expected one
+	VerdictWrongAnswer  Verdict = "wrong_answer"  // output differs from the expected one
+	VerdictTimeLimit    Verdict = "time_limit"    // time limit exceeded
+	VerdictMemoryLimit  Verdict = "memory_limit"  // program was killed (memory limit exceeded)
+	VerdictRuntimeError Verdict = "runtime_error" // program exited with non-zero code
+)
+
+// Lang represents commands to compile and run programs in the language.
+type Lang struct {
+	// docker image (for docker runner only)
+	Image string
+	// shell command to compile the program (it is skipped if empty)
+	Build string
+	// shell command to run the program
+	Run string
+}
+
+// Limits represents limits for the one program run.
+type Limits struct {
+	// time limit (sandbox start time is not counted)
+	Time time.Duration
+	// memory limit in megabytes
+	MemoryMB int
+}
+
+// Result represents a result of the one program run.
+type Result struct {
+	// program exit code
+	ExitCode int
+	// true if the program was killed because of the time limit
+	TimedOut bool
+	// wall time of the run (with sandbox start)
+	Time time.Duration
+	// program output (truncated to the max output size)
+	Stdout string
+	// program error output (truncated to the max output size)
+	Stderr string
+}
+
+// Verdict returns a verdict of the run for the expected output.
+func (r *Result) Verdict(expected string) Verdict {
+	switch {
+	case r.TimedOut:
+		return VerdictTimeLimit
+	case r.ExitCode == _exitKilled:
+		return VerdictMemoryLimit
+	case r.ExitCode != 0:
+		return VerdictRuntimeError
+	case !Match(expected, r.Stdout):
+		return VerdictWrongAnswer
+	}
+	return VerdictOK
+}
+
+// Match returns true if the output matches the expected one.
+// Trailing spaces of every line, trailing empty lines and CR symbols are ignored.
+func Match(expected, output string) bool {
+	return normalize(expected) == normalize(output)
+}
+
+// normalize removes CR symbols, trailing spaces of every line and trailing empty lines.
+func normalize(s string) string {
+	lines := strings.Split(strings.ReplaceAll(s, "\r", ""), "\n")
+	for idx := range lines {
+		lines[idx] = strings.TrimRight(lines[idx], " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// Sandbox runs programs from the given dirs in the isolated environment.
+// It is safe for concurrent use.
+type Sandbox struct {
+	// sandbox runner (docker or nsjail)
+	runner string
+	// path to the runner binary
+	path string
+	// existing host paths mounted read-only to the nsjail sandbox root
+	mounts []string
+	// extra time to start the sandbox added to the time limit
+	startGrace time.Duration
+	// max size of the saved program output in bytes
+	maxOutput int
+}
+
+// New returns a new instance of [Sandbox].
+// Mounts are host paths (like /usr) mounted read-only to the same paths inside
+// the nsjail sandbox, missing paths are skipped. Docker runner ignores them
+// and uses the language image.
+func New(runner, path string, mounts []string, startGrace time.Duration,
+	maxOutput int) (*Sandbox, error) {
+
+	if runner != RunnerDocker && runner != RunnerNsjail {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedRunner, runner)
+	}
+	existing := make([]string, 0, len(mounts))
+	for _, mount := range mounts {
+		if _, err := os.Stat(mount); err == nil {
+			existing = append(existing, mount)
+		}
+	}
+	return &Sandbox{
+		runner:     runner,
+		path:       path,
+		mounts:     existing,
+		startGrace: startGrace,
+		maxOutput:  maxOutput,
+	}, nil
+}
+
+// Build compiles the program in the given dir. The dir is writable during the build.
+// It returns nil result if the language has no build command.
+func (s *Sandbox) Build(ctx context.Context, lang *Lang, dir string,
+	timeout time.Duration) (*Result, error) {
+
+	if lang.Build == "" {
+		return nil, nil
+	}
+	return s.exec(ctx, lang, dir, lang.Build, "",
+		Limits{Time: timeout, MemoryMB: _buildMemoryMB}, true)
+}
+
+// Run runs the compiled program in the given dir with the given input.
+// The dir is read-only during the run.
+func (s *Sandbox) Run(ctx context.Context, lang *Lang, dir, stdin string,
+	limits Limits) (*Result, error) {
+
+	return s.exec(ctx, lang, dir, lang.Run, stdin, limits, false)
+}
+
+// exec executes the shell command in the sandbox.
+func (s *Sandbox) exec(ctx context.Context, lang *Lang, dir, command, stdin string,
+	limits Limits, writable bool) (*Result, error) {
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("get absolute dir path: %w", err)
+	}
+	runCtx, cancel := context.WithTimeout(ctx, limits.Time+s.startGrace)
+	defer cancel()
+
+	name := "skadi-sandbox-" + uuid.NewString()
+	cmd := exec.CommandContext(runCtx, s.path,
+		s.args(lang, name, absDir, command, limits, writable)...)
+	if s.runner == RunnerDocker {
+		// killing of the docker client does not stop the container
+		cmd.Cancel = func() error {
+			killCtx, killCancel := context.WithTimeout(context.Background(), _killTimeout)
+			defer killCancel()
+			_ = exec.CommandContext(killCtx, s.path, "kill", name).Run()
+			return cmd.Process.Kill()
+		}
+	}
+	cmd.WaitDelay = _killTimeout
+	stdout := &limitedBuffer{max: s.maxOutput}
+	stderr := &limitedBuffer{max: s.maxOutput}
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	startedAt := time.Now()
+	err = cmd.Run()
+	res := &Result{
+		Time:   time.Since(startedAt),
+		Stdout: stdout.String(),
+		Stderr: stderr.String(),
+	}
+	// sandbox is stopped because of the service shutdown
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		res.TimedOut = true
+		return res, nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		res.ExitCode = exitErr.ExitCode()
+		// like shells, use 128+N exit code for the process killed by signal N
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			res.ExitCode = 128 + int(status.Signal())
+		}
+		if s.runner == RunnerDocker && res.ExitCode == _exitDocker {
+			return nil, fmt.Errorf("start container: %s", strings.TrimSpace(res.Stderr))
+		}
+		return res, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("run sandbox: %w", err)
+	}
+	return res, nil
+}
+
+// args returns arguments of the runner binary to execute the shell command.
+func (s *Sandbox) args(lang *Lang, name, dir, command string, limits Limits,
+	writable bool) []string {
+
+	memory := strconv.Itoa(limits.MemoryMB)
+	mount := dir + ":" + _workDir
+	if s.runner == RunnerNsjail {
+		bindFlag := "--bindmount_ro"
+		if writable {
+			bindFlag = "--bindmount"
+		}
+		timeLimit := int((limits.Time + s.startGrace + time.Second - 1) / time.Second)
+		// without chroot the sandbox root is an empty tmpfs
+		args := []string{
+			"--mode", "o", "--quiet",
+			"--user", _user, "--group", _user,
+		}
+		for _, hostPath := range s.mounts {
+			args = append(args, "--bindmount_ro", hostPath)
+		}
+		return append(args,
+			bindFlag, mount,
+			"--cwd", _workDir,
+			"--tmpfsmount", "/tmp",
+			"--rlimit_as", memory,
+			"--rlimit_nproc", _pidsLimit,
+			"--time_limit", strconv.Itoa(timeLimit),
+			"--env", "HOME=/tmp",
+			"--env", "PATH=/usr/local/go/bin:/usr/local/bin:/usr/bin:/bin",
+			"--", "/bin/sh", "-c", command,
+		)
+	}
+
+	if !writable {
+		mount += ":ro"
+	}
+	return []string{
+		"run", "--rm", "-i",
+		"--name", name,
+		"--network", "none",
+		"--cpus", "1",
+		"--pids-limit", _pidsLimit,
+		"--memory", memory + "m",
+		"--memory-swap", memory + "m",
+		"--read-only",
+		"--tmpfs", "/tmp:rw,exec,size=" + memory + "m",
+		"--user", _user + ":" + _user,
+		"--env", "HOME=/tmp",
+		"--volume", mount,
+		"--workdir", _workDir,
+		lang.Image,
+		"sh", "-c", command,
+	}
+}
+
+// limitedBuffer is a buffer which keeps only the first max bytes.
+// Extra bytes are discarded without errors, so the program is not stopped.
+type limitedBuffer struct {
+	buf bytes.Buffer
+	max int
+}
+
+// Write implements io.Writer.
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if rest := b.max - b.buf.Len(); rest > 0 {
+		b.buf.Write(p[:min(rest, len(p))])
+	}
+	return len(p), nil
+}
+
+// String returns saved bytes as a valid UTF-8 string.
+func (b *limitedBuffer) String() string {
+	return strings.ToValidUTF8(b.buf.String(), "")
+}
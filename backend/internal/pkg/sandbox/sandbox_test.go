@@ -0,0 +1,95 @@
+package sandbox
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fake nsjail runs the command after "--" without isolation
+const _testRunner = "#!/bin/sh\nwhile [ \"$1\" != \"--\" ]; do shift; done\nshift\nexec \"$@\"\n"
+
+func newTestSandbox(t *testing.T) *Sandbox {
+	runnerPath := filepath.Join(t.TempDir(), "nsjail")
+	require.NoError(t, os.WriteFile(runnerPath, []byte(_testRunner), 0o755))
+
+	sb, err := New(RunnerNsjail, runnerPath, nil, 0, 8)
+	require.NoError(t, err)
+	return sb
+}
+
+func TestSandbox_Run(t *testing.T) {
+	t.Log("Run programs and get verdicts")
+
+	sb := newTestSandbox(t)
+	limits := Limits{Time: time.Second, MemoryMB: 64}
+	tests := []struct {
+		run      string
+		stdin    string
+		expected string
+		verdict  Verdict
+	}{
+		{run: "cat", stdin: "5  \r\n\n", expected: "5", verdict: VerdictOK},
+		{run: "cat", stdin: "6", expected: "5", verdict: VerdictWrongAnswer},
+		{run: "exit 3", verdict: VerdictRuntimeError},
+		{run: "kill -9 $$", verdict: VerdictMemoryLimit},
+		{run: "exec sleep 5", verdict: VerdictTimeLimit},
+	}
+	for _, tt := range tests {
+		res, err := sb.Run(context.Background(), &Lang{Run: tt.run}, t.TempDir(), tt.stdin,
+			limits)
+		require.NoError(t, err)
+		t.Log(tt.run, res.ExitCode, res.Time)
+		require.Equal(t, tt.verdict, res.Verdict(tt.expected))
+	}
+
+	t.Log("Truncate long output")
+	res, err := sb.Run(context.Background(), &Lang{Run: "cat"}, t.TempDir(),
+		"0123456789", limits)
+	require.NoError(t, err)
+	require.Equal(t, "01234567", res.Stdout)
+}
+
+func TestSandbox_HostFiles(t *testing.T) {
+	t.Log("Mount only the given host paths to the nsjail sandbox")
+
+	mediaDir := t.TempDir()
+	secretPath := filepath.Join(mediaDir, "secret.txt")
+	require.NoError(t, os.WriteFile(secretPath, []byte("secret"), 0o644))
+
+	sb, err := New(RunnerNsjail, "nsjail", []string{"/bin", "/usr", "/not-exists"}, 0, 1024)
+	require.NoError(t, err)
+	args := sb.args(&Lang{}, "test", t.TempDir(), "cat "+secretPath,
+		Limits{Time: time.Second}, false)
+	require.NotContains(t, args, "--chroot")
+	require.NotContains(t, args, "/not-exists")
+	for _, arg := range args[:slices.Index(args, "--")] {
+		require.NotContains(t, arg, mediaDir)
+	}
+
+	nsjailPath, err := exec.LookPath("nsjail")
+	if err != nil {
+		t.Skip("nsjail is not installed")
+	}
+	t.Log("Read the file outside the mounts with real nsjail")
+	sb, err = New(RunnerNsjail, nsjailPath, []string{"/bin", "/lib", "/lib64", "/usr"}, 0, 1024)
+	require.NoError(t, err)
+	res, err := sb.Run(context.Background(), &Lang{Run: "cat " + secretPath}, t.TempDir(), "",
+		Limits{Time: 5 * time.Second, MemoryMB: 64})
+	require.NoError(t, err)
+	require.Equal(t, VerdictRuntimeError, res.Verdict(""))
+	require.NotContains(t, res.Stdout, "secret")
+}
+
+func TestNew_Unsupported(t *testing.T) {
+	t.Log("Create sandbox with unsupported runner")
+
+	_, err := New("chroot", "chroot", nil, 0, 8)
+	require.ErrorIs(t, err, ErrUnsupportedRunner)
+}
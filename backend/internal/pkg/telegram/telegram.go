@@ -0,0 +1,165 @@
+// Package telegram provides a minimal client for the Telegram Bot API:
+// receiving updates by long polling and sending text messages.
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const _maxBodySize = 1 << 20 // max size of the Bot API response body (1 MB)
+
+// APIError represents an error returned by the Bot API.
+type APIError struct {
+	// error code (like HTTP status code)
+	Code int
+	// error description
+	Description string
+}
+
+// Error returns an error message.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("telegram api: %d %s", e.Code, e.Description)
+}
+
+// Forbidden returns true if the bot cannot write to the chat
+// (e.g. it was blocked by the user).
+func (e *APIError) Forbidden() bool {
+	return e.Code == http.StatusForbidden
+}
+
+// Update represents an incoming update.
+type Update struct {
+	// update ID (next offset is the last update ID + 1)
+	UpdateID int64 `json:"update_id"`
+	// new incoming message (it is nil for other update types)
+	Message *Message `json:"message"`
+}
+
+// Message represents a chat message.
+type Message struct {
+	// message ID
+	MessageID int64 `json:"message_id"`
+	// chat the message belongs to
+	Chat Chat `json:"chat"`
+	// message text
+	Text string `json:"text"`
+}
+
+// Chat represents a Telegram chat.
+type Chat struct {
+	// chat ID
+	ID int64 `json:"id"`
+	// chat type: private, group, supergroup or channel
+	Type string `json:"type"`
+}
+
+// Private returns true if it is a private chat with the user.
+func (c *Chat) Private() bool {
+	return c.Type == "private"
+}
+
+// response represents a common Bot API response.
+type response struct {
+	OK          bool            `json:"ok"`
+	Result      json.RawMessage `json:"result"`
+	ErrorCode   int             `json:"error_code"`
+	Description string          `json:"description"`
+}
+
+// Client represents a Bot API client.
+type Client struct {
+	// Bot API base URL with bot token (like https://api.telegram.org/bot<token>)
+	baseURL string
+	// timeout for one request (long polling timeout is added for updates)
+	timeout    time.Duration
+	httpClient *http.Client
+}
+
+// NewClient returns a new instance of [Client].
+// Base URL is an API server URL (like https://api.telegram.org),
+// it can be replaced with a local server for testing.
+func NewClient(baseURL, token string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/") + "/bot" + token,
+		timeout:    timeout,
+		httpClient: &http.Client{},
+	}
+}
+
+// GetUpdates returns incoming updates starting from the given offset.
+// If there are no updates, the request waits for them up to the poll timeout (long polling).
+func (c *Client) GetUpdates(ctx context.Context, offset int64,
+	pollTimeout time.Duration) ([]Update, error) {
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout+pollTimeout)
+	defer cancel()
+
+	updates := []Update{}
+	err := c.call(ctx, "getUpdates", map[string]any{
+		"offset":          offset,
+		"timeout":         int(pollTimeout.Seconds()),
+		"allowed_updates": []string{"message"},
+	}, &updates)
+	if err != nil {
+		return nil, err
+	}
+	return updates, nil
+}
+
+// SendMessage sends a plain text message to the chat.
+func (c *Client) SendMessage(ctx context.Context, chatID int64, text string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	return c.call(ctx, "sendMessage", map[string]any{
+		"chat_id":                  chatID,
+		"text":                     text,
+		"disable_web_page_preview": true,
+	}, nil)
+}
+
+// call calls the Bot API method with JSON params
+// and decodes the result to the given object (if it is not nil).
+func (c *Client) call(ctx context.Context, method string, params, result any) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("marshal params: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		c.baseURL+"/"+method, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	var respBody response
+	if err := json.NewDecoder(io.LimitReader(resp.Body, _maxBodySize)).Decode(&respBody); err != nil {
+		return fmt.Errorf("%s: decode response (status %d): %w", method, resp.StatusCode, err)
+	}
+	if !respBody.OK {
+		return fmt.Errorf("%s: %w", method, &APIError{
+			Code:        respBody.ErrorCode,
+			Description: respBody.Description,
+		})
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody.Result, result); err != nil {
+		return fmt.Errorf("%s: decode result: %w", method, err)
+	}
+	return nil
+}
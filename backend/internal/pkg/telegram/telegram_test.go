@@ -0,0 +1,91 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const _testToken = "123:test-token"
+
+// newTestServer returns a fake Bot API server.
+// Every request params are sent to the given chan.
+func newTestServer(t *testing.T, params chan<- map[string]any) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/bot"+_testToken+"/getUpdates", func(w http.ResponseWriter, r *http.Request) {
+		var reqParams map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&reqParams))
+		params <- reqParams
+		w.Write([]byte(`{"ok":true,"result":[{"update_id":7,"message":` +
+			`{"message_id":1,"chat":{"id":42,"type":"private"},"text":"/tasks"}}]}`))
+	})
+	mux.HandleFunc("/bot"+_testToken+"/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		var reqParams map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&reqParams))
+		params <- reqParams
+		if reqParams["chat_id"] == float64(403) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"ok":false,"error_code":403,` +
+				`"description":"Forbidden: bot was blocked by the user"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"result":{"message_id":2}}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClient_GetUpdates(t *testing.T) {
+	t.Log("Get updates from fake server")
+
+	params := make(chan map[string]any, 1)
+	srv := newTestServer(t, params)
+	client := NewClient(srv.URL+"/", _testToken, time.Second)
+
+	updates, err := client.GetUpdates(context.Background(), 5, 30*time.Second)
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	require.Equal(t, int64(7), updates[0].UpdateID)
+	require.Equal(t, int64(42), updates[0].Message.Chat.ID)
+	require.True(t, updates[0].Message.Chat.Private())
+	require.Equal(t, "/tasks", updates[0].Message.Text)
+
+	reqParams := <-params
+	require.Equal(t, float64(5), reqParams["offset"])
+	require.Equal(t, float64(30), reqParams["timeout"])
+}
+
+func TestClient_SendMessage(t *testing.T) {
+	t.Log("Send message to fake server")
+
+	params := make(chan map[string]any, 1)
+	srv := newTestServer(t, params)
+	client := NewClient(srv.URL, _testToken, time.Second)
+
+	require.NoError(t, client.SendMessage(context.Background(), 42, "Привет"))
+	reqParams := <-params
+	require.Equal(t, float64(42), reqParams["chat_id"])
+	require.Equal(t, "Привет", reqParams["text"])
+}
+
+func TestClient_SendMessageForbidden(t *testing.T) {
+	t.Log("Send message to chat which blocked the bot and get API error")
+
+	params := make(chan map[string]any, 1)
+	srv := newTestServer(t, params)
+	client := NewClient(srv.URL, _testToken, time.Second)
+
+	err := client.SendMessage(context.Background(), 403, "Привет")
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.True(t, apiErr.Forbidden())
+}
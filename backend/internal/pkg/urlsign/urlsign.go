@@ -0,0 +1,59 @@
+// Package urlsign provides HMAC-signing of the short-lived URLs
+// to access resources without authentication.
+package urlsign
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrInvalidSignature means that the signature does not match the signed data.
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrExpired means that the signature is valid but expired.
+	ErrExpired = errors.New("signature expired")
+)
+
+// Signer signs resource IDs with expiration time.
+type Signer struct {
+	secret []byte
+}
+
+// NewSigner returns a new instance of [Signer].
+func NewSigner(secret []byte) *Signer {
+	return &Signer{
+		secret: secret,
+	}
+}
+
+// Sign returns a hex-encoded signature for the given resource ID and expiration time.
+func (s *Signer) Sign(id int, exp time.Time) string {
+	return hex.EncodeToString(s.sum(id, exp.Unix()))
+}
+
+// Verify returns nil error if the signature matches the given resource ID
+// and expiration time (unix seconds) and the signature is not expired.
+func (s *Signer) Verify(id int, exp int64, signature string) error {
+	rawSignature, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal(rawSignature, s.sum(id, exp)) {
+		return ErrInvalidSignature
+	}
+	if time.Now().Unix() > exp {
+		return ErrExpired
+	}
+	return nil
+}
+
+// sum calculates HMAC-SHA256 for the given resource ID and expiration time.
+func (s *Signer) sum(id int, exp int64) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(strconv.Itoa(id) + ":" + strconv.FormatInt(exp, 10)))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,43 @@
+package urlsign
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var _testSigner = NewSigner([]byte("example-file-url-secret"))
+
+func TestSigner_Verify(t *testing.T) {
+	t.Log("Sign resource ID and verify signature")
+
+	exp := time.Now().Add(time.Minute)
+	signature := _testSigner.Sign(5, exp)
+
+	require.NoError(t, _testSigner.Verify(5, exp.Unix(), signature))
+}
+
+func TestSigner_VerifyInvalid(t *testing.T) {
+	t.Log("Verify signature for another resource ID and get error")
+
+	exp := time.Now().Add(time.Minute)
+	signature := _testSigner.Sign(5, exp)
+
+	err := _testSigner.Verify(6, exp.Unix(), signature)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+	err = _testSigner.Verify(5, exp.Unix()+1, signature)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+	err = _testSigner.Verify(5, exp.Unix(), "not-hex")
+	require.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestSigner_VerifyExpired(t *testing.T) {
+	t.Log("Verify expired signature and get error")
+
+	exp := time.Now().Add(-time.Minute)
+	signature := _testSigner.Sign(5, exp)
+
+	err := _testSigner.Verify(5, exp.Unix(), signature)
+	require.ErrorIs(t, err, ErrExpired)
+}
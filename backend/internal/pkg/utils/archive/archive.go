@@ -0,0 +1,224 @@
+// Package archive (utils) contains help-functions for building archives with files.
+package archive
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"skadi/backend/internal/app/entity"
+)
+
+const (
+	_manifestName = "manifest.csv" // name of the manifest file in the archive root
+	_answerName   = "answer.txt"   // name of the answer file in the student folder
+	_filesDir     = "files"        // name of the dir for solution files in the student folder
+
+	_utf8BOM = "\ufeff" // BOM for correct opening of the CSV manifest in Excel
+)
+
+// MIME-types of already compressed files (they are stored without compression)
+var _compressedTypes = []string{"image/", "video/", "audio/", "application/pdf",
+	"application/zip", "application/x-7z-compressed", "application/x-rar", "application/gzip"}
+
+// manifest columns
+var _manifestHeader = []string{"solution_id", "student_id", "student", "folder",
+	"status", "grade", "updated_at", "files"}
+
+// WriteSolutionsZIP writes a ZIP archive with the given solutions to the writer.
+// Every student has its own folder with the answer text and solution files.
+// Archive root contains a manifest CSV with status, grade and update datetime of solutions.
+// Solution files are read one by one and written directly to the writer
+// (archive is not buffered in memory or on disk).
+func WriteSolutionsZIP(w io.Writer, solutions []entity.Solution) error {
+	zipWriter := zip.NewWriter(w)
+
+	// collect unique folder names for students
+	folders := make([]string, len(solutions))
+	usedFolders := make(map[string]int, len(solutions))
+	for idx := range solutions {
+		folders[idx] = uniqueName(solutionFolder(&solutions[idx]), usedFolders)
+	}
+
+	if err := writeManifest(zipWriter, solutions, folders); err != nil {
+		return fmt.Errorf("manifest: %w", err)
+	}
+	for idx := range solutions {
+		if err := writeSolution(zipWriter, &solutions[idx], folders[idx]); err != nil {
+			return fmt.Errorf("solution %d: %w", solutions[idx].ID, err)
+		}
+	}
+	return zipWriter.Close()
+}
+
+// writeManifest writes a manifest CSV to the archive root.
+func writeManifest(zipWriter *zip.Writer, solutions []entity.Solution, folders []string) error {
+	entry, err := zipWriter.CreateHeader(&zip.FileHeader{
+		Name:     _manifestName,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(entry, _utf8BOM); err != nil {
+		return err
+	}
+
+	csvWriter := csv.NewWriter(entry)
+	if err := csvWriter.Write(_manifestHeader); err != nil {
+		return err
+	}
+	for idx := range solutions {
+		sol := &solutions[idx]
+		record := []string{
+			strconv.Itoa(sol.ID),
+			strconv.Itoa(sol.StudentID),
+			studentName(sol),
+			folders[idx],
+			"",
+			"",
+			"",
+			strconv.Itoa(len(sol.Files)),
+		}
+		if sol.Status != nil {
+			record[4] = sol.Status.Name
+		}
+		if sol.Grade != nil {
+			record[5] = *sol.Grade
+		}
+		if sol.UpdatedAt != nil {
+			record[6] = sol.UpdatedAt.Format(time.RFC3339)
+		}
+		if err := csvWriter.Write(record); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// writeSolution writes the solution answer and files to the student folder.
+func writeSolution(zipWriter *zip.Writer, sol *entity.Solution, folder string) error {
+	modified := time.Now()
+	if sol.UpdatedAt != nil {
+		modified = *sol.UpdatedAt
+	}
+
+	// create student folder (it will exist even for the empty solution)
+	_, err := zipWriter.CreateHeader(&zip.FileHeader{
+		Name:     folder + "/",
+		Modified: modified,
+	})
+	if err != nil {
+		return fmt.Errorf("create folder: %w", err)
+	}
+
+	// write answer
+	if sol.Answer != nil {
+		entry, err := zipWriter.CreateHeader(&zip.FileHeader{
+			Name:     path.Join(folder, _answerName),
+			Method:   zip.Deflate,
+			Modified: modified,
+		})
+		if err != nil {
+			return fmt.Errorf("create answer: %w", err)
+		}
+		if _, err := io.WriteString(entry, *sol.Answer); err != nil {
+			return fmt.Errorf("write answer: %w", err)
+		}
+	}
+
+	// write files
+	usedNames := make(map[string]int, len(sol.Files))
+	for _, fileObj := range sol.Files {
+		name := path.Join(folder, _filesDir, uniqueName(cleanName(fileObj.Name), usedNames))
+		if err := writeFile(zipWriter, fileObj, name, modified); err != nil {
+			return fmt.Errorf("file %d: %w", fileObj.ID, err)
+		}
+	}
+	return nil
+}
+
+// writeFile copies the file from the file system to the archive.
+// Missing files are skipped, because they cannot be restored anyway.
+func writeFile(zipWriter *zip.Writer, fileObj *entity.File, name string,
+	modified time.Time) error {
+
+	src, err := os.Open(fileObj.Path)
+	if err != nil {
+		slog.Warn("skip file in archive", "path", fileObj.Path, "error", err)
+		return nil
+	}
+	defer src.Close()
+
+	method := zip.Deflate
+	if isCompressed(fileObj.MimeType) {
+		method = zip.Store
+	}
+	entry, err := zipWriter.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   method,
+		Modified: modified,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entry, src)
+	return err
+}
+
+// solutionFolder returns a folder name for the solution student.
+func solutionFolder(sol *entity.Solution) string {
+	return fmt.Sprintf("%s (%d)", cleanName(studentName(sol)), sol.StudentID)
+}
+
+// studentName returns a student fullname or empty string if student is not loaded.
+func studentName(sol *entity.Solution) string {
+	if sol.Student == nil {
+		return ""
+	}
+	return sol.Student.Fullname
+}
+
+// cleanName replaces symbols which are not allowed in the archive entry names.
+func cleanName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < ' ' || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if name == "" || name == "." || name == ".." {
+		return "_"
+	}
+	return name
+}
+
+// uniqueName returns the given name or the name with a number suffix
+// if the name was already used.
+func uniqueName(name string, used map[string]int) string {
+	used[name]++
+	if used[name] == 1 {
+		return name
+	}
+	ext := path.Ext(name)
+	return fmt.Sprintf("%s (%d)%s", strings.TrimSuffix(name, ext), used[name], ext)
+}
+
+// isCompressed returns true if the file with the given MIME-type is already compressed.
+func isCompressed(mimeType string) bool {
+	for _, prefix := range _compressedTypes {
+		if strings.HasPrefix(mimeType, prefix) {
+			return true
+		}
+	}
+	return false
+}
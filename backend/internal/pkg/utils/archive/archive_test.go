@@ -0,0 +1,58 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"skadi/backend/internal/app/entity"
+)
+
+func TestWriteSolutionsZIP(t *testing.T) {
+	t.Log("Write archive with two solutions of students with the same name")
+
+	// create solution file
+	filePath := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(filePath, []byte("print(1)"), 0o600))
+
+	answer := "my answer"
+	solutions := []entity.Solution{
+		{
+			ID:        1,
+			StudentID: 10,
+			Answer:    &answer,
+			Student:   &entity.Profile{Fullname: "Иванов Иван"},
+			Files: entity.Files{
+				{ID: 1, Name: "main.py", MimeType: "text/x-python", Path: filePath},
+				{ID: 2, Name: "main.py", MimeType: "text/x-python", Path: filePath},
+			},
+		},
+		{
+			ID:        2,
+			StudentID: 10,
+			Student:   &entity.Profile{Fullname: "Иванов Иван"},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSolutionsZIP(&buf, solutions))
+
+	zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	names := make([]string, len(zipReader.File))
+	for idx, f := range zipReader.File {
+		names[idx] = f.Name
+	}
+	require.Equal(t, []string{
+		"manifest.csv",
+		"Иванов Иван (10)/",
+		"Иванов Иван (10)/answer.txt",
+		"Иванов Иван (10)/files/main.py",
+		"Иванов Иван (10)/files/main (2).py",
+		"Иванов Иван (10) (2)/",
+	}, names)
+}
@@ -11,8 +11,29 @@ import (
 
 const _mpfdFileKey = "file" // key for mpfd files
 
+// Queue describes a queue of the saved files waiting for
+// background processing (making previews, antivirus scanning etc).
+type Queue interface {
+	// Enqueue adds files to the queue. This method is non-blocking.
+	Enqueue(files ...*entity.File)
+}
+
+// Queues represents a group of queues. Every file is added to all of them.
+// It implements the [Queue] interface.
+type Queues []Queue
+
+// Enqueue adds files to all queues.
+func (q Queues) Enqueue(files ...*entity.File) {
+	for _, queue := range q {
+		queue.Enqueue(files...)
+	}
+}
+
 // ParseAndSaveFiles parses files from mpfd, saves the to file system
-func ParseAndSaveFiles(ctx *fiber.Ctx, fileDir string) (entity.Files, error) {
+// and adds them to the queue for background processing (if it is not nil).
+func ParseAndSaveFiles(ctx *fiber.Ctx, fileDir string,
+	fileQueue Queue) (entity.Files, error) {
+
 	// parse mpfd
 	mpfd, err := ctx.MultipartForm()
 	if err != nil {
@@ -37,5 +58,9 @@ func ParseAndSaveFiles(ctx *fiber.Ctx, fileDir string) (entity.Files, error) {
 			return nil, fmt.Errorf("save file %s: %w", rawFile.Filename, err)
 		}
 	}
+	// process files in background
+	if fileQueue != nil {
+		fileQueue.Enqueue(uploadedFiles...)
+	}
 	return uploadedFiles, nil
 }
@@ -0,0 +1,108 @@
+// Package webhook provides sending of HMAC-signed JSON-requests to the webhook URLs.
+//
+// Every request has headers:
+//   - X-Skadi-Event - event type;
+//   - X-Skadi-Delivery - delivery ID (the same for all retries);
+//   - X-Skadi-Timestamp - unix time of the request;
+//   - X-Skadi-Signature - "sha256=" and hex-encoded HMAC-SHA256 of "<timestamp>.<body>".
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderEvent     = "X-Skadi-Event"     // header with the event type
+	HeaderDelivery  = "X-Skadi-Delivery"  // header with the delivery ID
+	HeaderTimestamp = "X-Skadi-Timestamp" // header with the request unix time
+	HeaderSignature = "X-Skadi-Signature" // header with the request signature
+
+	_signaturePrefix = "sha256=" // prefix of the signature value
+	_userAgent       = "Skadi-Webhook/1.0"
+	_maxDrainSize    = 64 * 1024 // max size of the response body read to reuse connection
+)
+
+// Request represents a webhook request.
+type Request struct {
+	// webhook URL
+	URL string
+	// secret to sign the request
+	Secret string
+	// event type
+	Event string
+	// delivery ID
+	DeliveryID int
+	// JSON-body
+	Body []byte
+}
+
+// Sender represents a HTTP-client to send webhook requests.
+type Sender struct {
+	httpClient *http.Client
+}
+
+// NewSender returns a new instance of [Sender].
+// Redirects are not followed, because the signed body must not be sent to another URL.
+func NewSender(timeout time.Duration) *Sender {
+	return &Sender{
+		httpClient: &http.Client{
+			Timeout: timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Send sends the signed request and returns the response status code.
+// Error is returned if the request failed or the status code is not 2xx
+// (status code is not zero if the response was received).
+func (s *Sender) Send(ctx context.Context, req *Request) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL,
+		bytes.NewReader(req.Body))
+	if err != nil {
+		return 0, fmt.Errorf("create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", _userAgent)
+	httpReq.Header.Set(HeaderEvent, req.Event)
+	httpReq.Header.Set(HeaderDelivery, strconv.Itoa(req.DeliveryID))
+	httpReq.Header.Set(HeaderTimestamp, timestamp)
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, timestamp, req.Body))
+
+	resp, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, _maxDrainSize))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns a signature header value for the given timestamp and body.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return _signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify returns true if the signature header value matches the timestamp and body.
+// It can be used by receivers written in Go.
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
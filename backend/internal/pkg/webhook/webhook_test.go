@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const _testSecret = "example-webhook-secret"
+
+func TestSender_Send(t *testing.T) {
+	t.Log("Send signed request and verify signature on receiver")
+
+	headers := make(chan http.Header, 1)
+	bodies := make(chan []byte, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		headers <- r.Header
+		bodies <- body
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	sender := NewSender(time.Second)
+	statusCode, err := sender.Send(context.Background(), &Request{
+		URL:        srv.URL,
+		Secret:     _testSecret,
+		Event:      "solution.graded",
+		DeliveryID: 15,
+		Body:       []byte(`{"event":"solution.graded"}`),
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusAccepted, statusCode)
+
+	header := <-headers
+	body := <-bodies
+	require.Equal(t, "solution.graded", header.Get(HeaderEvent))
+	require.Equal(t, "15", header.Get(HeaderDelivery))
+	require.True(t, Verify(_testSecret, header.Get(HeaderTimestamp), body,
+		header.Get(HeaderSignature)))
+	require.False(t, Verify("another-secret", header.Get(HeaderTimestamp), body,
+		header.Get(HeaderSignature)))
+}
+
+func TestSender_SendFailed(t *testing.T) {
+	t.Log("Send request to failing receiver and get status code with error")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/another", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	sender := NewSender(time.Second)
+	statusCode, err := sender.Send(context.Background(), &Request{
+		URL:  srv.URL,
+		Body: []byte(`{}`),
+	})
+	require.Error(t, err)
+	require.Equal(t, http.StatusFound, statusCode)
+}
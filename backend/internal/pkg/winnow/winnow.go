@@ -0,0 +1,220 @@
+// Package winnow provides token-based fingerprinting of texts and source code
+// by winnowing (Schleimer, Wilkerson, Aiken, "Winnowing: local algorithms for document
+// fingerprinting") to estimate their similarity and find matching fragments.
+package winnow
+
+import (
+	"hash/fnv"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Span represents a fragment of the text as byte offsets [Start, End).
+type Span struct {
+	Start int
+	End   int
+}
+
+// Lines returns numbers of the first and the last lines (starting from 1)
+// of the span in the given text.
+func (s Span) Lines(text string) (int, int) {
+	first := strings.Count(text[:s.Start], "\n") + 1
+	last := first + strings.Count(text[s.Start:max(s.Start, s.End-1)], "\n")
+	return first, last
+}
+
+// Fingerprint represents a hash of k consecutive tokens selected by winnowing
+// with the span of these tokens in the text.
+type Fingerprint struct {
+	Hash uint64
+	Span Span
+}
+
+// Fragment represents a pair of matching fragments of two texts.
+type Fragment struct {
+	A Span
+	B Span
+}
+
+// token represents a normalized text token with its span.
+type token struct {
+	value string
+	span  Span
+}
+
+// Fingerprinter makes text fingerprints with the fixed k-gram size and window.
+// Any match of at least k+w-1 tokens is guaranteed to be found,
+// matches shorter than k tokens are ignored.
+type Fingerprinter struct {
+	k int
+	w int
+}
+
+// New returns a new instance of [Fingerprinter] with the given number of tokens
+// in one k-gram and the winnowing window (both are at least 1).
+func New(k, w int) *Fingerprinter {
+	return &Fingerprinter{
+		k: max(k, 1),
+		w: max(w, 1),
+	}
+}
+
+// Fingerprints returns fingerprints of the text (in the text order).
+// Words are compared case-insensitive, whitespaces are ignored
+// and every other symbol is a separate token.
+func (f *Fingerprinter) Fingerprints(text string) []Fingerprint {
+	tokens := tokenize(text)
+	if len(tokens) < f.k {
+		return nil
+	}
+
+	// hash all k-grams
+	grams := make([]Fingerprint, len(tokens)-f.k+1)
+	for idx := range grams {
+		h := fnv.New64a()
+		for _, tok := range tokens[idx : idx+f.k] {
+			h.Write([]byte(tok.value))
+			h.Write([]byte{0})
+		}
+		grams[idx] = Fingerprint{
+			Hash: h.Sum64(),
+			Span: Span{Start: tokens[idx].span.Start, End: tokens[idx+f.k-1].span.End},
+		}
+	}
+
+	// select the minimal hash (the rightmost one) in every window
+	window := min(f.w, len(grams))
+	res := make([]Fingerprint, 0, 2*len(grams)/(window+1)+1)
+	last := -1
+	for start := 0; start+window <= len(grams); start++ {
+		minIdx := start
+		for idx := start + 1; idx < start+window; idx++ {
+			if grams[idx].Hash <= grams[minIdx].Hash {
+				minIdx = idx
+			}
+		}
+		if minIdx != last {
+			res = append(res, grams[minIdx])
+			last = minIdx
+		}
+	}
+	return res
+}
+
+// Exclude returns fingerprints without hashes of the base fingerprints
+// (e.g. the code template given to everyone).
+func Exclude(fps, base []Fingerprint) []Fingerprint {
+	if len(base) == 0 {
+		return fps
+	}
+	baseHashes := hashes(base)
+	return slices.DeleteFunc(slices.Clone(fps), func(fp Fingerprint) bool {
+		_, ok := baseHashes[fp.Hash]
+		return ok
+	})
+}
+
+// Similarity returns a share of the common distinct hashes of two fingerprint sets
+// relative to the smaller set (from 0 to 1). So the text copied into a larger one is
+// fully similar to it.
+func Similarity(a, b []Fingerprint) float64 {
+	hashesA, hashesB := hashes(a), hashes(b)
+	if len(hashesA) == 0 || len(hashesB) == 0 {
+		return 0
+	}
+	common := 0
+	for hash := range hashesA {
+		if _, ok := hashesB[hash]; ok {
+			common++
+		}
+	}
+	return float64(common) / float64(min(len(hashesA), len(hashesB)))
+}
+
+// Match returns matching fragments of two texts by their fingerprints (in the order of text A).
+// Overlapping matches following each other in both texts are merged into one fragment.
+func Match(a, b []Fingerprint) []Fragment {
+	// all occurrences of every hash in text B
+	spansB := make(map[uint64][]Span, len(b))
+	for _, fp := range b {
+		spansB[fp.Hash] = append(spansB[fp.Hash], fp.Span)
+	}
+
+	var res []Fragment
+	for _, fp := range a {
+		candidates, ok := spansB[fp.Hash]
+		if !ok {
+			continue
+		}
+		if len(res) == 0 {
+			res = append(res, Fragment{A: fp.Span, B: candidates[0]})
+			continue
+		}
+		last := &res[len(res)-1]
+		// continue the last fragment if the match overlaps its end in both texts
+		idx := slices.IndexFunc(candidates, func(span Span) bool {
+			return span.Start >= last.B.Start && span.Start <= last.B.End &&
+				span.End > last.B.End
+		})
+		if fp.Span.Start <= last.A.End && idx != -1 {
+			last.A.End = max(last.A.End, fp.Span.End)
+			last.B.End = max(last.B.End, candidates[idx].End)
+			continue
+		}
+		// prefer the occurrence after the last fragment
+		idx = slices.IndexFunc(candidates, func(span Span) bool {
+			return span.Start >= last.B.End
+		})
+		res = append(res, Fragment{A: fp.Span, B: candidates[max(idx, 0)]})
+	}
+	return res
+}
+
+// hashes returns a set of distinct hashes of fingerprints.
+func hashes(fps []Fingerprint) map[uint64]struct{} {
+	res := make(map[uint64]struct{}, len(fps))
+	for _, fp := range fps {
+		res[fp.Hash] = struct{}{}
+	}
+	return res
+}
+
+// tokenize splits the text into tokens: lowercase words (letters, digits and underscores)
+// and separate symbols. Whitespaces are skipped.
+func tokenize(text string) []token {
+	var res []token
+	for pos := 0; pos < len(text); {
+		r, size := utf8.DecodeRuneInString(text[pos:])
+		switch {
+		case unicode.IsSpace(r):
+			pos += size
+		case isWordRune(r):
+			start := pos
+			for pos < len(text) {
+				r, size = utf8.DecodeRuneInString(text[pos:])
+				if !isWordRune(r) {
+					break
+				}
+				pos += size
+			}
+			res = append(res, token{
+				value: strings.ToLower(text[start:pos]),
+				span:  Span{Start: start, End: pos},
+			})
+		default:
+			res = append(res, token{
+				value: text[pos : pos+size],
+				span:  Span{Start: pos, End: pos + size},
+			})
+			pos += size
+		}
+	}
+	return res
+}
+
+// isWordRune returns true if the rune is a part of the word token.
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,68 @@
+package winnow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var _testFingerprinter = New(5, 4)
+
+const _testCode = `def fib(n):
+    a, b = 0, 1
+    for _ in range(n):
+        a, b = b, a + b
+    return a
+
+print(fib(int(input())))
+`
+
+func TestFingerprinter_Copy(t *testing.T) {
+	t.Log("Compare the code with its reformatted copy and get full similarity")
+
+	copied := "# my solution\nDEF fib(n):\n  a,b=0,1\n  for _ in range(n):\n" +
+		"    a,b=b,a+b\n  return a\n\nprint(fib(int(input())))"
+	a := _testFingerprinter.Fingerprints(_testCode)
+	b := _testFingerprinter.Fingerprints(copied)
+	require.NotEmpty(t, a)
+	require.InDelta(t, 1.0, Similarity(a, b), 0.001)
+
+	fragments := Match(a, b)
+	require.Len(t, fragments, 1)
+	// edge tokens may be not covered by selected fingerprints
+	require.Contains(t, _testCode[fragments[0].A.Start:fragments[0].A.End],
+		"a, b = 0, 1\n    for _ in range(n):\n        a, b = b, a + b\n    return a")
+	first, last := fragments[0].B.Lines(copied)
+	require.Equal(t, 2, first)
+	require.Equal(t, 8, last)
+}
+
+func TestFingerprinter_Different(t *testing.T) {
+	t.Log("Compare different texts and get no matches")
+
+	a := _testFingerprinter.Fingerprints(_testCode)
+	b := _testFingerprinter.Fingerprints("Числа Фибоначчи считаются циклом, " +
+		"каждое следующее число равно сумме двух предыдущих.")
+	require.Zero(t, Similarity(a, b))
+	require.Empty(t, Match(a, b))
+}
+
+func TestFingerprinter_Short(t *testing.T) {
+	t.Log("Fingerprint the text shorter than k-gram and get no fingerprints")
+
+	require.Empty(t, _testFingerprinter.Fingerprints("print(n)"))
+	require.Zero(t, Similarity(nil, _testFingerprinter.Fingerprints(_testCode)))
+}
+
+func TestExclude(t *testing.T) {
+	t.Log("Exclude the template from both texts and get no similarity")
+
+	template := "print(fib(int(input())))"
+	a := _testFingerprinter.Fingerprints("def fib(n):\n    return n\n\n" + template)
+	b := _testFingerprinter.Fingerprints("x = 1\ny = 2\n" + template)
+	require.Positive(t, Similarity(a, b))
+
+	base := _testFingerprinter.Fingerprints(template)
+	a, b = Exclude(a, base), Exclude(b, base)
+	require.Zero(t, Similarity(a, b))
+}
@@ -0,0 +1,3 @@
+ALTER TABLE upload DROP CONSTRAINT upload_user_fk;
+
+DROP TABLE IF EXISTS upload;
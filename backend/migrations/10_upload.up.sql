@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS upload;
+
+CREATE TABLE IF NOT EXISTS upload (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    mime_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    uploaded BIGINT NOT NULL DEFAULT 0,
+    path VARCHAR(255) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL
+);
+
+ALTER TABLE upload
+ADD CONSTRAINT upload_user_fk FOREIGN KEY (user_id) REFERENCES user (id) ON UPDATE CASCADE ON DELETE CASCADE;
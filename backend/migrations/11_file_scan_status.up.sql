@@ -0,0 +1,2 @@
+ALTER TABLE file
+ADD COLUMN scan_status ENUM('not_scanned', 'pending', 'clean', 'infected', 'failed') NOT NULL DEFAULT 'not_scanned';
@@ -0,0 +1,9 @@
+ALTER TABLE comment DROP CONSTRAINT comment_parent_fk;
+
+ALTER TABLE comment DROP CONSTRAINT comment_user_fk;
+
+ALTER TABLE comment
+DROP COLUMN user_id,
+DROP COLUMN parent_id,
+DROP COLUMN edited_at,
+DROP COLUMN deleted_at;
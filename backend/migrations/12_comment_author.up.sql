@@ -0,0 +1,11 @@
+ALTER TABLE comment
+ADD COLUMN user_id BIGINT NULL AFTER solution_id,
+ADD COLUMN parent_id BIGINT NULL AFTER user_id,
+ADD COLUMN edited_at TIMESTAMP NULL,
+ADD COLUMN deleted_at TIMESTAMP NULL;
+
+ALTER TABLE comment
+ADD CONSTRAINT comment_user_fk FOREIGN KEY (user_id) REFERENCES user (id) ON UPDATE CASCADE ON DELETE SET NULL;
+
+ALTER TABLE comment
+ADD CONSTRAINT comment_parent_fk FOREIGN KEY (parent_id) REFERENCES comment (id) ON UPDATE CASCADE ON DELETE SET NULL;
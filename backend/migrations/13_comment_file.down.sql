@@ -0,0 +1,13 @@
+ALTER TABLE comment DROP CONSTRAINT comment_anchor_file_fk;
+
+ALTER TABLE comment
+DROP COLUMN anchor_file_id,
+DROP COLUMN anchor_line_from,
+DROP COLUMN anchor_line_to,
+DROP COLUMN anchor_page;
+
+ALTER TABLE comment_file DROP CONSTRAINT comment_file_file_fk;
+
+ALTER TABLE comment_file DROP CONSTRAINT comment_file_comment_fk;
+
+DROP TABLE IF EXISTS comment_file;
@@ -0,0 +1,22 @@
+DROP TABLE IF EXISTS comment_file;
+
+CREATE TABLE IF NOT EXISTS comment_file (
+    comment_id BIGINT NOT NULL,
+    file_id BIGINT NOT NULL,
+    PRIMARY KEY (comment_id, file_id)
+);
+
+ALTER TABLE comment_file
+ADD CONSTRAINT comment_file_comment_fk FOREIGN KEY (comment_id) REFERENCES comment (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE comment_file
+ADD CONSTRAINT comment_file_file_fk FOREIGN KEY (file_id) REFERENCES file (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE comment
+ADD COLUMN anchor_file_id BIGINT NULL,
+ADD COLUMN anchor_line_from INT NULL,
+ADD COLUMN anchor_line_to INT NULL,
+ADD COLUMN anchor_page INT NULL;
+
+ALTER TABLE comment
+ADD CONSTRAINT comment_anchor_file_fk FOREIGN KEY (anchor_file_id) REFERENCES file (id) ON UPDATE CASCADE ON DELETE SET NULL;
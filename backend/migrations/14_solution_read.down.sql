@@ -0,0 +1,5 @@
+ALTER TABLE solution_read DROP CONSTRAINT solution_read_user_fk;
+
+ALTER TABLE solution_read DROP CONSTRAINT solution_read_solution_fk;
+
+DROP TABLE IF EXISTS solution_read;
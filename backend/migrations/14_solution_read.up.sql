@@ -0,0 +1,22 @@
+DROP TABLE IF EXISTS solution_read;
+
+CREATE TABLE IF NOT EXISTS solution_read (
+    solution_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    read_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (solution_id, user_id)
+);
+
+ALTER TABLE solution_read
+ADD CONSTRAINT solution_read_solution_fk FOREIGN KEY (solution_id) REFERENCES solution (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE solution_read
+ADD CONSTRAINT solution_read_user_fk FOREIGN KEY (user_id) REFERENCES user (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+-- existing solutions are considered as read by their students and teachers
+INSERT INTO solution_read (solution_id, user_id)
+SELECT id, student_id FROM solution;
+
+INSERT INTO solution_read (solution_id, user_id)
+SELECT solution.id, task.teacher_id FROM solution
+INNER JOIN task ON task.id = solution.task_id;
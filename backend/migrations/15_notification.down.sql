@@ -0,0 +1,13 @@
+ALTER TABLE notification_pref DROP CONSTRAINT notification_pref_user_fk;
+
+DROP TABLE IF EXISTS notification_pref;
+
+ALTER TABLE notification DROP CONSTRAINT notification_comment_fk;
+
+ALTER TABLE notification DROP CONSTRAINT notification_solution_fk;
+
+ALTER TABLE notification DROP CONSTRAINT notification_task_fk;
+
+ALTER TABLE notification DROP CONSTRAINT notification_user_fk;
+
+DROP TABLE IF EXISTS notification;
@@ -0,0 +1,38 @@
+DROP TABLE IF EXISTS notification;
+
+CREATE TABLE IF NOT EXISTS notification (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    type ENUM('task_assigned', 'solution_submitted', 'solution_checked', 'new_comment', 'deadline_approaching') NOT NULL,
+    message VARCHAR(512) NOT NULL,
+    task_id BIGINT NULL,
+    solution_id BIGINT NULL,
+    comment_id BIGINT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    read_at TIMESTAMP NULL,
+    INDEX notification_user_read_idx (user_id, read_at)
+);
+
+ALTER TABLE notification
+ADD CONSTRAINT notification_user_fk FOREIGN KEY (user_id) REFERENCES user (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE notification
+ADD CONSTRAINT notification_task_fk FOREIGN KEY (task_id) REFERENCES task (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE notification
+ADD CONSTRAINT notification_solution_fk FOREIGN KEY (solution_id) REFERENCES solution (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE notification
+ADD CONSTRAINT notification_comment_fk FOREIGN KEY (comment_id) REFERENCES comment (id) ON UPDATE CASCADE ON DELETE SET NULL;
+
+DROP TABLE IF EXISTS notification_pref;
+
+CREATE TABLE IF NOT EXISTS notification_pref (
+    user_id BIGINT NOT NULL,
+    type ENUM('task_assigned', 'solution_submitted', 'solution_checked', 'new_comment', 'deadline_approaching') NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    PRIMARY KEY (user_id, type)
+);
+
+ALTER TABLE notification_pref
+ADD CONSTRAINT notification_pref_user_fk FOREIGN KEY (user_id) REFERENCES user (id) ON UPDATE CASCADE ON DELETE CASCADE;
@@ -0,0 +1,5 @@
+ALTER TABLE mail_pref DROP CONSTRAINT mail_pref_user_fk;
+
+DROP TABLE IF EXISTS mail_pref;
+
+DROP TABLE IF EXISTS mail_queue;
@@ -0,0 +1,27 @@
+DROP TABLE IF EXISTS mail_queue;
+
+CREATE TABLE IF NOT EXISTS mail_queue (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    recipient VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    body MEDIUMTEXT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error VARCHAR(1024) NULL,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX mail_queue_due_idx (sent_at, next_attempt_at)
+);
+
+DROP TABLE IF EXISTS mail_pref;
+
+CREATE TABLE IF NOT EXISTS mail_pref (
+    user_id BIGINT NOT NULL PRIMARY KEY,
+    mode ENUM('off', 'immediate', 'daily') NOT NULL DEFAULT 'daily',
+    parent_summary BOOLEAN NOT NULL DEFAULT FALSE,
+    digest_sent_at TIMESTAMP NULL,
+    summary_sent_at TIMESTAMP NULL
+);
+
+ALTER TABLE mail_pref
+ADD CONSTRAINT mail_pref_user_fk FOREIGN KEY (user_id) REFERENCES user (id) ON UPDATE CASCADE ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+ALTER TABLE telegram_chat DROP CONSTRAINT telegram_chat_user_fk;
+
+DROP TABLE IF EXISTS telegram_chat;
@@ -0,0 +1,14 @@
+DROP TABLE IF EXISTS telegram_chat;
+
+CREATE TABLE IF NOT EXISTS telegram_chat (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    chat_id BIGINT NOT NULL,
+    is_parent BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE INDEX uni_telegram_chat_user (chat_id, user_id),
+    INDEX telegram_chat_user_idx (user_id)
+);
+
+ALTER TABLE telegram_chat
+ADD CONSTRAINT telegram_chat_user_fk FOREIGN KEY (user_id) REFERENCES user (id) ON UPDATE CASCADE ON DELETE CASCADE;
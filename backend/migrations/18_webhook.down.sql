@@ -0,0 +1,5 @@
+ALTER TABLE webhook_delivery DROP CONSTRAINT webhook_delivery_webhook_fk;
+
+DROP TABLE IF EXISTS webhook_delivery;
+
+DROP TABLE IF EXISTS webhook;
@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS job_run;
+
+CREATE TABLE IF NOT EXISTS job_run (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    job VARCHAR(64) NOT NULL,
+    instance VARCHAR(255) NOT NULL,
+    scheduled_at TIMESTAMP NOT NULL,
+    started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP NULL,
+    error VARCHAR(1024) NULL,
+    INDEX job_run_job_idx (job, id),
+    INDEX job_run_started_idx (started_at)
+);
@@ -0,0 +1,5 @@
+ALTER TABLE task_share DROP CONSTRAINT task_share_teacher_fk;
+
+ALTER TABLE task_share DROP CONSTRAINT task_share_task_fk;
+
+DROP TABLE IF EXISTS task_share;
@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS task_share;
+
+CREATE TABLE IF NOT EXISTS task_share (
+    task_id BIGINT NOT NULL,
+    teacher_id BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, teacher_id),
+    INDEX task_share_teacher_idx (teacher_id)
+);
+
+ALTER TABLE task_share
+ADD CONSTRAINT task_share_task_fk FOREIGN KEY (task_id) REFERENCES task (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE task_share
+ADD CONSTRAINT task_share_teacher_fk FOREIGN KEY (teacher_id) REFERENCES user (id) ON UPDATE CASCADE ON DELETE CASCADE;
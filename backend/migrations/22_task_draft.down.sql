@@ -0,0 +1,4 @@
+ALTER TABLE task
+DROP INDEX task_publish_idx,
+DROP COLUMN draft,
+DROP COLUMN publish_at;
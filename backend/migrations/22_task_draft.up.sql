@@ -0,0 +1,4 @@
+ALTER TABLE task
+ADD COLUMN draft BOOLEAN NOT NULL DEFAULT FALSE AFTER teacher_id,
+ADD COLUMN publish_at TIMESTAMP NULL AFTER draft,
+ADD INDEX task_publish_idx (draft, publish_at);
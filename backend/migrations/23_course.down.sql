@@ -0,0 +1,19 @@
+ALTER TABLE task DROP CONSTRAINT task_module_fk;
+
+ALTER TABLE course_module DROP CONSTRAINT course_module_course_fk;
+
+ALTER TABLE course_class DROP CONSTRAINT course_class_class_fk;
+
+ALTER TABLE course_class DROP CONSTRAINT course_class_course_fk;
+
+ALTER TABLE course DROP CONSTRAINT course_teacher_fk;
+
+ALTER TABLE task
+DROP COLUMN module_id,
+DROP COLUMN position;
+
+DROP TABLE IF EXISTS course_module;
+
+DROP TABLE IF EXISTS course_class;
+
+DROP TABLE IF EXISTS course;
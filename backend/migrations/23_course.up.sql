@@ -0,0 +1,48 @@
+DROP TABLE IF EXISTS course_module;
+
+DROP TABLE IF EXISTS course_class;
+
+DROP TABLE IF EXISTS course;
+
+CREATE TABLE IF NOT EXISTS course (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    title VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL,
+    teacher_id BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX course_teacher_idx (teacher_id)
+);
+
+CREATE TABLE IF NOT EXISTS course_class (
+    course_id BIGINT NOT NULL,
+    class_id BIGINT NOT NULL,
+    PRIMARY KEY (course_id, class_id),
+    INDEX course_class_class_idx (class_id)
+);
+
+CREATE TABLE IF NOT EXISTS course_module (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    course_id BIGINT NOT NULL,
+    title VARCHAR(100) NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    INDEX course_module_course_idx (course_id, position)
+);
+
+ALTER TABLE task
+ADD COLUMN module_id BIGINT NULL AFTER teacher_id,
+ADD COLUMN position INT NOT NULL DEFAULT 0 AFTER module_id;
+
+ALTER TABLE course
+ADD CONSTRAINT course_teacher_fk FOREIGN KEY (teacher_id) REFERENCES user (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE course_class
+ADD CONSTRAINT course_class_course_fk FOREIGN KEY (course_id) REFERENCES course (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE course_class
+ADD CONSTRAINT course_class_class_fk FOREIGN KEY (class_id) REFERENCES class (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE course_module
+ADD CONSTRAINT course_module_course_fk FOREIGN KEY (course_id) REFERENCES course (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE task
+ADD CONSTRAINT task_module_fk FOREIGN KEY (module_id) REFERENCES course_module (id) ON UPDATE CASCADE ON DELETE SET NULL;
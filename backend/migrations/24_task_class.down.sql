@@ -0,0 +1,5 @@
+ALTER TABLE task_class DROP CONSTRAINT task_class_class_fk;
+
+ALTER TABLE task_class DROP CONSTRAINT task_class_task_fk;
+
+DROP TABLE IF EXISTS task_class;
@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS task_class;
+
+CREATE TABLE IF NOT EXISTS task_class (
+    task_id BIGINT NOT NULL,
+    class_id BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, class_id),
+    INDEX task_class_class_idx (class_id)
+);
+
+ALTER TABLE task_class
+ADD CONSTRAINT task_class_task_fk FOREIGN KEY (task_id) REFERENCES task (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE task_class
+ADD CONSTRAINT task_class_class_fk FOREIGN KEY (class_id) REFERENCES class (id) ON UPDATE CASCADE ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+ALTER TABLE comment DROP COLUMN message_html;
+
+ALTER TABLE task DROP COLUMN description_html;
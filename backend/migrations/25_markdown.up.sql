@@ -0,0 +1,3 @@
+ALTER TABLE task ADD COLUMN description_html TEXT NULL;
+
+ALTER TABLE comment ADD COLUMN message_html TEXT NULL;
@@ -0,0 +1,15 @@
+ALTER TABLE autotest_result DROP CONSTRAINT autotest_result_run_fk;
+
+ALTER TABLE autotest_run DROP CONSTRAINT autotest_run_solution_fk;
+
+ALTER TABLE autotest_case DROP CONSTRAINT autotest_case_suite_fk;
+
+ALTER TABLE autotest_suite DROP CONSTRAINT autotest_suite_task_fk;
+
+DROP TABLE IF EXISTS autotest_result;
+
+DROP TABLE IF EXISTS autotest_run;
+
+DROP TABLE IF EXISTS autotest_case;
+
+DROP TABLE IF EXISTS autotest_suite;
@@ -0,0 +1,66 @@
+DROP TABLE IF EXISTS autotest_result;
+
+DROP TABLE IF EXISTS autotest_run;
+
+DROP TABLE IF EXISTS autotest_case;
+
+DROP TABLE IF EXISTS autotest_suite;
+
+CREATE TABLE IF NOT EXISTS autotest_suite (
+    task_id BIGINT NOT NULL PRIMARY KEY,
+    lang VARCHAR(20) NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS autotest_case (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    task_id BIGINT NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    stdin MEDIUMTEXT NOT NULL,
+    expected MEDIUMTEXT NOT NULL,
+    time_limit_ms INT NOT NULL,
+    memory_limit_mb INT NOT NULL,
+    hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    INDEX autotest_case_task_idx (task_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS autotest_run (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    solution_id BIGINT NOT NULL,
+    status ENUM('pending', 'running', 'done', 'compile_error', 'failed') NOT NULL DEFAULT 'pending',
+    passed INT NOT NULL DEFAULT 0,
+    total INT NOT NULL DEFAULT 0,
+    score INT NULL,
+    error TEXT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP NULL,
+    finished_at TIMESTAMP NULL,
+    UNIQUE INDEX autotest_run_solution_idx (solution_id),
+    INDEX autotest_run_status_idx (status, created_at)
+);
+
+CREATE TABLE IF NOT EXISTS autotest_result (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    run_id BIGINT NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    status ENUM('ok', 'wrong_answer', 'time_limit', 'memory_limit', 'runtime_error') NOT NULL,
+    time_ms INT NOT NULL DEFAULT 0,
+    stdin MEDIUMTEXT NULL,
+    expected MEDIUMTEXT NULL,
+    stdout MEDIUMTEXT NULL,
+    stderr MEDIUMTEXT NULL,
+    INDEX autotest_result_run_idx (run_id, position)
+);
+
+ALTER TABLE autotest_suite
+ADD CONSTRAINT autotest_suite_task_fk FOREIGN KEY (task_id) REFERENCES task (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE autotest_case
+ADD CONSTRAINT autotest_case_suite_fk FOREIGN KEY (task_id) REFERENCES autotest_suite (task_id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE autotest_run
+ADD CONSTRAINT autotest_run_solution_fk FOREIGN KEY (solution_id) REFERENCES solution (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE autotest_result
+ADD CONSTRAINT autotest_result_run_fk FOREIGN KEY (run_id) REFERENCES autotest_run (id) ON UPDATE CASCADE ON DELETE CASCADE;
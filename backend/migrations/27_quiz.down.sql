@@ -0,0 +1,12 @@
+ALTER TABLE quiz_answer DROP CONSTRAINT quiz_answer_question_fk;
+
+ALTER TABLE quiz_answer DROP CONSTRAINT quiz_answer_solution_fk;
+
+ALTER TABLE quiz_question DROP CONSTRAINT quiz_question_task_fk;
+
+DROP TABLE IF EXISTS quiz_answer;
+
+DROP TABLE IF EXISTS quiz_question;
+
+ALTER TABLE task
+DROP COLUMN type;
@@ -0,0 +1,44 @@
+DROP TABLE IF EXISTS quiz_answer;
+
+DROP TABLE IF EXISTS quiz_question;
+
+ALTER TABLE task
+ADD COLUMN type ENUM('assignment', 'quiz') NOT NULL DEFAULT 'assignment' AFTER teacher_id;
+
+CREATE TABLE IF NOT EXISTS quiz_question (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    task_id BIGINT NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    kind ENUM('single', 'multiple', 'short', 'numeric') NOT NULL,
+    text TEXT NOT NULL,
+    points INT NOT NULL DEFAULT 1,
+    options JSON NULL,
+    correct JSON NULL,
+    answers JSON NULL,
+    regex BOOLEAN NOT NULL DEFAULT FALSE,
+    value DOUBLE NULL,
+    tolerance DOUBLE NOT NULL DEFAULT 0,
+    INDEX quiz_question_task_idx (task_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS quiz_answer (
+    solution_id BIGINT NOT NULL,
+    question_id BIGINT NOT NULL,
+    choice JSON NULL,
+    text VARCHAR(1000) NULL,
+    number DOUBLE NULL,
+    points INT NULL,
+    override_points INT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (solution_id, question_id),
+    INDEX quiz_answer_question_idx (question_id)
+);
+
+ALTER TABLE quiz_question
+ADD CONSTRAINT quiz_question_task_fk FOREIGN KEY (task_id) REFERENCES task (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE quiz_answer
+ADD CONSTRAINT quiz_answer_solution_fk FOREIGN KEY (solution_id) REFERENCES solution (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE quiz_answer
+ADD CONSTRAINT quiz_answer_question_fk FOREIGN KEY (question_id) REFERENCES quiz_question (id) ON UPDATE CASCADE ON DELETE CASCADE;
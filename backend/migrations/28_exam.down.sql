@@ -0,0 +1,8 @@
+ALTER TABLE exam_attempt DROP CONSTRAINT exam_attempt_solution_fk;
+
+DROP TABLE IF EXISTS exam_attempt;
+
+ALTER TABLE task
+DROP COLUMN exam_opens_at,
+DROP COLUMN exam_closes_at,
+DROP COLUMN exam_duration;
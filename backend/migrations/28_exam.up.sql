@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS exam_attempt;
+
+ALTER TABLE task
+ADD COLUMN exam_opens_at TIMESTAMP NULL AFTER publish_at,
+ADD COLUMN exam_closes_at TIMESTAMP NULL AFTER exam_opens_at,
+ADD COLUMN exam_duration INT NULL AFTER exam_closes_at;
+
+CREATE TABLE IF NOT EXISTS exam_attempt (
+    solution_id BIGINT NOT NULL PRIMARY KEY,
+    started_at TIMESTAMP NULL,
+    extra_minutes INT NOT NULL DEFAULT 0
+);
+
+ALTER TABLE exam_attempt
+ADD CONSTRAINT exam_attempt_solution_fk FOREIGN KEY (solution_id) REFERENCES solution (id) ON UPDATE CASCADE ON DELETE CASCADE;
@@ -0,0 +1,11 @@
+ALTER TABLE plagiarism_pair DROP CONSTRAINT plagiarism_pair_other_solution_fk;
+
+ALTER TABLE plagiarism_pair DROP CONSTRAINT plagiarism_pair_solution_fk;
+
+ALTER TABLE plagiarism_pair DROP CONSTRAINT plagiarism_pair_task_fk;
+
+ALTER TABLE plagiarism_check DROP CONSTRAINT plagiarism_check_task_fk;
+
+DROP TABLE IF EXISTS plagiarism_pair;
+
+DROP TABLE IF EXISTS plagiarism_check;
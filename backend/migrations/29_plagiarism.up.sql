@@ -0,0 +1,36 @@
+DROP TABLE IF EXISTS plagiarism_pair;
+
+DROP TABLE IF EXISTS plagiarism_check;
+
+CREATE TABLE IF NOT EXISTS plagiarism_check (
+    task_id BIGINT NOT NULL PRIMARY KEY,
+    status ENUM('pending', 'running', 'done', 'failed') NOT NULL DEFAULT 'pending',
+    error TEXT NULL,
+    requested_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP NULL,
+    finished_at TIMESTAMP NULL
+);
+
+CREATE TABLE IF NOT EXISTS plagiarism_pair (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    task_id BIGINT NOT NULL,
+    solution_id BIGINT NOT NULL,
+    other_solution_id BIGINT NOT NULL,
+    similarity INT NOT NULL,
+    fragments JSON NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE INDEX plagiarism_pair_solutions_idx (solution_id, other_solution_id),
+    INDEX plagiarism_pair_task_idx (task_id, similarity)
+);
+
+ALTER TABLE plagiarism_check
+ADD CONSTRAINT plagiarism_check_task_fk FOREIGN KEY (task_id) REFERENCES task (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE plagiarism_pair
+ADD CONSTRAINT plagiarism_pair_task_fk FOREIGN KEY (task_id) REFERENCES task (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE plagiarism_pair
+ADD CONSTRAINT plagiarism_pair_solution_fk FOREIGN KEY (solution_id) REFERENCES solution (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE plagiarism_pair
+ADD CONSTRAINT plagiarism_pair_other_solution_fk FOREIGN KEY (other_solution_id) REFERENCES solution (id) ON UPDATE CASCADE ON DELETE CASCADE;
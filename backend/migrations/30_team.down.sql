@@ -0,0 +1,11 @@
+ALTER TABLE solution
+ADD UNIQUE INDEX uni_student_task (task_id, student_id),
+DROP INDEX solution_task_student_idx;
+
+ALTER TABLE solution_member DROP CONSTRAINT solution_member_student_fk;
+
+ALTER TABLE solution_member DROP CONSTRAINT solution_member_task_fk;
+
+ALTER TABLE solution_member DROP CONSTRAINT solution_member_solution_fk;
+
+DROP TABLE IF EXISTS solution_member;
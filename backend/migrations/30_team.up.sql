@@ -0,0 +1,28 @@
+DROP TABLE IF EXISTS solution_member;
+
+CREATE TABLE IF NOT EXISTS solution_member (
+    solution_id BIGINT NOT NULL,
+    task_id BIGINT NOT NULL,
+    student_id BIGINT NOT NULL,
+    grade VARCHAR(5) NULL,
+    PRIMARY KEY (solution_id, student_id),
+    UNIQUE INDEX uni_member_task (task_id, student_id)
+);
+
+ALTER TABLE solution_member
+ADD CONSTRAINT solution_member_solution_fk FOREIGN KEY (solution_id) REFERENCES solution (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE solution_member
+ADD CONSTRAINT solution_member_task_fk FOREIGN KEY (task_id) REFERENCES task (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE solution_member
+ADD CONSTRAINT solution_member_student_fk FOREIGN KEY (student_id) REFERENCES user (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+-- existing solutions are individual: their students are the only members
+INSERT INTO solution_member (solution_id, task_id, student_id)
+SELECT id, task_id, student_id FROM solution;
+
+-- the student can be a member of the team solution only once, solution.student_id is the team owner
+ALTER TABLE solution
+ADD INDEX solution_task_student_idx (task_id, student_id),
+DROP INDEX uni_student_task;
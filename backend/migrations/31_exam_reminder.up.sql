@@ -0,0 +1,2 @@
+ALTER TABLE task
+ADD COLUMN exam_reminded BOOLEAN NOT NULL DEFAULT FALSE AFTER exam_duration;
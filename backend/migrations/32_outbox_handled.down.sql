@@ -0,0 +1,3 @@
+ALTER TABLE outbox_handled DROP CONSTRAINT outbox_handled_event_fk;
+
+DROP TABLE IF EXISTS outbox_handled;
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS outbox_handled;
+
+CREATE TABLE IF NOT EXISTS outbox_handled (
+    event_id BIGINT NOT NULL,
+    handler VARCHAR(64) NOT NULL,
+    handled_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (event_id, handler)
+);
+
+ALTER TABLE outbox_handled
+ADD CONSTRAINT outbox_handled_event_fk FOREIGN KEY (event_id) REFERENCES outbox (id) ON UPDATE CASCADE ON DELETE CASCADE;
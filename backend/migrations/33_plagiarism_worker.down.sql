@@ -0,0 +1,9 @@
+ALTER TABLE plagiarism_print DROP CONSTRAINT plagiarism_print_solution_fk;
+
+ALTER TABLE plagiarism_submit DROP CONSTRAINT plagiarism_submit_task_fk;
+
+ALTER TABLE plagiarism_submit DROP CONSTRAINT plagiarism_submit_solution_fk;
+
+DROP TABLE IF EXISTS plagiarism_print;
+
+DROP TABLE IF EXISTS plagiarism_submit;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS plagiarism_submit (
+    solution_id BIGINT NOT NULL PRIMARY KEY,
+    task_id BIGINT NOT NULL,
+    requested_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP NULL,
+    INDEX plagiarism_submit_requested_idx (started_at, requested_at)
+);
+
+CREATE TABLE IF NOT EXISTS plagiarism_print (
+    solution_id BIGINT NOT NULL PRIMARY KEY,
+    content_key CHAR(64) NOT NULL,
+    docs JSON NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE plagiarism_submit
+ADD CONSTRAINT plagiarism_submit_solution_fk FOREIGN KEY (solution_id) REFERENCES solution (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE plagiarism_submit
+ADD CONSTRAINT plagiarism_submit_task_fk FOREIGN KEY (task_id) REFERENCES task (id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE plagiarism_print
+ADD CONSTRAINT plagiarism_print_solution_fk FOREIGN KEY (solution_id) REFERENCES solution (id) ON UPDATE CASCADE ON DELETE CASCADE;
@@ -0,0 +1,4 @@
+ALTER TABLE task
+DROP INDEX task_deadline_idx,
+DROP COLUMN deadline,
+DROP COLUMN deadline_reminded;
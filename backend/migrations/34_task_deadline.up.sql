@@ -0,0 +1,4 @@
+ALTER TABLE task
+ADD COLUMN deadline TIMESTAMP NULL AFTER publish_at,
+ADD COLUMN deadline_reminded BOOLEAN NOT NULL DEFAULT FALSE AFTER deadline,
+ADD INDEX task_deadline_idx (deadline_reminded, deadline);